@@ -15,10 +15,15 @@ package main
 
 import (
 	"flag"
+	"os"
 	"strings"
+	"time"
 
+	"github.com/tektoncd/chains/pkg/backfill"
+	"github.com/tektoncd/chains/pkg/reconciler/customrun"
 	"github.com/tektoncd/chains/pkg/reconciler/pipelinerun"
 	"github.com/tektoncd/chains/pkg/reconciler/taskrun"
+	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
 
 	"k8s.io/client-go/rest"
 
@@ -45,6 +50,15 @@ func main() {
 	flag.IntVar(&controller.DefaultThreadsPerController, "threads-per-controller", controller.DefaultThreadsPerController, "Threads (goroutines) to create per controller")
 	namespaceList := flag.String("namespace", "", "Comma-separated list of namespaces to restrict informer to. Optional, if empty defaults to all namespaces.")
 
+	backfillEnabled := flag.Bool("backfill", false, "Request the runs matching the backfill flags to be signed again by the running controllers, then exit, instead of running the controllers.")
+	backfillKinds := flag.String("backfill-kinds", backfill.KindTaskRun+","+backfill.KindPipelineRun, "Comma-separated list of the kinds of runs to sign again: taskrun, pipelinerun and customrun.")
+	backfillSelector := flag.String("backfill-selector", "", "Label selector of the runs to sign again.")
+	backfillSince := flag.String("backfill-since", "", "Only sign again the runs completed at or after this RFC 3339 time.")
+	backfillUntil := flag.String("backfill-until", "", "Only sign again the runs completed at or before this RFC 3339 time.")
+	backfillReason := flag.String("backfill-reason", "", "Reason of the re-sign requests, the runs already requested for the same reason are skipped.")
+	backfillFormat := flag.String("backfill-format", "", "Format requested for the new provenance, the configured one when empty.")
+	backfillQPS := flag.Float64("backfill-qps", 5, "Maximum number of runs requested per second, unlimited when zero.")
+
 	// This also calls flag.Parse().
 	cfg := injection.ParseAndGetRESTConfigOrDie()
 
@@ -58,6 +72,32 @@ func main() {
 		logger.Infof("controller is scoped to the following namespaces: %s\n", namespaces)
 	}
 
+	if *backfillEnabled {
+		opts := backfill.Options{
+			Namespaces: namespaces,
+			Selector:   *backfillSelector,
+			Kinds:      strings.Split(strings.ReplaceAll(*backfillKinds, " ", ""), ","),
+			Reason:     *backfillReason,
+			Format:     *backfillFormat,
+			QPS:        *backfillQPS,
+		}
+		var err error
+		if *backfillSince != "" {
+			if opts.Since, err = time.Parse(time.RFC3339, *backfillSince); err != nil {
+				logger.Fatalf("Invalid --backfill-since: %v", err)
+			}
+		}
+		if *backfillUntil != "" {
+			if opts.Until, err = time.Parse(time.RFC3339, *backfillUntil); err != nil {
+				logger.Fatalf("Invalid --backfill-until: %v", err)
+			}
+		}
+		if _, err := backfill.Run(ctx, versioned.NewForConfigOrDie(cfg), opts); err != nil {
+			logger.Fatalf("Backfill failed: %v", err)
+		}
+		os.Exit(0)
+	}
+
 	if cfg.QPS == 0 {
 		cfg.QPS = 2 * rest.DefaultQPS
 	}
@@ -66,8 +106,11 @@ func main() {
 	}
 
 	// Multiply by number of controllers
-	cfg.QPS = 2 * cfg.QPS
-	cfg.Burst = 2 * cfg.Burst
+	cfg.QPS = 3 * cfg.QPS
+	cfg.Burst = 3 * cfg.Burst
 
-	sharedmain.MainWithConfig(ctx, "watcher", cfg, taskrun.NewNamespacesScopedController(namespaces), pipelinerun.NewNamespacesScopedController(namespaces))
+	sharedmain.MainWithConfig(ctx, "watcher", cfg,
+		taskrun.NewNamespacesScopedController(namespaces),
+		pipelinerun.NewNamespacesScopedController(namespaces),
+		customrun.NewNamespacesScopedController(namespaces))
 }
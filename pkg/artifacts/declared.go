@@ -0,0 +1,71 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package artifacts
+
+import (
+	"context"
+	"fmt"
+
+	intoto "github.com/in-toto/attestation/go/v1"
+	"github.com/in-toto/in-toto-golang/in_toto/slsa_provenance/common"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"knative.dev/pkg/logging"
+)
+
+// ExtractDeclaredArtifacts returns the values of the artifacts declared through
+// the Tekton Artifacts API, e.g. in `status.artifacts` of a TaskRun. Each value
+// becomes a descriptor named after its uri, holding the digests that are valid.
+// When buildOutputsOnly is set, only the artifacts marked with `buildOutput` are
+// returned, as with the isBuildArtifact field of type-hinted results.
+func ExtractDeclaredArtifacts(ctx context.Context, declared []v1.Artifact, buildOutputsOnly bool) []*intoto.ResourceDescriptor {
+	logger := logging.FromContext(ctx)
+	descriptors := []*intoto.ResourceDescriptor{}
+	for _, a := range declared {
+		if buildOutputsOnly && !a.BuildOutput {
+			continue
+		}
+		for _, v := range a.Values {
+			if v.Uri == "" {
+				logger.Debugf("Ignoring a value of the artifact %s without uri", a.Name)
+				continue
+			}
+			digest := common.DigestSet{}
+			for alg, hex := range v.Digest {
+				parsedAlg, parsedHex, err := ParseDigest(fmt.Sprintf("%s:%s", alg, hex))
+				if err != nil {
+					logger.Debugf("Ignoring the %s digest of %s in the artifact %s: %v", alg, v.Uri, a.Name, err)
+					continue
+				}
+				digest[parsedAlg] = parsedHex
+			}
+			if len(digest) == 0 {
+				logger.Debugf("Ignoring %s in the artifact %s without valid digest", v.Uri, a.Name)
+				continue
+			}
+			logger.Debugf("Extracted declared artifact %s from %s", v.Uri, a.Name)
+			descriptors = append(descriptors, &intoto.ResourceDescriptor{Name: v.Uri, Digest: digest})
+		}
+	}
+	return descriptors
+}
+
+// RetrieveMaterialsFromDeclaredArtifacts converts the values of the declared
+// input artifacts into materials.
+func RetrieveMaterialsFromDeclaredArtifacts(ctx context.Context, declared []v1.Artifact) []common.ProvenanceMaterial {
+	mats := []common.ProvenanceMaterial{}
+	for _, d := range ExtractDeclaredArtifacts(ctx, declared, false) {
+		mats = append(mats, common.ProvenanceMaterial{URI: d.Name, Digest: d.Digest})
+	}
+	return mats
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package artifacts
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	intoto "github.com/in-toto/attestation/go/v1"
+	"github.com/in-toto/in-toto-golang/in_toto/slsa_provenance/common"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"google.golang.org/protobuf/testing/protocmp"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+const (
+	declaredDigest1 = "05f95b26ed10668b7183c1e2da98610e91372fa9f510046d4ce5812addad86b5"
+	declaredDigest2 = "a2e500bebfe16cf12fc56316ba72c645e1d29054541dc1ab6c286197434170a9"
+)
+
+func declaredArtifacts() []v1.Artifact {
+	return []v1.Artifact{{
+		Name: "image",
+		Values: []v1.ArtifactValue{{
+			Uri:    "pkg:oci/image?repository_url=gcr.io/foo",
+			Digest: map[v1.Algorithm]string{"sha256": declaredDigest1},
+		}},
+		BuildOutput: true,
+	}, {
+		Name: "logs",
+		Values: []v1.ArtifactValue{{
+			Uri:    "gs://bucket/logs",
+			Digest: map[v1.Algorithm]string{"sha256": declaredDigest2, "md5": "abc"},
+		}, {
+			// no uri
+			Digest: map[v1.Algorithm]string{"sha256": declaredDigest2},
+		}, {
+			Uri:    "gs://bucket/invalid",
+			Digest: map[v1.Algorithm]string{"sha256": "abc"},
+		}},
+	}}
+}
+
+func TestExtractDeclaredArtifacts(t *testing.T) {
+	tests := []struct {
+		name             string
+		buildOutputsOnly bool
+		want             []*intoto.ResourceDescriptor
+	}{{
+		name: "all artifacts",
+		want: []*intoto.ResourceDescriptor{{
+			Name:   "pkg:oci/image?repository_url=gcr.io/foo",
+			Digest: common.DigestSet{"sha256": declaredDigest1},
+		}, {
+			Name:   "gs://bucket/logs",
+			Digest: common.DigestSet{"sha256": declaredDigest2},
+		}},
+	}, {
+		name:             "build outputs only",
+		buildOutputsOnly: true,
+		want: []*intoto.ResourceDescriptor{{
+			Name:   "pkg:oci/image?repository_url=gcr.io/foo",
+			Digest: common.DigestSet{"sha256": declaredDigest1},
+		}},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := logtesting.TestContextWithLogger(t)
+			got := ExtractDeclaredArtifacts(ctx, declaredArtifacts(), tc.buildOutputsOnly)
+			if d := cmp.Diff(tc.want, got, protocmp.Transform()); d != "" {
+				t.Errorf("ExtractDeclaredArtifacts() (-want +got): %s", d)
+			}
+		})
+	}
+}
+
+func TestRetrieveMaterialsFromDeclaredArtifacts(t *testing.T) {
+	ctx := logtesting.TestContextWithLogger(t)
+	want := []common.ProvenanceMaterial{{
+		URI:    "pkg:oci/image?repository_url=gcr.io/foo",
+		Digest: common.DigestSet{"sha256": declaredDigest1},
+	}, {
+		URI:    "gs://bucket/logs",
+		Digest: common.DigestSet{"sha256": declaredDigest2},
+	}}
+	got := RetrieveMaterialsFromDeclaredArtifacts(ctx, declaredArtifacts())
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("RetrieveMaterialsFromDeclaredArtifacts() (-want +got): %s", d)
+	}
+}
@@ -0,0 +1,331 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package artifacts
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	intoto "github.com/in-toto/attestation/go/v1"
+	"github.com/tektoncd/chains/pkg/chains/objects"
+	"github.com/tektoncd/chains/pkg/config"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/logging"
+)
+
+const (
+	// TestResultsResultName is the suffix of the object results holding the
+	// outcome of the tests run by a Task.
+	TestResultsResultName = "TEST_RESULTS"
+	// ScanResultsResultName is the suffix of the object results holding the
+	// outcome of a vulnerability scan run by a Task.
+	ScanResultsResultName = "SCAN_RESULTS"
+
+	// TestResultPassed is the outcome of tests which all passed.
+	TestResultPassed = "PASSED"
+	// TestResultWarned is the outcome of tests which passed with warnings.
+	TestResultWarned = "WARNED"
+	// TestResultFailed is the outcome of tests of which at least one failed.
+	TestResultFailed = "FAILED"
+)
+
+// ScanSeverities are the keys of the scan results counting the vulnerabilities
+// found by severity.
+var ScanSeverities = []string{"critical", "high", "medium", "low", "unknown"}
+
+// TestResult is the outcome of the tests run by a run, read from an object
+// result with the keys result, passed, warned, failed and url. The test lists
+// are separated by commas or newlines, and the result is derived from them when
+// it is missing.
+type TestResult struct {
+	// Name is the name of the result.
+	Name   string
+	Result string
+	Passed []string
+	Warned []string
+	Failed []string
+	// URL links to the test run or report.
+	URL string
+	// Subjects are the subjects of the provenance signed for the run.
+	Subjects []*intoto.ResourceDescriptor
+	// Run is the run which ran the tests.
+	Run objects.TektonObject
+}
+
+// ScanResult is the outcome of a vulnerability scan run by a run, read from an
+// object result with the keys scanner, scannerVersion, db, dbVersion, report,
+// reportDigest and the number of vulnerabilities of each of the ScanSeverities.
+type ScanResult struct {
+	// Name is the name of the result.
+	Name           string
+	ScannerURI     string
+	ScannerVersion string
+	DBURI          string
+	DBVersion      string
+	// Summary counts the vulnerabilities found by severity.
+	Summary map[string]int
+	// Report is the scan report, when the Task stored it.
+	Report *intoto.ResourceDescriptor
+	// Subjects are the subjects of the provenance signed for the run.
+	Subjects []*intoto.ResourceDescriptor
+	// Run is the run which ran the scan.
+	Run objects.TektonObject
+}
+
+// objectResults returns the object results of the run with the suffix, along
+// with the subjects of the provenance signed for the run. Evidence is only
+// attested for the subjects of trusted runs whose provenance was signed.
+func objectResults(ctx context.Context, obj objects.TektonObject, suffix string) ([]objects.Result, []*intoto.ResourceDescriptor) {
+	results := []objects.Result{}
+	for _, res := range obj.GetResults() {
+		if strings.HasSuffix(res.Name, suffix) && res.Value.Type == v1.ParamTypeObject {
+			results = append(results, res)
+		}
+	}
+	if len(results) == 0 || !TrustedProducer(ctx, obj) {
+		return nil, nil
+	}
+	p := SignedProvenanceFromContext(ctx)
+	if p == nil || len(p.Subjects) == 0 {
+		logging.FromContext(ctx).Warnf("Skipping the %s results of %s %s/%s, whose provenance has no signed subjects", suffix, obj.GetGVK(), obj.GetNamespace(), obj.GetName())
+		return nil, nil
+	}
+	return results, p.Subjects
+}
+
+func splitList(value string) []string {
+	list := []string{}
+	for _, v := range strings.FieldsFunc(value, split) {
+		if v = strings.TrimSpace(v); v != "" {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
+func newTestResult(res objects.Result) (*TestResult, error) {
+	values := res.Value.ObjectVal
+	tr := &TestResult{
+		Name:   res.Name,
+		Result: strings.ToUpper(strings.TrimSpace(values["result"])),
+		Passed: splitList(values["passed"]),
+		Warned: splitList(values["warned"]),
+		Failed: splitList(values["failed"]),
+		URL:    strings.TrimSpace(values["url"]),
+	}
+	switch tr.Result {
+	case TestResultPassed, TestResultWarned, TestResultFailed:
+	case "":
+		switch {
+		case len(tr.Failed) > 0:
+			tr.Result = TestResultFailed
+		case len(tr.Warned) > 0:
+			tr.Result = TestResultWarned
+		default:
+			tr.Result = TestResultPassed
+		}
+	default:
+		return nil, fmt.Errorf("invalid result %q, wanted one of %s, %s or %s", tr.Result, TestResultPassed, TestResultWarned, TestResultFailed)
+	}
+	return tr, nil
+}
+
+func newScanResult(res objects.Result) (*ScanResult, error) {
+	values := res.Value.ObjectVal
+	sr := &ScanResult{
+		Name:           res.Name,
+		ScannerURI:     strings.TrimSpace(values["scanner"]),
+		ScannerVersion: strings.TrimSpace(values["scannerVersion"]),
+		DBURI:          strings.TrimSpace(values["db"]),
+		DBVersion:      strings.TrimSpace(values["dbVersion"]),
+		Summary:        map[string]int{},
+	}
+	if sr.ScannerURI == "" {
+		return nil, fmt.Errorf("no scanner")
+	}
+	for _, severity := range ScanSeverities {
+		raw := strings.TrimSpace(values[severity])
+		if raw == "" {
+			continue
+		}
+		count, err := strconv.Atoi(raw)
+		if err != nil || count < 0 {
+			return nil, fmt.Errorf("invalid number of %s vulnerabilities %q", severity, raw)
+		}
+		sr.Summary[severity] = count
+	}
+	if digest := strings.TrimSpace(values["reportDigest"]); digest != "" {
+		alg, h, err := ParseDigest(digest)
+		if err != nil {
+			return nil, err
+		}
+		sr.Report = &intoto.ResourceDescriptor{
+			Uri:    strings.TrimSpace(values["report"]),
+			Digest: map[string]string{alg: h},
+		}
+	}
+	return sr, nil
+}
+
+// SubjectURIs returns the subjects pinned to each of their digests, as
+// `<NAME>@<ALG>:<HEX>`.
+func SubjectURIs(subjects []*intoto.ResourceDescriptor) []string {
+	uris := []string{}
+	for _, s := range subjects {
+		algs := make([]string, 0, len(s.GetDigest()))
+		for alg := range s.GetDigest() {
+			algs = append(algs, alg)
+		}
+		sort.Strings(algs)
+		for _, alg := range algs {
+			uris = append(uris, fmt.Sprintf("%s@%s:%s", s.GetName(), alg, s.GetDigest()[alg]))
+		}
+	}
+	return uris
+}
+
+// evidenceKey returns the short key of the evidence in a result, made of the
+// prefix and the first 12 chars of the digest of the result name, which may be
+// too long for an annotation.
+func evidenceKey(prefix, name string) string {
+	sum := sha256.Sum256([]byte(name))
+	return prefix + hex.EncodeToString(sum[:])[:12]
+}
+
+// evidenceFullKey returns the full key of the run followed by the result name.
+func evidenceFullKey(run objects.TektonObject, name string) string {
+	return fmt.Sprintf("%s-%s-%s", strings.ReplaceAll(run.GetGVK(), "/", "-"), run.GetUID(), name)
+}
+
+// TestResultArtifact is the attestation of the outcome of the tests run by a
+// run, whose subjects are those of the provenance signed for the run.
+type TestResultArtifact struct{}
+
+var _ Signable = &TestResultArtifact{}
+
+// ExtractObjects returns the test results of the run, which requires the test
+// result artifact to be signed after the TaskRun and PipelineRun ones.
+func (ta *TestResultArtifact) ExtractObjects(ctx context.Context, obj objects.TektonObject) []interface{} {
+	logger := logging.FromContext(ctx)
+	objs := []interface{}{}
+	results, subjects := objectResults(ctx, obj, TestResultsResultName)
+	for _, res := range results {
+		tr, err := newTestResult(res)
+		if err != nil {
+			logger.Errorf("Skipping the %s result of %s %s/%s: %v", res.Name, obj.GetGVK(), obj.GetNamespace(), obj.GetName(), err)
+			continue
+		}
+		tr.Subjects = subjects
+		tr.Run = obj
+		objs = append(objs, tr)
+	}
+	return objs
+}
+
+func (ta *TestResultArtifact) Type() string {
+	return "test-result"
+}
+
+func (ta *TestResultArtifact) StorageBackend(cfg config.Config) sets.Set[string] {
+	return cfg.Artifacts.TestResults.StorageBackend
+}
+
+func (ta *TestResultArtifact) PayloadFormat(cfg config.Config) config.PayloadType {
+	return config.PayloadType(cfg.Artifacts.TestResults.Format)
+}
+
+func (ta *TestResultArtifact) Signer(cfg config.Config) string {
+	return cfg.Artifacts.TestResults.Signer
+}
+
+func (ta *TestResultArtifact) TlogEntryType(cfg config.Config) string {
+	return cfg.Artifacts.TestResults.TlogEntryType(cfg.Transparency)
+}
+
+func (ta *TestResultArtifact) ShortKey(obj interface{}) string {
+	return evidenceKey("testresult-", obj.(*TestResult).Name)
+}
+
+func (ta *TestResultArtifact) FullKey(obj interface{}) string {
+	v := obj.(*TestResult)
+	return evidenceFullKey(v.Run, v.Name)
+}
+
+func (ta *TestResultArtifact) Enabled(cfg config.Config) bool {
+	return cfg.Artifacts.TestResults.Enabled()
+}
+
+// ScanResultArtifact is the attestation of the outcome of a vulnerability scan
+// run by a run, whose subjects are those of the provenance signed for the run.
+type ScanResultArtifact struct{}
+
+var _ Signable = &ScanResultArtifact{}
+
+// ExtractObjects returns the scan results of the run, which requires the scan
+// result artifact to be signed after the TaskRun and PipelineRun ones.
+func (sa *ScanResultArtifact) ExtractObjects(ctx context.Context, obj objects.TektonObject) []interface{} {
+	logger := logging.FromContext(ctx)
+	objs := []interface{}{}
+	results, subjects := objectResults(ctx, obj, ScanResultsResultName)
+	for _, res := range results {
+		sr, err := newScanResult(res)
+		if err != nil {
+			logger.Errorf("Skipping the %s result of %s %s/%s: %v", res.Name, obj.GetGVK(), obj.GetNamespace(), obj.GetName(), err)
+			continue
+		}
+		sr.Subjects = subjects
+		sr.Run = obj
+		objs = append(objs, sr)
+	}
+	return objs
+}
+
+func (sa *ScanResultArtifact) Type() string {
+	return "vuln"
+}
+
+func (sa *ScanResultArtifact) StorageBackend(cfg config.Config) sets.Set[string] {
+	return cfg.Artifacts.Vuln.StorageBackend
+}
+
+func (sa *ScanResultArtifact) PayloadFormat(cfg config.Config) config.PayloadType {
+	return config.PayloadType(cfg.Artifacts.Vuln.Format)
+}
+
+func (sa *ScanResultArtifact) Signer(cfg config.Config) string {
+	return cfg.Artifacts.Vuln.Signer
+}
+
+func (sa *ScanResultArtifact) TlogEntryType(cfg config.Config) string {
+	return cfg.Artifacts.Vuln.TlogEntryType(cfg.Transparency)
+}
+
+func (sa *ScanResultArtifact) ShortKey(obj interface{}) string {
+	return evidenceKey("vuln-", obj.(*ScanResult).Name)
+}
+
+func (sa *ScanResultArtifact) FullKey(obj interface{}) string {
+	v := obj.(*ScanResult)
+	return evidenceFullKey(v.Run, v.Name)
+}
+
+func (sa *ScanResultArtifact) Enabled(cfg config.Config) bool {
+	return cfg.Artifacts.Vuln.Enabled()
+}
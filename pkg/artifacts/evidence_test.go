@@ -0,0 +1,171 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package artifacts
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	intoto "github.com/in-toto/attestation/go/v1"
+	"github.com/tektoncd/chains/pkg/chains/objects"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func evidenceRun(results ...v1.TaskRunResult) objects.TektonObject {
+	return objects.NewTaskRunObjectV1(&v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "tests", Namespace: "default", UID: "uid"},
+		Status: v1.TaskRunStatus{
+			TaskRunStatusFields: v1.TaskRunStatusFields{Results: results},
+		},
+	})
+}
+
+func signedProvenanceContext(t *testing.T) context.Context {
+	t.Helper()
+	p := &SignedProvenance{Compliant: true}
+	if err := p.Add("tekton.dev-v1-TaskRun-uid", []byte(vsaStatement), []byte("envelope")); err != nil {
+		t.Fatal(err)
+	}
+	return WithSignedProvenance(context.Background(), p)
+}
+
+func TestTestResultArtifact(t *testing.T) {
+	ta := &TestResultArtifact{}
+	obj := evidenceRun(v1.TaskRunResult{
+		Name: "UNIT_TEST_RESULTS",
+		Value: *v1.NewObject(map[string]string{
+			"passed": "TestA, TestB",
+			"failed": "TestC\nTestD",
+			"url":    "https://ci.example.com/runs/1",
+		}),
+	}, v1.TaskRunResult{
+		Name:  "E2E_TEST_RESULTS",
+		Value: *v1.NewObject(map[string]string{"passed": "TestE", "warned": "TestF"}),
+	}, v1.TaskRunResult{
+		Name:  "LINT_TEST_RESULTS",
+		Value: *v1.NewObject(map[string]string{"result": "SKIPPED"}),
+	}, v1.TaskRunResult{
+		Name:  "STRING_TEST_RESULTS",
+		Value: *v1.NewStructuredValues("PASSED"),
+	})
+
+	if got := ta.ExtractObjects(context.Background(), obj); len(got) != 0 {
+		t.Errorf("expected no test results without signed provenance, got %v", got)
+	}
+
+	ctx := signedProvenanceContext(t)
+	got := ta.ExtractObjects(ctx, obj)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 test results, got %d", len(got))
+	}
+	want := []*TestResult{{
+		Name:   "UNIT_TEST_RESULTS",
+		Result: TestResultFailed,
+		Passed: []string{"TestA", "TestB"},
+		Warned: []string{},
+		Failed: []string{"TestC", "TestD"},
+		URL:    "https://ci.example.com/runs/1",
+	}, {
+		Name:   "E2E_TEST_RESULTS",
+		Result: TestResultWarned,
+		Passed: []string{"TestE"},
+		Warned: []string{"TestF"},
+		Failed: []string{},
+	}}
+	for i, w := range want {
+		tr := got[i].(*TestResult)
+		if len(tr.Subjects) != 2 || tr.Run != obj {
+			t.Errorf("expected the subjects of the signed provenance and the run, got %v", tr.Subjects)
+		}
+		tr.Subjects, tr.Run = nil, nil
+		if d := cmp.Diff(w, tr); d != "" {
+			t.Errorf("unexpected test result, diff: %s", d)
+		}
+	}
+
+	tr := got[0].(*TestResult)
+	tr.Run = obj
+	if got, want := ta.ShortKey(tr), "testresult-"; len(got) != len(want)+12 || got[:len(want)] != want {
+		t.Errorf("ShortKey() = %q, want a %q prefix and 12 chars", got, want)
+	}
+	if got, want := ta.FullKey(tr), "tekton.dev-v1-TaskRun-uid-UNIT_TEST_RESULTS"; got != want {
+		t.Errorf("FullKey() = %q, want %q", got, want)
+	}
+}
+
+func TestScanResultArtifact(t *testing.T) {
+	sa := &ScanResultArtifact{}
+	obj := evidenceRun(v1.TaskRunResult{
+		Name: "TRIVY_SCAN_RESULTS",
+		Value: *v1.NewObject(map[string]string{
+			"scanner":        "pkg:github/aquasecurity/trivy",
+			"scannerVersion": "0.50.0",
+			"db":             "pkg:github/aquasecurity/trivy-db",
+			"dbVersion":      "2",
+			"critical":       "0",
+			"high":           "3",
+			"report":         "oci://gcr.io/foo/reports",
+			"reportDigest":   "sha256:05f95b26ed10668b7183c1e2da98610e91372fa9f510046d4ce5812addad86b5",
+		}),
+	}, v1.TaskRunResult{
+		Name:  "NO_SCANNER_SCAN_RESULTS",
+		Value: *v1.NewObject(map[string]string{"high": "1"}),
+	}, v1.TaskRunResult{
+		Name:  "NEGATIVE_SCAN_RESULTS",
+		Value: *v1.NewObject(map[string]string{"scanner": "grype", "low": "-1"}),
+	}, v1.TaskRunResult{
+		Name:  "BAD_DIGEST_SCAN_RESULTS",
+		Value: *v1.NewObject(map[string]string{"scanner": "grype", "reportDigest": "sha256"}),
+	})
+
+	got := sa.ExtractObjects(signedProvenanceContext(t), obj)
+	if len(got) != 1 {
+		t.Fatalf("expected 1 scan result, got %d", len(got))
+	}
+	sr := got[0].(*ScanResult)
+	if len(sr.Subjects) != 2 || sr.Run != obj {
+		t.Errorf("expected the subjects of the signed provenance and the run, got %v", sr.Subjects)
+	}
+	if got, want := sr.Report.GetUri(), "oci://gcr.io/foo/reports"; got != want {
+		t.Errorf("expected the report %q, got %q", want, got)
+	}
+	if got, want := sr.Report.GetDigest()["sha256"], "05f95b26ed10668b7183c1e2da98610e91372fa9f510046d4ce5812addad86b5"; got != want {
+		t.Errorf("expected the report digest %q, got %q", want, got)
+	}
+	sr.Subjects, sr.Run, sr.Report = nil, nil, nil
+	want := &ScanResult{
+		Name:           "TRIVY_SCAN_RESULTS",
+		ScannerURI:     "pkg:github/aquasecurity/trivy",
+		ScannerVersion: "0.50.0",
+		DBURI:          "pkg:github/aquasecurity/trivy-db",
+		DBVersion:      "2",
+		Summary:        map[string]int{"critical": 0, "high": 3},
+	}
+	if d := cmp.Diff(want, sr); d != "" {
+		t.Errorf("unexpected scan result, diff: %s", d)
+	}
+}
+
+func TestSubjectURIs(t *testing.T) {
+	subjects := []*intoto.ResourceDescriptor{
+		{Name: "gcr.io/foo/bar", Digest: map[string]string{"sha256": "abc"}},
+		{Name: "gcr.io/foo/baz", Digest: map[string]string{"sha512": "def", "sha1": "ghi"}},
+	}
+	want := []string{"gcr.io/foo/bar@sha256:abc", "gcr.io/foo/baz@sha1:ghi", "gcr.io/foo/baz@sha512:def"}
+	if d := cmp.Diff(want, SubjectURIs(subjects)); d != "" {
+		t.Errorf("unexpected URIs, diff: %s", d)
+	}
+}
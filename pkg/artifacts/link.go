@@ -0,0 +1,203 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package artifacts
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+
+	intoto "github.com/in-toto/attestation/go/v1"
+	"github.com/tektoncd/chains/pkg/chains/objects"
+	"github.com/tektoncd/chains/pkg/config"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/logging"
+)
+
+// StepLink is what a step of a TaskRun did, as recorded by an in-toto link.
+type StepLink struct {
+	// Name is the name of the step.
+	Name string
+	// Command is the command of the step followed by its arguments.
+	Command []string
+	// Script is the script of the step, when it has one.
+	Script string
+	// Image is the image the step ran, pinned to its digest.
+	Image string
+	// Materials are the image of the step, its input artifacts and the products
+	// of the preceding steps.
+	Materials []*intoto.ResourceDescriptor
+	// Products are the output artifacts and the results of the step.
+	Products []*intoto.ResourceDescriptor
+	// ExitCode is the exit code of the step, when it terminated.
+	ExitCode *int32
+	// Run is the TaskRun of the step.
+	Run objects.TektonObject
+}
+
+// StepLinkArtifact is the in-toto link of each step of a TaskRun.
+type StepLinkArtifact struct{}
+
+var _ Signable = &StepLinkArtifact{}
+
+// ExtractObjects returns the links of the steps of the TaskRun, in the order the
+// steps ran, so that the materials of each step include the products of the
+// preceding ones.
+func (la *StepLinkArtifact) ExtractObjects(ctx context.Context, obj objects.TektonObject) []interface{} {
+	tr, ok := obj.(*objects.TaskRunObjectV1)
+	if !ok {
+		return nil
+	}
+	logger := logging.FromContext(ctx)
+
+	specs := map[string]v1.Step{}
+	if tr.Status.TaskSpec != nil {
+		for _, s := range tr.Status.TaskSpec.Steps {
+			specs[s.Name] = s
+		}
+	}
+	images := tr.GetStepImages()
+
+	objs := []interface{}{}
+	preceding := []*intoto.ResourceDescriptor{}
+	for i, step := range tr.Status.Steps {
+		link := &StepLink{
+			Name:      step.Name,
+			Image:     images[i],
+			Materials: []*intoto.ResourceDescriptor{},
+			Products:  []*intoto.ResourceDescriptor{},
+			Run:       obj,
+		}
+		if spec, ok := specs[step.Name]; ok {
+			link.Command = append(append([]string{}, spec.Command...), spec.Args...)
+			link.Script = spec.Script
+		}
+		if step.Terminated != nil {
+			exitCode := step.Terminated.ExitCode
+			link.ExitCode = &exitCode
+		}
+
+		if image, err := imageDescriptor(step.ImageID); err != nil {
+			logger.Warnf("Skipping the image %q of step %s of %s/%s: %v", step.ImageID, step.Name, tr.Namespace, tr.Name, err)
+		} else {
+			link.Materials = append(link.Materials, image)
+		}
+		link.Materials = append(link.Materials, stepArtifacts(step.Inputs)...)
+		link.Materials = append(link.Materials, preceding...)
+
+		link.Products = append(link.Products, stepArtifacts(step.Outputs)...)
+		for _, res := range step.Results {
+			link.Products = append(link.Products, resultDescriptor(res))
+		}
+		preceding = append(preceding, link.Products...)
+
+		objs = append(objs, link)
+	}
+	return objs
+}
+
+// imageDescriptor returns the descriptor of the image ID of a step, as reported
+// by the container runtime, e.g. `docker-pullable://gcr.io/foo/bar@sha256:<HEX>`.
+func imageDescriptor(imageID string) (*intoto.ResourceDescriptor, error) {
+	_, ref, found := strings.Cut(imageID, "://")
+	if !found {
+		ref = imageID
+	}
+	name, digest, _ := strings.Cut(ref, "@")
+	alg, h, err := ParseDigest(digest)
+	if err != nil {
+		return nil, err
+	}
+	return &intoto.ResourceDescriptor{
+		Name:   OCIScheme + name,
+		Digest: map[string]string{alg: h},
+	}, nil
+}
+
+// stepArtifacts returns the descriptors of the input or output artifacts of a step.
+func stepArtifacts(artifacts []v1.TaskRunStepArtifact) []*intoto.ResourceDescriptor {
+	descriptors := []*intoto.ResourceDescriptor{}
+	for _, a := range artifacts {
+		for _, v := range a.Values {
+			digest := map[string]string{}
+			for alg, h := range v.Digest {
+				digest[string(alg)] = h
+			}
+			descriptors = append(descriptors, &intoto.ResourceDescriptor{Name: v.Uri, Digest: digest})
+		}
+	}
+	return descriptors
+}
+
+// resultDescriptor returns the descriptor of a step result. Type-hinted object
+// results describe the artifact at their uri, while the other results are named
+// after themselves with the digest of their value.
+func resultDescriptor(res v1.TaskRunStepResult) *intoto.ResourceDescriptor {
+	if res.Value.Type == v1.ParamTypeObject {
+		if uri, digest := res.Value.ObjectVal["uri"], res.Value.ObjectVal["digest"]; uri != "" {
+			if alg, h, err := ParseDigest(digest); err == nil {
+				return &intoto.ResourceDescriptor{Name: uri, Digest: map[string]string{alg: h}}
+			}
+		}
+	}
+
+	value := []byte(res.Value.StringVal)
+	if res.Value.Type != v1.ParamTypeString {
+		// The marshalled value of arrays and objects has its keys sorted.
+		value, _ = json.Marshal(res.Value)
+	}
+	sum := sha256.Sum256(value)
+	return &intoto.ResourceDescriptor{
+		Name:   "result:" + res.Name,
+		Digest: map[string]string{"sha256": hex.EncodeToString(sum[:])},
+	}
+}
+
+func (la *StepLinkArtifact) Type() string {
+	return "in-toto-link"
+}
+
+func (la *StepLinkArtifact) StorageBackend(cfg config.Config) sets.Set[string] {
+	return cfg.Artifacts.Links.StorageBackend
+}
+
+func (la *StepLinkArtifact) PayloadFormat(cfg config.Config) config.PayloadType {
+	return config.PayloadType(cfg.Artifacts.Links.Format)
+}
+
+func (la *StepLinkArtifact) Signer(cfg config.Config) string {
+	return cfg.Artifacts.Links.Signer
+}
+
+func (la *StepLinkArtifact) TlogEntryType(cfg config.Config) string {
+	return cfg.Artifacts.Links.TlogEntryType(cfg.Transparency)
+}
+
+func (la *StepLinkArtifact) ShortKey(obj interface{}) string {
+	return evidenceKey("link-", obj.(*StepLink).Name)
+}
+
+func (la *StepLinkArtifact) FullKey(obj interface{}) string {
+	v := obj.(*StepLink)
+	return evidenceFullKey(v.Run, v.Name)
+}
+
+// Enabled returns true when a storage backend is configured for the links, which
+// are not generated by default.
+func (la *StepLinkArtifact) Enabled(cfg config.Config) bool {
+	return cfg.Artifacts.Links.StorageBackend.Len() > 0 && cfg.Artifacts.Links.Enabled()
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package artifacts
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	intoto "github.com/in-toto/attestation/go/v1"
+	"github.com/tektoncd/chains/pkg/chains/objects"
+	"github.com/tektoncd/chains/pkg/config"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func TestStepLinkArtifact(t *testing.T) {
+	la := &StepLinkArtifact{}
+	obj := objects.NewTaskRunObjectV1(&v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "build", Namespace: "default", UID: "uid"},
+		Status: v1.TaskRunStatus{
+			TaskRunStatusFields: v1.TaskRunStatusFields{
+				TaskSpec: &v1.TaskSpec{Steps: []v1.Step{{
+					Name:    "clone",
+					Command: []string{"git", "clone"},
+					Args:    []string{"https://github.com/tektoncd/chains"},
+				}, {
+					Name:   "build",
+					Script: "make build",
+				}}},
+				Steps: []v1.StepState{{
+					Name:    "clone",
+					ImageID: "docker-pullable://gcr.io/foo/git@sha256:05f95b26ed10668b7183c1e2da98610e91372fa9f510046d4ce5812addad86b5",
+					ContainerState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{ExitCode: 0},
+					},
+					Results: []v1.TaskRunStepResult{{
+						Name:  "commit",
+						Type:  v1.ResultsTypeString,
+						Value: *v1.NewStructuredValues("abc"),
+					}},
+				}, {
+					Name:    "build",
+					ImageID: "gcr.io/foo/go",
+					Inputs: []v1.TaskRunStepArtifact{{
+						Name:   "source",
+						Values: []v1.ArtifactValue{{Uri: "git+https://github.com/tektoncd/chains", Digest: map[v1.Algorithm]string{"sha1": "f99d13e5"}}},
+					}},
+					Outputs: []v1.TaskRunStepArtifact{{
+						Name:   "binary",
+						Values: []v1.ArtifactValue{{Uri: "gs://foo/bin", Digest: map[v1.Algorithm]string{"sha256": "a0cfeb3b"}}},
+					}},
+					Results: []v1.TaskRunStepResult{{
+						Name: "image",
+						Type: v1.ResultsTypeObject,
+						Value: *v1.NewObject(map[string]string{
+							"uri":    "gcr.io/foo/bar",
+							"digest": "sha256:586789aa031fafc7d78a5393cdc772e0b55107ea54bb8bcf3f2cdac6c6da51ee",
+						}),
+					}},
+				}},
+			},
+		},
+	})
+
+	if got := la.ExtractObjects(context.Background(), objects.NewPipelineRunObjectV1(&v1.PipelineRun{})); len(got) != 0 {
+		t.Errorf("expected no links for a PipelineRun, got %v", got)
+	}
+
+	got := la.ExtractObjects(context.Background(), obj)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 links, got %d", len(got))
+	}
+	commit := &intoto.ResourceDescriptor{
+		Name:   "result:commit",
+		Digest: map[string]string{"sha256": "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"},
+	}
+	exitCode := int32(0)
+	want := []*StepLink{{
+		Name:    "clone",
+		Command: []string{"git", "clone", "https://github.com/tektoncd/chains"},
+		Image:   "docker-pullable://gcr.io/foo/git@sha256:05f95b26ed10668b7183c1e2da98610e91372fa9f510046d4ce5812addad86b5",
+		Materials: []*intoto.ResourceDescriptor{{
+			Name:   "oci://gcr.io/foo/git",
+			Digest: map[string]string{"sha256": "05f95b26ed10668b7183c1e2da98610e91372fa9f510046d4ce5812addad86b5"},
+		}},
+		Products: []*intoto.ResourceDescriptor{commit},
+		ExitCode: &exitCode,
+	}, {
+		Name:    "build",
+		Command: []string{},
+		Script:  "make build",
+		Image:   "gcr.io/foo/go",
+		Materials: []*intoto.ResourceDescriptor{
+			{Name: "git+https://github.com/tektoncd/chains", Digest: map[string]string{"sha1": "f99d13e5"}},
+			commit,
+		},
+		Products: []*intoto.ResourceDescriptor{
+			{Name: "gs://foo/bin", Digest: map[string]string{"sha256": "a0cfeb3b"}},
+			{Name: "gcr.io/foo/bar", Digest: map[string]string{"sha256": "586789aa031fafc7d78a5393cdc772e0b55107ea54bb8bcf3f2cdac6c6da51ee"}},
+		},
+	}}
+	for i, w := range want {
+		link := got[i].(*StepLink)
+		if link.Run != obj {
+			t.Errorf("expected the link of a step of the TaskRun")
+		}
+		link.Run = nil
+		if d := cmp.Diff(w, link, cmp.Comparer(func(a, b *intoto.ResourceDescriptor) bool {
+			return a.GetName() == b.GetName() && cmp.Equal(a.GetDigest(), b.GetDigest())
+		})); d != "" {
+			t.Errorf("unexpected link of step %s, diff: %s", w.Name, d)
+		}
+	}
+
+	link := got[0].(*StepLink)
+	link.Run = obj
+	if got, want := la.FullKey(link), "tekton.dev-v1-TaskRun-uid-clone"; got != want {
+		t.Errorf("FullKey() = %q, want %q", got, want)
+	}
+}
+
+func TestStepLinkArtifactEnabled(t *testing.T) {
+	la := &StepLinkArtifact{}
+	cfg := config.Config{Artifacts: config.ArtifactConfigs{Links: config.Artifact{Format: "in-toto-link", Signer: "x509"}}}
+	if la.Enabled(cfg) {
+		t.Error("expected the links to be disabled without a storage backend")
+	}
+	cfg.Artifacts.Links.StorageBackend = sets.New[string]("tekton")
+	if !la.Enabled(cfg) {
+		t.Error("expected the links to be enabled")
+	}
+}
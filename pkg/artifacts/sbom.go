@@ -0,0 +1,221 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package artifacts
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/tektoncd/chains/pkg/chains/objects"
+	"github.com/tektoncd/chains/pkg/config"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/logging"
+)
+
+const (
+	// SBOMResultName is the suffix of the results holding an SBOM document, such
+	// as results backed by a workspace or sidecar logs to lift the size limit.
+	SBOMResultName = "SBOM"
+	// SBOMURIResultName is the suffix of the results holding the reference of the
+	// OCI blob an SBOM was pushed to, as `<REPOSITORY>@sha256:<DIGEST>`.
+	SBOMURIResultName = "SBOM_URI"
+	// SBOMDigestResultName is the suffix of the results holding the digest of an SBOM.
+	SBOMDigestResultName = "SBOM_DIGEST"
+	// SBOMFormatResultName is the suffix of the results holding the format of an
+	// SBOM, detected from the document when missing.
+	SBOMFormatResultName = "SBOM_FORMAT"
+
+	// SBOMFormatSPDX is the format of SPDX JSON documents.
+	SBOMFormatSPDX = "spdx"
+	// SBOMFormatCycloneDX is the format of CycloneDX JSON documents.
+	SBOMFormatCycloneDX = "cyclonedx"
+)
+
+// SBOM is a software bill of materials of an image built by a run. The results
+// describing it share their prefix with the IMAGE_URL and IMAGE_DIGEST results
+// of the image, e.g. APP_SBOM_URI describes the image in APP_IMAGE_URL.
+type SBOM struct {
+	// Subject is the image described by the SBOM.
+	Subject name.Digest
+	// URI is the reference of the OCI blob holding the SBOM, when it is not in Content.
+	URI string
+	// Digest is the digest of the SBOM document, as `<ALG>:<HEX>`.
+	Digest string
+	// Format is either SBOMFormatSPDX, SBOMFormatCycloneDX or empty when it is to
+	// be detected from the document.
+	Format string
+	// Content is the SBOM document, when it was inlined in a result.
+	Content []byte
+	// Run is the run which produced the SBOM, whose service account pulls the blob.
+	Run objects.TektonObject
+}
+
+type SBOMArtifact struct{}
+
+var _ Signable = &SBOMArtifact{}
+
+type sbomHint struct {
+	uri, digest, format, content string
+}
+
+// ExtractObjects returns the SBOMs in the results of the run. SBOMs without an
+// image, or whose digest does not match, are skipped.
+func (sa *SBOMArtifact) ExtractObjects(ctx context.Context, obj objects.TektonObject) []interface{} {
+	logger := logging.FromContext(ctx)
+	objs := []interface{}{}
+	if !TrustedProducer(ctx, obj) {
+		return objs
+	}
+
+	hints := map[string]*sbomHint{}
+	images := map[string]*image{}
+	hint := func(prefix string) *sbomHint {
+		if _, ok := hints[prefix]; !ok {
+			hints[prefix] = &sbomHint{}
+		}
+		return hints[prefix]
+	}
+	img := func(prefix string) *image {
+		if _, ok := images[prefix]; !ok {
+			images[prefix] = &image{}
+		}
+		return images[prefix]
+	}
+	for _, res := range obj.GetResults() {
+		value := strings.TrimSpace(res.Value.StringVal)
+		if value == "" {
+			continue
+		}
+		// The longer suffixes come first, as they all end with SBOM_... or SBOM.
+		switch {
+		case strings.HasSuffix(res.Name, SBOMURIResultName):
+			hint(strings.TrimSuffix(res.Name, SBOMURIResultName)).uri = value
+		case strings.HasSuffix(res.Name, SBOMDigestResultName):
+			hint(strings.TrimSuffix(res.Name, SBOMDigestResultName)).digest = value
+		case strings.HasSuffix(res.Name, SBOMFormatResultName):
+			hint(strings.TrimSuffix(res.Name, SBOMFormatResultName)).format = strings.ToLower(value)
+		case strings.HasSuffix(res.Name, SBOMResultName):
+			// The document is not trimmed, so that it matches its digest.
+			hint(strings.TrimSuffix(res.Name, SBOMResultName)).content = res.Value.StringVal
+		case strings.HasSuffix(res.Name, OCIImageURLResultName):
+			img(strings.TrimSuffix(res.Name, OCIImageURLResultName)).url = value
+		case strings.HasSuffix(res.Name, OCIImageDigestResultName):
+			img(strings.TrimSuffix(res.Name, OCIImageDigestResultName)).digest = value
+		}
+	}
+
+	prefixes := make([]string, 0, len(hints))
+	for prefix := range hints {
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Strings(prefixes)
+	for _, prefix := range prefixes {
+		sbom, err := newSBOM(hints[prefix], images[prefix])
+		if err != nil {
+			logger.Errorf("Skipping the %s%s results of %s %s/%s: %v", prefix, SBOMResultName, obj.GetGVK(), obj.GetNamespace(), obj.GetName(), err)
+			continue
+		}
+		sbom.Run = obj
+		objs = append(objs, sbom)
+	}
+	return objs
+}
+
+func newSBOM(hint *sbomHint, img *image) (*SBOM, error) {
+	if img == nil || img.url == "" || img.digest == "" {
+		return nil, fmt.Errorf("no %s and %s results with the same prefix", OCIImageURLResultName, OCIImageDigestResultName)
+	}
+	subject, err := name.NewDigest(fmt.Sprintf("%s@%s", img.url, img.digest))
+	if err != nil {
+		return nil, err
+	}
+	switch hint.format {
+	case "", SBOMFormatSPDX, SBOMFormatCycloneDX:
+	default:
+		return nil, fmt.Errorf("unsupported format %q, wanted one of %s or %s", hint.format, SBOMFormatSPDX, SBOMFormatCycloneDX)
+	}
+	sbom := &SBOM{Subject: subject, Format: hint.format}
+
+	switch {
+	case hint.content != "":
+		sum := sha256.Sum256([]byte(hint.content))
+		sbom.Content = []byte(hint.content)
+		sbom.Digest = "sha256:" + hex.EncodeToString(sum[:])
+	case hint.uri != "":
+		ref, err := name.NewDigest(strings.TrimPrefix(hint.uri, OCIScheme))
+		if err != nil {
+			return nil, err
+		}
+		sbom.URI = ref.Name()
+		sbom.Digest = ref.DigestStr()
+	default:
+		return nil, fmt.Errorf("neither %s nor %s results", SBOMResultName, SBOMURIResultName)
+	}
+
+	if hint.digest != "" {
+		alg, h, err := ParseDigest(hint.digest)
+		if err != nil {
+			return nil, err
+		}
+		if want := alg + ":" + h; want != sbom.Digest {
+			return nil, fmt.Errorf("the SBOM digest %s does not match %s", sbom.Digest, want)
+		}
+	}
+	return sbom, nil
+}
+
+func (sa *SBOMArtifact) Type() string {
+	return "sbom"
+}
+
+func (sa *SBOMArtifact) StorageBackend(cfg config.Config) sets.Set[string] {
+	return cfg.Artifacts.SBOM.StorageBackend
+}
+
+func (sa *SBOMArtifact) PayloadFormat(cfg config.Config) config.PayloadType {
+	return config.PayloadType(cfg.Artifacts.SBOM.Format)
+}
+
+func (sa *SBOMArtifact) Signer(cfg config.Config) string {
+	return cfg.Artifacts.SBOM.Signer
+}
+
+func (sa *SBOMArtifact) TlogEntryType(cfg config.Config) string {
+	return cfg.Artifacts.SBOM.TlogEntryType(cfg.Transparency)
+}
+
+// ShortKey returns "sbom-" followed by the first 12 chars of the SBOM digest.
+func (sa *SBOMArtifact) ShortKey(obj interface{}) string {
+	v := obj.(*SBOM)
+	_, h, _ := strings.Cut(v.Digest, ":")
+	if len(h) > 12 {
+		h = h[:12]
+	}
+	return "sbom-" + h
+}
+
+// FullKey returns the image described by the SBOM, as `<NAME>@sha256:<DIGEST>`.
+func (sa *SBOMArtifact) FullKey(obj interface{}) string {
+	v := obj.(*SBOM)
+	return v.Subject.Name()
+}
+
+func (sa *SBOMArtifact) Enabled(cfg config.Config) bool {
+	return cfg.Artifacts.SBOM.Enabled()
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package artifacts
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/tektoncd/chains/pkg/chains/objects"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+const (
+	sbomImageDigest = "sha256:05f95b26ed10668b7183c1e2da98610e91372fa9f510046d4ce5812addad86b5"
+	sbomBlobDigest  = "sha256:a0cfeb3bb3e3ec5a0ffb0e0d4a9b4fe9f6b6bb0bca1b2b0d4e2e5d4b1c6a5f3e"
+	sbomDocument    = `{"spdxVersion": "SPDX-2.3"}`
+	// The sha256 digest of sbomDocument.
+	sbomDocumentDigest = "sha256:ffa8e939a31f71d03fdb0e9edef305f234bdfc09ee6ec9887af0ceb97f5f084b"
+)
+
+func TestSBOMArtifact_ExtractObjects(t *testing.T) {
+	image, err := name.NewDigest("gcr.io/foo/bar@" + sbomImageDigest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	app, err := name.NewDigest("gcr.io/foo/app@" + sbomImageDigest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		results map[string]string
+		want    []*SBOM
+	}{{
+		name: "blob",
+		results: map[string]string{
+			"IMAGE_URL":    "gcr.io/foo/bar",
+			"IMAGE_DIGEST": sbomImageDigest,
+			"SBOM_URI":     "oci://gcr.io/foo/sbom@" + sbomBlobDigest,
+			"SBOM_DIGEST":  sbomBlobDigest,
+			"SBOM_FORMAT":  "SPDX",
+		},
+		want: []*SBOM{{Subject: image, URI: "gcr.io/foo/sbom@" + sbomBlobDigest, Digest: sbomBlobDigest, Format: SBOMFormatSPDX}},
+	}, {
+		name: "inlined",
+		results: map[string]string{
+			"APP_IMAGE_URL":    "gcr.io/foo/app",
+			"APP_IMAGE_DIGEST": sbomImageDigest,
+			"APP_SBOM":         sbomDocument,
+			"IMAGE_URL":        "gcr.io/foo/bar",
+			"IMAGE_DIGEST":     sbomImageDigest,
+		},
+		want: []*SBOM{{Subject: app, Digest: sbomDocumentDigest, Content: []byte(sbomDocument)}},
+	}, {
+		name: "digest mismatch",
+		results: map[string]string{
+			"IMAGE_URL":    "gcr.io/foo/bar",
+			"IMAGE_DIGEST": sbomImageDigest,
+			"SBOM":         sbomDocument,
+			"SBOM_DIGEST":  sbomBlobDigest,
+		},
+	}, {
+		name: "no image",
+		results: map[string]string{
+			"IMAGE_URL":      "gcr.io/foo/bar",
+			"IMAGE_DIGEST":   sbomImageDigest,
+			"OTHER_SBOM_URI": "gcr.io/foo/sbom@" + sbomBlobDigest,
+		},
+	}, {
+		name: "unsupported format",
+		results: map[string]string{
+			"IMAGE_URL":    "gcr.io/foo/bar",
+			"IMAGE_DIGEST": sbomImageDigest,
+			"SBOM_URI":     "gcr.io/foo/sbom@" + sbomBlobDigest,
+			"SBOM_FORMAT":  "syft",
+		},
+	}, {
+		name: "no document",
+		results: map[string]string{
+			"IMAGE_URL":    "gcr.io/foo/bar",
+			"IMAGE_DIGEST": sbomImageDigest,
+			"SBOM_FORMAT":  "spdx",
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := logtesting.TestContextWithLogger(t)
+			tr := &v1.TaskRun{}
+			for name, value := range tt.results {
+				tr.Status.Results = append(tr.Status.Results, v1.TaskRunResult{Name: name, Value: *v1.NewStructuredValues(value)})
+			}
+			obj := objects.NewTaskRunObjectV1(tr)
+
+			got := []*SBOM{}
+			for _, o := range (&SBOMArtifact{}).ExtractObjects(ctx, obj) {
+				sbom := o.(*SBOM)
+				if sbom.Run != obj {
+					t.Errorf("expected the SBOM to reference the run")
+				}
+				got = append(got, sbom)
+			}
+			want := tt.want
+			if want == nil {
+				want = []*SBOM{}
+			}
+			if d := cmp.Diff(want, got, cmpopts.IgnoreFields(SBOM{}, "Run"), cmp.Comparer(func(a, b name.Digest) bool {
+				return a.String() == b.String()
+			})); d != "" {
+				t.Errorf("ExtractObjects() (-want +got): %s", d)
+			}
+		})
+	}
+}
+
+func TestSBOMArtifact_Keys(t *testing.T) {
+	image, err := name.NewDigest("gcr.io/foo/bar@" + sbomImageDigest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sbom := &SBOM{Subject: image, Digest: sbomBlobDigest}
+	sa := &SBOMArtifact{}
+	if got, want := sa.ShortKey(sbom), "sbom-a0cfeb3bb3e3"; got != want {
+		t.Errorf("ShortKey() = %q, want %q", got, want)
+	}
+	if got, want := sa.FullKey(sbom), "gcr.io/foo/bar@"+sbomImageDigest; got != want {
+		t.Errorf("FullKey() = %q, want %q", got, want)
+	}
+}
@@ -50,6 +50,8 @@ type Signable interface {
 	StorageBackend(cfg config.Config) sets.Set[string]
 	Signer(cfg config.Config) string
 	PayloadFormat(cfg config.Config) config.PayloadType
+	// TlogEntryType returns the kind of transparency log entry to upload.
+	TlogEntryType(cfg config.Config) string
 	// FullKey returns the full identifier for a signable artifact.
 	// - For OCI artifact, it is the full representation in the format of `<NAME>@sha256:<DIGEST>`.
 	// - For TaskRun/PipelineRun artifact, it is `<GROUP>-<VERSION>-<KIND>-<UID>`
@@ -97,6 +99,10 @@ func (ta *TaskRunArtifact) Signer(cfg config.Config) string {
 	return cfg.Artifacts.TaskRuns.Signer
 }
 
+func (ta *TaskRunArtifact) TlogEntryType(cfg config.Config) string {
+	return cfg.Artifacts.TaskRuns.TlogEntryType(cfg.Transparency)
+}
+
 func (ta *TaskRunArtifact) Enabled(cfg config.Config) bool {
 	return cfg.Artifacts.TaskRuns.Enabled()
 }
@@ -137,10 +143,57 @@ func (pa *PipelineRunArtifact) Signer(cfg config.Config) string {
 	return cfg.Artifacts.PipelineRuns.Signer
 }
 
+func (pa *PipelineRunArtifact) TlogEntryType(cfg config.Config) string {
+	return cfg.Artifacts.PipelineRuns.TlogEntryType(cfg.Transparency)
+}
+
 func (pa *PipelineRunArtifact) Enabled(cfg config.Config) bool {
 	return cfg.Artifacts.PipelineRuns.Enabled()
 }
 
+type CustomRunArtifact struct{}
+
+var _ Signable = &CustomRunArtifact{}
+
+func (ca *CustomRunArtifact) ShortKey(obj interface{}) string {
+	cro := obj.(*objects.CustomRunObjectV1beta1)
+	return "customrun-" + string(cro.UID)
+}
+
+func (ca *CustomRunArtifact) FullKey(obj interface{}) string {
+	cro := obj.(*objects.CustomRunObjectV1beta1)
+	gvk := cro.GetGroupVersionKind()
+	return fmt.Sprintf("%s-%s-%s-%s", gvk.Group, gvk.Version, gvk.Kind, cro.UID)
+}
+
+func (ca *CustomRunArtifact) ExtractObjects(ctx context.Context, obj objects.TektonObject) []interface{} {
+	return []interface{}{obj}
+}
+
+func (ca *CustomRunArtifact) Type() string {
+	return "tekton-custom-run"
+}
+
+func (ca *CustomRunArtifact) StorageBackend(cfg config.Config) sets.Set[string] {
+	return cfg.Artifacts.CustomRuns.StorageBackend
+}
+
+func (ca *CustomRunArtifact) PayloadFormat(cfg config.Config) config.PayloadType {
+	return config.PayloadType(cfg.Artifacts.CustomRuns.Format)
+}
+
+func (ca *CustomRunArtifact) Signer(cfg config.Config) string {
+	return cfg.Artifacts.CustomRuns.Signer
+}
+
+func (ca *CustomRunArtifact) TlogEntryType(cfg config.Config) string {
+	return cfg.Artifacts.CustomRuns.TlogEntryType(cfg.Transparency)
+}
+
+func (ca *CustomRunArtifact) Enabled(cfg config.Config) bool {
+	return cfg.Artifacts.CustomRuns.Enabled()
+}
+
 type OCIArtifact struct{}
 
 var _ Signable = &OCIArtifact{}
@@ -152,6 +205,9 @@ type image struct {
 
 func (oa *OCIArtifact) ExtractObjects(ctx context.Context, obj objects.TektonObject) []interface{} {
 	objs := []interface{}{}
+	if !TrustedProducer(ctx, obj) {
+		return objs
+	}
 
 	// Now check TaskResults
 	resultImages := ExtractOCIImagesFromResults(ctx, obj.GetResults())
@@ -412,6 +468,10 @@ func (oa *OCIArtifact) Signer(cfg config.Config) string {
 	return cfg.Artifacts.OCI.Signer
 }
 
+func (oa *OCIArtifact) TlogEntryType(cfg config.Config) string {
+	return cfg.Artifacts.OCI.TlogEntryType(cfg.Transparency)
+}
+
 func (oa *OCIArtifact) ShortKey(obj interface{}) string {
 	v := obj.(name.Digest)
 	return strings.TrimPrefix(v.DigestStr(), "sha256:")[:12]
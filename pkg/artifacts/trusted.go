@@ -0,0 +1,73 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package artifacts
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/tektoncd/chains/pkg/chains/objects"
+	"github.com/tektoncd/chains/pkg/config"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"knative.dev/pkg/logging"
+)
+
+// TrustedProducer returns true when the type-hinted results of the run can be
+// honoured as the artifacts it produced, that is when the Task or Pipeline it
+// resolved is on the trusted producers allowlist of its namespace, or when the
+// producers of the namespace are not restricted.
+// The subjects of untrusted runs are dropped, which is logged as a security event.
+func TrustedProducer(ctx context.Context, obj objects.TektonObject) bool {
+	cfg := config.FromContext(ctx)
+	if cfg == nil {
+		return true
+	}
+	allowlist, ok := cfg.TrustedProducers.Allowlist(obj.GetNamespace())
+	if !ok {
+		return true
+	}
+
+	var source *v1.RefSource
+	if p := obj.GetProvenance(); p != nil {
+		source = p.RefSource
+	}
+	refs := producerRefs(source)
+	for _, ref := range refs {
+		if allowlist.Has(ref) {
+			return true
+		}
+	}
+
+	logging.FromContext(ctx).Warnw("Security: ignoring the type-hinted results of an untrusted producer",
+		"kind", obj.GetGVK(),
+		"namespace", obj.GetNamespace(),
+		"name", obj.GetName(),
+		"producer", refs)
+	return false
+}
+
+// producerRefs returns the references matching the resolved source: its URI, and
+// the URI pinned to each of its digests.
+func producerRefs(source *v1.RefSource) []string {
+	if source == nil || source.URI == "" {
+		return nil
+	}
+	refs := []string{source.URI}
+	for alg, hex := range source.Digest {
+		refs = append(refs, fmt.Sprintf("%s@%s:%s", source.URI, alg, hex))
+	}
+	sort.Strings(refs[1:])
+	return refs
+}
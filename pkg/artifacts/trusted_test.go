@@ -0,0 +1,118 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package artifacts
+
+import (
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/tektoncd/chains/pkg/chains/objects"
+	"github.com/tektoncd/chains/pkg/config"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+const (
+	trustedTaskURI    = "git+https://github.com/org/tasks.git"
+	trustedTaskCommit = "7f2b1cf5dd8e9b6fb8bbd1a1c0d3f5e1b6f2a9d4"
+	trustedImage      = "gcr.io/foo/bar@sha256:05f95b26ed10668b7183c1e2da98610e91372fa9f510046d4ce5812addad86b5"
+)
+
+func producerTaskRun(namespace string, source *v1.RefSource) *objects.TaskRunObjectV1 {
+	return objects.NewTaskRunObjectV1(&v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "build", Namespace: namespace},
+		Status: v1.TaskRunStatus{
+			TaskRunStatusFields: v1.TaskRunStatusFields{
+				Provenance: &v1.Provenance{RefSource: source},
+				Results: []v1.TaskRunResult{{
+					Name:  OCIImagesResultName,
+					Value: *v1.NewStructuredValues(trustedImage),
+				}},
+			},
+		},
+	})
+}
+
+func TestTrustedProducer(t *testing.T) {
+	source := &v1.RefSource{URI: trustedTaskURI, Digest: map[string]string{"sha1": trustedTaskCommit}}
+	otherCommit := &v1.RefSource{URI: trustedTaskURI, Digest: map[string]string{"sha1": "0000000000000000000000000000000000000000"}}
+
+	tests := []struct {
+		name      string
+		producers map[string]sets.Set[string]
+		namespace string
+		source    *v1.RefSource
+		want      bool
+	}{{
+		name:   "no allowlist",
+		source: source,
+		want:   true,
+	}, {
+		name:      "pinned reference",
+		producers: map[string]sets.Set[string]{"build": sets.New(trustedTaskURI + "@sha1:" + trustedTaskCommit)},
+		namespace: "build",
+		source:    source,
+		want:      true,
+	}, {
+		name:      "reference of another revision",
+		producers: map[string]sets.Set[string]{"build": sets.New(trustedTaskURI + "@sha1:" + trustedTaskCommit)},
+		namespace: "build",
+		source:    otherCommit,
+	}, {
+		name:      "any revision of the URI",
+		producers: map[string]sets.Set[string]{"build": sets.New(trustedTaskURI)},
+		namespace: "build",
+		source:    otherCommit,
+		want:      true,
+	}, {
+		name:      "unresolved reference",
+		producers: map[string]sets.Set[string]{"build": sets.New(trustedTaskURI)},
+		namespace: "build",
+	}, {
+		name:      "namespace without allowlist",
+		producers: map[string]sets.Set[string]{"build": sets.New[string]()},
+		namespace: "other",
+		want:      true,
+	}, {
+		name: "allowlist of all namespaces",
+		producers: map[string]sets.Set[string]{
+			"build":                              sets.New(trustedTaskURI),
+			config.TrustedProducersAllNamespaces: sets.New[string](),
+		},
+		namespace: "other",
+		source:    source,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := logtesting.TestContextWithLogger(t)
+			ctx = config.ToContext(ctx, &config.Config{
+				TrustedProducers: config.TrustedProducersConfig{Namespaces: tt.producers},
+			})
+			tro := producerTaskRun(tt.namespace, tt.source)
+
+			if got := TrustedProducer(ctx, tro); got != tt.want {
+				t.Errorf("TrustedProducer() = %v, want %v", got, tt.want)
+			}
+			got := (&OCIArtifact{}).ExtractObjects(ctx, tro)
+			if tt.want && (len(got) != 1 || got[0].(name.Digest).String() != trustedImage) {
+				t.Errorf("expected the image of the trusted producer, got %v", got)
+			}
+			if !tt.want && len(got) != 0 {
+				t.Errorf("expected the image of the untrusted producer to be dropped, got %v", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package artifacts
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	intoto "github.com/in-toto/attestation/go/v1"
+	"github.com/pkg/errors"
+	"github.com/tektoncd/chains/pkg/chains/objects"
+	"github.com/tektoncd/chains/pkg/config"
+	"google.golang.org/protobuf/encoding/protojson"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// SignedProvenance collects the provenance signed for a run, from which the
+// verification summary attestations of its subjects are generated.
+type SignedProvenance struct {
+	// Subjects are the subjects of the signed provenance.
+	Subjects []*intoto.ResourceDescriptor
+	// Attestations identify the signed provenance by the digest of its envelope.
+	Attestations []*intoto.ResourceDescriptor
+	// Compliant is false when the run violated the policies evaluated before signing.
+	Compliant bool
+}
+
+// Add records the signed in-toto statement, named after the key of the run, and
+// the envelope holding its signature.
+func (p *SignedProvenance) Add(name string, statement, envelope []byte) error {
+	s := &intoto.Statement{}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(statement, s); err != nil {
+		return errors.Wrap(err, "decoding statement")
+	}
+	digest := sha256.Sum256(envelope)
+	p.Attestations = append(p.Attestations, &intoto.ResourceDescriptor{
+		Name:   name,
+		Digest: map[string]string{"sha256": hex.EncodeToString(digest[:])},
+	})
+	for _, subject := range s.GetSubject() {
+		if !p.hasSubject(subject) {
+			p.Subjects = append(p.Subjects, subject)
+		}
+	}
+	return nil
+}
+
+func (p *SignedProvenance) hasSubject(subject *intoto.ResourceDescriptor) bool {
+	for _, s := range p.Subjects {
+		if s.GetName() == subject.GetName() && subjectDigest(s) == subjectDigest(subject) {
+			return true
+		}
+	}
+	return false
+}
+
+type signedProvenanceKey struct{}
+
+// WithSignedProvenance returns a context holding the provenance signed for the
+// run being signed.
+func WithSignedProvenance(ctx context.Context, p *SignedProvenance) context.Context {
+	return context.WithValue(ctx, signedProvenanceKey{}, p)
+}
+
+// SignedProvenanceFromContext returns the signed provenance in the context, or
+// nil when there is none.
+func SignedProvenanceFromContext(ctx context.Context) *SignedProvenance {
+	p, _ := ctx.Value(signedProvenanceKey{}).(*SignedProvenance)
+	return p
+}
+
+// VSASubject is a subject of the signed provenance, along with the provenance.
+type VSASubject struct {
+	Subject    *intoto.ResourceDescriptor
+	Provenance *SignedProvenance
+}
+
+// VSAArtifact is the verification summary attestation of each subject of the
+// provenance signed for a run.
+type VSAArtifact struct{}
+
+var _ Signable = &VSAArtifact{}
+
+// ExtractObjects returns the subjects of the provenance signed so far, which
+// requires the VSA artifact to be signed after the TaskRun and PipelineRun ones.
+func (va *VSAArtifact) ExtractObjects(ctx context.Context, obj objects.TektonObject) []interface{} {
+	p := SignedProvenanceFromContext(ctx)
+	if p == nil || len(p.Attestations) == 0 {
+		return nil
+	}
+	objs := []interface{}{}
+	for _, s := range p.Subjects {
+		objs = append(objs, &VSASubject{Subject: s, Provenance: p})
+	}
+	return objs
+}
+
+func (va *VSAArtifact) Type() string {
+	return "vsa"
+}
+
+func (va *VSAArtifact) StorageBackend(cfg config.Config) sets.Set[string] {
+	return cfg.Artifacts.VSA.StorageBackend
+}
+
+func (va *VSAArtifact) PayloadFormat(cfg config.Config) config.PayloadType {
+	return config.PayloadType(cfg.Artifacts.VSA.Format)
+}
+
+func (va *VSAArtifact) Signer(cfg config.Config) string {
+	return cfg.Artifacts.VSA.Signer
+}
+
+func (va *VSAArtifact) TlogEntryType(cfg config.Config) string {
+	return cfg.Artifacts.VSA.TlogEntryType(cfg.Transparency)
+}
+
+// ShortKey returns "vsa-" followed by the first 12 chars of the subject digest.
+func (va *VSAArtifact) ShortKey(obj interface{}) string {
+	v := obj.(*VSASubject)
+	_, h, _ := strings.Cut(subjectDigest(v.Subject), ":")
+	if len(h) > 12 {
+		h = h[:12]
+	}
+	return "vsa-" + h
+}
+
+// FullKey returns the subject name pinned to its digest, as `<NAME>@<ALG>:<HEX>`.
+func (va *VSAArtifact) FullKey(obj interface{}) string {
+	v := obj.(*VSASubject)
+	return fmt.Sprintf("%s@%s", v.Subject.GetName(), subjectDigest(v.Subject))
+}
+
+// Enabled returns true when a storage backend is configured for the attestations,
+// which are not generated by default.
+func (va *VSAArtifact) Enabled(cfg config.Config) bool {
+	return cfg.Artifacts.VSA.StorageBackend.Len() > 0 && cfg.Artifacts.VSA.Enabled()
+}
+
+// subjectDigest returns the sha256 digest of the subject as `<ALG>:<HEX>`, or its
+// first digest in the order of the algorithms when it has none.
+func subjectDigest(subject *intoto.ResourceDescriptor) string {
+	digests := subject.GetDigest()
+	if h, ok := digests["sha256"]; ok {
+		return "sha256:" + h
+	}
+	algs := make([]string, 0, len(digests))
+	for alg := range digests {
+		algs = append(algs, alg)
+	}
+	if len(algs) == 0 {
+		return ""
+	}
+	sort.Strings(algs)
+	return algs[0] + ":" + digests[algs[0]]
+}
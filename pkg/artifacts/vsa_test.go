@@ -0,0 +1,99 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package artifacts
+
+import (
+	"context"
+	"testing"
+
+	"github.com/tektoncd/chains/pkg/chains/objects"
+	"github.com/tektoncd/chains/pkg/config"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const vsaStatement = `{
+	"_type": "https://in-toto.io/Statement/v1",
+	"predicateType": "https://slsa.dev/provenance/v1",
+	"subject": [
+		{"name": "gcr.io/foo/bar", "digest": {"sha256": "05f95b26ed10668b7183c1e2da98610e91372fa9f510046d4ce5812addad86b5"}},
+		{"name": "gcr.io/foo/baz", "digest": {"sha512": "a0cfeb3bb3e3ec5a", "sha1": "0d4a9b4fe9f6b6bb"}}
+	],
+	"predicate": {"buildDefinition": {}}
+}`
+
+func TestVSAArtifact(t *testing.T) {
+	ctx := context.Background()
+	va := &VSAArtifact{}
+	obj := objects.NewTaskRunObjectV1(&v1.TaskRun{})
+
+	if got := va.ExtractObjects(ctx, obj); len(got) != 0 {
+		t.Errorf("expected no subjects without signed provenance, got %v", got)
+	}
+
+	p := &SignedProvenance{Compliant: true}
+	ctx = WithSignedProvenance(ctx, p)
+	if got := va.ExtractObjects(ctx, obj); len(got) != 0 {
+		t.Errorf("expected no subjects before the provenance is signed, got %v", got)
+	}
+	if err := p.Add("tekton.dev-v1-TaskRun-uid", []byte(vsaStatement), []byte("envelope")); err != nil {
+		t.Fatal(err)
+	}
+	// The subjects of a second statement are only summarized once.
+	if err := p.Add("tekton.dev-v1-PipelineRun-uid", []byte(vsaStatement), []byte("envelope")); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Add("tekton", []byte(`not a statement`), []byte("envelope")); err == nil {
+		t.Error("expected an error recording a payload that is not a statement")
+	}
+	if len(p.Attestations) != 2 {
+		t.Errorf("expected 2 input attestations, got %d", len(p.Attestations))
+	}
+	if got, want := p.Attestations[0].GetDigest()["sha256"], "4c503ca67761e5c4aaecfe996244c25d8c0b40902d1085c85b4468bd567548c6"; got != want {
+		t.Errorf("expected the sha256 digest of the envelope, got %q", got)
+	}
+
+	subjects := va.ExtractObjects(ctx, obj)
+	if len(subjects) != 2 {
+		t.Fatalf("expected 2 subjects, got %d", len(subjects))
+	}
+	keys := []struct{ short, full string }{
+		{"vsa-05f95b26ed10", "gcr.io/foo/bar@sha256:05f95b26ed10668b7183c1e2da98610e91372fa9f510046d4ce5812addad86b5"},
+		{"vsa-0d4a9b4fe9f6", "gcr.io/foo/baz@sha1:0d4a9b4fe9f6b6bb"},
+	}
+	for i, want := range keys {
+		if got := va.ShortKey(subjects[i]); got != want.short {
+			t.Errorf("ShortKey() = %q, want %q", got, want.short)
+		}
+		if got := va.FullKey(subjects[i]); got != want.full {
+			t.Errorf("FullKey() = %q, want %q", got, want.full)
+		}
+	}
+}
+
+func TestVSAArtifactEnabled(t *testing.T) {
+	va := &VSAArtifact{}
+	cfg := config.Config{Artifacts: config.ArtifactConfigs{VSA: config.Artifact{Format: "vsa", Signer: "x509"}}}
+	if va.Enabled(cfg) {
+		t.Error("expected the verification summaries to be disabled without a storage backend")
+	}
+	cfg.Artifacts.VSA.StorageBackend = sets.New[string]("tekton")
+	if !va.Enabled(cfg) {
+		t.Error("expected the verification summaries to be enabled")
+	}
+	cfg.Artifacts.VSA.Signer = "none"
+	if va.Enabled(cfg) {
+		t.Error("expected the verification summaries to be disabled without a signer")
+	}
+}
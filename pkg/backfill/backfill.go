@@ -0,0 +1,262 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package backfill requests the runs matching a label selector and a time
+// window to be signed again by the Chains controller.
+package backfill
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tektoncd/chains/pkg/chains/annotations"
+	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/logging"
+)
+
+const (
+	// KindTaskRun, KindPipelineRun and KindCustomRun are the kinds of runs that
+	// can be signed again.
+	KindTaskRun     = "taskrun"
+	KindPipelineRun = "pipelinerun"
+	KindCustomRun   = "customrun"
+
+	pageSize         = 100
+	progressInterval = 100
+)
+
+// Options select the runs to sign again.
+type Options struct {
+	// Namespaces are the namespaces of the runs, all of them when empty.
+	Namespaces []string
+	// Selector is the label selector of the runs.
+	Selector string
+	// Since and Until bound the completion time of the runs, when set.
+	Since time.Time
+	Until time.Time
+	// Kinds are the kinds of runs to sign again.
+	Kinds []string
+	// Reason is the reason of the re-sign requests. The runs already requested
+	// to be signed again for the same reason are skipped, so that an interrupted
+	// backfill can be resumed.
+	Reason string
+	// Format, when set, is the format requested for the new provenance.
+	Format string
+	// QPS is the maximum number of runs requested per second, unlimited when zero.
+	QPS float64
+}
+
+// Progress counts the runs processed by a backfill.
+type Progress struct {
+	// Matched is the number of completed runs matching the options.
+	Matched int
+	// Requested is the number of runs requested to be signed again.
+	Requested int
+	// Skipped is the number of runs already requested to be signed again for
+	// the same reason.
+	Skipped int
+	// Failed is the number of runs that could not be requested to be signed again.
+	Failed int
+}
+
+func (p Progress) String() string {
+	return fmt.Sprintf("%d runs matched, %d requested, %d skipped, %d failed", p.Matched, p.Requested, p.Skipped, p.Failed)
+}
+
+// run holds the fields of a run the backfill needs.
+type run struct {
+	name        string
+	namespace   string
+	annotations map[string]string
+	done        bool
+	completed   *metav1.Time
+}
+
+// resource lists and patches the runs of a kind.
+type resource struct {
+	list  func(ctx context.Context, namespace string, opts metav1.ListOptions) ([]run, string, error)
+	patch func(ctx context.Context, namespace, name string, patch []byte) error
+}
+
+func resources(client versioned.Interface) map[string]resource {
+	return map[string]resource{
+		KindTaskRun: {
+			list: func(ctx context.Context, namespace string, opts metav1.ListOptions) ([]run, string, error) {
+				list, err := client.TektonV1().TaskRuns(namespace).List(ctx, opts)
+				if err != nil {
+					return nil, "", err
+				}
+				runs := make([]run, 0, len(list.Items))
+				for _, tr := range list.Items {
+					runs = append(runs, run{tr.Name, tr.Namespace, tr.Annotations, tr.IsDone(), tr.Status.CompletionTime})
+				}
+				return runs, list.Continue, nil
+			},
+			patch: func(ctx context.Context, namespace, name string, patch []byte) error {
+				_, err := client.TektonV1().TaskRuns(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+				return err
+			},
+		},
+		KindPipelineRun: {
+			list: func(ctx context.Context, namespace string, opts metav1.ListOptions) ([]run, string, error) {
+				list, err := client.TektonV1().PipelineRuns(namespace).List(ctx, opts)
+				if err != nil {
+					return nil, "", err
+				}
+				runs := make([]run, 0, len(list.Items))
+				for _, pr := range list.Items {
+					runs = append(runs, run{pr.Name, pr.Namespace, pr.Annotations, pr.IsDone(), pr.Status.CompletionTime})
+				}
+				return runs, list.Continue, nil
+			},
+			patch: func(ctx context.Context, namespace, name string, patch []byte) error {
+				_, err := client.TektonV1().PipelineRuns(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+				return err
+			},
+		},
+		KindCustomRun: {
+			list: func(ctx context.Context, namespace string, opts metav1.ListOptions) ([]run, string, error) {
+				list, err := client.TektonV1beta1().CustomRuns(namespace).List(ctx, opts)
+				if err != nil {
+					return nil, "", err
+				}
+				runs := make([]run, 0, len(list.Items))
+				for _, cr := range list.Items {
+					runs = append(runs, run{cr.Name, cr.Namespace, cr.Annotations, cr.IsDone(), cr.Status.CompletionTime})
+				}
+				return runs, list.Continue, nil
+			},
+			patch: func(ctx context.Context, namespace, name string, patch []byte) error {
+				_, err := client.TektonV1beta1().CustomRuns(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+				return err
+			},
+		},
+	}
+}
+
+// Run requests the completed runs matching the options to be signed again, by
+// annotating them with a re-sign request that the Chains controller handles.
+// The runs that cannot be annotated are logged and counted as failed.
+func Run(ctx context.Context, client versioned.Interface, opts Options) (Progress, error) {
+	logger := logging.FromContext(ctx)
+	progress := Progress{}
+
+	if opts.Reason == "" {
+		return progress, errors.New("the reason of the re-sign requests is required")
+	}
+	if !opts.Since.IsZero() && !opts.Until.IsZero() && opts.Until.Before(opts.Since) {
+		return progress, fmt.Errorf("the end of the time window %s is before its start %s", opts.Until, opts.Since)
+	}
+	all := resources(client)
+	for _, kind := range opts.Kinds {
+		if _, ok := all[kind]; !ok {
+			return progress, fmt.Errorf("unsupported kind %q", kind)
+		}
+	}
+	patch, err := requestPatch(opts)
+	if err != nil {
+		return progress, err
+	}
+
+	var tick <-chan time.Time
+	if opts.QPS > 0 {
+		ticker := time.NewTicker(time.Duration(float64(time.Second) / opts.QPS))
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	namespaces := opts.Namespaces
+	if len(namespaces) == 0 {
+		namespaces = []string{metav1.NamespaceAll}
+	}
+	for _, kind := range opts.Kinds {
+		res := all[kind]
+		for _, namespace := range namespaces {
+			listOpts := metav1.ListOptions{LabelSelector: opts.Selector, Limit: pageSize}
+			for {
+				runs, next, err := res.list(ctx, namespace, listOpts)
+				if err != nil {
+					return progress, fmt.Errorf("listing %ss: %w", kind, err)
+				}
+				for _, r := range runs {
+					if !r.done || !inWindow(r.completed, opts.Since, opts.Until) {
+						continue
+					}
+					progress.Matched++
+					if r.annotations[annotations.ResignAnnotation] == opts.Reason {
+						progress.Skipped++
+					} else {
+						if tick != nil {
+							select {
+							case <-ctx.Done():
+								return progress, ctx.Err()
+							case <-tick:
+							}
+						}
+						if err := res.patch(ctx, r.namespace, r.name, patch); err != nil {
+							logger.Errorf("Requesting %s %s/%s to be signed again: %v", kind, r.namespace, r.name, err)
+							progress.Failed++
+						} else {
+							progress.Requested++
+						}
+					}
+					if progress.Matched%progressInterval == 0 {
+						logger.Infof("Backfill in progress: %s", progress)
+					}
+				}
+				if next == "" {
+					break
+				}
+				listOpts.Continue = next
+			}
+		}
+	}
+	logger.Infof("Backfill done: %s", progress)
+	return progress, nil
+}
+
+// requestPatch returns the merge patch annotating a run with the re-sign request.
+func requestPatch(opts Options) ([]byte, error) {
+	requestAnnotations := map[string]any{
+		annotations.ResignAnnotation: opts.Reason,
+		// Remove the format of a previous request.
+		annotations.ResignFormatAnnotation: nil,
+	}
+	if opts.Format != "" {
+		requestAnnotations[annotations.ResignFormatAnnotation] = opts.Format
+	}
+	return json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"annotations": requestAnnotations,
+		},
+	})
+}
+
+// inWindow returns true when the completion time is within the bounds that are set.
+func inWindow(completed *metav1.Time, since, until time.Time) bool {
+	if since.IsZero() && until.IsZero() {
+		return true
+	}
+	if completed == nil {
+		return false
+	}
+	if !since.IsZero() && completed.Time.Before(since) {
+		return false
+	}
+	return until.IsZero() || !completed.Time.After(until)
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backfill
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/chains/pkg/chains/annotations"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	fakepipelineclientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+var (
+	start = time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	end   = time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func taskRun(name string, completed time.Time, labels, runAnnotations map[string]string) *v1.TaskRun {
+	tr := &v1.TaskRun{ObjectMeta: metav1.ObjectMeta{
+		Namespace:   "default",
+		Name:        name,
+		Labels:      labels,
+		Annotations: runAnnotations,
+	}}
+	if !completed.IsZero() {
+		tr.Status.Conditions = duckv1.Conditions{{Type: apis.ConditionSucceeded, Status: "True"}}
+		tr.Status.CompletionTime = &metav1.Time{Time: completed}
+	}
+	return tr
+}
+
+func TestRun(t *testing.T) {
+	ctx := logtesting.TestContextWithLogger(t)
+	team := map[string]string{"team": "payments"}
+	client := fakepipelineclientset.NewSimpleClientset(
+		taskRun("in-window", start.Add(time.Hour), team, nil),
+		taskRun("previous-format", start.Add(2*time.Hour), team, map[string]string{
+			annotations.ResignAnnotation:       "previous",
+			annotations.ResignFormatAnnotation: "slsa/v1",
+		}),
+		taskRun("already-requested", start.Add(3*time.Hour), team, map[string]string{annotations.ResignAnnotation: "new format"}),
+		taskRun("before-window", start.Add(-time.Hour), team, nil),
+		taskRun("after-window", end.Add(time.Hour), team, nil),
+		taskRun("running", time.Time{}, team, nil),
+		taskRun("other-team", start.Add(time.Hour), map[string]string{"team": "web"}, nil),
+		&v1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pipeline", Labels: team},
+			Status: v1.PipelineRunStatus{
+				Status: duckv1.Status{Conditions: duckv1.Conditions{{Type: apis.ConditionSucceeded, Status: "False"}}},
+				PipelineRunStatusFields: v1.PipelineRunStatusFields{
+					CompletionTime: &metav1.Time{Time: start.Add(time.Hour)},
+				},
+			},
+		},
+		&v1beta1.CustomRun{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "custom", Labels: team},
+		},
+	)
+
+	progress, err := Run(ctx, client, Options{
+		Selector: "team=payments",
+		Since:    start,
+		Until:    end,
+		Kinds:    []string{KindTaskRun, KindPipelineRun, KindCustomRun},
+		Reason:   "new format",
+		Format:   "slsa/v1.1",
+		QPS:      1000,
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if d := cmp.Diff(Progress{Matched: 4, Requested: 3, Skipped: 1}, progress); d != "" {
+		t.Errorf("progress (-want, +got):\n%s", d)
+	}
+
+	want := map[string]string{annotations.ResignAnnotation: "new format", annotations.ResignFormatAnnotation: "slsa/v1.1"}
+	for _, name := range []string{"in-window", "previous-format"} {
+		tr, err := client.TektonV1().TaskRuns("default").Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d := cmp.Diff(want, tr.Annotations); d != "" {
+			t.Errorf("annotations of %s (-want, +got):\n%s", name, d)
+		}
+	}
+	pr, err := client.TektonV1().PipelineRuns("default").Get(ctx, "pipeline", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := cmp.Diff(want, pr.Annotations); d != "" {
+		t.Errorf("annotations of the PipelineRun (-want, +got):\n%s", d)
+	}
+	for _, name := range []string{"before-window", "after-window", "running", "other-team"} {
+		tr, err := client.TektonV1().TaskRuns("default").Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := tr.Annotations[annotations.ResignAnnotation]; ok {
+			t.Errorf("expected %s not to be requested to be signed again", name)
+		}
+	}
+}
+
+func TestRun_Invalid(t *testing.T) {
+	ctx := logtesting.TestContextWithLogger(t)
+	client := fakepipelineclientset.NewSimpleClientset()
+	for name, opts := range map[string]Options{
+		"no reason":       {Kinds: []string{KindTaskRun}},
+		"unknown kind":    {Kinds: []string{"taskruns"}, Reason: "new format"},
+		"inverted window": {Kinds: []string{KindTaskRun}, Reason: "new format", Since: end, Until: start},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Run(ctx, client, opts); err == nil {
+				t.Error("expected an error for invalid options, got nil")
+			}
+		})
+	}
+}
+
+func TestRun_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(logtesting.TestContextWithLogger(t))
+	cancel()
+	objs := []runtime.Object{}
+	for _, name := range []string{"first", "second"} {
+		objs = append(objs, taskRun(name, start, nil, nil))
+	}
+	client := fakepipelineclientset.NewSimpleClientset(objs...)
+
+	progress, err := Run(ctx, client, Options{Kinds: []string{KindTaskRun}, Reason: "new format", QPS: 0.001})
+	if err == nil {
+		t.Error("expected an error for a cancelled backfill, got nil")
+	}
+	if progress.Requested != 0 {
+		t.Errorf("expected no run requested, got %d", progress.Requested)
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/tektoncd/chains/pkg/chains/objects"
@@ -33,7 +34,48 @@ const (
 	ChainsAnnotation             = ChainsAnnotationPrefix + "signed"
 	RetryAnnotation              = ChainsAnnotationPrefix + "retries"
 	ChainsTransparencyAnnotation = ChainsAnnotationPrefix + "transparency"
-	MaxRetries                   = 3
+	// SignedMACAnnotation authenticates the ChainsAnnotation marker with an HMAC
+	// of the object UID and the marker value, keyed by the marker key.
+	SignedMACAnnotation = ChainsAnnotationPrefix + "signed-mac"
+	// PolicyResultAnnotation holds the outcome of the policies evaluated before
+	// signing the object, either compliant or non-compliant.
+	PolicyResultAnnotation = ChainsAnnotationPrefix + "policy-result"
+	// PolicyViolationsAnnotation holds the JSON list of the policy violations.
+	PolicyViolationsAnnotation = ChainsAnnotationPrefix + "policy-violations"
+	// OptInAnnotation opts a run in, as an annotation or a label, when only the
+	// runs opted in are processed.
+	OptInAnnotation = ChainsAnnotationPrefix + "opt-in"
+	// SkipAnnotation, FormatAnnotation and StorageAnnotation override how a run
+	// is signed, when the cluster allows it.
+	SkipAnnotation    = ChainsAnnotationPrefix + "skip"
+	FormatAnnotation  = ChainsAnnotationPrefix + "format"
+	StorageAnnotation = ChainsAnnotationPrefix + "storage"
+	// ResignAnnotation requests the object to be signed again, with the reason
+	// of the request as value. ResignFormatAnnotation optionally requests the
+	// format of the new provenance.
+	ResignAnnotation       = ChainsAnnotationPrefix + "resign"
+	ResignFormatAnnotation = ChainsAnnotationPrefix + "resign-format"
+	// ResignedAnnotation holds the reason of the last re-sign request handled,
+	// so that a request is handled once.
+	ResignedAnnotation = ChainsAnnotationPrefix + "resigned"
+	// StatusAnnotation holds the JSON summary of the outcome of signing the
+	// object, per artifact and storage backend, when it is enabled.
+	StatusAnnotation = ChainsAnnotationPrefix + "status"
+	// TransparencyPendingAnnotation holds the transparency log uploads that failed
+	// after the object was signed, so they can be retried in the background.
+	// It is emptied once all of them have been uploaded.
+	TransparencyPendingAnnotation = ChainsAnnotationPrefix + "transparency-pending"
+	// RetryTimesAnnotation holds the comma-separated RFC 3339 times of the
+	// failures the object was retried after, the backoff of the next retry
+	// being counted from the last one.
+	RetryTimesAnnotation = ChainsAnnotationPrefix + "retry-times"
+
+	// ResigningMarker is the value of the signed marker of the objects waiting
+	// to be signed again.
+	ResigningMarker = "resigning"
+	// SkippedMarker is the value of the signed marker of the objects not signed
+	// because of their SkipAnnotation.
+	SkippedMarker = "skipped"
 )
 
 // Reconciled determines whether a Tekton object has already been reconciled.
@@ -41,8 +83,10 @@ const (
 // has not been reconciled, then Reconciled fetches the latest version of the
 // TektonObject from the cluster and inspects that version as well. This aims
 // to avoid creating multiple attestations due to a stale cached TektonObject.
+// When the context holds a marker key, markers that were not written by Chains
+// are ignored.
 func Reconciled(ctx context.Context, client versioned.Interface, obj objects.TektonObject) bool {
-	if reconciledFromAnnotations(obj.GetAnnotations()) {
+	if reconciledFromAnnotations(ctx, obj, obj.GetAnnotations()) {
 		return true
 	}
 
@@ -52,15 +96,53 @@ func Reconciled(ctx context.Context, client versioned.Interface, obj objects.Tek
 		logger.Warnf("Ignoring error when fetching latest annotations: %s", err)
 		return false
 	}
-	return reconciledFromAnnotations(annotations)
+	return reconciledFromAnnotations(ctx, obj, annotations)
+}
+
+// ReconciledLocally is like Reconciled, but only checks the annotations of the Tekton object
+// without fetching the latest ones. It suits the objects that are not in the cluster anymore.
+func ReconciledLocally(ctx context.Context, obj objects.TektonObject) bool {
+	return reconciledFromAnnotations(ctx, obj, obj.GetAnnotations())
 }
 
-func reconciledFromAnnotations(annotations map[string]string) bool {
+func reconciledFromAnnotations(ctx context.Context, obj objects.TektonObject, annotations map[string]string) bool {
 	val, ok := annotations[ChainsAnnotation]
 	if !ok {
 		return false
 	}
-	return val == "true" || val == "failed"
+	return (val == "true" || val == "failed" || val == SkippedMarker) && authenticMarker(ctx, obj, annotations)
+}
+
+// TlogPending returns true when the Tekton object has been signed but some of its
+// transparency log uploads are still pending.
+func TlogPending(annotations map[string]string) bool {
+	return annotations[ChainsAnnotation] == "true" && annotations[TransparencyPendingAnnotation] != ""
+}
+
+// ResignRequested returns true when the annotations hold a re-sign request that
+// has not been handled yet.
+func ResignRequested(annotations map[string]string) bool {
+	reason := annotations[ResignAnnotation]
+	return reason != "" && annotations[ResignedAnnotation] != reason
+}
+
+// Resigned returns true when the last re-sign request of the annotations has
+// been handled.
+func Resigned(annotations map[string]string) bool {
+	reason := annotations[ResignAnnotation]
+	return reason != "" && annotations[ResignedAnnotation] == reason
+}
+
+// ResetForResign handles the re-sign request of a Tekton object: it resets the
+// signed marker, the retries and the pending transparency log uploads, so that
+// the object is signed again, and records the request as handled.
+func ResetForResign(ctx context.Context, obj objects.TektonObject, ps versioned.Interface) error {
+	return AddAnnotations(ctx, obj, ps, markerAnnotations(ctx, obj, map[string]string{
+		RetryAnnotation:               "",
+		RetryTimesAnnotation:          "",
+		TransparencyPendingAnnotation: "",
+		ResignedAnnotation:            obj.GetAnnotations()[ResignAnnotation],
+	}, ResigningMarker))
 }
 
 // mergeAnnotations creates a new map with existing annotations plus a new key-value pair
@@ -75,42 +157,72 @@ func mergeAnnotations(annotations map[string]string, key, value string) map[stri
 
 // MarkSigned marks a Tekton object as signed.
 func MarkSigned(ctx context.Context, obj objects.TektonObject, ps versioned.Interface, annotations map[string]string) error {
-	if _, ok := obj.GetAnnotations()[ChainsAnnotation]; ok {
+	if _, ok := obj.GetAnnotations()[ChainsAnnotation]; ok && !ForgedMarker(ctx, obj) {
 		// Object is already signed, but we may still need to apply additional annotations
 		if len(annotations) > 0 {
-			return AddAnnotations(ctx, obj, ps, mergeAnnotations(annotations, ChainsAnnotation, "true"))
+			return AddAnnotations(ctx, obj, ps, markerAnnotations(ctx, obj, annotations, "true"))
 		}
 		return nil
 	}
-	return AddAnnotations(ctx, obj, ps, mergeAnnotations(annotations, ChainsAnnotation, "true"))
+	return AddAnnotations(ctx, obj, ps, markerAnnotations(ctx, obj, annotations, "true"))
+}
+
+// MarkSkipped marks the Tekton object as reconciled without being signed.
+func MarkSkipped(ctx context.Context, obj objects.TektonObject, ps versioned.Interface) error {
+	return AddAnnotations(ctx, obj, ps, markerAnnotations(ctx, obj, map[string]string{}, SkippedMarker))
 }
 
 func MarkFailed(ctx context.Context, obj objects.TektonObject, ps versioned.Interface, annotations map[string]string) error {
-	return AddAnnotations(ctx, obj, ps, mergeAnnotations(annotations, ChainsAnnotation, "failed"))
+	return AddAnnotations(ctx, obj, ps, markerAnnotations(ctx, obj, annotations, "failed"))
 }
 
-func RetryAvailable(obj objects.TektonObject) bool {
+// RetryAvailable returns true when the object can be retried after a failure,
+// given the maximum number of retries.
+func RetryAvailable(obj objects.TektonObject, maxRetries int) bool {
 	ann, ok := obj.GetAnnotations()[RetryAnnotation]
-	if !ok {
-		return true
+	if !ok || ann == "" {
+		return maxRetries > 0
 	}
 	val, err := strconv.Atoi(ann)
 	if err != nil {
 		return false
 	}
-	return val < MaxRetries
+	return val < maxRetries
 }
 
+// AddRetry counts a retry of the object and records the time of the failure.
 func AddRetry(ctx context.Context, obj objects.TektonObject, ps versioned.Interface, annotations map[string]string) error {
-	ann := obj.GetAnnotations()[RetryAnnotation]
-	if ann == "" {
-		return AddAnnotations(ctx, obj, ps, mergeAnnotations(annotations, RetryAnnotation, "0"))
+	retry := "0"
+	if ann := obj.GetAnnotations()[RetryAnnotation]; ann != "" {
+		val, err := strconv.Atoi(ann)
+		if err != nil {
+			return errors.Wrap(err, "adding retry")
+		}
+		retry = fmt.Sprintf("%d", val+1)
 	}
-	val, err := strconv.Atoi(ann)
+	retryTimes := time.Now().UTC().Format(time.RFC3339)
+	if previous := obj.GetAnnotations()[RetryTimesAnnotation]; previous != "" {
+		retryTimes = previous + "," + retryTimes
+	}
+	merged := mergeAnnotations(annotations, RetryAnnotation, retry)
+	merged[RetryTimesAnnotation] = retryTimes
+	return AddAnnotations(ctx, obj, ps, merged)
+}
+
+// LastRetry returns the number of the last retry of the object, counted from
+// zero, and the time of the failure it was scheduled after. It returns false
+// when the object was not retried.
+func LastRetry(annotations map[string]string) (int, time.Time, bool) {
+	retry, err := strconv.Atoi(annotations[RetryAnnotation])
+	if err != nil {
+		return 0, time.Time{}, false
+	}
+	retryTimes := strings.Split(annotations[RetryTimesAnnotation], ",")
+	last, err := time.Parse(time.RFC3339, retryTimes[len(retryTimes)-1])
 	if err != nil {
-		return errors.Wrap(err, "adding retry")
+		return 0, time.Time{}, false
 	}
-	return AddAnnotations(ctx, obj, ps, mergeAnnotations(annotations, RetryAnnotation, fmt.Sprintf("%d", val+1)))
+	return retry, last, true
 }
 
 // AddAnnotations adds annotation to the k8s object
@@ -155,8 +267,8 @@ func AddAnnotations(ctx context.Context, obj objects.TektonObject, ps versioned.
 }
 
 // HandleRetry handles retries managed as annotation on the k8s object
-func HandleRetry(ctx context.Context, obj objects.TektonObject, ps versioned.Interface, annotationsMap map[string]string) error {
-	if RetryAvailable(obj) {
+func HandleRetry(ctx context.Context, obj objects.TektonObject, ps versioned.Interface, annotationsMap map[string]string, maxRetries int) error {
+	if RetryAvailable(obj, maxRetries) {
 		return AddRetry(ctx, obj, ps, annotationsMap)
 	}
 	return MarkFailed(ctx, obj, ps, annotationsMap)
@@ -14,7 +14,9 @@ limitations under the License.
 package annotations
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/tektoncd/chains/pkg/chains/objects"
 	"github.com/tektoncd/chains/pkg/test/tekton"
@@ -126,6 +128,23 @@ func TestReconciled(t *testing.T) {
 	}
 }
 
+func TestReconciledLocally(t *testing.T) {
+	ctx, _ := rtesting.SetupFakeContext(t)
+	for annotation, want := range map[string]bool{"true": true, "failed": true, "baz": false, "": false} {
+		// The TaskRun is not in the cluster, as when it was archived and pruned.
+		taskRun := objects.NewTaskRunObjectV1(&v1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					ChainsAnnotation: annotation,
+				},
+			},
+		})
+		if got := ReconciledLocally(ctx, taskRun); got != want {
+			t.Errorf("ReconciledLocally() with annotation %q got = %v, want %v", annotation, got, want)
+		}
+	}
+}
+
 func TestMarkSigned(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -247,7 +266,7 @@ func TestMarkFailed(t *testing.T) {
 			tekton.CreateObject(t, ctx, c, tt.object)
 
 			// Test HandleRetry, should mark it as failed
-			if err := HandleRetry(ctx, tt.object, c, nil); err != nil {
+			if err := HandleRetry(ctx, tt.object, c, nil, 3); err != nil {
 				t.Errorf("HandleRetry() error = %v", err)
 			}
 
@@ -279,6 +298,12 @@ func TestRetryAvailble(t *testing.T) {
 				RetryAnnotation: "2",
 			},
 			expected: true,
+		}, {
+			description: "annotation reset",
+			annotations: map[string]string{
+				RetryAnnotation: "",
+			},
+			expected: true,
 		}, {
 			description: "annotation not a number",
 			annotations: map[string]string{
@@ -300,7 +325,7 @@ func TestRetryAvailble(t *testing.T) {
 				},
 			}
 			trObj := objects.NewTaskRunObjectV1(tr)
-			got := RetryAvailable(trObj)
+			got := RetryAvailable(trObj, 3)
 			if got != test.expected {
 				t.Fatalf("RetryAvailble() got %v expected %v", got, test.expected)
 			}
@@ -311,7 +336,7 @@ func TestRetryAvailble(t *testing.T) {
 				},
 			}
 			prObj := objects.NewPipelineRunObjectV1(pr)
-			got = RetryAvailable(prObj)
+			got = RetryAvailable(prObj, 3)
 			if got != test.expected {
 				t.Fatalf("RetryAvailble() got %v expected %v", got, test.expected)
 			}
@@ -319,6 +344,46 @@ func TestRetryAvailble(t *testing.T) {
 	}
 }
 
+func TestLastRetry(t *testing.T) {
+	last := time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		wantRetry   int
+		wantOK      bool
+	}{{
+		name: "not retried",
+	}, {
+		name: "retried",
+		annotations: map[string]string{
+			RetryAnnotation:      "1",
+			RetryTimesAnnotation: "2026-05-01T09:59:00Z,2026-05-01T10:00:00Z",
+		},
+		wantRetry: 1,
+		wantOK:    true,
+	}, {
+		name: "retries reset",
+		annotations: map[string]string{
+			RetryAnnotation:      "",
+			RetryTimesAnnotation: "",
+		},
+	}, {
+		name:        "retried before the times were recorded",
+		annotations: map[string]string{RetryAnnotation: "0"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			retry, at, ok := LastRetry(tt.annotations)
+			if ok != tt.wantOK || retry != tt.wantRetry {
+				t.Fatalf("LastRetry() = %d, %v, want %d, %v", retry, ok, tt.wantRetry, tt.wantOK)
+			}
+			if ok && !at.Equal(last) {
+				t.Errorf("LastRetry() time = %v, want %v", at, last)
+			}
+		})
+	}
+}
+
 func TestAddRetry(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -369,6 +434,12 @@ func TestAddRetry(t *testing.T) {
 			if val, ok := signed.GetAnnotations()[RetryAnnotation]; val != "1" {
 				t.Fatalf("annotation isn't correct: %v %v", ok, val)
 			}
+			if retryTimes := strings.Split(signed.GetAnnotations()[RetryTimesAnnotation], ","); len(retryTimes) != 2 {
+				t.Fatalf("expected the times of 2 retries, got %v", retryTimes)
+			}
+			if retry, _, ok := LastRetry(signed.GetAnnotations()); !ok || retry != 1 {
+				t.Fatalf("LastRetry() = %v, %v, want 1, true", retry, ok)
+			}
 		})
 	}
 }
@@ -618,3 +689,136 @@ func TestTransparencyAnnotation(t *testing.T) {
 		t.Errorf("Expected transparency annotation value %q, got %q", expectedValue, annotations[ChainsTransparencyAnnotation])
 	}
 }
+
+func TestTlogPending(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        bool
+	}{
+		{
+			name: "not signed",
+			annotations: map[string]string{
+				TransparencyPendingAnnotation: "pending",
+			},
+		},
+		{
+			name: "signed, nothing pending",
+			annotations: map[string]string{
+				ChainsAnnotation: "true",
+			},
+		},
+		{
+			name: "signed, pending uploads done",
+			annotations: map[string]string{
+				ChainsAnnotation:              "true",
+				TransparencyPendingAnnotation: "",
+			},
+		},
+		{
+			name: "signed, uploads pending",
+			annotations: map[string]string{
+				ChainsAnnotation:              "true",
+				TransparencyPendingAnnotation: "pending",
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TlogPending(tt.annotations); got != tt.want {
+				t.Errorf("TlogPending() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResignRequested(t *testing.T) {
+	tests := []struct {
+		name          string
+		annotations   map[string]string
+		wantRequested bool
+		wantResigned  bool
+	}{
+		{
+			name: "no request",
+		},
+		{
+			name:          "new request",
+			annotations:   map[string]string{ResignAnnotation: "new format"},
+			wantRequested: true,
+		},
+		{
+			name:          "another request",
+			annotations:   map[string]string{ResignAnnotation: "storage outage", ResignedAnnotation: "new format"},
+			wantRequested: true,
+		},
+		{
+			name:         "handled request",
+			annotations:  map[string]string{ResignAnnotation: "new format", ResignedAnnotation: "new format"},
+			wantResigned: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ResignRequested(tt.annotations); got != tt.wantRequested {
+				t.Errorf("ResignRequested() = %v, want %v", got, tt.wantRequested)
+			}
+			if got := Resigned(tt.annotations); got != tt.wantResigned {
+				t.Errorf("Resigned() = %v, want %v", got, tt.wantResigned)
+			}
+		})
+	}
+}
+
+func TestResetForResign(t *testing.T) {
+	ctx, _ := rtesting.SetupFakeContext(t)
+	ctx = WithMarkerKey(ctx, []byte("0123456789abcdef0123456789abcdef"))
+	c := fakepipelineclient.Get(ctx)
+
+	obj := objects.NewTaskRunObjectV1(&v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-taskrun",
+			UID:  "my-taskrun-uid",
+		},
+	})
+	tekton.CreateObject(t, ctx, c, obj)
+	if err := MarkFailed(ctx, obj, c, map[string]string{
+		RetryAnnotation:               "3",
+		RetryTimesAnnotation:          "2026-05-01T09:59:00Z,2026-05-01T10:00:00Z",
+		TransparencyPendingAnnotation: "pending",
+		ResignAnnotation:              "storage outage",
+	}); err != nil {
+		t.Fatalf("MarkFailed() error = %v", err)
+	}
+	failed, err := tekton.GetObject(t, ctx, c, obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !Reconciled(ctx, c, failed) || !ResignRequested(failed.GetAnnotations()) {
+		t.Fatal("expected a failed object with a re-sign request")
+	}
+
+	if err := ResetForResign(ctx, failed, c); err != nil {
+		t.Fatalf("ResetForResign() error = %v", err)
+	}
+	reset, err := tekton.GetObject(t, ctx, c, obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if Reconciled(ctx, c, reset) {
+		t.Error("expected the object to be signed again")
+	}
+	if ForgedMarker(ctx, reset) {
+		t.Error("expected the reset marker to be authentic")
+	}
+	if _, _, retried := LastRetry(reset.GetAnnotations()); retried || !RetryAvailable(reset, 3) {
+		t.Error("expected the retries to be reset")
+	}
+	if TlogPending(reset.GetAnnotations()) || reset.GetAnnotations()[TransparencyPendingAnnotation] != "" {
+		t.Error("expected the pending uploads to be reset")
+	}
+	if ResignRequested(reset.GetAnnotations()) || !Resigned(reset.GetAnnotations()) {
+		t.Error("expected the re-sign request to be handled")
+	}
+}
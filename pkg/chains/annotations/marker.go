@@ -0,0 +1,141 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package annotations
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/pkg/errors"
+	"github.com/tektoncd/chains/pkg/chains/objects"
+)
+
+const (
+	// MarkerKeyFile is the name of the file in the signing secrets holding the
+	// key used to authenticate the signed marker.
+	MarkerKeyFile = "marker.key"
+
+	minMarkerKeySize = 32
+)
+
+type markerKeyCtxKey struct{}
+
+// WithMarkerKey returns a context holding the key used to authenticate the
+// ChainsAnnotation marker. Without a key, markers are only checked against the
+// managed fields of the object.
+func WithMarkerKey(ctx context.Context, key []byte) context.Context {
+	if len(key) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, markerKeyCtxKey{}, key)
+}
+
+func markerKeyFromContext(ctx context.Context) []byte {
+	key, _ := ctx.Value(markerKeyCtxKey{}).([]byte)
+	return key
+}
+
+// LoadMarkerKey reads the marker key from the signing secrets mounted at
+// secretPath. It returns a nil key when none has been configured.
+func LoadMarkerKey(secretPath string) ([]byte, error) {
+	key, err := os.ReadFile(filepath.Join(secretPath, MarkerKeyFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "reading marker key")
+	}
+	key = bytes.TrimSpace(key)
+	if len(key) < minMarkerKeySize {
+		return nil, fmt.Errorf("marker key must be at least %d bytes long, got %d", minMarkerKeySize, len(key))
+	}
+	return key, nil
+}
+
+// markerMAC returns the HMAC binding the marker value to the object, so that it
+// can neither be set by the creator of the object nor copied from another one.
+func markerMAC(key []byte, obj objects.TektonObject, value string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(obj.GetUID()))
+	mac.Write([]byte{0})
+	mac.Write([]byte(value))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// authenticMarker returns true when the marker in the annotations was written
+// by Chains: its MAC is checked when there is a marker key, and its managed
+// fields otherwise.
+func authenticMarker(ctx context.Context, obj objects.TektonObject, annotations map[string]string) bool {
+	key := markerKeyFromContext(ctx)
+	if key == nil {
+		return managedMarker(obj)
+	}
+	want := markerMAC(key, obj, annotations[ChainsAnnotation])
+	return hmac.Equal([]byte(annotations[SignedMACAnnotation]), []byte(want))
+}
+
+// managedMarker returns false when the managed fields of the object show that
+// the marker is owned by other managers than Chains, e.g. because it was set when
+// the object was created. Anyone can pick the name of their field manager, so
+// this only catches the markers set inadvertently or naively, the marker key is
+// needed to authenticate them. Objects without managed fields, or whose managed
+// fields do not record the marker yet, cannot be checked and are trusted.
+func managedMarker(obj objects.TektonObject) bool {
+	var owners []string
+	for _, mf := range obj.GetManagedFields() {
+		if mf.FieldsV1 == nil {
+			continue
+		}
+		var fields struct {
+			Metadata struct {
+				Annotations map[string]json.RawMessage `json:"f:annotations"`
+			} `json:"f:metadata"`
+		}
+		if err := json.Unmarshal(mf.FieldsV1.Raw, &fields); err != nil {
+			continue
+		}
+		if _, ok := fields.Metadata.Annotations["f:"+ChainsAnnotation]; ok {
+			owners = append(owners, mf.Manager)
+		}
+	}
+	return len(owners) == 0 || slices.Contains(owners, objects.FieldManager)
+}
+
+// ForgedMarker returns true when the object carries a signed marker that was
+// not written by Chains.
+func ForgedMarker(ctx context.Context, obj objects.TektonObject) bool {
+	annotations := obj.GetAnnotations()
+	if _, ok := annotations[ChainsAnnotation]; !ok {
+		return false
+	}
+	return !authenticMarker(ctx, obj, annotations)
+}
+
+// markerAnnotations adds the marker, and its MAC when there is a marker key, to
+// the annotations.
+func markerAnnotations(ctx context.Context, obj objects.TektonObject, annotations map[string]string, value string) map[string]string {
+	merged := mergeAnnotations(annotations, ChainsAnnotation, value)
+	if key := markerKeyFromContext(ctx); key != nil {
+		merged[SignedMACAnnotation] = markerMAC(key, obj, value)
+	}
+	return merged
+}
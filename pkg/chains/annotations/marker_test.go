@@ -0,0 +1,240 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package annotations
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tektoncd/chains/pkg/chains/objects"
+	"github.com/tektoncd/chains/pkg/test/tekton"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	fakepipelineclient "github.com/tektoncd/pipeline/pkg/client/injection/client/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+var testMarkerKey = bytes.Repeat([]byte("k"), minMarkerKeySize)
+
+func TestLoadMarkerKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []byte
+		wantErr bool
+	}{{
+		name: "no key",
+	}, {
+		name:    "key",
+		content: string(testMarkerKey) + "\n",
+		want:    testMarkerKey,
+	}, {
+		name:    "key too short",
+		content: "short",
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if tt.content != "" {
+				if err := os.WriteFile(filepath.Join(dir, MarkerKeyFile), []byte(tt.content), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			got, err := LoadMarkerKey(dir)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadMarkerKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !bytes.Equal(got, tt.want) {
+				t.Errorf("LoadMarkerKey() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReconciledWithMarkerKey(t *testing.T) {
+	signed := objects.NewTaskRunObjectV1(&v1.TaskRun{ObjectMeta: metav1.ObjectMeta{UID: "signed"}})
+	other := objects.NewTaskRunObjectV1(&v1.TaskRun{ObjectMeta: metav1.ObjectMeta{UID: "other"}})
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		noKey       bool
+		want        bool
+		wantForged  bool
+	}{{
+		name:        "signed by chains",
+		annotations: map[string]string{ChainsAnnotation: "true", SignedMACAnnotation: markerMAC(testMarkerKey, signed, "true")},
+		want:        true,
+	}, {
+		name:        "failed by chains",
+		annotations: map[string]string{ChainsAnnotation: "failed", SignedMACAnnotation: markerMAC(testMarkerKey, signed, "failed")},
+		want:        true,
+	}, {
+		name: "not signed",
+	}, {
+		name:        "forged marker",
+		annotations: map[string]string{ChainsAnnotation: "true"},
+		wantForged:  true,
+	}, {
+		name:        "forged MAC",
+		annotations: map[string]string{ChainsAnnotation: "true", SignedMACAnnotation: "Zm9yZ2Vk"},
+		wantForged:  true,
+	}, {
+		name:        "MAC copied from another object",
+		annotations: map[string]string{ChainsAnnotation: "true", SignedMACAnnotation: markerMAC(testMarkerKey, other, "true")},
+		wantForged:  true,
+	}, {
+		name:        "MAC of another value",
+		annotations: map[string]string{ChainsAnnotation: "true", SignedMACAnnotation: markerMAC(testMarkerKey, signed, "failed")},
+		wantForged:  true,
+	}, {
+		name:        "no marker key",
+		annotations: map[string]string{ChainsAnnotation: "true"},
+		noKey:       true,
+		want:        true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := rtesting.SetupFakeContext(t)
+			c := fakepipelineclient.Get(ctx)
+			if !tt.noKey {
+				ctx = WithMarkerKey(ctx, testMarkerKey)
+			}
+
+			obj := objects.NewTaskRunObjectV1(&v1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "my-taskrun",
+					UID:         types.UID("signed"),
+					Annotations: tt.annotations,
+				},
+			})
+			tekton.CreateObject(t, ctx, c, obj)
+
+			if got := Reconciled(ctx, c, obj); got != tt.want {
+				t.Errorf("Reconciled() = %v, want %v", got, tt.want)
+			}
+			if got := ForgedMarker(ctx, obj); got != tt.wantForged {
+				t.Errorf("ForgedMarker() = %v, want %v", got, tt.wantForged)
+			}
+		})
+	}
+}
+
+func TestReconciledWithoutMarkerKey(t *testing.T) {
+	markerFields := func(manager string) metav1.ManagedFieldsEntry {
+		return metav1.ManagedFieldsEntry{
+			Manager:  manager,
+			FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:metadata":{"f:annotations":{"f:chains.tekton.dev/signed":{}}}}`)},
+		}
+	}
+	tests := []struct {
+		name          string
+		managedFields []metav1.ManagedFieldsEntry
+		want          bool
+	}{{
+		name:          "written by chains",
+		managedFields: []metav1.ManagedFieldsEntry{markerFields(objects.FieldManager)},
+		want:          true,
+	}, {
+		name:          "also written by chains",
+		managedFields: []metav1.ManagedFieldsEntry{markerFields("kubectl-create"), markerFields(objects.FieldManager)},
+		want:          true,
+	}, {
+		name:          "set on creation",
+		managedFields: []metav1.ManagedFieldsEntry{markerFields("kubectl-create")},
+	}, {
+		name: "other annotations written by chains",
+		managedFields: []metav1.ManagedFieldsEntry{markerFields("kubectl-annotate"), {
+			Manager:  objects.FieldManager,
+			FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:metadata":{"f:annotations":{"f:chains.tekton.dev/retries":{}}}}`)},
+		}},
+	}, {
+		name: "no managed fields",
+		want: true,
+	}, {
+		name: "marker not recorded in the managed fields",
+		managedFields: []metav1.ManagedFieldsEntry{{
+			Manager:  "kubectl-create",
+			FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:metadata":{"f:labels":{"f:app":{}}}}`)},
+		}},
+		want: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := rtesting.SetupFakeContext(t)
+			c := fakepipelineclient.Get(ctx)
+
+			obj := objects.NewTaskRunObjectV1(&v1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:          "my-taskrun",
+					Annotations:   map[string]string{ChainsAnnotation: "true"},
+					ManagedFields: tt.managedFields,
+				},
+			})
+			tekton.CreateObject(t, ctx, c, obj)
+
+			if got := Reconciled(ctx, c, obj); got != tt.want {
+				t.Errorf("Reconciled() = %v, want %v", got, tt.want)
+			}
+			if got := ForgedMarker(ctx, obj); got == tt.want {
+				t.Errorf("ForgedMarker() = %v, want %v", got, !tt.want)
+			}
+		})
+	}
+}
+
+func TestMarkSignedWithMarkerKey(t *testing.T) {
+	ctx, _ := rtesting.SetupFakeContext(t)
+	ctx = WithMarkerKey(ctx, testMarkerKey)
+	c := fakepipelineclient.Get(ctx)
+
+	obj := objects.NewTaskRunObjectV1(&v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "my-taskrun",
+			UID:         types.UID("forged"),
+			Annotations: map[string]string{ChainsAnnotation: "true"},
+		},
+	})
+	tekton.CreateObject(t, ctx, c, obj)
+
+	// The forged marker must not prevent Chains from writing its own.
+	if err := MarkSigned(ctx, obj, c, nil); err != nil {
+		t.Fatalf("MarkSigned() error = %v", err)
+	}
+	signed, err := tekton.GetObject(t, ctx, c, obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := signed.GetAnnotations()[SignedMACAnnotation], markerMAC(testMarkerKey, obj, "true"); got != want {
+		t.Errorf("expected marker MAC %q, got %q", want, got)
+	}
+	if ForgedMarker(ctx, signed) {
+		t.Error("expected the marker written by MarkSigned to be authentic")
+	}
+
+	if err := MarkFailed(ctx, signed, c, nil); err != nil {
+		t.Fatalf("MarkFailed() error = %v", err)
+	}
+	failed, err := tekton.GetObject(t, ctx, c, obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !Reconciled(ctx, c, failed) {
+		t.Error("expected the object marked as failed to be reconciled")
+	}
+}
@@ -15,8 +15,14 @@
 package all
 
 import (
+	_ "github.com/tektoncd/chains/pkg/chains/formats/link"
+	_ "github.com/tektoncd/chains/pkg/chains/formats/sbom"
 	_ "github.com/tektoncd/chains/pkg/chains/formats/simple"
 	_ "github.com/tektoncd/chains/pkg/chains/formats/slsa/v1"
+	_ "github.com/tektoncd/chains/pkg/chains/formats/slsa/v1_1"
 	_ "github.com/tektoncd/chains/pkg/chains/formats/slsa/v2alpha3"
 	_ "github.com/tektoncd/chains/pkg/chains/formats/slsa/v2alpha4"
+	_ "github.com/tektoncd/chains/pkg/chains/formats/testresult"
+	_ "github.com/tektoncd/chains/pkg/chains/formats/vsa"
+	_ "github.com/tektoncd/chains/pkg/chains/formats/vuln"
 )
@@ -35,6 +35,12 @@ const (
 	PayloadTypeSlsav1        config.PayloadType = "slsa/v1"
 	PayloadTypeSlsav2alpha3  config.PayloadType = "slsa/v2alpha3"
 	PayloadTypeSlsav2alpha4  config.PayloadType = "slsa/v2alpha4"
+	PayloadTypeSlsav11       config.PayloadType = "slsa/v1.1"
+	PayloadTypeVSA           config.PayloadType = "vsa"
+	PayloadTypeSBOM          config.PayloadType = "sbom"
+	PayloadTypeTestResult    config.PayloadType = "test-result"
+	PayloadTypeVuln          config.PayloadType = "vuln"
+	PayloadTypeInTotoLink    config.PayloadType = "in-toto-link"
 )
 
 var (
@@ -43,6 +49,12 @@ var (
 		PayloadTypeSlsav1:       {},
 		PayloadTypeSlsav2alpha3: {},
 		PayloadTypeSlsav2alpha4: {},
+		PayloadTypeSlsav11:      {},
+		PayloadTypeVSA:          {},
+		PayloadTypeSBOM:         {},
+		PayloadTypeTestResult:   {},
+		PayloadTypeVuln:         {},
+		PayloadTypeInTotoLink:   {},
 	}
 	payloaderMap = map[config.PayloadType]PayloaderInit{}
 )
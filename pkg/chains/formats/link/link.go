@@ -0,0 +1,129 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package link generates the in-toto links of the steps of a TaskRun, so that
+// in-toto layouts can be verified against Tekton builds.
+package link
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	intoto "github.com/in-toto/attestation/go/v1"
+	"github.com/tektoncd/chains/pkg/artifacts"
+	"github.com/tektoncd/chains/pkg/chains/formats"
+	"github.com/tektoncd/chains/pkg/config"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// PredicateType is the predicate type of in-toto links.
+const PredicateType = "https://in-toto.io/attestation/link/v0.3"
+
+func init() {
+	formats.RegisterPayloader(formats.PayloadTypeInTotoLink, NewFormatter)
+}
+
+// Link is the payloader of the in-toto links of steps.
+type Link struct{}
+
+// NewFormatter returns a new link payloader.
+func NewFormatter(config.Config) (formats.Payloader, error) { //nolint:ireturn
+	return &Link{}, nil
+}
+
+type predicate struct {
+	Name        string               `json:"name"`
+	Command     []string             `json:"command"`
+	Materials   []resourceDescriptor `json:"materials"`
+	Byproducts  map[string]string    `json:"byproducts"`
+	Environment map[string]string    `json:"environment"`
+}
+
+type resourceDescriptor struct {
+	Name   string            `json:"name,omitempty"`
+	Digest map[string]string `json:"digest"`
+}
+
+// CreatePayload returns the in-toto link of a step, whose products are the
+// subjects of the statement.
+func (l *Link) CreatePayload(ctx context.Context, obj interface{}) (interface{}, error) {
+	link, ok := obj.(*artifacts.StepLink)
+	if !ok {
+		return nil, fmt.Errorf("in-toto-link does not support type: %T", obj)
+	}
+
+	p := predicate{
+		Name:        link.Name,
+		Command:     link.Command,
+		Materials:   []resourceDescriptor{},
+		Byproducts:  map[string]string{},
+		Environment: map[string]string{"image": link.Image},
+	}
+	if p.Command == nil {
+		p.Command = []string{}
+	}
+	for _, m := range link.Materials {
+		p.Materials = append(p.Materials, resourceDescriptor{Name: m.GetName(), Digest: m.GetDigest()})
+	}
+	if link.ExitCode != nil {
+		p.Byproducts["return-value"] = strconv.Itoa(int(*link.ExitCode))
+	}
+	if link.Script != "" {
+		p.Environment["script"] = link.Script
+	}
+
+	predicateStruct, err := getStruct(p)
+	if err != nil {
+		return nil, err
+	}
+	return &intoto.Statement{
+		Type:          intoto.StatementTypeUri,
+		PredicateType: PredicateType,
+		Subject:       link.Products,
+		Predicate:     predicateStruct,
+	}, nil
+}
+
+func getStruct(p predicate) (*structpb.Struct, error) {
+	raw, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+	protoStruct := &structpb.Struct{}
+	if err := protojson.Unmarshal(raw, protoStruct); err != nil {
+		return nil, err
+	}
+	return protoStruct, nil
+}
+
+// Wrap indicates that the links are signed in a DSSE envelope.
+func (l *Link) Wrap() bool {
+	return true
+}
+
+// Type returns the type of this payloader.
+func (l *Link) Type() config.PayloadType {
+	return formats.PayloadTypeInTotoLink
+}
+
+// RetrieveAllArtifactURIs returns the products of the step pinned to their digests.
+func (l *Link) RetrieveAllArtifactURIs(ctx context.Context, obj interface{}) ([]string, error) {
+	link, ok := obj.(*artifacts.StepLink)
+	if !ok {
+		return nil, fmt.Errorf("in-toto-link does not support type: %T", obj)
+	}
+	return artifacts.SubjectURIs(link.Products), nil
+}
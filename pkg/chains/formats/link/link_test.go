@@ -0,0 +1,103 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package link
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	intoto "github.com/in-toto/attestation/go/v1"
+	"github.com/tektoncd/chains/pkg/artifacts"
+	"github.com/tektoncd/chains/pkg/config"
+)
+
+func TestCreatePayload(t *testing.T) {
+	product := &intoto.ResourceDescriptor{
+		Name:   "gcr.io/foo/bar",
+		Digest: map[string]string{"sha256": "586789aa031fafc7d78a5393cdc772e0b55107ea54bb8bcf3f2cdac6c6da51ee"},
+	}
+	exitCode := int32(1)
+
+	tests := []struct {
+		name string
+		link *artifacts.StepLink
+		want map[string]interface{}
+	}{{
+		name: "command",
+		link: &artifacts.StepLink{
+			Name:    "build",
+			Command: []string{"go", "build"},
+			Image:   "gcr.io/foo/go@sha256:05f95b26ed10668b7183c1e2da98610e91372fa9f510046d4ce5812addad86b5",
+			Materials: []*intoto.ResourceDescriptor{{
+				Name:   "oci://gcr.io/foo/go",
+				Digest: map[string]string{"sha256": "05f95b26ed10668b7183c1e2da98610e91372fa9f510046d4ce5812addad86b5"},
+			}},
+			Products: []*intoto.ResourceDescriptor{product},
+			ExitCode: &exitCode,
+		},
+		want: map[string]interface{}{
+			"name":    "build",
+			"command": []interface{}{"go", "build"},
+			"materials": []interface{}{map[string]interface{}{
+				"name":   "oci://gcr.io/foo/go",
+				"digest": map[string]interface{}{"sha256": "05f95b26ed10668b7183c1e2da98610e91372fa9f510046d4ce5812addad86b5"},
+			}},
+			"byproducts":  map[string]interface{}{"return-value": "1"},
+			"environment": map[string]interface{}{"image": "gcr.io/foo/go@sha256:05f95b26ed10668b7183c1e2da98610e91372fa9f510046d4ce5812addad86b5"},
+		},
+	}, {
+		name: "script",
+		link: &artifacts.StepLink{
+			Name:     "test",
+			Script:   "go test ./...",
+			Image:    "gcr.io/foo/go",
+			Products: []*intoto.ResourceDescriptor{product},
+		},
+		want: map[string]interface{}{
+			"name":        "test",
+			"command":     []interface{}{},
+			"materials":   []interface{}{},
+			"byproducts":  map[string]interface{}{},
+			"environment": map[string]interface{}{"image": "gcr.io/foo/go", "script": "go test ./..."},
+		},
+	}}
+
+	f, err := NewFormatter(config.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := f.CreatePayload(context.Background(), tc.link)
+			if err != nil {
+				t.Fatalf("CreatePayload() error = %v", err)
+			}
+			statement := got.(*intoto.Statement)
+			if statement.GetPredicateType() != PredicateType {
+				t.Errorf("unexpected predicate type %s", statement.GetPredicateType())
+			}
+			if len(statement.GetSubject()) != 1 || statement.GetSubject()[0] != product {
+				t.Errorf("expected the products to be the subjects, got %v", statement.GetSubject())
+			}
+			if d := cmp.Diff(tc.want, statement.GetPredicate().AsMap()); d != "" {
+				t.Errorf("unexpected predicate, diff: %s", d)
+			}
+		})
+	}
+
+	if _, err := f.CreatePayload(context.Background(), &artifacts.TestResult{}); err == nil {
+		t.Error("expected an error creating the payload of another type")
+	}
+}
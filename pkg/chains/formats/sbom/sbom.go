@@ -0,0 +1,187 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sbom attests the SPDX and CycloneDX SBOMs of the images built by a
+// run, with in-toto statements whose subject is the image.
+package sbom
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn/k8schain"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	intoto "github.com/in-toto/attestation/go/v1"
+	"github.com/pkg/errors"
+	"github.com/tektoncd/chains/pkg/artifacts"
+	"github.com/tektoncd/chains/pkg/chains/formats"
+	"github.com/tektoncd/chains/pkg/chains/objects"
+	"github.com/tektoncd/chains/pkg/config"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
+)
+
+const (
+	// PredicateTypeSPDX is the predicate type of SPDX SBOM attestations.
+	PredicateTypeSPDX = "https://spdx.dev/Document"
+	// PredicateTypeCycloneDX is the predicate type of CycloneDX SBOM attestations.
+	PredicateTypeCycloneDX = "https://cyclonedx.org/bom"
+
+	// maxSize bounds the size of the SBOMs pulled from registries.
+	maxSize = 64 << 20
+)
+
+func init() {
+	formats.RegisterPayloader(formats.PayloadTypeSBOM, NewFormatter)
+}
+
+// remoteOptions returns the options pulling the SBOM blobs with the credentials
+// of the service account of the run, like the OCI storage backend pushes images.
+var remoteOptions = func(ctx context.Context, run objects.TektonObject) ([]remote.Option, error) {
+	kc, err := k8schain.New(ctx, kubeclient.Get(ctx), k8schain.Options{
+		Namespace:          run.GetNamespace(),
+		ServiceAccountName: run.GetServiceAccountName(),
+		UseMountSecrets:    true,
+		IgnorePullSecrets:  true,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "creating new keychain from serviceaccount %s/%s", run.GetNamespace(), run.GetServiceAccountName())
+	}
+	return []remote.Option{remote.WithAuthFromKeychain(kc), remote.WithContext(ctx)}, nil
+}
+
+// SBOM is the payloader of SBOM attestations.
+type SBOM struct{}
+
+// NewFormatter returns a new SBOM payloader.
+func NewFormatter(config.Config) (formats.Payloader, error) { //nolint:ireturn
+	return &SBOM{}, nil
+}
+
+// CreatePayload returns the in-toto statement attesting the SBOM of an image,
+// with the SBOM document as its predicate.
+func (s *SBOM) CreatePayload(ctx context.Context, obj interface{}) (interface{}, error) {
+	sbom, ok := obj.(*artifacts.SBOM)
+	if !ok {
+		return nil, fmt.Errorf("sbom does not support type: %T", obj)
+	}
+
+	content := sbom.Content
+	if content == nil {
+		var err error
+		if content, err = fetch(ctx, sbom); err != nil {
+			return nil, err
+		}
+	}
+
+	document := &structpb.Struct{}
+	if err := protojson.Unmarshal(content, document); err != nil {
+		return nil, errors.Wrap(err, "decoding SBOM, only JSON documents are supported")
+	}
+	predicateType, err := predicateType(sbom.Format, content)
+	if err != nil {
+		return nil, err
+	}
+
+	alg, hex, _ := strings.Cut(sbom.Subject.DigestStr(), ":")
+	return &intoto.Statement{
+		Type:          intoto.StatementTypeUri,
+		PredicateType: predicateType,
+		Subject: []*intoto.ResourceDescriptor{{
+			Name:   sbom.Subject.Repository.Name(),
+			Digest: map[string]string{alg: hex},
+		}},
+		Predicate: document,
+	}, nil
+}
+
+// fetch pulls the SBOM blob, whose digest is verified while it is read.
+func fetch(ctx context.Context, sbom *artifacts.SBOM) ([]byte, error) {
+	ref, err := name.NewDigest(sbom.URI)
+	if err != nil {
+		return nil, err
+	}
+	opts, err := remoteOptions(ctx, sbom.Run)
+	if err != nil {
+		return nil, err
+	}
+	layer, err := remote.Layer(ref, opts...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "pulling SBOM %s", sbom.URI)
+	}
+	rc, err := layer.Compressed()
+	if err != nil {
+		return nil, errors.Wrapf(err, "pulling SBOM %s", sbom.URI)
+	}
+	defer rc.Close()
+	content, err := io.ReadAll(io.LimitReader(rc, maxSize+1))
+	if err != nil {
+		return nil, errors.Wrapf(err, "pulling SBOM %s", sbom.URI)
+	}
+	if len(content) > maxSize {
+		return nil, fmt.Errorf("SBOM %s is larger than %d bytes", sbom.URI, maxSize)
+	}
+	return content, nil
+}
+
+// predicateType returns the predicate type of the SBOM format, which is detected
+// from the document when it is not known.
+func predicateType(format string, content []byte) (string, error) {
+	if format == "" {
+		var doc struct {
+			SPDXVersion string `json:"spdxVersion"`
+			BOMFormat   string `json:"bomFormat"`
+		}
+		if err := json.Unmarshal(content, &doc); err != nil {
+			return "", errors.Wrap(err, "decoding SBOM")
+		}
+		switch {
+		case doc.SPDXVersion != "":
+			format = artifacts.SBOMFormatSPDX
+		case doc.BOMFormat == "CycloneDX":
+			format = artifacts.SBOMFormatCycloneDX
+		}
+	}
+
+	switch format {
+	case artifacts.SBOMFormatSPDX:
+		return PredicateTypeSPDX, nil
+	case artifacts.SBOMFormatCycloneDX:
+		return PredicateTypeCycloneDX, nil
+	}
+	return "", errors.New("unknown SBOM format, neither an SPDX nor a CycloneDX JSON document")
+}
+
+// Wrap indicates that the attestations are signed in a DSSE envelope.
+func (s *SBOM) Wrap() bool {
+	return true
+}
+
+// Type returns the type of this payloader.
+func (s *SBOM) Type() config.PayloadType {
+	return formats.PayloadTypeSBOM
+}
+
+// RetrieveAllArtifactURIs returns the image described by the SBOM.
+func (s *SBOM) RetrieveAllArtifactURIs(ctx context.Context, obj interface{}) ([]string, error) {
+	sbom, ok := obj.(*artifacts.SBOM)
+	if !ok {
+		return nil, fmt.Errorf("sbom does not support type: %T", obj)
+	}
+	return []string{sbom.Subject.Name()}, nil
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sbom
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	intoto "github.com/in-toto/attestation/go/v1"
+	"github.com/tektoncd/chains/pkg/artifacts"
+	"github.com/tektoncd/chains/pkg/chains/objects"
+	"github.com/tektoncd/chains/pkg/config"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+const (
+	imageDigest = "05f95b26ed10668b7183c1e2da98610e91372fa9f510046d4ce5812addad86b5"
+	spdx        = `{"spdxVersion": "SPDX-2.3", "name": "app"}`
+	cyclonedx   = `{"bomFormat": "CycloneDX", "specVersion": "1.5"}`
+)
+
+func TestCreatePayload(t *testing.T) {
+	image, err := name.NewDigest("gcr.io/foo/bar@sha256:" + imageDigest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		sbom          *artifacts.SBOM
+		wantPredicate string
+		wantErr       string
+	}{{
+		name:          "detected spdx",
+		sbom:          &artifacts.SBOM{Subject: image, Content: []byte(spdx)},
+		wantPredicate: PredicateTypeSPDX,
+	}, {
+		name:          "detected cyclonedx",
+		sbom:          &artifacts.SBOM{Subject: image, Content: []byte(cyclonedx)},
+		wantPredicate: PredicateTypeCycloneDX,
+	}, {
+		name:          "hinted format",
+		sbom:          &artifacts.SBOM{Subject: image, Content: []byte(`{"SPDXID": "SPDXRef-DOCUMENT"}`), Format: artifacts.SBOMFormatSPDX},
+		wantPredicate: PredicateTypeSPDX,
+	}, {
+		name:    "unknown format",
+		sbom:    &artifacts.SBOM{Subject: image, Content: []byte(`{"name": "app"}`)},
+		wantErr: "unknown SBOM format",
+	}, {
+		name:    "tag-value document",
+		sbom:    &artifacts.SBOM{Subject: image, Content: []byte("SPDXVersion: SPDX-2.3"), Format: artifacts.SBOMFormatSPDX},
+		wantErr: "only JSON documents are supported",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			formatter, err := NewFormatter(config.Config{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			payload, err := formatter.CreatePayload(context.Background(), tt.sbom)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("CreatePayload() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CreatePayload() error = %v", err)
+			}
+			checkStatement(t, payload, tt.wantPredicate, tt.sbom.Content)
+		})
+	}
+}
+
+func TestCreatePayloadFromRegistry(t *testing.T) {
+	s := httptest.NewServer(registry.New())
+	defer s.Close()
+	registryName := strings.TrimPrefix(s.URL, "http://")
+
+	layer := static.NewLayer([]byte(cyclonedx), types.MediaType("application/vnd.cyclonedx+json"))
+	digest, err := layer.Digest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ref, err := name.NewDigest(fmt.Sprintf("%s/foo/sbom@%s", registryName, digest))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := remote.WriteLayer(ref.Repository, layer); err != nil {
+		t.Fatal(err)
+	}
+	image, err := name.NewDigest(fmt.Sprintf("%s/foo/bar@sha256:%s", registryName, imageDigest))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	orig := remoteOptions
+	defer func() { remoteOptions = orig }()
+	remoteOptions = func(ctx context.Context, _ objects.TektonObject) ([]remote.Option, error) {
+		return []remote.Option{remote.WithContext(ctx)}, nil
+	}
+
+	formatter, err := NewFormatter(config.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	run := objects.NewTaskRunObjectV1(&v1.TaskRun{})
+	payload, err := formatter.CreatePayload(context.Background(), &artifacts.SBOM{Subject: image, URI: ref.Name(), Digest: digest.String(), Run: run})
+	if err != nil {
+		t.Fatalf("CreatePayload() error = %v", err)
+	}
+	checkStatement(t, payload, PredicateTypeCycloneDX, []byte(cyclonedx))
+
+	// Blobs are pulled by digest, so a blob missing from the registry cannot be replaced.
+	missing, err := name.NewDigest(fmt.Sprintf("%s/foo/sbom@sha256:%s", registryName, imageDigest))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := formatter.CreatePayload(context.Background(), &artifacts.SBOM{Subject: image, URI: missing.Name(), Run: run}); err == nil {
+		t.Error("expected an error pulling a missing SBOM")
+	}
+}
+
+func checkStatement(t *testing.T, payload interface{}, wantPredicate string, document []byte) {
+	t.Helper()
+	statement, ok := payload.(*intoto.Statement)
+	if !ok {
+		t.Fatalf("expected an in-toto statement, got %T", payload)
+	}
+	if statement.GetPredicateType() != wantPredicate {
+		t.Errorf("expected predicate type %s, got %s", wantPredicate, statement.GetPredicateType())
+	}
+	if len(statement.GetSubject()) != 1 || statement.GetSubject()[0].GetDigest()["sha256"] != imageDigest || !strings.HasSuffix(statement.GetSubject()[0].GetName(), "/foo/bar") {
+		t.Errorf("expected the image to be the subject, got %v", statement.GetSubject())
+	}
+	want := map[string]interface{}{}
+	if err := json.Unmarshal(document, &want); err != nil {
+		t.Fatal(err)
+	}
+	if d := cmp.Diff(want, statement.GetPredicate().AsMap()); d != "" {
+		t.Errorf("expected the SBOM to be the predicate (-want +got): %s", d)
+	}
+}
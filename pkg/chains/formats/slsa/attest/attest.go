@@ -17,12 +17,15 @@ limitations under the License.
 package attest
 
 import (
+	"context"
 	"fmt"
+	"strconv"
 	"strings"
 
 	slsa "github.com/in-toto/in-toto-golang/in_toto/slsa_provenance/v0.2"
 	"github.com/tektoncd/chains/pkg/artifacts"
 	"github.com/tektoncd/chains/pkg/chains/objects"
+	"github.com/tektoncd/chains/pkg/chains/spire"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -108,6 +111,25 @@ func Invocation(obj objects.TektonObject, params []v1.Param, paramSpecs []v1.Par
 	return i
 }
 
+// SpireVerification adds the verification outcome of the run results in the
+// context, if any, to the invocation environment.
+func SpireVerification(ctx context.Context, i slsa.ProvenanceInvocation) slsa.ProvenanceInvocation {
+	v := spire.VerificationFromContext(ctx)
+	if v == nil {
+		return i
+	}
+	environment, _ := i.Environment.(map[string]map[string]string)
+	if environment == nil {
+		environment = map[string]map[string]string{}
+	}
+	environment[spire.ProvenanceKey] = map[string]string{"verified": strconv.FormatBool(v.Verified)}
+	if v.Reason != "" {
+		environment[spire.ProvenanceKey]["reason"] = v.Reason
+	}
+	i.Environment = environment
+	return i
+}
+
 func convertConfigSource(source *v1.RefSource) slsa.ConfigSource {
 	if source == nil {
 		return slsa.ConfigSource{}
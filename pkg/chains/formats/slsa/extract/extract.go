@@ -35,6 +35,7 @@ import (
 // SubjectDigests returns software artifacts produced from the TaskRun/PipelineRun object
 // in the form of standard subject field of intoto statement.
 // The type hinting fields expected in results help identify the generated software artifacts.
+// The output artifacts declared through the Tekton Artifacts API are subjects too.
 // Valid type hinting fields must:
 //   - have suffix `IMAGE_URL` & `IMAGE_DIGEST` or `ARTIFACT_URI` & `ARTIFACT_DIGEST` pair.
 //   - the `*_DIGEST` field must be in the format of "<algorithm>:<actual-sha>" where the algorithm must be "sha256" and actual sha must be valid per https://github.com/opencontainers/image-spec/blob/main/descriptor.md#sha-256.
@@ -100,6 +101,10 @@ func subjectsFromPipelineRun(ctx context.Context, obj objects.TektonObject, slsa
 }
 
 func subjectsFromTektonObject(ctx context.Context, obj objects.TektonObject) []*intoto.ResourceDescriptor {
+	if !artifacts.TrustedProducer(ctx, obj) {
+		return nil
+	}
+
 	logger := logging.FromContext(ctx)
 	var subjects []*intoto.ResourceDescriptor
 
@@ -143,6 +148,11 @@ func subjectsFromTektonObject(ctx context.Context, obj objects.TektonObject) []*
 		})
 	}
 
+	// Artifacts declared through the Tekton Artifacts API, merged with the ones
+	// found in type-hinted results.
+	declared := artifacts.ExtractDeclaredArtifacts(ctx, obj.GetOutputArtifacts(), false)
+	subjects = artifact.AppendSubjects(subjects, declared...)
+
 	return subjects
 }
 
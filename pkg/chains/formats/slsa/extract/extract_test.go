@@ -28,9 +28,11 @@ import (
 	"github.com/tektoncd/chains/pkg/chains/formats/slsa/internal/compare"
 	"github.com/tektoncd/chains/pkg/chains/formats/slsa/internal/slsaconfig"
 	"github.com/tektoncd/chains/pkg/chains/objects"
+	"github.com/tektoncd/chains/pkg/config"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"google.golang.org/protobuf/testing/protocmp"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	logtesting "knative.dev/pkg/logging/testing"
 )
 
@@ -271,6 +273,76 @@ func TestPipelineRunObserveModeForSubjects(t *testing.T) {
 	}
 }
 
+func TestTrustedProducersForSubjects(t *testing.T) {
+	trusted := &v1.RefSource{URI: "gcr.io/tekton-releases/catalog/upstream/kaniko", Digest: map[string]string{"sha256": artifactDigest1}}
+
+	pro := createProWithTaskRunResults(
+		createProWithPipelineResults(map[string]string{artifactURL1: "sha256:" + artifactDigest1}),
+		[]artifact{
+			{uri: artifactURL1, digest: "sha256:" + artifactDigest1},
+			{uri: artifactURL2, digest: "sha256:" + artifactDigest2},
+		}).(*objects.PipelineRunObjectV1)
+	// Only the first child taskrun was resolved from the trusted bundle.
+	pro.GetTaskRuns()[0].Status.Provenance = &v1.Provenance{RefSource: trusted}
+
+	ctx := logtesting.TestContextWithLogger(t)
+	ctx = config.ToContext(ctx, &config.Config{
+		TrustedProducers: config.TrustedProducersConfig{Namespaces: map[string]sets.Set[string]{
+			config.TrustedProducersAllNamespaces: sets.New(trusted.URI + "@sha256:" + artifactDigest1),
+		}},
+	})
+
+	want := []*intoto.ResourceDescriptor{{
+		Name:   artifactURL1,
+		Digest: map[string]string{"sha256": artifactDigest1},
+	}}
+	got := extract.SubjectDigests(ctx, pro, &slsaconfig.SlsaConfig{DeepInspectionEnabled: true})
+	if diff := cmp.Diff(want, got, compare.SubjectCompareOption(), protocmp.Transform()); diff != "" {
+		t.Errorf("Wrong subjects extracted, diff=%s", diff)
+	}
+
+	if got := extract.SubjectDigests(ctx, pro, &slsaconfig.SlsaConfig{}); len(got) != 0 {
+		t.Errorf("expected the subjects of the untrusted pipelinerun to be dropped, got %v", got)
+	}
+}
+
+func TestSubjectDigestsFromDeclaredArtifacts(t *testing.T) {
+	tro := createTaskRunObjectWithResults(map[string]string{artifactURL1: "sha256:" + artifactDigest1})
+	tro.Status.Artifacts = &v1.Artifacts{
+		Outputs: []v1.Artifact{{
+			Name: "image",
+			Values: []v1.ArtifactValue{{
+				Uri:    artifactURL1,
+				Digest: map[v1.Algorithm]string{"sha256": artifactDigest1},
+			}},
+			BuildOutput: true,
+		}},
+	}
+	tro.Status.Steps = []v1.StepState{{
+		Outputs: []v1.TaskRunStepArtifact{{
+			Name: "package",
+			Values: []v1.ArtifactValue{{
+				Uri:    artifactURL2,
+				Digest: map[v1.Algorithm]string{"sha256": artifactDigest2},
+			}},
+		}},
+	}}
+
+	// The declared image is deduplicated with the type-hinted one.
+	want := []*intoto.ResourceDescriptor{{
+		Name:   artifactURL1,
+		Digest: map[string]string{"sha256": artifactDigest1},
+	}, {
+		Name:   artifactURL2,
+		Digest: map[string]string{"sha256": artifactDigest2},
+	}}
+	ctx := logtesting.TestContextWithLogger(t)
+	got := extract.SubjectDigests(ctx, tro, &slsaconfig.SlsaConfig{})
+	if diff := cmp.Diff(want, got, compare.SubjectCompareOption(), protocmp.Transform()); diff != "" {
+		t.Errorf("Wrong subjects extracted, diff=%s", diff)
+	}
+}
+
 func TestSubjectsFromBuildArtifact(t *testing.T) {
 	tests := []struct {
 		name             string
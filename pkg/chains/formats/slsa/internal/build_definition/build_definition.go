@@ -46,7 +46,7 @@ func GetTaskRunBuildDefinition(ctx context.Context, tro *objects.TaskRunObjectV1
 		buildDefinitionType = buildtypes.SlsaBuildType
 	}
 
-	internalParams, err := internalparameters.GetInternalParamters(tro, buildDefinitionType)
+	internalParams, err := internalparameters.GetInternalParamters(ctx, tro, buildDefinitionType)
 	if err != nil {
 		return slsa.BuildDefinition{}, err
 	}
@@ -86,7 +86,7 @@ func GetPipelineRunBuildDefinition(ctx context.Context, pro *objects.PipelineRun
 		return slsa.BuildDefinition{}, err
 	}
 
-	internalParams, err := internalparameters.GetInternalParamters(pro, buildDefinitionType)
+	internalParams, err := internalparameters.GetInternalParamters(ctx, pro, buildDefinitionType)
 	if err != nil {
 		return slsa.BuildDefinition{}, err
 	}
@@ -103,6 +103,35 @@ func GetPipelineRunBuildDefinition(ctx context.Context, pro *objects.PipelineRun
 	}, nil
 }
 
+// GetCustomRunBuildDefinition returns the buildDefinition for the given CustomRun based on the configured buildType. This will default to the slsa buildType
+func GetCustomRunBuildDefinition(ctx context.Context, cro *objects.CustomRunObjectV1beta1, buildType string) (slsa.BuildDefinition, error) {
+	externalParams := externalparameters.CustomRun(cro)
+	structExternalParams, err := getStruct(externalParams)
+	if err != nil {
+		return slsa.BuildDefinition{}, err
+	}
+
+	buildDefinitionType := buildType
+	if buildDefinitionType == "" {
+		buildDefinitionType = buildtypes.SlsaBuildType
+	}
+
+	internalParams, err := internalparameters.GetInternalParamters(ctx, cro, buildDefinitionType)
+	if err != nil {
+		return slsa.BuildDefinition{}, err
+	}
+	structInternalParams, err := getStruct(internalParams)
+	if err != nil {
+		return slsa.BuildDefinition{}, err
+	}
+
+	return slsa.BuildDefinition{
+		BuildType:          buildDefinitionType,
+		ExternalParameters: structExternalParams,
+		InternalParameters: structInternalParams,
+	}, nil
+}
+
 func getStruct(data map[string]any) (*structpb.Struct, error) {
 	bytes, err := json.Marshal(data)
 	if err != nil {
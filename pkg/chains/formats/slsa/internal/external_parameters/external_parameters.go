@@ -58,3 +58,10 @@ func TaskRun(tro *objects.TaskRunObjectV1) map[string]any {
 	externalParams["runSpec"] = tro.Spec
 	return externalParams
 }
+
+// CustomRun adds the custom run spec
+func CustomRun(cro *objects.CustomRunObjectV1beta1) map[string]any {
+	externalParams := make(map[string]any)
+	externalParams["runSpec"] = cro.Spec
+	return externalParams
+}
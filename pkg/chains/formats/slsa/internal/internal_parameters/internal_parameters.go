@@ -17,10 +17,12 @@ limitations under the License.
 package internalparameters
 
 import (
+	"context"
 	"fmt"
 
 	buildtypes "github.com/tektoncd/chains/pkg/chains/formats/slsa/internal/build_types"
 	"github.com/tektoncd/chains/pkg/chains/objects"
+	"github.com/tektoncd/chains/pkg/chains/spire"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 )
 
@@ -44,8 +46,9 @@ func TektonInternalParameters(tko objects.TektonObject) map[string]any {
 	return internalParams
 }
 
-// GetInternalParamters returns the internal parameters for the given tekton object based on the build type.
-func GetInternalParamters(obj objects.TektonObject, buildDefinitionType string) (map[string]any, error) {
+// GetInternalParamters returns the internal parameters for the given tekton object based on the build type,
+// along with the verification outcome of its results if they were verified.
+func GetInternalParamters(ctx context.Context, obj objects.TektonObject, buildDefinitionType string) (map[string]any, error) {
 	var internalParameters map[string]any
 
 	switch buildDefinitionType {
@@ -56,6 +59,9 @@ func GetInternalParamters(obj objects.TektonObject, buildDefinitionType string)
 	default:
 		return nil, fmt.Errorf("unsupported buildType %v", buildDefinitionType)
 	}
+	if v := spire.VerificationFromContext(ctx); v != nil {
+		internalParameters[spire.ProvenanceKey] = v
+	}
 
 	return internalParameters, nil
 }
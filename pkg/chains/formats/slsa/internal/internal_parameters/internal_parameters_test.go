@@ -17,11 +17,13 @@ limitations under the License.
 package internalparameters
 
 import (
+	"context"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	buildtypes "github.com/tektoncd/chains/pkg/chains/formats/slsa/internal/build_types"
 	"github.com/tektoncd/chains/pkg/chains/objects"
+	"github.com/tektoncd/chains/pkg/chains/spire"
 	"github.com/tektoncd/chains/pkg/internal/objectloader"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 )
@@ -31,6 +33,7 @@ func TestGetInternalParamters(t *testing.T) {
 		name                string
 		shouldErr           bool
 		buildDefinitionType string
+		verification        *spire.Verification
 		expected            map[string]any
 	}{
 		{
@@ -49,6 +52,15 @@ func TestGetInternalParamters(t *testing.T) {
 				"tekton-pipelines-feature-flags": config.FeatureFlags{EnableAPIFields: "beta", ResultExtractionMethod: "termination-message"},
 			},
 		},
+		{
+			name:                "SPIRE verified results",
+			buildDefinitionType: buildtypes.SlsaBuildType,
+			verification:        &spire.Verification{Reason: "no SVID found in the results"},
+			expected: map[string]any{
+				"tekton-pipelines-feature-flags": config.FeatureFlags{EnableAPIFields: "beta", ResultExtractionMethod: "termination-message"},
+				"spire-results-verification":     &spire.Verification{Reason: "no SVID found in the results"},
+			},
+		},
 		{
 			name:                "Invalid build type",
 			buildDefinitionType: "invalid-type",
@@ -64,7 +76,11 @@ func TestGetInternalParamters(t *testing.T) {
 			}
 			tro := objects.NewTaskRunObjectV1(tr)
 
-			got, err := GetInternalParamters(tro, test.buildDefinitionType)
+			ctx := context.Background()
+			if test.verification != nil {
+				ctx = spire.WithVerification(ctx, test.verification)
+			}
+			got, err := GetInternalParamters(ctx, tro, test.buildDefinitionType)
 
 			didError := err != nil
 			if didError != test.shouldErr {
@@ -55,6 +55,8 @@ func TaskMaterials(ctx context.Context, tro *objects.TaskRunObjectV1) ([]common.
 
 	mats = artifact.AppendMaterials(mats, FromTaskParamsAndResults(ctx, tro)...)
 
+	mats = artifact.AppendMaterials(mats, FromInputArtifacts(ctx, tro)...)
+
 	return mats, nil
 }
 
@@ -214,6 +216,11 @@ func FromTaskParamsAndResults(ctx context.Context, tro *objects.TaskRunObjectV1)
 	return mats
 }
 
+// FromInputArtifacts returns the input artifacts the taskrun and its steps declared through the Tekton Artifacts API.
+func FromInputArtifacts(ctx context.Context, tro *objects.TaskRunObjectV1) []common.ProvenanceMaterial {
+	return artifacts.RetrieveMaterialsFromDeclaredArtifacts(ctx, tro.GetInputArtifacts())
+}
+
 // FromStepActionsResults extracts type hinted results from StepActions associated with the TaskRun and adds the url and digest to materials.
 func FromStepActionsResults(ctx context.Context, tro *objects.TaskRunObjectV1) (mats []common.ProvenanceMaterial) {
 	for _, s := range tro.Status.Steps {
@@ -270,6 +277,7 @@ func FromPipelineParamsAndResults(ctx context.Context, pro *objects.PipelineRunO
 					}
 					materialsFromTasks := FromTaskParamsAndResults(ctx, tr)
 					mats = artifact.AppendMaterials(mats, materialsFromTasks...)
+					mats = artifact.AppendMaterials(mats, FromInputArtifacts(ctx, tr)...)
 				}
 			}
 		}
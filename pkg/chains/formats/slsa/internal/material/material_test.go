@@ -261,6 +261,62 @@ func TestTaskMaterials(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "materials from declared input artifacts",
+			obj: objects.NewTaskRunObjectV1(&v1.TaskRun{
+				Spec: v1.TaskRunSpec{
+					Params: []v1.Param{{
+						Name:  "CHAINS-GIT_COMMIT",
+						Value: *v1.NewStructuredValues("a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4e5f6a1b2"),
+					}, {
+						Name:  "CHAINS-GIT_URL",
+						Value: *v1.NewStructuredValues("github.com/something"),
+					}},
+				},
+				Status: v1.TaskRunStatus{
+					TaskRunStatusFields: v1.TaskRunStatusFields{
+						Artifacts: &v1.Artifacts{
+							Inputs: []v1.Artifact{{
+								Name: "source",
+								Values: []v1.ArtifactValue{{
+									Uri:    artifacts.GitSchemePrefix + "github.com/something.git",
+									Digest: map[v1.Algorithm]string{"sha1": "a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4e5f6a1b2"},
+								}},
+							}},
+						},
+						Steps: []v1.StepState{{
+							ImageID: "gcr.io/foo/bar@sha256:b963f6e7a69617db57b685893256f978436277094c21d43b153994acd8a01247",
+							Inputs: []v1.TaskRunStepArtifact{{
+								Name: "dataset",
+								Values: []v1.ArtifactValue{{
+									Uri:    "gs://bucket/dataset",
+									Digest: map[v1.Algorithm]string{"sha256": "05f95b26ed10668b7183c1e2da98610e91372fa9f510046d4ce5812addad86b5"},
+								}},
+							}},
+						}},
+					},
+				},
+			}),
+			want: []common.ProvenanceMaterial{
+				{
+					URI: artifacts.OCIScheme + "gcr.io/foo/bar",
+					Digest: common.DigestSet{
+						"sha256": "b963f6e7a69617db57b685893256f978436277094c21d43b153994acd8a01247",
+					},
+				}, {
+					// The declared source is merged with the one of the type-hinted params.
+					URI: artifacts.GitSchemePrefix + "github.com/something.git",
+					Digest: common.DigestSet{
+						"sha1": "a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4e5f6a1b2",
+					},
+				}, {
+					URI: "gs://bucket/dataset",
+					Digest: common.DigestSet{
+						"sha256": "05f95b26ed10668b7183c1e2da98610e91372fa9f510046d4ce5812addad86b5",
+					},
+				},
+			},
+		},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
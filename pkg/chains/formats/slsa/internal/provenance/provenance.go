@@ -18,6 +18,7 @@ import (
 	intoto "github.com/in-toto/attestation/go/v1"
 	slsaprov "github.com/in-toto/in-toto-golang/in_toto/slsa_provenance/v1"
 	"github.com/tektoncd/chains/pkg/chains/formats/slsa/internal/metadata"
+	"github.com/tektoncd/chains/pkg/chains/formats/slsa/internal/results"
 	"github.com/tektoncd/chains/pkg/chains/formats/slsa/internal/slsaconfig"
 	"github.com/tektoncd/chains/pkg/chains/objects"
 	"google.golang.org/protobuf/encoding/protojson"
@@ -26,11 +27,21 @@ import (
 
 // GetSLSA1Statement returns a predicate in SLSA v1.0 format using the given data.
 func GetSLSA1Statement(obj objects.TektonObject, sub []*intoto.ResourceDescriptor, bd *slsa.BuildDefinition, bp []*intoto.ResourceDescriptor, slsaConfig *slsaconfig.SlsaConfig) (intoto.Statement, error) {
+	if slsaConfig.RecordOutcome.Has(obj.GetKindName()) {
+		outcome, err := results.GetOutcome(obj)
+		if err != nil {
+			return intoto.Statement{}, err
+		}
+		bp = append(bp, outcome)
+	}
+
 	predicate := slsa.Provenance{
 		BuildDefinition: bd,
 		RunDetails: &slsa.RunDetails{
 			Builder: &slsa.Builder{
-				Id: slsaConfig.BuilderID,
+				Id:                  slsaConfig.BuilderID,
+				Version:             slsaConfig.BuilderVersion,
+				BuilderDependencies: slsaConfig.BuilderDependencies,
 			},
 			Metadata:   metadata.GetBuildMetadata(obj),
 			Byproducts: bp,
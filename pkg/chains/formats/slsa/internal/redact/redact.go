@@ -0,0 +1,259 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redact
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	intoto "github.com/in-toto/attestation/go/v1"
+	"github.com/tektoncd/chains/pkg/config"
+	"google.golang.org/protobuf/types/known/structpb"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// MarkerPrefix starts the marker replacing a redacted value. It is followed by
+// the hex encoded sha256 digest of the value, so that a verifier knowing the
+// value can check it is the one that was redacted.
+const MarkerPrefix = "REDACTED:sha256:"
+
+// Redactor redacts sensitive values from the predicate of SLSA provenance.
+type Redactor struct {
+	names  []string
+	values []*regexp.Regexp
+	fields [][]segment
+}
+
+// segment is a step of the JSONPath of a removed field: either a field of an
+// object, where "*" stands for all of them, or all the elements of an array.
+type segment struct {
+	field string
+	array bool
+}
+
+// New returns the Redactor applying the redaction rules, or nil when there are
+// none.
+func New(cfg config.RedactionConfig) (*Redactor, error) {
+	r := &Redactor{}
+	for _, name := range sets.List(cfg.Params) {
+		if name == "" {
+			continue
+		}
+		if _, err := path.Match(name, ""); err != nil {
+			return nil, fmt.Errorf("invalid redaction glob %q: %w", name, err)
+		}
+		r.names = append(r.names, name)
+	}
+
+	rules := make([]string, 0, len(cfg.Values))
+	for rule := range cfg.Values {
+		rules = append(rules, rule)
+	}
+	sort.Strings(rules)
+	for _, rule := range rules {
+		re, err := regexp.Compile(cfg.Values[rule])
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction rule %q: %w", rule, err)
+		}
+		r.values = append(r.values, re)
+	}
+
+	for _, field := range sets.List(cfg.Fields) {
+		if field == "" {
+			continue
+		}
+		segments, err := parsePath(field)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction field %q: %w", field, err)
+		}
+		r.fields = append(r.fields, segments)
+	}
+
+	if len(r.names) == 0 && len(r.values) == 0 && len(r.fields) == 0 {
+		return nil, nil
+	}
+	return r, nil
+}
+
+// parsePath parses a JSONPath made of fields and array wildcards, rooted at the
+// predicate, e.g. `$.buildDefinition.externalParameters.runSpec.taskSpec.steps[*].env`.
+func parsePath(p string) ([]segment, error) {
+	p = strings.TrimPrefix(strings.TrimPrefix(p, "$"), ".")
+	if p == "" {
+		return nil, fmt.Errorf("the path has no field")
+	}
+	segments := []segment{}
+	for _, part := range strings.Split(p, ".") {
+		field, arrays, _ := strings.Cut(part, "[")
+		if field == "" {
+			return nil, fmt.Errorf("empty field in %q", part)
+		}
+		segments = append(segments, segment{field: field})
+		for arrays != "" {
+			rest, ok := strings.CutPrefix(arrays, "*]")
+			if !ok {
+				return nil, fmt.Errorf("only the [*] wildcard is supported in %q", part)
+			}
+			segments = append(segments, segment{array: true})
+			arrays = strings.TrimPrefix(rest, "[")
+		}
+	}
+	if segments[len(segments)-1].array {
+		return nil, fmt.Errorf("the path must end with a field")
+	}
+	return segments, nil
+}
+
+// Payload redacts the predicate of the in-toto statement in place. It does
+// nothing when the Redactor is nil.
+func (r *Redactor) Payload(payload interface{}) error {
+	if r == nil {
+		return nil
+	}
+	var predicate *structpb.Struct
+	switch s := payload.(type) {
+	case intoto.Statement:
+		predicate = s.Predicate
+	case *intoto.Statement:
+		if s != nil {
+			predicate = s.Predicate
+		}
+	default:
+		return fmt.Errorf("redaction does not support the payload type %T", payload)
+	}
+	if predicate == nil {
+		return nil
+	}
+
+	fields := predicate.AsMap()
+	for _, segments := range r.fields {
+		remove(fields, segments)
+	}
+	r.redact(fields, "")
+	redacted, err := structpb.NewStruct(fields)
+	if err != nil {
+		return fmt.Errorf("failed to convert the redacted predicate: %w", err)
+	}
+	predicate.Fields = redacted.Fields
+	return nil
+}
+
+// remove removes the field at the end of the path from the value.
+func remove(v interface{}, segments []segment) {
+	if len(segments) == 0 {
+		return
+	}
+	s, rest := segments[0], segments[1:]
+	if s.array {
+		if elems, ok := v.([]interface{}); ok {
+			for _, e := range elems {
+				remove(e, rest)
+			}
+		}
+		return
+	}
+	obj, ok := v.(map[string]interface{})
+	if !ok {
+		return
+	}
+	for k, e := range obj {
+		if s.field != "*" && s.field != k {
+			continue
+		}
+		if len(rest) == 0 {
+			delete(obj, k)
+		} else {
+			remove(e, rest)
+		}
+	}
+}
+
+// redact replaces the sensitive values found in the value, which is the value
+// of the given key in its parent object. Params and environment variables are
+// objects with a name and a value, except for the parameters of the invocation
+// of SLSA v0.2 provenance which map their names to their values.
+func (r *Redactor) redact(v interface{}, key string) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		if name, ok := t["name"].(string); ok {
+			if value, ok := t["value"]; ok && r.matchesName(name) {
+				t["value"] = Marker(value)
+			}
+		}
+		for k, e := range t {
+			if key == "parameters" && r.matchesName(k) {
+				t[k] = Marker(e)
+				continue
+			}
+			t[k] = r.redact(e, k)
+		}
+	case []interface{}:
+		for i, e := range t {
+			t[i] = r.redact(e, key)
+		}
+	case string:
+		if r.matchesValue(t) {
+			return Marker(t)
+		}
+	}
+	return v
+}
+
+func (r *Redactor) matchesName(name string) bool {
+	for _, glob := range r.names {
+		if ok, _ := path.Match(glob, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Redactor) matchesValue(value string) bool {
+	if strings.HasPrefix(value, MarkerPrefix) {
+		return false
+	}
+	for _, re := range r.values {
+		if re.MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Marker returns the marker replacing a redacted value. The digest is computed
+// over a string value itself, and over the JSON encoding of any other value.
+func Marker(value interface{}) string {
+	var raw []byte
+	switch t := value.(type) {
+	case string:
+		if strings.HasPrefix(t, MarkerPrefix) {
+			return t
+		}
+		raw = []byte(t)
+	default:
+		// The keys of encoded objects are sorted.
+		raw, _ = json.Marshal(value)
+	}
+	sum := sha256.Sum256(raw)
+	return MarkerPrefix + hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redact
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	intoto "github.com/in-toto/attestation/go/v1"
+	"github.com/tektoncd/chains/pkg/config"
+	"google.golang.org/protobuf/types/known/structpb"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     config.RedactionConfig
+		wantNil bool
+		wantErr bool
+	}{{
+		name:    "no rules",
+		wantNil: true,
+	}, {
+		name:    "empty rules",
+		cfg:     config.RedactionConfig{Params: sets.New(""), Fields: sets.New("")},
+		wantNil: true,
+	}, {
+		name: "rules",
+		cfg: config.RedactionConfig{
+			Params: sets.New("*TOKEN*"),
+			Values: map[string]string{"github": "^ghp_"},
+			Fields: sets.New("$.buildDefinition.internalParameters"),
+		},
+	}, {
+		name:    "invalid glob",
+		cfg:     config.RedactionConfig{Params: sets.New("[")},
+		wantErr: true,
+	}, {
+		name:    "invalid regex",
+		cfg:     config.RedactionConfig{Values: map[string]string{"bad": "("}},
+		wantErr: true,
+	}, {
+		name:    "index in path",
+		cfg:     config.RedactionConfig{Fields: sets.New("$.a[0].b")},
+		wantErr: true,
+	}, {
+		name:    "path ending with an array",
+		cfg:     config.RedactionConfig{Fields: sets.New("$.a[*]")},
+		wantErr: true,
+	}, {
+		name:    "path without field",
+		cfg:     config.RedactionConfig{Fields: sets.New("$")},
+		wantErr: true,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := New(tc.cfg)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("New() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if !tc.wantErr && (r == nil) != tc.wantNil {
+				t.Errorf("New() = %v, wantNil %v", r, tc.wantNil)
+			}
+		})
+	}
+}
+
+func TestPayload(t *testing.T) {
+	predicate := map[string]interface{}{
+		"buildDefinition": map[string]interface{}{
+			"externalParameters": map[string]interface{}{
+				"runSpec": map[string]interface{}{
+					"params": []interface{}{
+						map[string]interface{}{"name": "GITHUB_TOKEN", "value": "secret"},
+						map[string]interface{}{"name": "ARGS", "value": []interface{}{"a", "b"}},
+						map[string]interface{}{"name": "IMAGE", "value": "gcr.io/foo/bar"},
+					},
+					"taskSpec": map[string]interface{}{
+						"steps": []interface{}{
+							map[string]interface{}{
+								"name":   "step1",
+								"script": "echo ghp_abc",
+								"env": []interface{}{
+									map[string]interface{}{"name": "API_TOKEN", "value": "token"},
+									map[string]interface{}{"name": "HOME", "value": "/root"},
+								},
+								"workingDir": "/workspace",
+							},
+						},
+					},
+				},
+			},
+			"internalParameters": map[string]interface{}{"labels": "foo"},
+		},
+		"invocation": map[string]interface{}{
+			"parameters": map[string]interface{}{
+				"GITHUB_TOKEN": "secret",
+				"IMAGE":        "gcr.io/foo/bar",
+			},
+		},
+	}
+	want := map[string]interface{}{
+		"buildDefinition": map[string]interface{}{
+			"externalParameters": map[string]interface{}{
+				"runSpec": map[string]interface{}{
+					"params": []interface{}{
+						map[string]interface{}{"name": "GITHUB_TOKEN", "value": Marker("secret")},
+						map[string]interface{}{"name": "ARGS", "value": Marker([]interface{}{"a", "b"})},
+						map[string]interface{}{"name": "IMAGE", "value": "gcr.io/foo/bar"},
+					},
+					"taskSpec": map[string]interface{}{
+						"steps": []interface{}{
+							map[string]interface{}{
+								"name":   "step1",
+								"script": Marker("echo ghp_abc"),
+								"env": []interface{}{
+									map[string]interface{}{"name": "API_TOKEN", "value": Marker("token")},
+									map[string]interface{}{"name": "HOME", "value": "/root"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"invocation": map[string]interface{}{
+			"parameters": map[string]interface{}{
+				"GITHUB_TOKEN": Marker("secret"),
+				"IMAGE":        "gcr.io/foo/bar",
+			},
+		},
+	}
+
+	r, err := New(config.RedactionConfig{
+		Params: sets.New("*_TOKEN", "ARGS"),
+		Values: map[string]string{"github": "ghp_[a-z]+"},
+		Fields: sets.New(
+			"$.buildDefinition.internalParameters",
+			"$.buildDefinition.externalParameters.runSpec.taskSpec.steps[*].workingDir",
+		),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := structpb.NewStruct(predicate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	statement := &intoto.Statement{Predicate: p}
+	if err := r.Payload(statement); err != nil {
+		t.Fatalf("Payload() error = %v", err)
+	}
+	if d := cmp.Diff(want, statement.Predicate.AsMap()); d != "" {
+		t.Errorf("Payload() (-want +got): %s", d)
+	}
+}
+
+func TestPayloadNilRedactor(t *testing.T) {
+	var r *Redactor
+	if err := r.Payload("not a statement"); err != nil {
+		t.Errorf("Payload() error = %v", err)
+	}
+}
+
+func TestMarker(t *testing.T) {
+	// echo -n secret | sha256sum
+	want := "REDACTED:sha256:2bb80d537b1da3e38bd30361aa855686bde0eacd7162fef6a25fe97bf527a25b"
+	if got := Marker("secret"); got != want {
+		t.Errorf("Marker() = %s, want %s", got, want)
+	}
+	if got := Marker(want); got != want {
+		t.Errorf("Marker() of a marker = %s, want %s", got, want)
+	}
+}
@@ -46,6 +46,8 @@ const (
 	PipelineResourceName = "pipelineResource"
 	// StepActionConfigName is the name of the resolved dependencies of remote StepAction
 	StepActionConfigName = "stepAction"
+	// ChildPipelineConfigName is the name of the resolved dependency of the pipelineRef of a child PipelineRun.
+	ChildPipelineConfigName = "childPipeline"
 )
 
 // AddTaskDescriptorContent is used to toggle the fields in  see AddTektonTaskDescriptor and AddSLSATaskDescriptor
@@ -198,6 +200,34 @@ func fromPipelineTask(logger *zap.SugaredLogger, pro *objects.PipelineRunObjectV
 	return resolvedDependencies, nil
 }
 
+// fromChildPipelineRuns walks the child PipelineRuns of the given PipelineRun recursively and
+// returns their pipeline config along with the resolved dependencies of their pipeline tasks.
+func fromChildPipelineRuns(logger *zap.SugaredLogger, pro *objects.PipelineRunObjectV1, opts ResolveOptions, addTasks AddTaskDescriptorContent) ([]*intoto.ResourceDescriptor, error) {
+	resolvedDependencies := []*intoto.ResourceDescriptor{}
+	for _, child := range pro.GetPipelineRuns() {
+		if p := child.Status.Provenance; p != nil && p.RefSource != nil {
+			resolvedDependencies = append(resolvedDependencies, &intoto.ResourceDescriptor{
+				Name:   ChildPipelineConfigName,
+				Uri:    p.RefSource.URI,
+				Digest: p.RefSource.Digest,
+			})
+		}
+
+		rds, err := fromPipelineTask(logger, child, opts, addTasks)
+		if err != nil {
+			return nil, err
+		}
+		resolvedDependencies = append(resolvedDependencies, rds...)
+
+		rds, err = fromChildPipelineRuns(logger, child, opts, addTasks)
+		if err != nil {
+			return nil, err
+		}
+		resolvedDependencies = append(resolvedDependencies, rds...)
+	}
+	return resolvedDependencies, nil
+}
+
 // fromRemoteStepActions converts remote StepAction provenance into resolved dependencies
 func fromRemoteStepActions(tro *objects.TaskRunObjectV1) []*intoto.ResourceDescriptor {
 	var rds []*intoto.ResourceDescriptor
@@ -246,6 +276,10 @@ func taskDependencies(ctx context.Context, opts ResolveOptions, tro *objects.Tas
 	resolvedDependencies = append(resolvedDependencies,
 		ConvertMaterialsToResolvedDependencies(mats, PipelineResourceName)...)
 
+	// add the input artifacts declared through the Tekton Artifacts API
+	mats = material.FromInputArtifacts(ctx, tro)
+	resolvedDependencies = append(resolvedDependencies, ConvertMaterialsToResolvedDependencies(mats, InputResultName)...)
+
 	// remove duplicate resolved dependencies
 	resolvedDependencies, err = RemoveDuplicateResolvedDependencies(resolvedDependencies)
 	if err != nil {
@@ -302,6 +336,13 @@ func PipelineRun(ctx context.Context, pro *objects.PipelineRunObjectV1, slsaconf
 	}
 	resolvedDependencies = append(resolvedDependencies, rds...)
 
+	// add resolved dependencies from child pipelineruns
+	rds, err = fromChildPipelineRuns(logger, pro, opts, addTasks)
+	if err != nil {
+		return nil, err
+	}
+	resolvedDependencies = append(resolvedDependencies, rds...)
+
 	if slsaconfig.DeepInspectionEnabled && opts.WithStepActionsResults {
 		execTasks := pro.GetExecutedTasks()
 		for _, task := range execTasks {
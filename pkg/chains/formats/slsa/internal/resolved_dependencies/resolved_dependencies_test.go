@@ -700,6 +700,42 @@ func TestPipelineRun(t *testing.T) {
 	}
 }
 
+func TestPipelineRunWithChildPipelineRuns(t *testing.T) {
+	ctx := logtesting.TestContextWithLogger(t)
+	child := createPro("../../testdata/slsa-v2alpha3/pipelinerun1.json")
+	// the nested pipeline is reached through an intermediate child without pipelineRef
+	intermediate := objects.NewPipelineRunObjectV1(&v1.PipelineRun{})
+	intermediate.AppendPipelineRun(child)
+	pro := objects.NewPipelineRunObjectV1(&v1.PipelineRun{})
+	pro.AppendPipelineRun(intermediate)
+
+	want := []*intoto.ResourceDescriptor{
+		{Name: "childPipeline", Uri: "git+https://github.com/test", Digest: common.DigestSet{"sha1": "28b123"}},
+		{Name: "pipelineTask", Uri: "git+https://github.com/catalog", Digest: common.DigestSet{"sha1": "x123"}},
+		{
+			Uri:    "oci://gcr.io/test1/test1",
+			Digest: common.DigestSet{"sha256": "d4b63d3e24d6eef04a6dc0795cf8a73470688803d97c52cffa3c8d4efd3397b6"},
+		},
+		{Name: "pipelineTask", Uri: "git+https://github.com/test", Digest: common.DigestSet{"sha1": "ab123"}},
+		{
+			Uri:    "oci://gcr.io/test2/test2",
+			Digest: common.DigestSet{"sha256": "4d6dd704ef58cb214dd826519929e92a978a57cdee43693006139c0080fd6fac"},
+		},
+		{
+			Uri:    "oci://gcr.io/test3/test3",
+			Digest: common.DigestSet{"sha256": "f1a8b8549c179f41e27ff3db0fe1a1793e4b109da46586501a8343637b1d0478"},
+		},
+	}
+
+	got, err := PipelineRun(ctx, pro, &slsaconfig.SlsaConfig{DeepInspectionEnabled: false}, ResolveOptions{}, AddSLSATaskDescriptor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := cmp.Diff(want, got, protocmp.Transform()); d != "" {
+		t.Errorf("PipelineRunResolvedDependencies(): -want +got: %s", d)
+	}
+}
+
 func TestGetTaskDescriptor(t *testing.T) {
 	tests := []struct {
 		name                string
@@ -20,10 +20,17 @@ import (
 
 	"github.com/tektoncd/chains/pkg/artifacts"
 	"github.com/tektoncd/chains/pkg/chains/objects"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"knative.dev/pkg/apis"
 
 	slsa "github.com/in-toto/attestation/go/v1"
 )
 
+const (
+	customRunName = "customRuns/%s"
+	outcomeName   = "outcome"
+)
+
 var imageResultsNamesSuffixs = []string{
 	artifacts.OCIImageURLResultName,
 	artifacts.OCIImageDigestResultName,
@@ -56,6 +63,53 @@ func GetResultsWithoutBuildArtifacts(objName string, results []objects.Result, r
 	return byProd, nil
 }
 
+// customRunContent is the content of the byproducts describing a CustomRun.
+type customRunContent struct {
+	PipelineTask string                    `json:"pipelineTask,omitempty"`
+	CustomRef    *v1beta1.TaskRef          `json:"customRef,omitempty"`
+	Params       v1beta1.Params            `json:"params,omitempty"`
+	Results      []v1beta1.CustomRunResult `json:"results,omitempty"`
+	Succeeded    bool                      `json:"succeeded"`
+	Reason       string                    `json:"reason,omitempty"`
+}
+
+// GetCustomRuns returns a byproduct for each CustomRun executed by the given PipelineRun,
+// including the ones of its child PipelineRuns.
+func GetCustomRuns(pro *objects.PipelineRunObjectV1) ([]*slsa.ResourceDescriptor, error) {
+	byProd := []*slsa.ResourceDescriptor{}
+	for _, cr := range pro.GetCustomRuns() {
+		c := customRunContent{
+			PipelineTask: cr.Labels[objects.PipelineTaskLabel],
+			CustomRef:    cr.Spec.CustomRef,
+			Params:       cr.Spec.Params,
+			Results:      cr.Status.Results,
+			Succeeded:    cr.IsSuccessful(),
+		}
+		if cond := cr.Status.GetCondition(apis.ConditionSucceeded); cond != nil {
+			c.Reason = cond.Reason
+		}
+		content, err := json.Marshal(c)
+		if err != nil {
+			return nil, err
+		}
+		byProd = append(byProd, &slsa.ResourceDescriptor{
+			Name:      fmt.Sprintf(customRunName, cr.Name),
+			Content:   content,
+			MediaType: "application/json",
+		})
+	}
+
+	for _, child := range pro.GetPipelineRuns() {
+		childProds, err := GetCustomRuns(child)
+		if err != nil {
+			return nil, err
+		}
+		byProd = append(byProd, childProds...)
+	}
+
+	return byProd, nil
+}
+
 func isOCIImage(resName string) bool {
 	for _, suffix := range imageResultsNamesSuffixs {
 		if strings.HasSuffix(resName, suffix) {
@@ -65,3 +119,24 @@ func isOCIImage(resName string) bool {
 
 	return resName == artifacts.OCIImagesResultName
 }
+
+// outcomeContent is the content of the byproduct recording the outcome of a run.
+type outcomeContent struct {
+	Outcome string `json:"outcome"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+// GetOutcome returns a byproduct recording whether the given run succeeded,
+// failed, was cancelled or timed out.
+func GetOutcome(obj objects.TektonObject) (*slsa.ResourceDescriptor, error) {
+	outcome, reason := objects.GetOutcome(obj)
+	content, err := json.Marshal(outcomeContent{Outcome: outcome, Reason: reason})
+	if err != nil {
+		return nil, err
+	}
+	return &slsa.ResourceDescriptor{
+		Name:      outcomeName,
+		Content:   content,
+		MediaType: "application/json",
+	}, nil
+}
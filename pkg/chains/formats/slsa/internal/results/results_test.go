@@ -21,7 +21,12 @@ import (
 	slsa "github.com/in-toto/attestation/go/v1"
 	"github.com/tektoncd/chains/pkg/chains/objects"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"google.golang.org/protobuf/testing/protocmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 )
 
 func TestGetResultsWithoutBuildArtifacts(t *testing.T) {
@@ -200,3 +205,85 @@ func toJSONString(t *testing.T, val v1.ParamValue) []byte {
 
 	return res
 }
+
+func TestGetCustomRuns(t *testing.T) {
+	approval := &v1beta1.CustomRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "approval",
+			Labels: map[string]string{objects.PipelineTaskLabel: "wait-for-approval"},
+		},
+		Spec: v1beta1.CustomRunSpec{
+			CustomRef: &v1beta1.TaskRef{APIVersion: "openshift-pipelines.org/v1alpha1", Kind: "ApprovalTask"},
+			Params:    v1beta1.Params{{Name: "approvers", Value: *v1beta1.NewStructuredValues("alice")}},
+		},
+		Status: v1beta1.CustomRunStatus{
+			Status: duckv1.Status{
+				Conditions: []apis.Condition{{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue, Reason: "Approved"}},
+			},
+		},
+	}
+	nested := &v1beta1.CustomRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "nested"},
+		Status: v1beta1.CustomRunStatus{
+			Status: duckv1.Status{
+				Conditions: []apis.Condition{{Type: apis.ConditionSucceeded, Status: corev1.ConditionFalse, Reason: "Rejected"}},
+			},
+			CustomRunStatusFields: v1beta1.CustomRunStatusFields{
+				Results: []v1beta1.CustomRunResult{{Name: "comment", Value: "no"}},
+			},
+		},
+	}
+
+	child := objects.NewPipelineRunObjectV1(&v1.PipelineRun{})
+	child.AppendCustomRun(nested)
+	pro := objects.NewPipelineRunObjectV1(&v1.PipelineRun{})
+	pro.AppendCustomRun(approval)
+	pro.AppendPipelineRun(child)
+
+	expected := []*slsa.ResourceDescriptor{
+		{
+			Name:      "customRuns/approval",
+			Content:   []byte(`{"pipelineTask":"wait-for-approval","customRef":{"kind":"ApprovalTask","apiVersion":"openshift-pipelines.org/v1alpha1"},"params":[{"name":"approvers","value":"alice"}],"succeeded":true,"reason":"Approved"}`),
+			MediaType: "application/json",
+		},
+		{
+			Name:      "customRuns/nested",
+			Content:   []byte(`{"results":[{"name":"comment","value":"no"}],"succeeded":false,"reason":"Rejected"}`),
+			MediaType: "application/json",
+		},
+	}
+
+	got, err := GetCustomRuns(pro)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(expected, got, protocmp.Transform()); diff != "" {
+		t.Errorf("GetCustomRuns(): -want +got: %s", diff)
+	}
+}
+
+func TestGetOutcome(t *testing.T) {
+	pro := objects.NewPipelineRunObjectV1(&v1.PipelineRun{
+		Status: v1.PipelineRunStatus{
+			Status: duckv1.Status{
+				Conditions: duckv1.Conditions{{
+					Type:   apis.ConditionSucceeded,
+					Status: corev1.ConditionFalse,
+					Reason: v1.PipelineRunReasonTimedOut.String(),
+				}},
+			},
+		},
+	})
+	got, err := GetOutcome(pro)
+	if err != nil {
+		t.Fatalf("GetOutcome() error = %v", err)
+	}
+	want := &slsa.ResourceDescriptor{
+		Name:      "outcome",
+		Content:   []byte(`{"outcome":"timedout","reason":"PipelineRunTimeout"}`),
+		MediaType: "application/json",
+	}
+	if d := cmp.Diff(want, got, protocmp.Transform()); d != "" {
+		t.Errorf("GetOutcome() (-want, +got):\n%s", d)
+	}
+}
@@ -14,6 +14,13 @@ limitations under the License.
 */
 package slsaconfig
 
+import (
+	intoto "github.com/in-toto/attestation/go/v1"
+	"github.com/tektoncd/chains/pkg/chains/formats/slsa/internal/redact"
+	"github.com/tektoncd/chains/pkg/config"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
 // SlsaConfig carries common information that is needed across different SLSA formatters.
 type SlsaConfig struct {
 	// BuilderID is the URI of the trusted build platform.
@@ -22,4 +29,30 @@ type SlsaConfig struct {
 	DeepInspectionEnabled bool
 	// The buildType for the build definition
 	BuildType string
+	// BuilderVersion maps the components of the build platform to their version.
+	BuilderVersion map[string]string
+	// BuilderDependencies are the images of the build platform the runs depend on.
+	BuilderDependencies []*intoto.ResourceDescriptor
+	// Redactor redacts sensitive values from the predicate, when redaction rules
+	// are configured.
+	Redactor *redact.Redactor
+	// RecordOutcome holds the kinds of the runs, as returned by GetKindName,
+	// whose outcome is recorded in the byproducts.
+	RecordOutcome sets.Set[string]
+}
+
+// RecordOutcomeKinds returns the kinds of the runs configured to record their
+// outcome in their provenance.
+func RecordOutcomeKinds(cfg config.Config) sets.Set[string] {
+	kinds := sets.New[string]()
+	for kind, artifact := range map[string]config.Artifact{
+		"taskrun":     cfg.Artifacts.TaskRuns,
+		"pipelinerun": cfg.Artifacts.PipelineRuns,
+		"customrun":   cfg.Artifacts.CustomRuns,
+	} {
+		if artifact.RecordOutcome {
+			kinds.Insert(kind)
+		}
+	}
+	return kinds
 }
@@ -22,6 +22,7 @@ import (
 
 	"github.com/tektoncd/chains/pkg/chains/formats"
 	"github.com/tektoncd/chains/pkg/chains/formats/slsa/extract"
+	"github.com/tektoncd/chains/pkg/chains/formats/slsa/internal/redact"
 	"github.com/tektoncd/chains/pkg/chains/formats/slsa/internal/slsaconfig"
 	"github.com/tektoncd/chains/pkg/chains/formats/slsa/v1/pipelinerun"
 	"github.com/tektoncd/chains/pkg/chains/formats/slsa/v1/taskrun"
@@ -44,10 +45,15 @@ type InTotoIte6 struct {
 }
 
 func NewFormatter(cfg config.Config) (formats.Payloader, error) {
+	redactor, err := redact.New(cfg.Redaction)
+	if err != nil {
+		return nil, err
+	}
 	return &InTotoIte6{
 		slsaConfig: &slsaconfig.SlsaConfig{
 			BuilderID:             cfg.Builder.ID,
 			DeepInspectionEnabled: cfg.Artifacts.PipelineRuns.DeepInspectionEnabled,
+			Redactor:              redactor,
 		},
 	}, nil
 }
@@ -57,14 +63,23 @@ func (i *InTotoIte6) Wrap() bool {
 }
 
 func (i *InTotoIte6) CreatePayload(ctx context.Context, obj interface{}) (interface{}, error) {
+	var payload interface{}
+	var err error
 	switch v := obj.(type) {
 	case *objects.TaskRunObjectV1:
-		return taskrun.GenerateAttestation(ctx, v, i.slsaConfig)
+		payload, err = taskrun.GenerateAttestation(ctx, v, i.slsaConfig)
 	case *objects.PipelineRunObjectV1:
-		return pipelinerun.GenerateAttestation(ctx, v, i.slsaConfig)
+		payload, err = pipelinerun.GenerateAttestation(ctx, v, i.slsaConfig)
 	default:
 		return nil, fmt.Errorf("intoto does not support type: %s", v)
 	}
+	if err != nil {
+		return nil, err
+	}
+	if err := i.slsaConfig.Redactor.Payload(payload); err != nil {
+		return nil, err
+	}
+	return payload, nil
 }
 
 func (i *InTotoIte6) Type() config.PayloadType {
@@ -65,7 +65,7 @@ func GenerateAttestation(ctx context.Context, pro *objects.PipelineRunObjectV1,
 			ID: slsaConfig.BuilderID,
 		},
 		BuildType:   pro.GetGVK(),
-		Invocation:  invocation(pro),
+		Invocation:  invocation(ctx, pro),
 		BuildConfig: buildConfig(ctx, pro),
 		Metadata:    metadata(pro),
 		Materials:   mat,
@@ -85,12 +85,12 @@ func GenerateAttestation(ctx context.Context, pro *objects.PipelineRunObjectV1,
 	return att, nil
 }
 
-func invocation(pro *objects.PipelineRunObjectV1) slsa.ProvenanceInvocation {
+func invocation(ctx context.Context, pro *objects.PipelineRunObjectV1) slsa.ProvenanceInvocation {
 	var paramSpecs []v1.ParamSpec
 	if ps := pro.Status.PipelineSpec; ps != nil {
 		paramSpecs = ps.Params
 	}
-	return attest.Invocation(pro, pro.Spec.Params, paramSpecs)
+	return attest.SpireVerification(ctx, attest.Invocation(pro, pro.Spec.Params, paramSpecs))
 }
 
 func buildConfig(ctx context.Context, pro *objects.PipelineRunObjectV1) BuildConfig {
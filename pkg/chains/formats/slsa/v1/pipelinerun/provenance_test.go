@@ -14,6 +14,7 @@ limitations under the License.
 package pipelinerun
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -83,7 +84,7 @@ func TestInvocation(t *testing.T) {
 			"IMAGE": {Type: "string", StringVal: "test.io/test/image"},
 		},
 	}
-	got := invocation(pro)
+	got := invocation(context.Background(), pro)
 	if diff := cmp.Diff(expected, got); diff != "" {
 		t.Errorf("invocation(): -want +got: %s", diff)
 	}
@@ -17,6 +17,7 @@ limitations under the License.
 package taskrun
 
 import (
+	"context"
 	"reflect"
 	"strings"
 	"testing"
@@ -188,7 +189,7 @@ status:
 		},
 	}
 
-	got := invocation(objects.NewTaskRunObjectV1(taskRun))
+	got := invocation(context.Background(), objects.NewTaskRunObjectV1(taskRun))
 	if !reflect.DeepEqual(expected, got) {
 		if d := cmp.Diff(expected, got); d != "" {
 			t.Log(d)
@@ -43,7 +43,7 @@ func GenerateAttestation(ctx context.Context, tro *objects.TaskRunObjectV1, slsa
 			ID: slsaConfig.BuilderID,
 		},
 		BuildType:   tro.GetGVK(),
-		Invocation:  invocation(tro),
+		Invocation:  invocation(ctx, tro),
 		BuildConfig: buildConfig(tro),
 		Metadata:    Metadata(tro),
 		Materials:   mat,
@@ -65,12 +65,12 @@ func GenerateAttestation(ctx context.Context, tro *objects.TaskRunObjectV1, slsa
 // invocation describes the event that kicked off the build
 // we currently don't set ConfigSource because we don't know
 // which material the Task definition came from
-func invocation(tro *objects.TaskRunObjectV1) slsa.ProvenanceInvocation {
+func invocation(ctx context.Context, tro *objects.TaskRunObjectV1) slsa.ProvenanceInvocation {
 	var paramSpecs []v1.ParamSpec
 	if ts := tro.Status.TaskSpec; ts != nil {
 		paramSpecs = ts.Params
 	}
-	return attest.Invocation(tro, tro.Spec.Params, paramSpecs)
+	return attest.SpireVerification(ctx, attest.Invocation(tro, tro.Spec.Params, paramSpecs))
 }
 
 // Metadata adds taskrun's start time, completion time and reproducibility labels
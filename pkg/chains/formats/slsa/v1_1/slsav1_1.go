@@ -0,0 +1,163 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package v11 implements the slsa/v1.1 payloader, which generates SLSA v1.1
+// provenance recording the version and the dependencies of the builder.
+package v11
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	intoto "github.com/in-toto/attestation/go/v1"
+	"github.com/tektoncd/chains/pkg/artifacts"
+	"github.com/tektoncd/chains/pkg/chains/formats"
+	"github.com/tektoncd/chains/pkg/chains/formats/slsa/internal/redact"
+	"github.com/tektoncd/chains/pkg/chains/formats/slsa/internal/slsaconfig"
+	"github.com/tektoncd/chains/pkg/chains/formats/slsa/v1_1/internal/customrun"
+	"github.com/tektoncd/chains/pkg/chains/formats/slsa/v1_1/internal/pipelinerun"
+	"github.com/tektoncd/chains/pkg/chains/formats/slsa/v1_1/internal/taskrun"
+	"github.com/tektoncd/chains/pkg/chains/objects"
+	"github.com/tektoncd/chains/pkg/config"
+)
+
+const (
+	payloadTypeSlsav11 = formats.PayloadTypeSlsav11
+
+	// PipelinesComponent is the builder version key of Tekton Pipelines.
+	PipelinesComponent = "tekton-pipelines"
+	// ChainsComponent is the builder version key of Tekton Chains.
+	ChainsComponent = "tekton-chains"
+)
+
+func init() {
+	formats.RegisterPayloader(payloadTypeSlsav11, NewFormatter)
+}
+
+// Slsa is a v1.1 payloader implementation.
+type Slsa struct {
+	slsaConfig *slsaconfig.SlsaConfig
+}
+
+// NewFormatter returns a new v1.1 payloader. It fails when the builder
+// dependencies are not images pinned to their digest.
+func NewFormatter(cfg config.Config) (formats.Payloader, error) { //nolint:ireturn
+	redactor, err := redact.New(cfg.Redaction)
+	if err != nil {
+		return nil, err
+	}
+	slsaConfig := &slsaconfig.SlsaConfig{
+		BuilderID:             cfg.Builder.ID,
+		DeepInspectionEnabled: cfg.Artifacts.PipelineRuns.DeepInspectionEnabled,
+		BuildType:             cfg.BuildDefinition.BuildType,
+		Redactor:              redactor,
+		RecordOutcome:         slsaconfig.RecordOutcomeKinds(cfg),
+	}
+
+	versions := map[string]string{}
+	if cfg.Builder.PipelinesVersion != "" {
+		versions[PipelinesComponent] = cfg.Builder.PipelinesVersion
+	}
+	if cfg.Builder.ChainsVersion != "" {
+		versions[ChainsComponent] = cfg.Builder.ChainsVersion
+	}
+	if len(versions) > 0 {
+		slsaConfig.BuilderVersion = versions
+	}
+
+	for _, dep := range []struct{ name, image string }{
+		{"controller", cfg.Builder.ControllerImage},
+		{"entrypoint", cfg.Builder.EntrypointImage},
+	} {
+		if dep.image == "" {
+			continue
+		}
+		descriptor, err := imageDescriptor(dep.name, dep.image)
+		if err != nil {
+			return nil, err
+		}
+		slsaConfig.BuilderDependencies = append(slsaConfig.BuilderDependencies, descriptor)
+	}
+
+	return &Slsa{slsaConfig: slsaConfig}, nil
+}
+
+// imageDescriptor returns the descriptor of an image of the build platform.
+func imageDescriptor(component, image string) (*intoto.ResourceDescriptor, error) {
+	ref, err := name.NewDigest(strings.TrimPrefix(image, artifacts.OCIScheme))
+	if err != nil {
+		return nil, fmt.Errorf("the %s image %q must be pinned to its digest: %w", component, image, err)
+	}
+	alg, hex, _ := strings.Cut(ref.DigestStr(), ":")
+	return &intoto.ResourceDescriptor{
+		Name:   component,
+		Uri:    artifacts.OCIScheme + ref.Context().Name(),
+		Digest: map[string]string{alg: hex},
+	}, nil
+}
+
+// Wrap indicates if the resulting payload should be wrapped.
+func (s *Slsa) Wrap() bool {
+	return true
+}
+
+// CreatePayload returns the payload for the given object using the v1.1 formatter logic.
+func (s *Slsa) CreatePayload(ctx context.Context, obj interface{}) (interface{}, error) {
+	var payload interface{}
+	var err error
+	switch v := obj.(type) {
+	case *objects.TaskRunObjectV1:
+		payload, err = taskrun.GenerateAttestation(ctx, v, s.slsaConfig)
+	case *objects.PipelineRunObjectV1:
+		payload, err = pipelinerun.GenerateAttestation(ctx, v, s.slsaConfig)
+	case *objects.CustomRunObjectV1beta1:
+		payload, err = customrun.GenerateAttestation(ctx, v, s.slsaConfig)
+	default:
+		return nil, fmt.Errorf("intoto does not support type: %s", v)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := s.slsaConfig.Redactor.Payload(payload); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+// Type returns the version of this payloader.
+func (s *Slsa) Type() config.PayloadType {
+	return payloadTypeSlsav11
+}
+
+// RetrieveAllArtifactURIs returns the full URI of all artifacts detected as subjects.
+func (s *Slsa) RetrieveAllArtifactURIs(ctx context.Context, obj interface{}) ([]string, error) {
+	var subjects []*intoto.ResourceDescriptor
+
+	switch v := obj.(type) {
+	case *objects.TaskRunObjectV1:
+		subjects = taskrun.SubjectDigests(ctx, v)
+	case *objects.PipelineRunObjectV1:
+		subjects = pipelinerun.SubjectDigests(ctx, v, s.slsaConfig)
+	case *objects.CustomRunObjectV1beta1:
+		subjects = customrun.SubjectDigests(ctx, v)
+	default:
+		return nil, fmt.Errorf("intoto does not support type: %s", v)
+	}
+
+	return artifacts.SubjectURIs(subjects), nil
+}
@@ -0,0 +1,292 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v11
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	intoto "github.com/in-toto/attestation/go/v1"
+	"github.com/tektoncd/chains/pkg/chains/formats/slsa/internal/redact"
+	"github.com/tektoncd/chains/pkg/chains/objects"
+	"github.com/tektoncd/chains/pkg/config"
+	"github.com/tektoncd/chains/pkg/internal/objectloader"
+	"google.golang.org/protobuf/encoding/protojson"
+	"k8s.io/apimachinery/pkg/util/sets"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+var builder = config.BuilderConfig{
+	ID:               "test_builder-1",
+	PipelinesVersion: "v1.15.0",
+	ChainsVersion:    "v0.26.0",
+	ControllerImage:  "gcr.io/tekton-releases/github.com/tektoncd/pipeline/cmd/controller@sha256:05f95b26ed10668b7183c1e2da98610e91372fa9f510046d4ce5812addad86b5",
+	EntrypointImage:  "oci://gcr.io/tekton-releases/github.com/tektoncd/pipeline/cmd/entrypoint@sha256:586789aa031fafc7d78a5393cdc772e0b55107ea54bb8bcf3f2cdac6c6da51ee",
+}
+
+func TestNewFormatter(t *testing.T) {
+	tests := []struct {
+		name    string
+		builder config.BuilderConfig
+		wantErr bool
+	}{{
+		name:    "builder",
+		builder: builder,
+	}, {
+		name:    "builder id only",
+		builder: config.BuilderConfig{ID: "test_builder-1"},
+	}, {
+		name:    "unpinned image",
+		builder: config.BuilderConfig{ID: "test_builder-1", ControllerImage: "gcr.io/tekton-releases/github.com/tektoncd/pipeline/cmd/controller:v1.15.0"},
+		wantErr: true,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := NewFormatter(config.Config{Builder: tc.builder})
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("NewFormatter() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if !tc.wantErr && f.Type() != "slsa/v1.1" {
+				t.Errorf("unexpected type %s", f.Type())
+			}
+		})
+	}
+}
+
+func TestCreatePayload(t *testing.T) {
+	tests := []struct {
+		name   string
+		obj    func(t *testing.T) interface{}
+		cfg    config.Config
+		golden string
+	}{{
+		name: "taskrun",
+		obj: func(t *testing.T) interface{} {
+			tr, err := objectloader.TaskRunV1FromFile("../testdata/slsa-v1.1/taskrun1.json")
+			if err != nil {
+				t.Fatal(err)
+			}
+			return objects.NewTaskRunObjectV1(tr)
+		},
+		cfg:    config.Config{Builder: builder},
+		golden: "../testdata/slsa-v1.1/taskrun1-provenance.json",
+	}, {
+		name: "pipelinerun",
+		obj: func(t *testing.T) interface{} {
+			pr, err := objectloader.PipelineRunV1FromFile("../testdata/slsa-v1.1/pipelinerun1.json")
+			if err != nil {
+				t.Fatal(err)
+			}
+			pro := objects.NewPipelineRunObjectV1(pr)
+			for _, path := range []string{"../testdata/slsa-v1.1/taskrun1.json", "../testdata/slsa-v1.1/taskrun2.json"} {
+				tr, err := objectloader.TaskRunV1FromFile(path)
+				if err != nil {
+					t.Fatal(err)
+				}
+				pro.AppendTaskRun(tr)
+			}
+			return pro
+		},
+		cfg: config.Config{
+			Builder:   builder,
+			Artifacts: config.ArtifactConfigs{PipelineRuns: config.Artifact{DeepInspectionEnabled: true}},
+		},
+		golden: "../testdata/slsa-v1.1/pipelinerun1-provenance.json",
+	}, {
+		name: "customrun",
+		obj: func(t *testing.T) interface{} {
+			cr, err := objectloader.CustomRunV1beta1FromFile("../testdata/slsa-v1.1/customrun1.json")
+			if err != nil {
+				t.Fatal(err)
+			}
+			return objects.NewCustomRunObjectV1beta1(cr)
+		},
+		cfg:    config.Config{Builder: builder},
+		golden: "../testdata/slsa-v1.1/customrun1-provenance.json",
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := logtesting.TestContextWithLogger(t)
+			f, err := NewFormatter(tc.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			payload, err := f.CreatePayload(ctx, tc.obj(t))
+			if err != nil {
+				t.Fatalf("CreatePayload() error = %v", err)
+			}
+			var raw []byte
+			switch statement := payload.(type) {
+			case intoto.Statement:
+				raw, err = protojson.Marshal(&statement)
+			default:
+				t.Fatalf("unexpected payload type %T", payload)
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got, want interface{}
+			if err := json.Unmarshal(raw, &got); err != nil {
+				t.Fatal(err)
+			}
+			golden, err := os.ReadFile(tc.golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Unmarshal(golden, &want); err != nil {
+				t.Fatal(err)
+			}
+			if d := cmp.Diff(want, got); d != "" {
+				t.Errorf("provenance does not match %s (-want +got): %s", tc.golden, d)
+			}
+		})
+	}
+}
+
+func TestRetrieveAllArtifactURIs(t *testing.T) {
+	tr, err := objectloader.TaskRunV1FromFile("../testdata/slsa-v1.1/taskrun1.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := NewFormatter(config.Config{Builder: builder})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := f.RetrieveAllArtifactURIs(logtesting.TestContextWithLogger(t), objects.NewTaskRunObjectV1(tr))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"gcr.io/my/image/fromstep3@sha256:827521c857fdcd4374f4da5442fbae2edb01e7fbae285c3ec15673d4c1daecb7",
+		"gcr.io/my/image@sha256:d31cc8328054de2bd93735f9cbf0ccfb6e0ee8f4c4225da7d8f8cb3900eaf466",
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("RetrieveAllArtifactURIs() (-want +got): %s", d)
+	}
+}
+
+func TestCreatePayloadRedacted(t *testing.T) {
+	tr, err := objectloader.TaskRunV1FromFile("../testdata/slsa-v1.1/taskrun1.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := NewFormatter(config.Config{
+		Builder: builder,
+		Redaction: config.RedactionConfig{
+			Params: sets.New("CHAINS-GIT_*"),
+			Fields: sets.New("$.buildDefinition.internalParameters"),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload, err := f.CreatePayload(logtesting.TestContextWithLogger(t), objects.NewTaskRunObjectV1(tr))
+	if err != nil {
+		t.Fatalf("CreatePayload() error = %v", err)
+	}
+	var predicate map[string]interface{}
+	switch statement := payload.(type) {
+	case intoto.Statement:
+		predicate = statement.Predicate.AsMap()
+	default:
+		t.Fatalf("unexpected payload type %T", payload)
+	}
+
+	buildDefinition := predicate["buildDefinition"].(map[string]interface{})
+	if _, ok := buildDefinition["internalParameters"]; ok {
+		t.Errorf("internalParameters were not removed")
+	}
+	runSpec := buildDefinition["externalParameters"].(map[string]interface{})["runSpec"].(map[string]interface{})
+	got := map[string]interface{}{}
+	for _, p := range runSpec["params"].([]interface{}) {
+		param := p.(map[string]interface{})
+		got[param["name"].(string)] = param["value"]
+	}
+	want := map[string]interface{}{
+		"IMAGE":             "test.io/test/image",
+		"CHAINS-GIT_COMMIT": redact.Marker("taskrun"),
+		"CHAINS-GIT_URL":    redact.Marker("https://git.test.com"),
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("params (-want +got): %s", d)
+	}
+}
+
+func TestCreatePayloadOutcome(t *testing.T) {
+	tr, err := objectloader.TaskRunV1FromFile("../testdata/slsa-v1.1/taskrun1.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name          string
+		recordOutcome bool
+		want          string
+	}{{
+		name:          "recorded",
+		recordOutcome: true,
+		want:          "succeeded",
+	}, {
+		name: "not recorded",
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := NewFormatter(config.Config{
+				Builder:   builder,
+				Artifacts: config.ArtifactConfigs{TaskRuns: config.Artifact{RecordOutcome: tc.recordOutcome}},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			payload, err := f.CreatePayload(logtesting.TestContextWithLogger(t), objects.NewTaskRunObjectV1(tr))
+			if err != nil {
+				t.Fatalf("CreatePayload() error = %v", err)
+			}
+			var predicate map[string]interface{}
+			switch statement := payload.(type) {
+			case intoto.Statement:
+				predicate = statement.Predicate.AsMap()
+			default:
+				t.Fatalf("unexpected payload type %T", payload)
+			}
+
+			got := ""
+			byproducts, _ := predicate["runDetails"].(map[string]interface{})["byproducts"].([]interface{})
+			for _, b := range byproducts {
+				byproduct := b.(map[string]interface{})
+				if byproduct["name"] != "outcome" {
+					continue
+				}
+				content, err := base64.StdEncoding.DecodeString(byproduct["content"].(string))
+				if err != nil {
+					t.Fatal(err)
+				}
+				var outcome map[string]string
+				if err := json.Unmarshal(content, &outcome); err != nil {
+					t.Fatal(err)
+				}
+				got = outcome["outcome"]
+			}
+			if got != tc.want {
+				t.Errorf("outcome = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -23,6 +23,7 @@ import (
 	builddefinition "github.com/tektoncd/chains/pkg/chains/formats/slsa/internal/build_definition"
 	"github.com/tektoncd/chains/pkg/chains/formats/slsa/internal/provenance"
 	resolveddependencies "github.com/tektoncd/chains/pkg/chains/formats/slsa/internal/resolved_dependencies"
+	"github.com/tektoncd/chains/pkg/chains/formats/slsa/internal/results"
 	"github.com/tektoncd/chains/pkg/chains/formats/slsa/internal/slsaconfig"
 	"github.com/tektoncd/chains/pkg/chains/objects"
 )
@@ -65,5 +66,12 @@ func byproducts(pro *objects.PipelineRunObjectV1) ([]*intoto.ResourceDescriptor,
 		}
 		byProd = append(byProd, bp)
 	}
+
+	customRuns, err := results.GetCustomRuns(pro)
+	if err != nil {
+		return nil, err
+	}
+	byProd = append(byProd, customRuns...)
+
 	return byProd, nil
 }
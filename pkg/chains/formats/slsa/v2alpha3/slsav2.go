@@ -22,6 +22,7 @@ import (
 
 	"github.com/tektoncd/chains/pkg/chains/formats"
 	"github.com/tektoncd/chains/pkg/chains/formats/slsa/extract"
+	"github.com/tektoncd/chains/pkg/chains/formats/slsa/internal/redact"
 	"github.com/tektoncd/chains/pkg/chains/formats/slsa/internal/slsaconfig"
 	"github.com/tektoncd/chains/pkg/chains/formats/slsa/v2alpha3/internal/pipelinerun"
 	"github.com/tektoncd/chains/pkg/chains/formats/slsa/v2alpha3/internal/taskrun"
@@ -42,11 +43,17 @@ type Slsa struct {
 }
 
 func NewFormatter(cfg config.Config) (formats.Payloader, error) {
+	redactor, err := redact.New(cfg.Redaction)
+	if err != nil {
+		return nil, err
+	}
 	return &Slsa{
 		slsaConfig: &slsaconfig.SlsaConfig{
 			BuilderID:             cfg.Builder.ID,
 			DeepInspectionEnabled: cfg.Artifacts.PipelineRuns.DeepInspectionEnabled,
 			BuildType:             cfg.BuildDefinition.BuildType,
+			Redactor:              redactor,
+			RecordOutcome:         slsaconfig.RecordOutcomeKinds(cfg),
 		},
 	}, nil
 }
@@ -56,14 +63,23 @@ func (s *Slsa) Wrap() bool {
 }
 
 func (s *Slsa) CreatePayload(ctx context.Context, obj interface{}) (interface{}, error) {
+	var payload interface{}
+	var err error
 	switch v := obj.(type) {
 	case *objects.TaskRunObjectV1:
-		return taskrun.GenerateAttestation(ctx, v, s.slsaConfig)
+		payload, err = taskrun.GenerateAttestation(ctx, v, s.slsaConfig)
 	case *objects.PipelineRunObjectV1:
-		return pipelinerun.GenerateAttestation(ctx, v, s.slsaConfig)
+		payload, err = pipelinerun.GenerateAttestation(ctx, v, s.slsaConfig)
 	default:
 		return nil, fmt.Errorf("intoto does not support type: %s", v)
 	}
+	if err != nil {
+		return nil, err
+	}
+	if err := s.slsaConfig.Redactor.Payload(payload); err != nil {
+		return nil, err
+	}
+	return payload, nil
 }
 
 func (s *Slsa) Type() config.PayloadType {
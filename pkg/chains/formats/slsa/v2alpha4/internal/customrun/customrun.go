@@ -0,0 +1,59 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customrun
+
+import (
+	"context"
+
+	intoto "github.com/in-toto/attestation/go/v1"
+	"github.com/tektoncd/chains/pkg/artifacts"
+	"github.com/tektoncd/chains/pkg/chains/formats/slsa/extract"
+	builddefinition "github.com/tektoncd/chains/pkg/chains/formats/slsa/internal/build_definition"
+	"github.com/tektoncd/chains/pkg/chains/formats/slsa/internal/provenance"
+	"github.com/tektoncd/chains/pkg/chains/formats/slsa/internal/results"
+	"github.com/tektoncd/chains/pkg/chains/formats/slsa/internal/slsaconfig"
+	"github.com/tektoncd/chains/pkg/chains/objects"
+)
+
+const customRunResults = "customRunResults/%s/%s"
+
+// GenerateAttestation returns the provenance for the given customrun in SLSA 1.0 format.
+func GenerateAttestation(ctx context.Context, cro *objects.CustomRunObjectV1beta1, slsaConfig *slsaconfig.SlsaConfig) (interface{}, error) {
+	bp, err := ByProducts(cro)
+	if err != nil {
+		return nil, err
+	}
+
+	bd, err := builddefinition.GetCustomRunBuildDefinition(ctx, cro, slsaConfig.BuildType)
+	if err != nil {
+		return nil, err
+	}
+
+	sub := SubjectDigests(ctx, cro)
+
+	return provenance.GetSLSA1Statement(cro, sub, &bd, bp, slsaConfig)
+}
+
+// ByProducts returns the results categorized as byproduct from the given CustomRun.
+func ByProducts(cro *objects.CustomRunObjectV1beta1) ([]*intoto.ResourceDescriptor, error) {
+	return results.GetResultsWithoutBuildArtifacts(cro.GetName(), cro.GetResults(), customRunResults)
+}
+
+// SubjectDigests returns the subjects detected in the results of the given CustomRun.
+func SubjectDigests(ctx context.Context, cro *objects.CustomRunObjectV1beta1) []*intoto.ResourceDescriptor {
+	if !artifacts.TrustedProducer(ctx, cro) {
+		return nil
+	}
+	return extract.SubjectsFromBuildArtifact(ctx, cro.GetResults())
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customrun
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	slsa "github.com/in-toto/attestation/go/predicates/provenance/v1"
+	intoto "github.com/in-toto/attestation/go/v1"
+	"github.com/in-toto/in-toto-golang/in_toto/slsa_provenance/common"
+	slsaprov "github.com/in-toto/in-toto-golang/in_toto/slsa_provenance/v1"
+	"github.com/tektoncd/chains/pkg/chains/formats/slsa/internal/slsaconfig"
+	"github.com/tektoncd/chains/pkg/chains/objects"
+	"github.com/tektoncd/chains/pkg/internal/objectloader"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/testing/protocmp"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+func TestByProducts(t *testing.T) {
+	cr, err := objectloader.CustomRunV1beta1FromFile("../../../testdata/slsa-v2alpha4/customrun1.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*intoto.ResourceDescriptor{
+		{
+			Name:      "customRunResults/customrun-remote-build/build-id",
+			Content:   []byte(`"bf-1234"`),
+			MediaType: "application/json",
+		},
+	}
+	got, err := ByProducts(objects.NewCustomRunObjectV1beta1(cr))
+	if err != nil {
+		t.Fatalf("Could not extract byproducts: %s", err)
+	}
+	if d := cmp.Diff(want, got, cmp.Options{protocmp.Transform()}); d != "" {
+		t.Fatalf("byproducts (-want, +got):\n%s", d)
+	}
+}
+
+func TestCustomRunGenerateAttestation(t *testing.T) {
+	ctx := logtesting.TestContextWithLogger(t)
+	cr, err := objectloader.CustomRunV1beta1FromFile("../../../testdata/slsa-v2alpha4/customrun1.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := GenerateAttestation(ctx, objects.NewCustomRunObjectV1beta1(cr), &slsaconfig.SlsaConfig{
+		BuilderID: "test_builder-1",
+		BuildType: "https://tekton.dev/chains/v2/slsa",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var statement *intoto.Statement
+	switch s := got.(type) {
+	case intoto.Statement:
+		statement = &s
+	default:
+		t.Fatalf("unexpected payload type %T", got)
+	}
+
+	wantSubject := []*intoto.ResourceDescriptor{
+		{
+			Name:   "gcr.io/my/remote-image",
+			Digest: common.DigestSet{"sha256": "586789aa031fafc7d78a5393cdc772e0b55107ea54bb8bcf3f2cdac6c6da51ee"},
+		},
+	}
+	if d := cmp.Diff(wantSubject, statement.Subject, protocmp.Transform()); d != "" {
+		t.Errorf("subject (-want, +got):\n%s", d)
+	}
+	if statement.PredicateType != slsaprov.PredicateSLSAProvenance {
+		t.Errorf("predicateType = %s, want %s", statement.PredicateType, slsaprov.PredicateSLSAProvenance)
+	}
+
+	var predicate slsa.Provenance
+	raw, err := statement.Predicate.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := protojson.Unmarshal(raw, &predicate); err != nil {
+		t.Fatal(err)
+	}
+	runSpec := predicate.BuildDefinition.ExternalParameters.Fields["runSpec"].GetStructValue()
+	if kind := runSpec.Fields["customRef"].GetStructValue().Fields["kind"].GetStringValue(); kind != "RemoteBuild" {
+		t.Errorf("runSpec.customRef.kind = %q, want RemoteBuild", kind)
+	}
+	if params := runSpec.Fields["params"].GetListValue().GetValues(); len(params) != 2 {
+		t.Errorf("runSpec.params = %v, want 2 params", params)
+	}
+	if id := predicate.GetRunDetails().GetMetadata().GetInvocationId(); id != "b7c1e0a4-5c5b-4f0e-9a51-3f1d2e9c7a10" {
+		t.Errorf("invocationId = %q, want the uid of the CustomRun", id)
+	}
+}
@@ -17,6 +17,7 @@ import (
 	"context"
 
 	intoto "github.com/in-toto/attestation/go/v1"
+	"github.com/tektoncd/chains/pkg/artifacts"
 	"github.com/tektoncd/chains/pkg/chains/formats/slsa/extract"
 	"github.com/tektoncd/chains/pkg/chains/formats/slsa/internal/artifact"
 	builddefinition "github.com/tektoncd/chains/pkg/chains/formats/slsa/internal/build_definition"
@@ -59,6 +60,12 @@ func byproducts(pro *objects.PipelineRunObjectV1, slsaconfig *slsaconfig.SlsaCon
 		return nil, err
 	}
 
+	customRuns, err := results.GetCustomRuns(pro)
+	if err != nil {
+		return nil, err
+	}
+	byProd = append(byProd, customRuns...)
+
 	if !slsaconfig.DeepInspectionEnabled {
 		return byProd, nil
 	}
@@ -76,7 +83,10 @@ func byproducts(pro *objects.PipelineRunObjectV1, slsaconfig *slsaconfig.SlsaCon
 
 // SubjectDigests calculates the subjects associated with the given PipelineRun.
 func SubjectDigests(ctx context.Context, pro *objects.PipelineRunObjectV1, slsaconfig *slsaconfig.SlsaConfig) []*intoto.ResourceDescriptor {
-	subjects := extract.SubjectsFromBuildArtifact(ctx, pro.GetResults())
+	var subjects []*intoto.ResourceDescriptor
+	if artifacts.TrustedProducer(ctx, pro) {
+		subjects = extract.SubjectsFromBuildArtifact(ctx, pro.GetResults())
+	}
 
 	if !slsaconfig.DeepInspectionEnabled {
 		return subjects
@@ -17,6 +17,7 @@ import (
 	"context"
 
 	intoto "github.com/in-toto/attestation/go/v1"
+	"github.com/tektoncd/chains/pkg/artifacts"
 	"github.com/tektoncd/chains/pkg/chains/formats/slsa/extract"
 	"github.com/tektoncd/chains/pkg/chains/formats/slsa/internal/artifact"
 	builddefinition "github.com/tektoncd/chains/pkg/chains/formats/slsa/internal/build_definition"
@@ -70,8 +71,13 @@ func ByProducts(tro *objects.TaskRunObjectV1) ([]*intoto.ResourceDescriptor, err
 	return byProd, nil
 }
 
-// SubjectDigests returns the subjects detected in the given TaskRun. It takes into account taskrun and step results.
+// SubjectDigests returns the subjects detected in the given TaskRun. It takes into account taskrun and step results,
+// and the output artifacts marked as build outputs.
 func SubjectDigests(ctx context.Context, tro *objects.TaskRunObjectV1) []*intoto.ResourceDescriptor {
+	if !artifacts.TrustedProducer(ctx, tro) {
+		return nil
+	}
+
 	var subjects []*intoto.ResourceDescriptor
 	for _, step := range tro.Status.Steps {
 		res := getObjectResults(step.Results)
@@ -82,6 +88,9 @@ func SubjectDigests(ctx context.Context, tro *objects.TaskRunObjectV1) []*intoto
 	taskSubjects := extract.SubjectsFromBuildArtifact(ctx, tro.GetResults())
 	subjects = artifact.AppendSubjects(subjects, taskSubjects...)
 
+	declaredSubjects := artifacts.ExtractDeclaredArtifacts(ctx, tro.GetOutputArtifacts(), true)
+	subjects = artifact.AppendSubjects(subjects, declaredSubjects...)
+
 	return subjects
 }
 
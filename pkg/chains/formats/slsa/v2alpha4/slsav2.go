@@ -22,7 +22,9 @@ import (
 
 	intoto "github.com/in-toto/attestation/go/v1"
 	"github.com/tektoncd/chains/pkg/chains/formats"
+	"github.com/tektoncd/chains/pkg/chains/formats/slsa/internal/redact"
 	"github.com/tektoncd/chains/pkg/chains/formats/slsa/internal/slsaconfig"
+	"github.com/tektoncd/chains/pkg/chains/formats/slsa/v2alpha4/internal/customrun"
 	"github.com/tektoncd/chains/pkg/chains/formats/slsa/v2alpha4/internal/pipelinerun"
 	"github.com/tektoncd/chains/pkg/chains/formats/slsa/v2alpha4/internal/taskrun"
 
@@ -45,11 +47,17 @@ type Slsa struct {
 
 // NewFormatter returns a new v2alpha4 payloader.
 func NewFormatter(cfg config.Config) (formats.Payloader, error) { //nolint:ireturn
+	redactor, err := redact.New(cfg.Redaction)
+	if err != nil {
+		return nil, err
+	}
 	return &Slsa{
 		slsaConfig: &slsaconfig.SlsaConfig{
 			BuilderID:             cfg.Builder.ID,
 			DeepInspectionEnabled: cfg.Artifacts.PipelineRuns.DeepInspectionEnabled,
 			BuildType:             cfg.BuildDefinition.BuildType,
+			Redactor:              redactor,
+			RecordOutcome:         slsaconfig.RecordOutcomeKinds(cfg),
 		},
 	}, nil
 }
@@ -61,14 +69,25 @@ func (s *Slsa) Wrap() bool {
 
 // CreatePayload returns the payload for the given object using the v2alpha4 formatter logic.
 func (s *Slsa) CreatePayload(ctx context.Context, obj interface{}) (interface{}, error) {
+	var payload interface{}
+	var err error
 	switch v := obj.(type) {
 	case *objects.TaskRunObjectV1:
-		return taskrun.GenerateAttestation(ctx, v, s.slsaConfig)
+		payload, err = taskrun.GenerateAttestation(ctx, v, s.slsaConfig)
 	case *objects.PipelineRunObjectV1:
-		return pipelinerun.GenerateAttestation(ctx, v, s.slsaConfig)
+		payload, err = pipelinerun.GenerateAttestation(ctx, v, s.slsaConfig)
+	case *objects.CustomRunObjectV1beta1:
+		payload, err = customrun.GenerateAttestation(ctx, v, s.slsaConfig)
 	default:
 		return nil, fmt.Errorf("intoto does not support type: %s", v)
 	}
+	if err != nil {
+		return nil, err
+	}
+	if err := s.slsaConfig.Redactor.Payload(payload); err != nil {
+		return nil, err
+	}
+	return payload, nil
 }
 
 // Type returns the version of this payloader.
@@ -86,6 +105,8 @@ func (s *Slsa) RetrieveAllArtifactURIs(ctx context.Context, obj interface{}) ([]
 		subjects = taskrun.SubjectDigests(ctx, v)
 	case *objects.PipelineRunObjectV1:
 		subjects = pipelinerun.SubjectDigests(ctx, v, s.slsaConfig)
+	case *objects.CustomRunObjectV1beta1:
+		subjects = customrun.SubjectDigests(ctx, v)
 	default:
 		return nil, fmt.Errorf("intoto does not support type: %s", v)
 	}
@@ -0,0 +1,126 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package testresult attests the outcome of the tests run by a run, with
+// in-toto test-result statements whose subjects are those of its provenance.
+package testresult
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	intoto "github.com/in-toto/attestation/go/v1"
+	"github.com/tektoncd/chains/pkg/artifacts"
+	"github.com/tektoncd/chains/pkg/chains/formats"
+	"github.com/tektoncd/chains/pkg/config"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// PredicateType is the predicate type of in-toto test result attestations.
+const PredicateType = "https://in-toto.io/attestation/test-result/v0.1"
+
+func init() {
+	formats.RegisterPayloader(formats.PayloadTypeTestResult, NewFormatter)
+}
+
+// TestResult is the payloader of test result attestations.
+type TestResult struct{}
+
+// NewFormatter returns a new test result payloader.
+func NewFormatter(config.Config) (formats.Payloader, error) { //nolint:ireturn
+	return &TestResult{}, nil
+}
+
+type predicate struct {
+	Result        string               `json:"result"`
+	Configuration []resourceDescriptor `json:"configuration"`
+	URL           string               `json:"url,omitempty"`
+	PassedTests   []string             `json:"passedTests"`
+	WarnedTests   []string             `json:"warnedTests"`
+	FailedTests   []string             `json:"failedTests"`
+}
+
+type resourceDescriptor struct {
+	Name   string            `json:"name,omitempty"`
+	URI    string            `json:"uri,omitempty"`
+	Digest map[string]string `json:"digest,omitempty"`
+}
+
+// CreatePayload returns the in-toto statement attesting the outcome of the tests,
+// whose configuration is the source of the Task or Pipeline which ran them.
+func (t *TestResult) CreatePayload(ctx context.Context, obj interface{}) (interface{}, error) {
+	tr, ok := obj.(*artifacts.TestResult)
+	if !ok {
+		return nil, fmt.Errorf("test-result does not support type: %T", obj)
+	}
+
+	p := predicate{
+		Result:        tr.Result,
+		Configuration: []resourceDescriptor{},
+		URL:           tr.URL,
+		PassedTests:   tr.Passed,
+		WarnedTests:   tr.Warned,
+		FailedTests:   tr.Failed,
+	}
+	if prov := tr.Run.GetProvenance(); prov != nil && prov.RefSource != nil {
+		p.Configuration = append(p.Configuration, resourceDescriptor{
+			Name:   prov.RefSource.EntryPoint,
+			URI:    prov.RefSource.URI,
+			Digest: prov.RefSource.Digest,
+		})
+	}
+
+	predicateStruct, err := getStruct(p)
+	if err != nil {
+		return nil, err
+	}
+	return &intoto.Statement{
+		Type:          intoto.StatementTypeUri,
+		PredicateType: PredicateType,
+		Subject:       tr.Subjects,
+		Predicate:     predicateStruct,
+	}, nil
+}
+
+func getStruct(p predicate) (*structpb.Struct, error) {
+	raw, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+	protoStruct := &structpb.Struct{}
+	if err := protojson.Unmarshal(raw, protoStruct); err != nil {
+		return nil, err
+	}
+	return protoStruct, nil
+}
+
+// Wrap indicates that the attestations are signed in a DSSE envelope.
+func (t *TestResult) Wrap() bool {
+	return true
+}
+
+// Type returns the type of this payloader.
+func (t *TestResult) Type() config.PayloadType {
+	return formats.PayloadTypeTestResult
+}
+
+// RetrieveAllArtifactURIs returns the subjects of the attestation pinned to their digests.
+func (t *TestResult) RetrieveAllArtifactURIs(ctx context.Context, obj interface{}) ([]string, error) {
+	tr, ok := obj.(*artifacts.TestResult)
+	if !ok {
+		return nil, fmt.Errorf("test-result does not support type: %T", obj)
+	}
+	return artifacts.SubjectURIs(tr.Subjects), nil
+}
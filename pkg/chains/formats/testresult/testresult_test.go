@@ -0,0 +1,98 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testresult
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	intoto "github.com/in-toto/attestation/go/v1"
+	"github.com/tektoncd/chains/pkg/artifacts"
+	"github.com/tektoncd/chains/pkg/chains/objects"
+	"github.com/tektoncd/chains/pkg/config"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+func TestCreatePayload(t *testing.T) {
+	subject := &intoto.ResourceDescriptor{
+		Name:   "gcr.io/foo/bar",
+		Digest: map[string]string{"sha256": "05f95b26ed10668b7183c1e2da98610e91372fa9f510046d4ce5812addad86b5"},
+	}
+	run := objects.NewTaskRunObjectV1(&v1.TaskRun{
+		Status: v1.TaskRunStatus{
+			TaskRunStatusFields: v1.TaskRunStatusFields{
+				Provenance: &v1.Provenance{
+					RefSource: &v1.RefSource{
+						URI:        "git+https://github.com/tektoncd/catalog",
+						Digest:     map[string]string{"sha1": "f99d13e554ffcb696dee719fa85b695cb5b0f428"},
+						EntryPoint: "task/go-test/go-test.yaml",
+					},
+				},
+			},
+		},
+	})
+
+	f, err := NewFormatter(config.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := f.CreatePayload(context.Background(), &artifacts.TestResult{
+		Name:     "TEST_RESULTS",
+		Result:   artifacts.TestResultFailed,
+		Passed:   []string{"TestA"},
+		Warned:   []string{},
+		Failed:   []string{"TestB"},
+		URL:      "https://ci.example.com/runs/1",
+		Subjects: []*intoto.ResourceDescriptor{subject},
+		Run:      run,
+	})
+	if err != nil {
+		t.Fatalf("CreatePayload() error = %v", err)
+	}
+	statement := got.(*intoto.Statement)
+	if statement.GetPredicateType() != PredicateType {
+		t.Errorf("unexpected predicate type %s", statement.GetPredicateType())
+	}
+	if len(statement.GetSubject()) != 1 || statement.GetSubject()[0] != subject {
+		t.Errorf("expected the subjects of the provenance, got %v", statement.GetSubject())
+	}
+	want := map[string]interface{}{
+		"result": "FAILED",
+		"configuration": []interface{}{map[string]interface{}{
+			"name":   "task/go-test/go-test.yaml",
+			"uri":    "git+https://github.com/tektoncd/catalog",
+			"digest": map[string]interface{}{"sha1": "f99d13e554ffcb696dee719fa85b695cb5b0f428"},
+		}},
+		"url":         "https://ci.example.com/runs/1",
+		"passedTests": []interface{}{"TestA"},
+		"warnedTests": []interface{}{},
+		"failedTests": []interface{}{"TestB"},
+	}
+	if d := cmp.Diff(want, statement.GetPredicate().AsMap()); d != "" {
+		t.Errorf("unexpected predicate, diff: %s", d)
+	}
+
+	uris, err := f.RetrieveAllArtifactURIs(context.Background(), &artifacts.TestResult{Subjects: []*intoto.ResourceDescriptor{subject}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := cmp.Diff([]string{"gcr.io/foo/bar@sha256:05f95b26ed10668b7183c1e2da98610e91372fa9f510046d4ce5812addad86b5"}, uris); d != "" {
+		t.Errorf("unexpected URIs, diff: %s", d)
+	}
+
+	if _, err := f.CreatePayload(context.Background(), &artifacts.ScanResult{}); err == nil {
+		t.Error("expected an error creating the payload of another type")
+	}
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package vsa generates the SLSA verification summary attestations of the
+// subjects of the provenance signed by Chains.
+package vsa
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	intoto "github.com/in-toto/attestation/go/v1"
+	"github.com/tektoncd/chains/pkg/artifacts"
+	"github.com/tektoncd/chains/pkg/chains/formats"
+	"github.com/tektoncd/chains/pkg/chains/spire"
+	"github.com/tektoncd/chains/pkg/config"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	// PredicateType is the predicate type of SLSA verification summary attestations.
+	PredicateType = "https://slsa.dev/verification_summary/v1"
+
+	// ResultPassed is the verification result of the subjects of compliant runs.
+	ResultPassed = "PASSED"
+	// ResultFailed is the verification result of the subjects of runs violating
+	// the policies evaluated before signing.
+	ResultFailed = "FAILED"
+
+	// BuildLevel2 is the level recorded by default: the provenance is generated
+	// and signed by Chains, not by the run steps. Higher levels also depend on
+	// the isolation of the runs, which Chains cannot check, so they have to be
+	// configured by the operators vouching for it.
+	BuildLevel2 = "SLSA_BUILD_LEVEL_2"
+	// BuildLevel3 additionally requires the provenance not to be forgeable by the
+	// runs, e.g. with their results verified to be signed with SPIRE, and the runs
+	// to be isolated from each other.
+	BuildLevel3 = "SLSA_BUILD_LEVEL_3"
+
+	slsaVersion = "1.0"
+)
+
+func init() {
+	formats.RegisterPayloader(formats.PayloadTypeVSA, NewFormatter)
+}
+
+// VSA is the payloader of verification summary attestations.
+type VSA struct {
+	verifierID          string
+	policyURI           string
+	verifiedLevels      []string
+	spireVerifiedLevels []string
+}
+
+// NewFormatter returns a new VSA payloader. The verifier ID defaults to the
+// builder ID, the policy URI to the verifier ID, the verified levels to
+// BuildLevel2, and the levels of the runs with results verified with SPIRE to
+// the verified levels.
+func NewFormatter(cfg config.Config) (formats.Payloader, error) { //nolint:ireturn
+	v := &VSA{
+		verifierID:          cfg.VSA.VerifierID,
+		policyURI:           cfg.VSA.PolicyURI,
+		verifiedLevels:      levels(cfg.VSA.VerifiedLevels),
+		spireVerifiedLevels: levels(cfg.VSA.SpireVerifiedLevels),
+	}
+	if v.verifierID == "" {
+		v.verifierID = cfg.Builder.ID
+	}
+	if v.policyURI == "" {
+		v.policyURI = v.verifierID
+	}
+	if len(v.verifiedLevels) == 0 {
+		v.verifiedLevels = []string{BuildLevel2}
+	}
+	if len(v.spireVerifiedLevels) == 0 {
+		v.spireVerifiedLevels = v.verifiedLevels
+	}
+	return v, nil
+}
+
+// levels returns the configured levels, ignoring the empty ones.
+func levels(configured sets.Set[string]) []string {
+	l := []string{}
+	for _, level := range sets.List(configured) {
+		if level != "" {
+			l = append(l, level)
+		}
+	}
+	return l
+}
+
+type predicate struct {
+	Verifier           verifier             `json:"verifier"`
+	TimeVerified       string               `json:"timeVerified"`
+	ResourceURI        string               `json:"resourceUri"`
+	Policy             policy               `json:"policy"`
+	InputAttestations  []resourceDescriptor `json:"inputAttestations"`
+	VerificationResult string               `json:"verificationResult"`
+	VerifiedLevels     []string             `json:"verifiedLevels"`
+	SlsaVersion        string               `json:"slsaVersion"`
+}
+
+type verifier struct {
+	ID string `json:"id"`
+}
+
+type policy struct {
+	URI string `json:"uri"`
+}
+
+type resourceDescriptor struct {
+	Name   string            `json:"name,omitempty"`
+	Digest map[string]string `json:"digest"`
+}
+
+// CreatePayload returns the verification summary attestation of a subject of
+// the signed provenance.
+func (v *VSA) CreatePayload(ctx context.Context, obj interface{}) (interface{}, error) {
+	subject, ok := obj.(*artifacts.VSASubject)
+	if !ok {
+		return nil, fmt.Errorf("vsa does not support type: %T", obj)
+	}
+
+	p := predicate{
+		Verifier:           verifier{ID: v.verifierID},
+		TimeVerified:       time.Now().UTC().Format(time.RFC3339),
+		ResourceURI:        subject.Subject.GetName(),
+		Policy:             policy{URI: v.policyURI},
+		InputAttestations:  []resourceDescriptor{},
+		VerificationResult: ResultPassed,
+		VerifiedLevels:     v.buildLevels(ctx),
+		SlsaVersion:        slsaVersion,
+	}
+	for _, a := range subject.Provenance.Attestations {
+		p.InputAttestations = append(p.InputAttestations, resourceDescriptor{Name: a.GetName(), Digest: a.GetDigest()})
+	}
+	// A subject failing the policies is not verified at any level.
+	if !subject.Provenance.Compliant {
+		p.VerificationResult = ResultFailed
+		p.VerifiedLevels = []string{}
+	}
+
+	predicateStruct, err := getStruct(p)
+	if err != nil {
+		return nil, err
+	}
+	return &intoto.Statement{
+		Type:          intoto.StatementTypeUri,
+		PredicateType: PredicateType,
+		Subject:       []*intoto.ResourceDescriptor{subject.Subject},
+		Predicate:     predicateStruct,
+	}, nil
+}
+
+// buildLevels returns the levels recorded for the run being signed.
+func (v *VSA) buildLevels(ctx context.Context) []string {
+	if verification := spire.VerificationFromContext(ctx); verification != nil && verification.Verified {
+		return v.spireVerifiedLevels
+	}
+	return v.verifiedLevels
+}
+
+func getStruct(p predicate) (*structpb.Struct, error) {
+	raw, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+	protoStruct := &structpb.Struct{}
+	if err := protojson.Unmarshal(raw, protoStruct); err != nil {
+		return nil, err
+	}
+	return protoStruct, nil
+}
+
+// Wrap indicates that the attestations are signed in a DSSE envelope.
+func (v *VSA) Wrap() bool {
+	return true
+}
+
+// Type returns the type of this payloader.
+func (v *VSA) Type() config.PayloadType {
+	return formats.PayloadTypeVSA
+}
+
+// RetrieveAllArtifactURIs returns the subject of the attestation pinned to its digests.
+func (v *VSA) RetrieveAllArtifactURIs(ctx context.Context, obj interface{}) ([]string, error) {
+	subject, ok := obj.(*artifacts.VSASubject)
+	if !ok {
+		return nil, fmt.Errorf("vsa does not support type: %T", obj)
+	}
+	uris := []string{}
+	for alg, digest := range subject.Subject.GetDigest() {
+		uris = append(uris, fmt.Sprintf("%s@%s:%s", subject.Subject.GetName(), alg, digest))
+	}
+	return uris, nil
+}
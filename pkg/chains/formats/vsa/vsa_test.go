@@ -0,0 +1,185 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vsa
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	intoto "github.com/in-toto/attestation/go/v1"
+	"github.com/tektoncd/chains/pkg/artifacts"
+	"github.com/tektoncd/chains/pkg/chains/spire"
+	"github.com/tektoncd/chains/pkg/config"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func TestCreatePayload(t *testing.T) {
+	subject := &intoto.ResourceDescriptor{
+		Name:   "gcr.io/foo/bar",
+		Digest: map[string]string{"sha256": "05f95b26ed10668b7183c1e2da98610e91372fa9f510046d4ce5812addad86b5"},
+	}
+	provenance := &artifacts.SignedProvenance{
+		Subjects: []*intoto.ResourceDescriptor{subject},
+		Attestations: []*intoto.ResourceDescriptor{{
+			Name:   "tekton.dev-v1-TaskRun-uid",
+			Digest: map[string]string{"sha256": "a0cfeb3bb3e3ec5a0ffb0e0d4a9b4fe9f6b6bb0bca1b2b0d4e2e5d4b1c6a5f3e"},
+		}},
+		Compliant: true,
+	}
+
+	tests := []struct {
+		name         string
+		cfg          config.Config
+		verification *spire.Verification
+		compliant    bool
+		want         map[string]interface{}
+	}{{
+		name:      "defaults to the builder",
+		cfg:       config.Config{Builder: config.BuilderConfig{ID: "https://chains.example.com"}},
+		compliant: true,
+		want: map[string]interface{}{
+			"verifier":           map[string]interface{}{"id": "https://chains.example.com"},
+			"policy":             map[string]interface{}{"uri": "https://chains.example.com"},
+			"verificationResult": ResultPassed,
+			"verifiedLevels":     []interface{}{BuildLevel2},
+		},
+	}, {
+		name: "verified results",
+		cfg: config.Config{
+			Builder: config.BuilderConfig{ID: "https://chains.example.com"},
+			VSA:     config.VSAConfig{VerifierID: "https://verifier.example.com", PolicyURI: "https://policies.example.com/release"},
+		},
+		verification: &spire.Verification{Verified: true},
+		compliant:    true,
+		want: map[string]interface{}{
+			"verifier":           map[string]interface{}{"id": "https://verifier.example.com"},
+			"policy":             map[string]interface{}{"uri": "https://policies.example.com/release"},
+			"verificationResult": ResultPassed,
+			"verifiedLevels":     []interface{}{BuildLevel2},
+		},
+	}, {
+		name: "configured levels",
+		cfg: config.Config{
+			VSA: config.VSAConfig{
+				VerifierID:          "https://verifier.example.com",
+				VerifiedLevels:      sets.New[string]("SLSA_BUILD_LEVEL_1", ""),
+				SpireVerifiedLevels: sets.New[string](BuildLevel3),
+			},
+		},
+		verification: &spire.Verification{Reason: "no SVID found in the results"},
+		compliant:    true,
+		want: map[string]interface{}{
+			"verifier":           map[string]interface{}{"id": "https://verifier.example.com"},
+			"policy":             map[string]interface{}{"uri": "https://verifier.example.com"},
+			"verificationResult": ResultPassed,
+			"verifiedLevels":     []interface{}{"SLSA_BUILD_LEVEL_1"},
+		},
+	}, {
+		name: "configured levels of verified results",
+		cfg: config.Config{
+			VSA: config.VSAConfig{
+				VerifierID:          "https://verifier.example.com",
+				VerifiedLevels:      sets.New[string]("SLSA_BUILD_LEVEL_1"),
+				SpireVerifiedLevels: sets.New[string](BuildLevel3, "FEDRAMP_LOW"),
+			},
+		},
+		verification: &spire.Verification{Verified: true},
+		compliant:    true,
+		want: map[string]interface{}{
+			"verifier":           map[string]interface{}{"id": "https://verifier.example.com"},
+			"policy":             map[string]interface{}{"uri": "https://verifier.example.com"},
+			"verificationResult": ResultPassed,
+			"verifiedLevels":     []interface{}{"FEDRAMP_LOW", BuildLevel3},
+		},
+	}, {
+		name:         "unverified results of a non-compliant run",
+		cfg:          config.Config{VSA: config.VSAConfig{VerifierID: "https://verifier.example.com"}},
+		verification: &spire.Verification{Reason: "no SVID found in the results"},
+		want: map[string]interface{}{
+			"verifier":           map[string]interface{}{"id": "https://verifier.example.com"},
+			"policy":             map[string]interface{}{"uri": "https://verifier.example.com"},
+			"verificationResult": ResultFailed,
+			"verifiedLevels":     []interface{}{},
+		},
+	}, {
+		name: "verified results of a non-compliant run",
+		cfg: config.Config{
+			VSA: config.VSAConfig{
+				VerifierID:          "https://verifier.example.com",
+				SpireVerifiedLevels: sets.New[string](BuildLevel3),
+			},
+		},
+		verification: &spire.Verification{Verified: true},
+		want: map[string]interface{}{
+			"verifier":           map[string]interface{}{"id": "https://verifier.example.com"},
+			"policy":             map[string]interface{}{"uri": "https://verifier.example.com"},
+			"verificationResult": ResultFailed,
+			"verifiedLevels":     []interface{}{},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.verification != nil {
+				ctx = spire.WithVerification(ctx, tt.verification)
+			}
+			p := *provenance
+			p.Compliant = tt.compliant
+
+			formatter, err := NewFormatter(tt.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			payload, err := formatter.CreatePayload(ctx, &artifacts.VSASubject{Subject: subject, Provenance: &p})
+			if err != nil {
+				t.Fatalf("CreatePayload() error = %v", err)
+			}
+			statement := payload.(*intoto.Statement)
+			if statement.GetPredicateType() != PredicateType {
+				t.Errorf("unexpected predicate type %s", statement.GetPredicateType())
+			}
+			if d := cmp.Diff([]*intoto.ResourceDescriptor{subject}, statement.GetSubject(), cmp.Comparer(func(a, b *intoto.ResourceDescriptor) bool {
+				return a.GetName() == b.GetName() && cmp.Equal(a.GetDigest(), b.GetDigest())
+			})); d != "" {
+				t.Errorf("unexpected subject (-want +got): %s", d)
+			}
+
+			got := statement.GetPredicate().AsMap()
+			if got["timeVerified"] == "" {
+				t.Error("expected the verification time to be set")
+			}
+			delete(got, "timeVerified")
+			tt.want["resourceUri"] = "gcr.io/foo/bar"
+			tt.want["slsaVersion"] = "1.0"
+			tt.want["inputAttestations"] = []interface{}{map[string]interface{}{
+				"name":   "tekton.dev-v1-TaskRun-uid",
+				"digest": map[string]interface{}{"sha256": "a0cfeb3bb3e3ec5a0ffb0e0d4a9b4fe9f6b6bb0bca1b2b0d4e2e5d4b1c6a5f3e"},
+			}}
+			if d := cmp.Diff(tt.want, got); d != "" {
+				t.Errorf("unexpected predicate (-want +got): %s", d)
+			}
+		})
+	}
+}
+
+func TestCreatePayloadUnsupported(t *testing.T) {
+	formatter, err := NewFormatter(config.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := formatter.CreatePayload(context.Background(), "not a subject"); err == nil {
+		t.Error("expected an error for an unsupported object")
+	}
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package vuln attests the outcome of the vulnerability scans run by a run,
+// with cosign vulnerability statements whose subjects are those of its
+// provenance.
+package vuln
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	intoto "github.com/in-toto/attestation/go/v1"
+	"github.com/tektoncd/chains/pkg/artifacts"
+	"github.com/tektoncd/chains/pkg/chains/formats"
+	"github.com/tektoncd/chains/pkg/config"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// PredicateType is the predicate type of cosign vulnerability attestations.
+const PredicateType = "https://cosign.sigstore.dev/attestation/vuln/v1"
+
+func init() {
+	formats.RegisterPayloader(formats.PayloadTypeVuln, NewFormatter)
+}
+
+// Vuln is the payloader of vulnerability scan attestations.
+type Vuln struct {
+	builderID string
+}
+
+// NewFormatter returns a new vulnerability scan payloader.
+func NewFormatter(cfg config.Config) (formats.Payloader, error) { //nolint:ireturn
+	return &Vuln{builderID: cfg.Builder.ID}, nil
+}
+
+type predicate struct {
+	Invocation invocation `json:"invocation"`
+	Scanner    scanner    `json:"scanner"`
+	Metadata   metadata   `json:"metadata"`
+}
+
+type invocation struct {
+	Parameters []string `json:"parameters"`
+	URI        string   `json:"uri"`
+	EventID    string   `json:"event_id"`
+	BuilderID  string   `json:"builder.id"`
+}
+
+type scanner struct {
+	URI     string `json:"uri"`
+	Version string `json:"version"`
+	DB      db     `json:"db"`
+	Result  result `json:"result"`
+}
+
+type db struct {
+	URI     string `json:"uri,omitempty"`
+	Version string `json:"version,omitempty"`
+}
+
+type result struct {
+	Summary map[string]int      `json:"summary"`
+	Report  *resourceDescriptor `json:"report,omitempty"`
+}
+
+type resourceDescriptor struct {
+	URI    string            `json:"uri,omitempty"`
+	Digest map[string]string `json:"digest"`
+}
+
+type metadata struct {
+	ScanStartedOn  string `json:"scanStartedOn,omitempty"`
+	ScanFinishedOn string `json:"scanFinishedOn,omitempty"`
+}
+
+// CreatePayload returns the cosign vulnerability statement attesting the outcome
+// of the scan, which was invoked by the run.
+func (v *Vuln) CreatePayload(ctx context.Context, obj interface{}) (interface{}, error) {
+	sr, ok := obj.(*artifacts.ScanResult)
+	if !ok {
+		return nil, fmt.Errorf("vuln does not support type: %T", obj)
+	}
+
+	p := predicate{
+		Invocation: invocation{
+			Parameters: []string{},
+			EventID:    string(sr.Run.GetUID()),
+			BuilderID:  v.builderID,
+		},
+		Scanner: scanner{
+			URI:     sr.ScannerURI,
+			Version: sr.ScannerVersion,
+			DB:      db{URI: sr.DBURI, Version: sr.DBVersion},
+			Result:  result{Summary: sr.Summary},
+		},
+	}
+	if prov := sr.Run.GetProvenance(); prov != nil && prov.RefSource != nil {
+		p.Invocation.URI = prov.RefSource.URI
+	}
+	if sr.Report != nil {
+		p.Scanner.Result.Report = &resourceDescriptor{URI: sr.Report.GetUri(), Digest: sr.Report.GetDigest()}
+	}
+	if t := sr.Run.GetStartTime(); t != nil {
+		p.Metadata.ScanStartedOn = t.UTC().Format(time.RFC3339)
+	}
+	if t := sr.Run.GetCompletitionTime(); t != nil {
+		p.Metadata.ScanFinishedOn = t.UTC().Format(time.RFC3339)
+	}
+
+	predicateStruct, err := getStruct(p)
+	if err != nil {
+		return nil, err
+	}
+	return &intoto.Statement{
+		Type:          intoto.StatementTypeUri,
+		PredicateType: PredicateType,
+		Subject:       sr.Subjects,
+		Predicate:     predicateStruct,
+	}, nil
+}
+
+func getStruct(p predicate) (*structpb.Struct, error) {
+	raw, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+	protoStruct := &structpb.Struct{}
+	if err := protojson.Unmarshal(raw, protoStruct); err != nil {
+		return nil, err
+	}
+	return protoStruct, nil
+}
+
+// Wrap indicates that the attestations are signed in a DSSE envelope.
+func (v *Vuln) Wrap() bool {
+	return true
+}
+
+// Type returns the type of this payloader.
+func (v *Vuln) Type() config.PayloadType {
+	return formats.PayloadTypeVuln
+}
+
+// RetrieveAllArtifactURIs returns the subjects of the attestation pinned to their digests.
+func (v *Vuln) RetrieveAllArtifactURIs(ctx context.Context, obj interface{}) ([]string, error) {
+	sr, ok := obj.(*artifacts.ScanResult)
+	if !ok {
+		return nil, fmt.Errorf("vuln does not support type: %T", obj)
+	}
+	return artifacts.SubjectURIs(sr.Subjects), nil
+}
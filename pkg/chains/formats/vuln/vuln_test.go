@@ -0,0 +1,129 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vuln
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	intoto "github.com/in-toto/attestation/go/v1"
+	"github.com/tektoncd/chains/pkg/artifacts"
+	"github.com/tektoncd/chains/pkg/chains/objects"
+	"github.com/tektoncd/chains/pkg/config"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCreatePayload(t *testing.T) {
+	subject := &intoto.ResourceDescriptor{
+		Name:   "gcr.io/foo/bar",
+		Digest: map[string]string{"sha256": "05f95b26ed10668b7183c1e2da98610e91372fa9f510046d4ce5812addad86b5"},
+	}
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	run := objects.NewTaskRunObjectV1(&v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{UID: "uid"},
+		Status: v1.TaskRunStatus{
+			TaskRunStatusFields: v1.TaskRunStatusFields{
+				StartTime:      &metav1.Time{Time: start},
+				CompletionTime: &metav1.Time{Time: start.Add(time.Minute)},
+				Provenance: &v1.Provenance{
+					RefSource: &v1.RefSource{URI: "git+https://github.com/tektoncd/catalog"},
+				},
+			},
+		},
+	})
+
+	tests := []struct {
+		name   string
+		result *artifacts.ScanResult
+		want   map[string]interface{}
+	}{{
+		name: "with a report",
+		result: &artifacts.ScanResult{
+			ScannerURI:     "pkg:github/aquasecurity/trivy",
+			ScannerVersion: "0.50.0",
+			DBURI:          "pkg:github/aquasecurity/trivy-db",
+			DBVersion:      "2",
+			Summary:        map[string]int{"critical": 0, "high": 3},
+			Report: &intoto.ResourceDescriptor{
+				Uri:    "oci://gcr.io/foo/reports",
+				Digest: map[string]string{"sha256": "a0cfeb3bb3e3ec5a"},
+			},
+		},
+		want: map[string]interface{}{
+			"uri":     "pkg:github/aquasecurity/trivy",
+			"version": "0.50.0",
+			"db":      map[string]interface{}{"uri": "pkg:github/aquasecurity/trivy-db", "version": "2"},
+			"result": map[string]interface{}{
+				"summary": map[string]interface{}{"critical": float64(0), "high": float64(3)},
+				"report": map[string]interface{}{
+					"uri":    "oci://gcr.io/foo/reports",
+					"digest": map[string]interface{}{"sha256": "a0cfeb3bb3e3ec5a"},
+				},
+			},
+		},
+	}, {
+		name: "without a report",
+		result: &artifacts.ScanResult{
+			ScannerURI: "grype",
+			Summary:    map[string]int{},
+		},
+		want: map[string]interface{}{
+			"uri":     "grype",
+			"version": "",
+			"db":      map[string]interface{}{},
+			"result":  map[string]interface{}{"summary": map[string]interface{}{}},
+		},
+	}}
+
+	f, err := NewFormatter(config.Config{Builder: config.BuilderConfig{ID: "https://chains.example.com"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.result.Subjects = []*intoto.ResourceDescriptor{subject}
+			tc.result.Run = run
+			got, err := f.CreatePayload(context.Background(), tc.result)
+			if err != nil {
+				t.Fatalf("CreatePayload() error = %v", err)
+			}
+			statement := got.(*intoto.Statement)
+			if statement.GetPredicateType() != PredicateType {
+				t.Errorf("unexpected predicate type %s", statement.GetPredicateType())
+			}
+			if len(statement.GetSubject()) != 1 || statement.GetSubject()[0] != subject {
+				t.Errorf("expected the subjects of the provenance, got %v", statement.GetSubject())
+			}
+			want := map[string]interface{}{
+				"invocation": map[string]interface{}{
+					"parameters": []interface{}{},
+					"uri":        "git+https://github.com/tektoncd/catalog",
+					"event_id":   "uid",
+					"builder.id": "https://chains.example.com",
+				},
+				"scanner": tc.want,
+				"metadata": map[string]interface{}{
+					"scanStartedOn":  "2026-01-02T03:04:05Z",
+					"scanFinishedOn": "2026-01-02T03:05:05Z",
+				},
+			}
+			if d := cmp.Diff(want, statement.GetPredicate().AsMap()); d != "" {
+				t.Errorf("unexpected predicate, diff: %s", d)
+			}
+		})
+	}
+}
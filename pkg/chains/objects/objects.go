@@ -17,10 +17,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -34,9 +36,20 @@ import (
 // Label added to TaskRuns identifying the associated pipeline Task
 const PipelineTaskLabel = "tekton.dev/pipelineTask"
 
+// The outcomes of a done run.
+const (
+	OutcomeSucceeded = "succeeded"
+	OutcomeFailed    = "failed"
+	OutcomeCancelled = "cancelled"
+	OutcomeTimedOut  = "timedout"
+)
+
+// FieldManager is the manager of the fields Chains applies to the Tekton objects.
+const FieldManager = "tekton-chains-controller"
+
 // patchOptions contains the default patch options
 var patchOptions = metav1.PatchOptions{
-	FieldManager: "tekton-chains-controller",
+	FieldManager: FieldManager,
 	Force:        ptr.Bool(false),
 }
 
@@ -79,11 +92,18 @@ type TektonObject interface {
 	IsSuccessful() bool
 	SupportsTaskRunArtifact() bool
 	SupportsPipelineRunArtifact() bool
+	SupportsCustomRunArtifact() bool
 	SupportsOCIArtifact() bool
 	GetRemoteProvenance() *v1.Provenance
 	IsRemote() bool
 	GetStartTime() *time.Time
 	GetCompletitionTime() *time.Time
+	// GetInputArtifacts returns the artifacts the run declared as its inputs
+	// through the Tekton Artifacts API.
+	GetInputArtifacts() []v1.Artifact
+	// GetOutputArtifacts returns the artifacts the run declared as its outputs
+	// through the Tekton Artifacts API.
+	GetOutputArtifacts() []v1.Artifact
 }
 
 func NewTektonObject(i interface{}) (TektonObject, error) {
@@ -92,11 +112,46 @@ func NewTektonObject(i interface{}) (TektonObject, error) {
 		return NewPipelineRunObjectV1(o), nil
 	case *v1.TaskRun:
 		return NewTaskRunObjectV1(o), nil
+	case *v1beta1.CustomRun:
+		return NewCustomRunObjectV1beta1(o), nil
 	default:
 		return nil, errors.New("unrecognized type when attempting to create tekton object")
 	}
 }
 
+// GetOutcome returns the outcome of a done run, and the reason of its Succeeded
+// condition. Runs stopped gracefully are cancelled.
+func GetOutcome(obj TektonObject) (string, string) {
+	var condition *apis.Condition
+	var cancelled, timedOut []string
+	switch o := obj.GetObject().(type) {
+	case *v1.TaskRun:
+		condition = o.Status.GetCondition(apis.ConditionSucceeded)
+		cancelled = []string{v1.TaskRunReasonCancelled.String()}
+		timedOut = []string{v1.TaskRunReasonTimedOut.String()}
+	case *v1.PipelineRun:
+		condition = o.Status.GetCondition(apis.ConditionSucceeded)
+		cancelled = []string{v1.PipelineRunReasonCancelled.String(), v1.PipelineRunReasonCancelledRunningFinally.String(), v1.PipelineRunReasonStoppedRunningFinally.String()}
+		timedOut = []string{v1.PipelineRunReasonTimedOut.String()}
+	case *v1beta1.CustomRun:
+		condition = o.Status.GetCondition(apis.ConditionSucceeded)
+		cancelled = []string{v1beta1.CustomRunReasonCancelled.String()}
+		timedOut = []string{v1beta1.CustomRunReasonTimedOut.String()}
+	}
+	if condition == nil {
+		return OutcomeFailed, ""
+	}
+	switch {
+	case condition.IsTrue():
+		return OutcomeSucceeded, condition.Reason
+	case slices.Contains(cancelled, condition.Reason):
+		return OutcomeCancelled, condition.Reason
+	case slices.Contains(timedOut, condition.Reason):
+		return OutcomeTimedOut, condition.Reason
+	}
+	return OutcomeFailed, condition.Reason
+}
+
 // TaskRunObjectV1 extends v1.TaskRun with additional functions.
 type TaskRunObjectV1 struct {
 	*v1.TaskRun
@@ -221,6 +276,10 @@ func (tro *TaskRunObjectV1) SupportsPipelineRunArtifact() bool {
 	return false
 }
 
+func (tro *TaskRunObjectV1) SupportsCustomRunArtifact() bool {
+	return false
+}
+
 func (tro *TaskRunObjectV1) SupportsOCIArtifact() bool {
 	return true
 }
@@ -262,12 +321,40 @@ func (tro *TaskRunObjectV1) GetCompletitionTime() *time.Time {
 	return utc
 }
 
+// GetInputArtifacts returns the input artifacts of the TaskRun and of its steps.
+func (tro *TaskRunObjectV1) GetInputArtifacts() []v1.Artifact {
+	arts := []v1.Artifact{}
+	if tro.Status.Artifacts != nil {
+		arts = append(arts, tro.Status.Artifacts.Inputs...)
+	}
+	for _, step := range tro.Status.Steps {
+		arts = append(arts, step.Inputs...)
+	}
+	return arts
+}
+
+// GetOutputArtifacts returns the output artifacts of the TaskRun and of its steps.
+func (tro *TaskRunObjectV1) GetOutputArtifacts() []v1.Artifact {
+	arts := []v1.Artifact{}
+	if tro.Status.Artifacts != nil {
+		arts = append(arts, tro.Status.Artifacts.Outputs...)
+	}
+	for _, step := range tro.Status.Steps {
+		arts = append(arts, step.Outputs...)
+	}
+	return arts
+}
+
 // PipelineRunObjectV1 extends v1.PipelineRun with additional functions.
 type PipelineRunObjectV1 struct {
 	// The base PipelineRun
 	*v1.PipelineRun
 	// taskRuns that were apart of this PipelineRun
 	taskRuns []*v1.TaskRun
+	// pipelineRuns that were apart of this PipelineRun
+	pipelineRuns []*PipelineRunObjectV1
+	// customRuns that were apart of this PipelineRun
+	customRuns []*v1beta1.CustomRun
 }
 
 var _ TektonObject = &PipelineRunObjectV1{}
@@ -352,6 +439,26 @@ func (pro *PipelineRunObjectV1) GetTaskRuns() []*v1.TaskRun {
 	return pro.taskRuns
 }
 
+// Append a child PipelineRun to this PipelineRun
+func (pro *PipelineRunObjectV1) AppendPipelineRun(child *PipelineRunObjectV1) {
+	pro.pipelineRuns = append(pro.pipelineRuns, child)
+}
+
+// Get the child PipelineRuns of this PipelineRun
+func (pro *PipelineRunObjectV1) GetPipelineRuns() []*PipelineRunObjectV1 {
+	return pro.pipelineRuns
+}
+
+// Append CustomRuns to this PipelineRun
+func (pro *PipelineRunObjectV1) AppendCustomRun(cr *v1beta1.CustomRun) {
+	pro.customRuns = append(pro.customRuns, cr)
+}
+
+// Get the CustomRuns of this PipelineRun
+func (pro *PipelineRunObjectV1) GetCustomRuns() []*v1beta1.CustomRun {
+	return pro.customRuns
+}
+
 // Get the associated TaskRun via the Task name
 func (pro *PipelineRunObjectV1) GetTaskRunsFromTask(taskName string) []*TaskRunObjectV1 {
 	var taskRuns []*TaskRunObjectV1
@@ -372,6 +479,10 @@ func (pro *PipelineRunObjectV1) SupportsPipelineRunArtifact() bool {
 	return true
 }
 
+func (pro *PipelineRunObjectV1) SupportsCustomRunArtifact() bool {
+	return false
+}
+
 func (pro *PipelineRunObjectV1) SupportsOCIArtifact() bool {
 	return false
 }
@@ -413,6 +524,18 @@ func (pro *PipelineRunObjectV1) GetCompletitionTime() *time.Time {
 	return utc
 }
 
+// GetInputArtifacts returns nil: PipelineRuns do not declare artifacts of their
+// own, those of their TaskRuns are only considered with deep inspection.
+func (pro *PipelineRunObjectV1) GetInputArtifacts() []v1.Artifact {
+	return nil
+}
+
+// GetOutputArtifacts returns nil: PipelineRuns do not declare artifacts of their
+// own, those of their TaskRuns are only considered with deep inspection.
+func (pro *PipelineRunObjectV1) GetOutputArtifacts() []v1.Artifact {
+	return nil
+}
+
 // GetExecutedTasks returns the tasks that were executed during the pipeline run.
 func (pro *PipelineRunObjectV1) GetExecutedTasks() (tro []*TaskRunObjectV1) {
 	pSpec := pro.Status.PipelineSpec
@@ -437,3 +560,131 @@ func (pro *PipelineRunObjectV1) GetExecutedTasks() (tro []*TaskRunObjectV1) {
 
 	return
 }
+
+// CustomRunObjectV1beta1 extends v1beta1.CustomRun with additional functions.
+type CustomRunObjectV1beta1 struct {
+	*v1beta1.CustomRun
+}
+
+var _ TektonObject = &CustomRunObjectV1beta1{}
+
+func NewCustomRunObjectV1beta1(cr *v1beta1.CustomRun) *CustomRunObjectV1beta1 {
+	return &CustomRunObjectV1beta1{
+		cr,
+	}
+}
+
+// Get the CustomRun GroupVersionKind
+func (cro *CustomRunObjectV1beta1) GetGVK() string {
+	return fmt.Sprintf("%s/%s", cro.GetGroupVersionKind().GroupVersion().String(), cro.GetGroupVersionKind().Kind)
+}
+
+func (cro *CustomRunObjectV1beta1) GetKindName() string {
+	return strings.ToLower(cro.GetGroupVersionKind().Kind)
+}
+
+// GetProvenance returns nil, the provenance of the custom task is not reported on CustomRuns.
+func (cro *CustomRunObjectV1beta1) GetProvenance() *v1.Provenance {
+	return nil
+}
+
+// Get the latest annotations on the CustomRun
+func (cro *CustomRunObjectV1beta1) GetLatestAnnotations(ctx context.Context, clientSet versioned.Interface) (map[string]string, error) {
+	cr, err := clientSet.TektonV1beta1().CustomRuns(cro.Namespace).Get(ctx, cro.Name, metav1.GetOptions{})
+	return cr.Annotations, err
+}
+
+// Get the base CustomRun object
+func (cro *CustomRunObjectV1beta1) GetObject() interface{} {
+	return cro.CustomRun
+}
+
+// Patch the original CustomRun object
+func (cro *CustomRunObjectV1beta1) Patch(ctx context.Context, clientSet versioned.Interface, patchBytes []byte) error {
+	logger := logging.FromContext(ctx)
+	_, err := clientSet.TektonV1beta1().CustomRuns(cro.Namespace).Patch(
+		ctx, cro.Name, types.ApplyPatchType, patchBytes, patchOptions)
+	if apierrors.IsConflict(err) {
+		// Since we only update the list of annotations we manage, there shouldn't be any conflicts unless
+		// another controller/client is updating our annotations. We log the issue and force patch.
+		logger.Warnf("failed to patch object %s/%s due to Server-Side Apply patch conflict, using force patch.", cro.Namespace, cro.Name)
+		// use a copy to avoid changing the global var
+		patchOptionsForce := patchOptions
+		patchOptionsForce.Force = ptr.Bool(true)
+		_, err = clientSet.TektonV1beta1().CustomRuns(cro.Namespace).Patch(
+			ctx, cro.Name, types.ApplyPatchType, patchBytes, patchOptionsForce)
+	}
+	return err
+}
+
+// Get the CustomRun results, which are always strings
+func (cro *CustomRunObjectV1beta1) GetResults() []Result {
+	res := []Result{}
+	for _, key := range cro.Status.Results {
+		res = append(res, Result{
+			Name:  key.Name,
+			Value: *v1.NewStructuredValues(key.Value),
+		})
+	}
+	return res
+}
+
+// Get the ServiceAccount declared in the CustomRun
+func (cro *CustomRunObjectV1beta1) GetServiceAccountName() string {
+	return cro.Spec.ServiceAccountName
+}
+
+func (cro *CustomRunObjectV1beta1) SupportsTaskRunArtifact() bool {
+	return false
+}
+
+func (cro *CustomRunObjectV1beta1) SupportsPipelineRunArtifact() bool {
+	return false
+}
+
+func (cro *CustomRunObjectV1beta1) SupportsCustomRunArtifact() bool {
+	return true
+}
+
+func (cro *CustomRunObjectV1beta1) SupportsOCIArtifact() bool {
+	return true
+}
+
+func (cro *CustomRunObjectV1beta1) GetRemoteProvenance() *v1.Provenance {
+	return nil
+}
+
+// IsRemote returns false, CustomRuns reference a custom task controller rather than a resolved Task.
+func (cro *CustomRunObjectV1beta1) IsRemote() bool {
+	return false
+}
+
+// GetStartTime returns the time when the CustomRun started.
+func (cro *CustomRunObjectV1beta1) GetStartTime() *time.Time {
+	var utc *time.Time
+	if cro.Status.StartTime != nil {
+		val := cro.Status.StartTime.Time.UTC()
+		utc = &val
+	}
+	return utc
+}
+
+// GetCompletitionTime returns the time when the CustomRun finished.
+func (cro *CustomRunObjectV1beta1) GetCompletitionTime() *time.Time {
+	var utc *time.Time
+	if cro.Status.CompletionTime != nil {
+		val := cro.Status.CompletionTime.Time.UTC()
+		utc = &val
+	}
+	return utc
+}
+
+// GetInputArtifacts returns nil, CustomRuns don't support the Tekton Artifacts API.
+func (cro *CustomRunObjectV1beta1) GetInputArtifacts() []v1.Artifact {
+	return nil
+}
+
+// GetOutputArtifacts returns nil, CustomRuns don't support the Tekton Artifacts API.
+func (cro *CustomRunObjectV1beta1) GetOutputArtifacts() []v1.Artifact {
+	return nil
+}
@@ -20,7 +20,11 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 )
 
 func getEmptyTemplate() *pod.PodTemplate {
@@ -172,6 +176,26 @@ func TestTaskRun_GetProvenance(t *testing.T) {
 
 }
 
+func getCustomRun() *v1beta1.CustomRun {
+	return &v1beta1.CustomRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "approval",
+			Namespace: "default",
+		},
+		Spec: v1beta1.CustomRunSpec{
+			ServiceAccountName: "customrun-sa",
+		},
+		Status: v1beta1.CustomRunStatus{
+			CustomRunStatusFields: v1beta1.CustomRunStatusFields{
+				Results: []v1beta1.CustomRunResult{
+					{Name: "IMAGE_URL", Value: "gcr.io/my/image"},
+					{Name: "IMAGE_DIGEST", Value: "sha256:05f95b26ed10668b7183c1e2da98610e91372fa9f510046d4ce5812addad86b5"},
+				},
+			},
+		},
+	}
+}
+
 func TestPipelineRun_GetResults(t *testing.T) {
 
 	t.Run("TestPipelineRun_GetResults", func(t *testing.T) {
@@ -192,6 +216,14 @@ func TestPipelineRun_GetResults(t *testing.T) {
 
 }
 
+func TestCustomRun_GetResults(t *testing.T) {
+	cr := NewCustomRunObjectV1beta1(getCustomRun())
+	assert.ElementsMatch(t, cr.GetResults(), []Result{
+		{Name: "IMAGE_URL", Value: *v1.NewStructuredValues("gcr.io/my/image")},
+		{Name: "IMAGE_DIGEST", Value: *v1.NewStructuredValues("sha256:05f95b26ed10668b7183c1e2da98610e91372fa9f510046d4ce5812addad86b5")},
+	})
+}
+
 func TestTaskRun_GetStepImages(t *testing.T) {
 
 	t.Run("TestTaskRun_GetStepImages", func(t *testing.T) {
@@ -296,6 +328,10 @@ func TestTaskRun_GetGVK(t *testing.T) {
 	assert.Equal(t, "tekton.dev/v1/TaskRun", NewTaskRunObjectV1(getTaskRun()).GetGVK())
 }
 
+func TestCustomRun_GetGVK(t *testing.T) {
+	assert.Equal(t, "tekton.dev/v1beta1/CustomRun", NewCustomRunObjectV1beta1(getCustomRun()).GetGVK())
+}
+
 func TestPipelineRun_GetKindName(t *testing.T) {
 	assert.Equal(t, "pipelinerun", NewPipelineRunObjectV1(getPipelineRun()).GetKindName())
 }
@@ -304,6 +340,10 @@ func TestTaskRun_GetKindName(t *testing.T) {
 	assert.Equal(t, "taskrun", NewTaskRunObjectV1(getTaskRun()).GetKindName())
 }
 
+func TestCustomRun_GetKindName(t *testing.T) {
+	assert.Equal(t, "customrun", NewCustomRunObjectV1beta1(getCustomRun()).GetKindName())
+}
+
 func TestPipelineRun_GetServiceAccountName(t *testing.T) {
 	assert.Equal(t, "pipelinerun-sa", NewPipelineRunObjectV1(getPipelineRun()).GetServiceAccountName())
 }
@@ -312,6 +352,10 @@ func TestTaskRun_GetServiceAccountName(t *testing.T) {
 	assert.Equal(t, "taskrun-sa", NewTaskRunObjectV1(getTaskRun()).GetServiceAccountName())
 }
 
+func TestCustomRun_GetServiceAccountName(t *testing.T) {
+	assert.Equal(t, "customrun-sa", NewCustomRunObjectV1beta1(getCustomRun()).GetServiceAccountName())
+}
+
 func TestNewTektonObject(t *testing.T) {
 	tro, err := NewTektonObject(getTaskRun())
 	assert.NoError(t, err)
@@ -321,11 +365,65 @@ func TestNewTektonObject(t *testing.T) {
 	assert.NoError(t, err)
 	assert.IsType(t, &PipelineRunObjectV1{}, pro)
 
+	cro, err := NewTektonObject(getCustomRun())
+	assert.NoError(t, err)
+	assert.IsType(t, &CustomRunObjectV1beta1{}, cro)
+	assert.True(t, cro.SupportsCustomRunArtifact())
+	assert.False(t, cro.IsRemote())
+
 	unknown, err := NewTektonObject("someting-else")
 	assert.Nil(t, unknown)
 	assert.ErrorContains(t, err, "unrecognized type")
 }
 
+func TestGetOutcome(t *testing.T) {
+	status := func(condition corev1.ConditionStatus, reason string) duckv1.Status {
+		return duckv1.Status{Conditions: duckv1.Conditions{{Type: apis.ConditionSucceeded, Status: condition, Reason: reason}}}
+	}
+	tests := []struct {
+		name        string
+		obj         TektonObject
+		wantOutcome string
+		wantReason  string
+	}{{
+		name:        "succeeded TaskRun",
+		obj:         NewTaskRunObjectV1(&v1.TaskRun{Status: v1.TaskRunStatus{Status: status(corev1.ConditionTrue, "Succeeded")}}),
+		wantOutcome: OutcomeSucceeded,
+		wantReason:  "Succeeded",
+	}, {
+		name:        "failed TaskRun",
+		obj:         NewTaskRunObjectV1(&v1.TaskRun{Status: v1.TaskRunStatus{Status: status(corev1.ConditionFalse, "Failed")}}),
+		wantOutcome: OutcomeFailed,
+		wantReason:  "Failed",
+	}, {
+		name:        "timed out TaskRun",
+		obj:         NewTaskRunObjectV1(&v1.TaskRun{Status: v1.TaskRunStatus{Status: status(corev1.ConditionFalse, v1.TaskRunReasonTimedOut.String())}}),
+		wantOutcome: OutcomeTimedOut,
+		wantReason:  v1.TaskRunReasonTimedOut.String(),
+	}, {
+		name:        "stopped PipelineRun",
+		obj:         NewPipelineRunObjectV1(&v1.PipelineRun{Status: v1.PipelineRunStatus{Status: status(corev1.ConditionFalse, v1.PipelineRunReasonStoppedRunningFinally.String())}}),
+		wantOutcome: OutcomeCancelled,
+		wantReason:  v1.PipelineRunReasonStoppedRunningFinally.String(),
+	}, {
+		name:        "cancelled CustomRun",
+		obj:         NewCustomRunObjectV1beta1(&v1beta1.CustomRun{Status: v1beta1.CustomRunStatus{Status: status(corev1.ConditionFalse, v1beta1.CustomRunReasonCancelled.String())}}),
+		wantOutcome: OutcomeCancelled,
+		wantReason:  v1beta1.CustomRunReasonCancelled.String(),
+	}, {
+		name:        "no condition",
+		obj:         NewTaskRunObjectV1(&v1.TaskRun{}),
+		wantOutcome: OutcomeFailed,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outcome, reason := GetOutcome(tt.obj)
+			assert.Equal(t, tt.wantOutcome, outcome)
+			assert.Equal(t, tt.wantReason, reason)
+		})
+	}
+}
+
 func TestPipelineRun_GetTaskRunsFromTask(t *testing.T) {
 	pro := NewPipelineRunObjectV1(getPipelineRun())
 
@@ -339,6 +437,22 @@ func TestPipelineRun_GetTaskRunsFromTask(t *testing.T) {
 	assert.Equal(t, "foo", taskRuns[0].Name)
 }
 
+func TestPipelineRun_GetChildRuns(t *testing.T) {
+	pro := NewPipelineRunObjectV1(getPipelineRun())
+
+	assert.Nil(t, pro.GetPipelineRuns())
+	assert.Nil(t, pro.GetCustomRuns())
+
+	child := NewPipelineRunObjectV1(&v1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "child"}})
+	pro.AppendPipelineRun(child)
+	pro.AppendCustomRun(&v1beta1.CustomRun{ObjectMeta: metav1.ObjectMeta{Name: "approval"}})
+
+	assert.Equal(t, []*PipelineRunObjectV1{child}, pro.GetPipelineRuns())
+	customRuns := pro.GetCustomRuns()
+	assert.Len(t, customRuns, 1)
+	assert.Equal(t, "approval", customRuns[0].Name)
+}
+
 func TestProvenanceExists(t *testing.T) {
 	pro := NewPipelineRunObjectV1(getPipelineRun())
 	provenance := &v1.Provenance{
@@ -403,3 +517,37 @@ func TestTaskRunIsRemote(t *testing.T) {
 	}
 	assert.Equal(t, true, tro.IsRemote())
 }
+
+func TestTaskRun_GetArtifacts(t *testing.T) {
+	input := v1.Artifact{Name: "source", Values: []v1.ArtifactValue{{Uri: "git+https://github.com/foo/bar", Digest: map[v1.Algorithm]string{"sha1": "abc"}}}}
+	stepInput := v1.Artifact{Name: "base", Values: []v1.ArtifactValue{{Uri: "gcr.io/foo/base", Digest: map[v1.Algorithm]string{"sha256": "def"}}}}
+	output := v1.Artifact{Name: "image", Values: []v1.ArtifactValue{{Uri: "gcr.io/foo/bar", Digest: map[v1.Algorithm]string{"sha256": "123"}}}, BuildOutput: true}
+	stepOutput := v1.Artifact{Name: "logs", Values: []v1.ArtifactValue{{Uri: "gs://foo/logs", Digest: map[v1.Algorithm]string{"sha256": "456"}}}}
+
+	tr := getTaskRun()
+	tr.Status.Artifacts = &v1.Artifacts{Inputs: []v1.Artifact{input}, Outputs: []v1.Artifact{output}}
+	tr.Status.Steps[0].Inputs = []v1.TaskRunStepArtifact{stepInput}
+	tr.Status.Steps[0].Outputs = []v1.TaskRunStepArtifact{stepOutput}
+	tro := NewTaskRunObjectV1(tr)
+
+	if d := cmp.Diff([]v1.Artifact{input, stepInput}, tro.GetInputArtifacts()); d != "" {
+		t.Errorf("GetInputArtifacts() (-want, +got):\n%s", d)
+	}
+	if d := cmp.Diff([]v1.Artifact{output, stepOutput}, tro.GetOutputArtifacts()); d != "" {
+		t.Errorf("GetOutputArtifacts() (-want, +got):\n%s", d)
+	}
+
+	if got := NewTaskRunObjectV1(getTaskRun()).GetOutputArtifacts(); len(got) != 0 {
+		t.Errorf("GetOutputArtifacts() = %v, want none", got)
+	}
+}
+
+func TestPipelineRun_GetArtifacts(t *testing.T) {
+	pr := NewPipelineRunObjectV1(getPipelineRun())
+	if got := pr.GetInputArtifacts(); got != nil {
+		t.Errorf("GetInputArtifacts() = %v, want nil", got)
+	}
+	if got := pr.GetOutputArtifacts(); got != nil {
+		t.Errorf("GetOutputArtifacts() = %v, want nil", got)
+	}
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chains
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/tektoncd/chains/pkg/chains/annotations"
+	"github.com/tektoncd/chains/pkg/chains/objects"
+	"github.com/tektoncd/chains/pkg/config"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/logging"
+)
+
+// applyOverrides returns the configuration the run is signed with: the one of
+// the cluster, with the settings overridden by the annotations of the run when
+// the cluster allows it. It returns false when the run must not be signed.
+func (o *ObjectSigner) applyOverrides(ctx context.Context, cfg config.Config, obj objects.TektonObject) (config.Config, bool) {
+	logger := logging.FromContext(ctx)
+	allowed := cfg.Overrides.Allowed
+	runAnnotations := obj.GetAnnotations()
+	ignore := func(annotation, reason string) {
+		logger.Warnf("Ignoring the %s annotation of %s %s/%s: %s", annotation, obj.GetGVK(), obj.GetNamespace(), obj.GetName(), reason)
+	}
+
+	if skip, ok := runAnnotations[annotations.SkipAnnotation]; ok {
+		if !allowed.Has(config.OverrideSkip) {
+			ignore(annotations.SkipAnnotation, "skipping runs is not allowed")
+		} else if skip == "true" {
+			return cfg, false
+		}
+	}
+
+	artifact, formats := runArtifact(&cfg, obj)
+	if format, ok := runAnnotations[annotations.FormatAnnotation]; ok {
+		switch {
+		case !allowed.Has(config.OverrideFormat):
+			ignore(annotations.FormatAnnotation, "overriding the format is not allowed")
+		case !slices.Contains(formats, format):
+			ignore(annotations.FormatAnnotation, fmt.Sprintf("unsupported format %q", format))
+		default:
+			artifact.Format = format
+		}
+	}
+	// Anyone who can annotate the run can request it to be signed again, so the
+	// format of the request is an override like the format annotation.
+	if format, ok := runAnnotations[annotations.ResignFormatAnnotation]; ok && annotations.Resigned(runAnnotations) {
+		switch {
+		case !allowed.Has(config.OverrideFormat):
+			ignore(annotations.ResignFormatAnnotation, "overriding the format is not allowed")
+		case !slices.Contains(formats, format):
+			ignore(annotations.ResignFormatAnnotation, fmt.Sprintf("unsupported format %q", format))
+		default:
+			artifact.Format = format
+		}
+	}
+
+	if storage, ok := runAnnotations[annotations.StorageAnnotation]; ok {
+		backends := sets.New[string]()
+		for _, backend := range strings.Split(storage, ",") {
+			if backend = strings.TrimSpace(backend); backend != "" {
+				backends.Insert(backend)
+			}
+		}
+		var unconfigured []string
+		for _, backend := range sets.List(backends) {
+			if _, ok := o.Backends[backend]; !ok {
+				unconfigured = append(unconfigured, backend)
+			}
+		}
+		switch {
+		case !allowed.Has(config.OverrideStorage):
+			ignore(annotations.StorageAnnotation, "overriding the storage is not allowed")
+		case len(unconfigured) > 0:
+			ignore(annotations.StorageAnnotation, fmt.Sprintf("storage backends %v are not configured", unconfigured))
+		case backends.Len() == 0:
+			// Like in the configuration, an empty value disables the storage.
+			artifact.StorageBackend = sets.New[string]("")
+		default:
+			artifact.StorageBackend = backends
+		}
+	}
+
+	// Without the override, the annotation keeps opting the run in the uploads
+	// when transparency.enabled is set to manual.
+	if upload, ok := runAnnotations[RekorAnnotation]; ok && allowed.Has(config.OverrideTransparency) {
+		enabled, err := strconv.ParseBool(upload)
+		if err != nil {
+			ignore(RekorAnnotation, fmt.Sprintf("invalid value %q", upload))
+		} else {
+			cfg.Transparency.Enabled = enabled
+			cfg.Transparency.VerifyAnnotation = false
+		}
+	}
+
+	return cfg, true
+}
+
+// runArtifact returns the configuration of the provenance of the run, and its
+// supported formats.
+func runArtifact(cfg *config.Config, obj objects.TektonObject) (*config.Artifact, []string) {
+	switch {
+	case obj.SupportsPipelineRunArtifact():
+		return &cfg.Artifacts.PipelineRuns, config.PipelineRunFormats
+	case obj.SupportsCustomRunArtifact():
+		return &cfg.Artifacts.CustomRuns, config.CustomRunFormats
+	}
+	return &cfg.Artifacts.TaskRuns, config.TaskRunFormats
+}
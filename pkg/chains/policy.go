@@ -0,0 +1,69 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chains
+
+import (
+	"context"
+
+	"github.com/tektoncd/chains/pkg/artifacts"
+	"github.com/tektoncd/chains/pkg/chains/formats"
+	"github.com/tektoncd/chains/pkg/chains/objects"
+	"github.com/tektoncd/chains/pkg/chains/policy"
+	"github.com/tektoncd/chains/pkg/config"
+	"knative.dev/pkg/logging"
+)
+
+// evaluatePolicies evaluates the configured policies against the in-toto
+// statement generated for the run, in the format configured for its artifact,
+// and the run itself.
+func evaluatePolicies(ctx context.Context, tektonObj objects.TektonObject, signableTypes []artifacts.Signable, cfg config.Config) *policy.Result {
+	logger := logging.FromContext(ctx)
+
+	var statement []byte
+	for _, signableType := range signableTypes {
+		switch signableType.(type) {
+		case *artifacts.TaskRunArtifact, *artifacts.PipelineRunArtifact, *artifacts.CustomRunArtifact:
+		default:
+			continue
+		}
+		payloader, err := formats.GetPayloader(signableType.PayloadFormat(cfg), cfg)
+		if err != nil {
+			logger.Warnf("Unable to generate the statement evaluated by the policies: %v", err)
+			break
+		}
+		payload, err := payloader.CreatePayload(ctx, tektonObj)
+		if err == nil {
+			statement, err = getRawPayload(payload)
+		}
+		if err != nil {
+			logger.Warnf("Unable to generate the statement evaluated by the policies: %v", err)
+		}
+		break
+	}
+
+	input, err := policy.NewInput(statement, tektonObj)
+	if err != nil {
+		return &policy.Result{Violations: []string{err.Error()}}
+	}
+	return policy.Evaluate(policy.FromConfig(cfg.Policy), input)
+}
+
+// nonCompliantConfig returns the configuration of the signers of non-compliant
+// runs.
+func nonCompliantConfig(cfg config.Config) config.Config {
+	if cfg.Policy.NonCompliantKMSRef != "" {
+		cfg.Signers.KMS.KMSRef = cfg.Policy.NonCompliantKMSRef
+	}
+	return cfg
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/traits"
+	"github.com/pkg/errors"
+)
+
+type celPolicy struct {
+	name    string
+	program cel.Program
+}
+
+// NewCEL returns the policy made of a CEL expression over the statement and
+// object variables. The expression evaluates either to a bool, true when the
+// policy is satisfied, or to the violation messages as a string or a list of
+// strings, empty when the policy is satisfied.
+func NewCEL(name, expression string) (Policy, error) {
+	env, err := cel.NewEnv(
+		cel.Variable(InputStatement, cel.DynType),
+		cel.Variable(InputObject, cel.DynType),
+		// Optional field selection (x.?y) and indexing (x[?y]) ease the handling of
+		// the fields omitted from the JSON documents.
+		cel.OptionalTypes(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	ast, iss := env.Compile(expression)
+	if iss.Err() != nil {
+		return nil, errors.Wrapf(iss.Err(), "compiling policy %s", name)
+	}
+	program, err := env.Program(ast)
+	if err != nil {
+		return nil, errors.Wrapf(err, "compiling policy %s", name)
+	}
+	return &celPolicy{name: name, program: program}, nil
+}
+
+func (p *celPolicy) Name() string {
+	return p.name
+}
+
+func (p *celPolicy) Evaluate(input Input) ([]string, error) {
+	out, _, err := p.program.Eval(map[string]interface{}{
+		InputStatement: input.Statement,
+		InputObject:    input.Object,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	switch v := out.(type) {
+	case types.Bool:
+		if v {
+			return nil, nil
+		}
+		return []string{"not satisfied"}, nil
+	case types.String:
+		if v == "" {
+			return nil, nil
+		}
+		return []string{string(v)}, nil
+	case traits.Lister:
+		violations, err := v.ConvertToNative(reflect.TypeOf([]string{}))
+		if err != nil {
+			return nil, errors.Wrap(err, "expected a list of strings")
+		}
+		return violations.([]string), nil
+	}
+	return nil, fmt.Errorf("expected a bool, a string or a list of strings, got %s", out.Type().TypeName())
+}
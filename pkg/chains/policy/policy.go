@@ -0,0 +1,139 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package policy evaluates the policies a run must comply with before Chains
+// signs the artifacts it produced.
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/tektoncd/chains/pkg/chains/objects"
+	"github.com/tektoncd/chains/pkg/config"
+)
+
+const (
+	// InputStatement is the variable holding the in-toto statement generated for
+	// the run.
+	InputStatement = "statement"
+	// InputObject is the variable holding the TaskRun or PipelineRun.
+	InputObject = "object"
+
+	// ResultCompliant is the outcome of a run complying with all the policies.
+	ResultCompliant = "compliant"
+	// ResultNonCompliant is the outcome of a run violating a policy.
+	ResultNonCompliant = "non-compliant"
+)
+
+// Input is the document the policies are evaluated against, as decoded JSON.
+type Input struct {
+	Statement map[string]interface{}
+	Object    map[string]interface{}
+}
+
+// NewInput returns the input made of the JSON encoded statement and the run.
+// The statement is left empty when it could not be generated.
+func NewInput(statement []byte, obj objects.TektonObject) (Input, error) {
+	input := Input{}
+	if len(statement) > 0 {
+		if err := json.Unmarshal(statement, &input.Statement); err != nil {
+			return input, errors.Wrap(err, "decoding statement")
+		}
+	}
+	raw, err := json.Marshal(obj.GetObject())
+	if err != nil {
+		return input, errors.Wrap(err, "encoding object")
+	}
+	if err := json.Unmarshal(raw, &input.Object); err != nil {
+		return input, errors.Wrap(err, "decoding object")
+	}
+	return input, nil
+}
+
+// Policy is a rule runs must comply with.
+type Policy interface {
+	Name() string
+	// Evaluate returns the violations of the policy by the input.
+	Evaluate(input Input) ([]string, error)
+}
+
+// Result is the outcome of the evaluation of the policies.
+type Result struct {
+	Violations []string
+}
+
+// Compliant returns true when no policy was violated.
+func (r *Result) Compliant() bool {
+	return len(r.Violations) == 0
+}
+
+// Outcome returns ResultCompliant or ResultNonCompliant.
+func (r *Result) Outcome() string {
+	if r.Compliant() {
+		return ResultCompliant
+	}
+	return ResultNonCompliant
+}
+
+// FromConfig returns the policies in the configuration, sorted by name. A policy
+// that does not compile is returned as one that is always violated.
+func FromConfig(cfg config.PolicyConfig) []Policy {
+	names := make([]string, 0, len(cfg.CEL))
+	for name := range cfg.CEL {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	policies := make([]Policy, 0, len(names))
+	for _, name := range names {
+		p, err := NewCEL(name, cfg.CEL[name])
+		if err != nil {
+			p = &invalidPolicy{name: name, err: err}
+		}
+		policies = append(policies, p)
+	}
+	return policies
+}
+
+// Evaluate evaluates the policies against the input. A policy that cannot be
+// evaluated is violated, so that runs are never signed as compliant by mistake.
+func Evaluate(policies []Policy, input Input) *Result {
+	result := &Result{Violations: []string{}}
+	for _, p := range policies {
+		violations, err := p.Evaluate(input)
+		if err != nil {
+			result.Violations = append(result.Violations, fmt.Sprintf("%s: evaluation failed: %v", p.Name(), err))
+			continue
+		}
+		for _, v := range violations {
+			result.Violations = append(result.Violations, fmt.Sprintf("%s: %s", p.Name(), v))
+		}
+	}
+	return result
+}
+
+type invalidPolicy struct {
+	name string
+	err  error
+}
+
+func (p *invalidPolicy) Name() string {
+	return p.name
+}
+
+func (p *invalidPolicy) Evaluate(Input) ([]string, error) {
+	return nil, p.err
+}
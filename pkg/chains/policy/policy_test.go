@@ -0,0 +1,139 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/chains/pkg/chains/objects"
+	"github.com/tektoncd/chains/pkg/config"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const testStatement = `{
+	"_type": "https://in-toto.io/Statement/v1",
+	"subject": [{"name": "gcr.io/foo/bar", "digest": {"sha256": "05f95b26ed10668b7183c1e2da98610e91372fa9f510046d4ce5812addad86b5"}}],
+	"predicate": {"materials": [{"uri": "oci://gcr.io/foo/builder", "digest": {"sha256": "a2e500bebfe16cf12fc56316ba72c645e1d29054541dc1ab6c286197434170a9"}}]}
+}`
+
+func testInput(t *testing.T) Input {
+	t.Helper()
+	pr := objects.NewPipelineRunObjectV1(&v1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "release", Namespace: "build"},
+		Spec: v1.PipelineRunSpec{
+			Params: v1.Params{{Name: "revision", Value: *v1.NewStructuredValues("main")}},
+		},
+		Status: v1.PipelineRunStatus{
+			PipelineRunStatusFields: v1.PipelineRunStatusFields{
+				PipelineSpec: &v1.PipelineSpec{
+					Tasks: []v1.PipelineTask{{Name: "build"}, {Name: "scan"}},
+				},
+			},
+		},
+	})
+	input, err := NewInput([]byte(testStatement), pr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return input
+}
+
+func TestCEL(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		want       []string
+		wantErr    string
+	}{{
+		name:       "satisfied",
+		expression: `object.status.pipelineSpec.tasks.exists(t, t.name == "scan")`,
+	}, {
+		name:       "not satisfied",
+		expression: `object.status.pipelineSpec.tasks.exists(t, t.name == "sign")`,
+		want:       []string{"not satisfied"},
+	}, {
+		name:       "violation message",
+		expression: `statement.subject.size() > 1 ? "" : "expected several subjects"`,
+		want:       []string{"expected several subjects"},
+	}, {
+		name:       "violation messages",
+		expression: `statement.predicate.materials.filter(m, !m.uri.startsWith("oci://gcr.io/trusted/")).map(m, "untrusted material " + m.uri)`,
+		want:       []string{"untrusted material oci://gcr.io/foo/builder"},
+	}, {
+		name:       "no violation messages",
+		expression: `object.spec.params.filter(p, p.name == "untrusted").map(p, p.name)`,
+	}, {
+		name:       "optional field",
+		expression: `object.metadata.?labels[?"tekton.dev/pipeline"].orValue("") == ""`,
+	}, {
+		name:       "unexpected type",
+		expression: `statement.subject.size()`,
+		wantErr:    "expected a bool, a string or a list of strings",
+	}, {
+		name:       "missing field",
+		expression: `object.spec.serviceAccountName == "builder"`,
+		wantErr:    "no such key",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewCEL(tt.name, tt.expression)
+			if err != nil {
+				t.Fatalf("NewCEL() error = %v", err)
+			}
+			got, err := p.Evaluate(testInput(t))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Evaluate() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Evaluate() error = %v", err)
+			}
+			if len(got) == 0 && len(tt.want) == 0 {
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Evaluate() diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	policies := FromConfig(config.PolicyConfig{CEL: map[string]string{
+		"scan-task":  `object.status.pipelineSpec.tasks.exists(t, t.name == "scan")`,
+		"sign-task":  `object.status.pipelineSpec.tasks.exists(t, t.name == "sign")`,
+		"invalid":    `object.status.`,
+		"no-subject": `statement.subject.size() == 0 ? "" : "unexpected subject"`,
+	}})
+
+	result := Evaluate(policies, testInput(t))
+	if result.Compliant() || result.Outcome() != ResultNonCompliant {
+		t.Errorf("expected the input not to comply with the policies")
+	}
+	if len(result.Violations) != 3 || !strings.HasPrefix(result.Violations[0], "invalid: evaluation failed: compiling policy invalid") {
+		t.Fatalf("unexpected violations %q", result.Violations)
+	}
+	if diff := cmp.Diff([]string{"no-subject: unexpected subject", "sign-task: not satisfied"}, result.Violations[1:]); diff != "" {
+		t.Errorf("Evaluate() diff (-want, +got):\n%s", diff)
+	}
+
+	if result := Evaluate(policies[2:3], testInput(t)); !result.Compliant() || result.Outcome() != ResultCompliant {
+		t.Errorf("expected the input to comply with the scan-task policy, got %q", result.Violations)
+	}
+}
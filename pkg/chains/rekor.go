@@ -16,12 +16,22 @@ package chains
 import (
 	"context"
 	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"path"
 
 	"github.com/pkg/errors"
+	"github.com/secure-systems-lab/go-securesystemslib/dsse"
 	"github.com/sigstore/cosign/v2/pkg/cosign"
+	protorekor "github.com/sigstore/protobuf-specs/gen/pb-go/rekor/v1"
 	rc "github.com/sigstore/rekor/pkg/client"
 	"github.com/sigstore/rekor/pkg/generated/client"
+	"github.com/sigstore/rekor/pkg/generated/client/entries"
 	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/types"
+	"github.com/sigstore/rekor/pkg/types/intoto"
+	intoto_v002 "github.com/sigstore/rekor/pkg/types/intoto/v0.0.2"
 	"github.com/sigstore/sigstore/pkg/cryptoutils"
 	"github.com/tektoncd/chains/pkg/chains/annotations"
 	"github.com/tektoncd/chains/pkg/chains/formats"
@@ -39,18 +49,40 @@ type rekor struct {
 }
 
 type rekorClient interface {
-	UploadTlog(ctx context.Context, signer signing.Signer, signature, rawPayload []byte, cert, payloadFormat string) (*models.LogEntryAnon, error)
+	// UploadTlog uploads the signature to the transparency log, and returns the
+	// resulting entry in the Sigstore bundle format.
+	UploadTlog(ctx context.Context, signer signing.Signer, signature, rawPayload []byte, cert, payloadFormat, entryType string) (*protorekor.TransparencyLogEntry, error)
 }
 
-func (r *rekor) UploadTlog(ctx context.Context, signer signing.Signer, signature, rawPayload []byte, cert, payloadFormat string) (*models.LogEntryAnon, error) {
+func (r *rekor) UploadTlog(ctx context.Context, signer signing.Signer, signature, rawPayload []byte, cert, payloadFormat, entryType string) (*protorekor.TransparencyLogEntry, error) {
+	entry, err := r.upload(ctx, signer, signature, rawPayload, cert, payloadFormat, entryType)
+	if err != nil {
+		return nil, err
+	}
+	return tlogEntryFromAnon(entry)
+}
+
+func (r *rekor) upload(ctx context.Context, signer signing.Signer, signature, rawPayload []byte, cert, payloadFormat, entryType string) (*models.LogEntryAnon, error) {
 	pkoc, err := publicKeyOrCert(signer, cert)
 	if err != nil {
 		return nil, errors.Wrap(err, "public key or cert")
 	}
-	if _, ok := formats.IntotoAttestationSet[config.PayloadType(payloadFormat)]; ok {
+	switch tlogEntryKind(payloadFormat, entryType) {
+	case config.TlogEntryTypeDSSE:
 		return cosign.TLogUploadDSSEEnvelope(ctx, r.c, signature, pkoc)
+	case config.TlogEntryTypeIntoto:
+		return r.uploadIntoto(ctx, signature, pkoc)
 	}
 
+	if _, ok := formats.IntotoAttestationSet[config.PayloadType(payloadFormat)]; ok {
+		// The signature is a DSSE envelope: log the signature it holds, which is
+		// computed over the pre-authentication encoding of the payload.
+		sig, pae, err := envelopeSignature(signature)
+		if err != nil {
+			return nil, err
+		}
+		signature, rawPayload = sig, pae
+	}
 	h := sha256.New()
 	if _, err := h.Write(rawPayload); err != nil {
 		return nil, errors.Wrap(err, "error checksuming payload")
@@ -58,6 +90,69 @@ func (r *rekor) UploadTlog(ctx context.Context, signer signing.Signer, signature
 	return cosign.TLogUpload(ctx, r.c, signature, h, pkoc)
 }
 
+// uploadIntoto uploads the envelope as an intoto v0.0.2 entry, which cosign only
+// supports in its older v0.0.1 version.
+func (r *rekor) uploadIntoto(ctx context.Context, envelope, pubKey []byte) (*models.LogEntryAnon, error) {
+	e, err := types.NewProposedEntry(ctx, intoto.KIND, intoto_v002.APIVERSION, types.ArtifactProperties{
+		ArtifactBytes:  envelope,
+		PublicKeyBytes: [][]byte{pubKey},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "creating intoto entry")
+	}
+	params := entries.NewCreateLogEntryParamsWithContext(ctx)
+	params.SetProposedEntry(e)
+	resp, err := r.c.Entries.CreateLogEntry(params)
+	if err != nil {
+		// The entry is already in the log, fetch it.
+		var existsErr *entries.CreateLogEntryConflict
+		if errors.As(err, &existsErr) {
+			return cosign.GetTlogEntry(ctx, r.c, path.Base(existsErr.Location.String()))
+		}
+		return nil, err
+	}
+	for _, p := range resp.Payload {
+		return &p, nil
+	}
+	return nil, errors.New("bad response from server")
+}
+
+// envelopeSignature returns the signature held by a DSSE envelope along with the
+// pre-authentication encoding of its payload, which is what the signature is over.
+func envelopeSignature(signature []byte) ([]byte, []byte, error) {
+	env := dsse.Envelope{}
+	if err := json.Unmarshal(signature, &env); err != nil {
+		return nil, nil, errors.Wrap(err, "unmarshalling DSSE envelope")
+	}
+	if len(env.Signatures) != 1 {
+		return nil, nil, fmt.Errorf("expected a single signature in the DSSE envelope, got %d", len(env.Signatures))
+	}
+	sig, err := base64.StdEncoding.DecodeString(env.Signatures[0].Sig)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "decoding DSSE signature")
+	}
+	payload, err := env.DecodeB64Payload()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "decoding DSSE payload")
+	}
+	return sig, dsse.PAE(env.PayloadType, payload), nil
+}
+
+// tlogEntryKind returns the kind of transparency log entry used for a payload.
+// Only in-toto attestations are signed as DSSE envelopes, other payloads are
+// always logged as hashedrekord entries.
+func tlogEntryKind(payloadFormat, entryType string) string {
+	if _, ok := formats.IntotoAttestationSet[config.PayloadType(payloadFormat)]; !ok {
+		return config.TlogEntryTypeHashedRekord
+	}
+	switch entryType {
+	case config.TlogEntryTypeHashedRekord, config.TlogEntryTypeIntoto:
+		return entryType
+	default:
+		return config.TlogEntryTypeDSSE
+	}
+}
+
 // return the cert if we have it, otherwise return public key
 func publicKeyOrCert(signer signing.Signer, cert string) ([]byte, error) {
 	if cert != "" {
@@ -74,8 +169,11 @@ func publicKeyOrCert(signer signing.Signer, cert string) ([]byte, error) {
 	return pem, nil
 }
 
-var getRekor = func(url string) (rekorClient, error) {
-	rekorClient, err := rc.GetRekorClient(url)
+var getRekor = func(cfg config.TransparencyConfig) (rekorClient, error) {
+	if cfg.APIVersion == config.TransparencyAPIVersionV2 {
+		return newRekorV2(cfg.URL)
+	}
+	rekorClient, err := rc.GetRekorClient(cfg.URL)
 	if err != nil {
 		return nil, err
 	}
@@ -14,8 +14,13 @@ limitations under the License.
 package chains
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/in-toto/in-toto-golang/in_toto"
+	"github.com/secure-systems-lab/go-securesystemslib/dsse"
 	"github.com/tektoncd/chains/pkg/chains/annotations"
 	"github.com/tektoncd/chains/pkg/chains/objects"
 	"github.com/tektoncd/chains/pkg/config"
@@ -92,3 +97,49 @@ func TestShouldUploadTlog(t *testing.T) {
 		})
 	}
 }
+
+func TestTlogEntryKind(t *testing.T) {
+	tests := []struct {
+		payloadFormat string
+		entryType     string
+		want          string
+	}{
+		{payloadFormat: "slsa/v1", want: config.TlogEntryTypeDSSE},
+		{payloadFormat: "slsa/v1", entryType: config.TlogEntryTypeDSSE, want: config.TlogEntryTypeDSSE},
+		{payloadFormat: "in-toto", entryType: config.TlogEntryTypeIntoto, want: config.TlogEntryTypeIntoto},
+		{payloadFormat: "slsa/v2alpha4", entryType: config.TlogEntryTypeHashedRekord, want: config.TlogEntryTypeHashedRekord},
+		{payloadFormat: "simplesigning", want: config.TlogEntryTypeHashedRekord},
+		{payloadFormat: "simplesigning", entryType: config.TlogEntryTypeIntoto, want: config.TlogEntryTypeHashedRekord},
+	}
+	for _, tt := range tests {
+		if got := tlogEntryKind(tt.payloadFormat, tt.entryType); got != tt.want {
+			t.Errorf("tlogEntryKind(%q, %q) = %q, want %q", tt.payloadFormat, tt.entryType, got, tt.want)
+		}
+	}
+}
+
+func TestEnvelopeSignature(t *testing.T) {
+	payload := []byte(`{"_type":"https://in-toto.io/Statement/v0.1"}`)
+	envelope, err := json.Marshal(dsse.Envelope{
+		PayloadType: in_toto.PayloadType,
+		Payload:     base64.StdEncoding.EncodeToString(payload),
+		Signatures:  []dsse.Signature{{Sig: base64.StdEncoding.EncodeToString([]byte("sig"))}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, pae, err := envelopeSignature(envelope)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(sig) != "sig" {
+		t.Errorf("expected signature %q, got %q", "sig", sig)
+	}
+	if diff := cmp.Diff(dsse.PAE(in_toto.PayloadType, payload), pae); diff != "" {
+		t.Errorf("unexpected pre-authentication encoding (-want +got): %s", diff)
+	}
+
+	if _, _, err := envelopeSignature([]byte("not an envelope")); err == nil {
+		t.Error("expected an error for an invalid envelope")
+	}
+}
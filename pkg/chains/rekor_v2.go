@@ -0,0 +1,148 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chains
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+
+	"github.com/pkg/errors"
+	"github.com/secure-systems-lab/go-securesystemslib/dsse"
+	protocommon "github.com/sigstore/protobuf-specs/gen/pb-go/common/v1"
+	protodsse "github.com/sigstore/protobuf-specs/gen/pb-go/dsse"
+	protorekor "github.com/sigstore/protobuf-specs/gen/pb-go/rekor/v1"
+	"github.com/sigstore/rekor-tiles/v2/pkg/client/write"
+	pb "github.com/sigstore/rekor-tiles/v2/pkg/generated/protobuf"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/tektoncd/chains/pkg/chains/formats"
+	"github.com/tektoncd/chains/pkg/chains/signing"
+	"github.com/tektoncd/chains/pkg/config"
+)
+
+// rekorV2 uploads entries to a Rekor v2 tile-based transparency log.
+type rekorV2 struct {
+	w write.Client
+}
+
+func newRekorV2(url string) (*rekorV2, error) {
+	w, err := write.NewWriter(url)
+	if err != nil {
+		return nil, err
+	}
+	return &rekorV2{w: w}, nil
+}
+
+func (r *rekorV2) UploadTlog(ctx context.Context, signer signing.Signer, signature, rawPayload []byte, cert, payloadFormat, entryType string) (*protorekor.TransparencyLogEntry, error) {
+	pkoc, err := publicKeyOrCert(signer, cert)
+	if err != nil {
+		return nil, errors.Wrap(err, "public key or cert")
+	}
+	verifier, err := rekorV2Verifier(pkoc)
+	if err != nil {
+		return nil, err
+	}
+
+	switch tlogEntryKind(payloadFormat, entryType) {
+	case config.TlogEntryTypeDSSE:
+		env, err := protoEnvelope(signature)
+		if err != nil {
+			return nil, err
+		}
+		return r.w.Add(ctx, &pb.DSSERequestV002{
+			Envelope:  env,
+			Verifiers: []*pb.Verifier{verifier},
+		})
+	case config.TlogEntryTypeIntoto:
+		return nil, errors.New("intoto entries are not supported by Rekor v2 logs")
+	}
+
+	if _, ok := formats.IntotoAttestationSet[config.PayloadType(payloadFormat)]; ok {
+		sig, pae, err := envelopeSignature(signature)
+		if err != nil {
+			return nil, err
+		}
+		signature, rawPayload = sig, pae
+	}
+	digest := sha256.Sum256(rawPayload)
+	return r.w.Add(ctx, &pb.HashedRekordRequestV002{
+		Digest: digest[:],
+		Signature: &pb.Signature{
+			Content:  signature,
+			Verifier: verifier,
+		},
+	})
+}
+
+// rekorV2Verifier returns the verification material of a PEM encoded public key
+// or certificate.
+func rekorV2Verifier(pkoc []byte) (*pb.Verifier, error) {
+	block, _ := pem.Decode(pkoc)
+	if block == nil {
+		return nil, errors.New("no PEM block found in public key or cert")
+	}
+	if block.Type == "CERTIFICATE" {
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, errors.Wrap(err, "parsing certificate")
+		}
+		details, err := signature.GetDefaultPublicKeyDetails(cert.PublicKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "getting public key details")
+		}
+		return &pb.Verifier{
+			Verifier:   &pb.Verifier_X509Certificate{X509Certificate: &protocommon.X509Certificate{RawBytes: block.Bytes}},
+			KeyDetails: details,
+		}, nil
+	}
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing public key")
+	}
+	details, err := signature.GetDefaultPublicKeyDetails(pub)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting public key details")
+	}
+	return &pb.Verifier{
+		Verifier:   &pb.Verifier_PublicKey{PublicKey: &pb.PublicKey{RawBytes: block.Bytes}},
+		KeyDetails: details,
+	}, nil
+}
+
+// protoEnvelope converts a JSON encoded DSSE envelope to its protobuf form.
+func protoEnvelope(signature []byte) (*protodsse.Envelope, error) {
+	env := dsse.Envelope{}
+	if err := json.Unmarshal(signature, &env); err != nil {
+		return nil, errors.Wrap(err, "unmarshalling DSSE envelope")
+	}
+	payload, err := env.DecodeB64Payload()
+	if err != nil {
+		return nil, errors.Wrap(err, "decoding DSSE payload")
+	}
+	out := &protodsse.Envelope{
+		Payload:     payload,
+		PayloadType: env.PayloadType,
+	}
+	for _, s := range env.Signatures {
+		sig, err := base64.StdEncoding.DecodeString(s.Sig)
+		if err != nil {
+			return nil, errors.Wrap(err, "decoding DSSE signature")
+		}
+		out.Signatures = append(out.Signatures, &protodsse.Signature{Sig: sig, Keyid: s.KeyID})
+	}
+	return out, nil
+}
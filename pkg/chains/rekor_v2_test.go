@@ -0,0 +1,250 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chains
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	pb "github.com/sigstore/rekor-tiles/v2/pkg/generated/protobuf"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/tektoncd/chains/pkg/chains/annotations"
+	"github.com/tektoncd/chains/pkg/chains/objects"
+	"github.com/tektoncd/chains/pkg/chains/signing"
+	x509signer "github.com/tektoncd/chains/pkg/chains/signing/x509"
+	"github.com/tektoncd/chains/pkg/config"
+	testrekor "github.com/tektoncd/chains/pkg/test/rekor"
+	"github.com/tektoncd/chains/pkg/test/tekton"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	fakepipelineclient "github.com/tektoncd/pipeline/pkg/client/injection/client/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+func TestRekorV2_UploadTlog(t *testing.T) {
+	ctx := context.Background()
+	log := testrekor.NewLog(t)
+	client, err := newRekorV2(log.URL())
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifiers, err := rekorVerifiers(log.PublicKeyPEM(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherVerifiers, err := rekorVerifiers(testrekor.NewLog(t).PublicKeyPEM(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signer, err := x509signer.NewSigner(ctx, "./signing/x509/testdata/", config.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wrapped, err := signing.Wrap(signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload := []byte(`{"_type":"https://in-toto.io/Statement/v0.1"}`)
+	rawSig, err := signer.SignMessage(bytes.NewReader(payload))
+	if err != nil {
+		t.Fatal(err)
+	}
+	envelope, err := wrapped.SignMessage(bytes.NewReader(payload))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		signature     []byte
+		payloadFormat string
+		entryType     string
+		wantKind      string
+		wantErr       bool
+	}{{
+		name:          "simplesigning",
+		signature:     rawSig,
+		payloadFormat: "simplesigning",
+		wantKind:      config.TlogEntryTypeHashedRekord,
+	}, {
+		name:          "dsse",
+		signature:     envelope,
+		payloadFormat: "slsa/v1",
+		wantKind:      config.TlogEntryTypeDSSE,
+	}, {
+		name:          "hashedrekord for an in-toto attestation",
+		signature:     envelope,
+		payloadFormat: "slsa/v1",
+		entryType:     config.TlogEntryTypeHashedRekord,
+		wantKind:      config.TlogEntryTypeHashedRekord,
+	}, {
+		name:          "intoto",
+		signature:     envelope,
+		payloadFormat: "slsa/v1",
+		entryType:     config.TlogEntryTypeIntoto,
+		wantErr:       true,
+	}, {
+		name:          "invalid signature",
+		signature:     []byte("not a signature"),
+		payloadFormat: "simplesigning",
+		wantErr:       true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry, err := client.UploadTlog(ctx, signer, tt.signature, payload, "", tt.payloadFormat, tt.entryType)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UploadTlog() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := entry.GetKindVersion().GetKind(); got != tt.wantKind {
+				t.Errorf("expected a %s entry, got %q", tt.wantKind, got)
+			}
+			if rekorEntry(entry) != nil {
+				t.Error("expected no Rekor v1 entry for a Rekor v2 log")
+			}
+
+			if err := verifyTlogEntryOffline(ctx, entry, payload, tt.payloadFormat, tt.entryType, verifiers); err != nil {
+				t.Errorf("verifyTlogEntryOffline() error = %v", err)
+			}
+			if err := verifyTlogEntryOffline(ctx, entry, []byte("tampered"), tt.payloadFormat, tt.entryType, verifiers); err == nil {
+				t.Error("expected an error verifying the entry against another payload")
+			}
+			if err := verifyTlogEntryOffline(ctx, entry, payload, tt.payloadFormat, tt.entryType, otherVerifiers); err == nil {
+				t.Error("expected an error verifying the entry against an untrusted log")
+			}
+			entry.InclusionProof.Checkpoint.Envelope += "tampered\n"
+			if err := verifyTlogEntryOffline(ctx, entry, payload, tt.payloadFormat, tt.entryType, verifiers); err == nil {
+				t.Error("expected an error verifying the entry with a tampered checkpoint")
+			}
+		})
+	}
+	if got := log.Size(); got != 3 {
+		t.Errorf("expected 3 entries in the log, got %d", got)
+	}
+}
+
+func TestRekorV2Verifier(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pemKey, err := cryptoutils.MarshalPublicKeyToPEM(key.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "chains"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}, &x509.Certificate{Subject: pkix.Name{CommonName: "chains"}}, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pemCert, err := cryptoutils.MarshalCertificateToPEM(&x509.Certificate{Raw: der})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v, err := rekorV2Verifier(pemKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := v.GetVerifier().(*pb.Verifier_PublicKey); !ok {
+		t.Errorf("expected a public key verifier, got %T", v.GetVerifier())
+	}
+	if v, err = rekorV2Verifier(pemCert); err != nil {
+		t.Fatal(err)
+	}
+	if got, ok := v.GetVerifier().(*pb.Verifier_X509Certificate); !ok || !bytes.Equal(got.X509Certificate.GetRawBytes(), der) {
+		t.Errorf("expected a certificate verifier, got %v", v.GetVerifier())
+	}
+	if _, err := rekorV2Verifier([]byte("not a key")); err == nil {
+		t.Error("expected an error without any PEM block")
+	}
+}
+
+func TestSigner_TransparencyV2(t *testing.T) {
+	log := testrekor.NewLog(t)
+	backend := &mockBackend{backendType: "mock"}
+	cfg := &config.Config{
+		Artifacts: config.ArtifactConfigs{
+			TaskRuns: config.Artifact{
+				Format:         "slsa/v1",
+				StorageBackend: sets.New[string]("mock"),
+				Signer:         "x509",
+			},
+		},
+		Transparency: config.TransparencyConfig{
+			Enabled:    true,
+			URL:        log.URL(),
+			APIVersion: config.TransparencyAPIVersionV2,
+		},
+	}
+
+	ctx, _ := rtesting.SetupFakeContext(t)
+	ps := fakepipelineclient.Get(ctx)
+	ctx = config.ToContext(ctx, cfg.DeepCopy())
+
+	os := &ObjectSigner{
+		Backends:          fakeAllBackends([]*mockBackend{backend}),
+		SecretPath:        "./signing/x509/testdata/",
+		Pipelineclientset: ps,
+	}
+	obj := objects.NewTaskRunObjectV1(&v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-rekor-v2"},
+	})
+	tekton.CreateObject(t, ctx, ps, obj)
+
+	if err := os.Sign(ctx, obj); err != nil {
+		t.Fatalf("Signer.Sign() error = %v", err)
+	}
+	if got := log.Size(); got != 1 {
+		t.Fatalf("expected 1 transparency log entry, got %d", got)
+	}
+
+	signed, err := tekton.GetObject(t, ctx, ps, obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := signed.GetAnnotations()[annotations.ChainsTransparencyAnnotation], log.URL()+"#0"; got != want {
+		t.Errorf("expected transparency annotation %q, got %q", want, got)
+	}
+	// Rekor v2 entries have no signed entry timestamp to build a cosign bundle from.
+	if backend.storedOpts.RekorBundle != nil {
+		t.Error("expected no RekorBundle for a Rekor v2 entry")
+	}
+	if backend.storedOpts.TlogEntry == nil {
+		t.Fatal("expected TlogEntry to be set in StorageOpts, got nil")
+	}
+	verifiers, err := rekorVerifiers(log.PublicKeyPEM(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := verifyTlogEntryOffline(ctx, backend.storedOpts.TlogEntry, backend.storedPayload, "slsa/v1", "", verifiers); err != nil {
+		t.Errorf("verifyTlogEntryOffline() error = %v", err)
+	}
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chains
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/tektoncd/chains/pkg/chains/annotations"
+	"github.com/tektoncd/chains/pkg/config"
+)
+
+// now is replaced in the tests.
+var now = time.Now
+
+// retryDelay returns how long to wait before retrying to sign a run that
+// failed, zero when the retry is due or the run did not fail.
+func retryDelay(cfg config.RetryConfig, runAnnotations map[string]string) time.Duration {
+	retry, last, ok := annotations.LastRetry(runAnnotations)
+	if !ok {
+		return 0
+	}
+	delay := last.Add(cfg.Backoff(retry)).Sub(now())
+	if delay < 0 {
+		return 0
+	}
+	return delay
+}
+
+// nextRetry returns the number of the retry scheduled after a failure of the
+// run, counted from zero.
+func nextRetry(runAnnotations map[string]string) int {
+	retry, err := strconv.Atoi(runAnnotations[annotations.RetryAnnotation])
+	if err != nil {
+		return 0
+	}
+	return retry + 1
+}
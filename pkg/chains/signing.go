@@ -18,11 +18,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/hashicorp/go-multierror"
 	intoto "github.com/in-toto/attestation/go/v1"
 	cbundle "github.com/sigstore/cosign/v2/pkg/cosign/bundle"
-	"github.com/sigstore/rekor/pkg/generated/models"
+	protorekor "github.com/sigstore/protobuf-specs/gen/pb-go/rekor/v1"
 	"github.com/tektoncd/chains/pkg/artifacts"
 	"github.com/tektoncd/chains/pkg/chains/annotations"
 	"github.com/tektoncd/chains/pkg/chains/formats"
@@ -30,13 +32,16 @@ import (
 	"github.com/tektoncd/chains/pkg/chains/signing"
 	"github.com/tektoncd/chains/pkg/chains/signing/kms"
 	"github.com/tektoncd/chains/pkg/chains/signing/x509"
+	"github.com/tektoncd/chains/pkg/chains/spire"
 	"github.com/tektoncd/chains/pkg/chains/storage"
 	"github.com/tektoncd/chains/pkg/config"
 	"github.com/tektoncd/chains/pkg/metrics"
 	versioned "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
 	"golang.org/x/exp/maps"
 	"google.golang.org/protobuf/encoding/protojson"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
 )
 
@@ -53,6 +58,11 @@ type ObjectSigner struct {
 	Pipelineclientset versioned.Interface
 
 	Recorder metrics.Recorder
+
+	// TlogQueue, when set, defers failed transparency log uploads: the object is
+	// marked as signed with its uploads pending, and they are retried in the
+	// background instead of failing (and re-signing) the whole object.
+	TlogQueue *TlogQueue
 }
 
 func allSigners(ctx context.Context, sp string, cfg config.Config) map[string]signing.Signer {
@@ -61,7 +71,13 @@ func allSigners(ctx context.Context, sp string, cfg config.Config) map[string]si
 	neededSigners := map[string]struct{}{
 		cfg.Artifacts.OCI.Signer:          {},
 		cfg.Artifacts.TaskRuns.Signer:     {},
+		cfg.Artifacts.CustomRuns.Signer:   {},
 		cfg.Artifacts.PipelineRuns.Signer: {},
+		cfg.Artifacts.SBOM.Signer:         {},
+		cfg.Artifacts.TestResults.Signer:  {},
+		cfg.Artifacts.Vuln.Signer:         {},
+		cfg.Artifacts.Links.Signer:        {},
+		cfg.Artifacts.VSA.Signer:          {},
 	}
 
 	for _, s := range signing.AllSigners {
@@ -96,48 +112,161 @@ func getSignableTypes(ctx context.Context, obj objects.TektonObject) ([]artifact
 	var types []artifacts.Signable
 
 	if obj.SupportsTaskRunArtifact() {
-		types = append(types, &artifacts.TaskRunArtifact{})
+		types = append(types, &artifacts.TaskRunArtifact{}, &artifacts.StepLinkArtifact{})
 	}
 
 	if obj.SupportsPipelineRunArtifact() {
 		types = append(types, &artifacts.PipelineRunArtifact{})
 	}
 
+	if obj.SupportsCustomRunArtifact() {
+		types = append(types, &artifacts.CustomRunArtifact{})
+	}
+
 	if obj.SupportsOCIArtifact() {
-		types = append(types, &artifacts.OCIArtifact{})
+		types = append(types, &artifacts.OCIArtifact{}, &artifacts.SBOMArtifact{})
 	}
 
 	if len(types) == 0 {
 		return nil, fmt.Errorf("no signable artifacts found for %v", obj)
 	}
 
+	// The test and scan results, and the verification summaries, are attested for
+	// the subjects of the provenance signed above, so they must come last.
+	types = append(types, &artifacts.TestResultArtifact{}, &artifacts.ScanResultArtifact{}, &artifacts.VSAArtifact{})
+
 	return types, nil
 }
 
+// skipsOutcome returns true when the artifact is configured not to be signed for
+// the runs with the given outcome. Only the provenance of the runs and their
+// images can be skipped.
+func skipsOutcome(cfg config.Config, signableType artifacts.Signable, outcome string) bool {
+	var artifact config.Artifact
+	switch signableType.(type) {
+	case *artifacts.TaskRunArtifact:
+		artifact = cfg.Artifacts.TaskRuns
+	case *artifacts.PipelineRunArtifact:
+		artifact = cfg.Artifacts.PipelineRuns
+	case *artifacts.CustomRunArtifact:
+		artifact = cfg.Artifacts.CustomRuns
+	case *artifacts.OCIArtifact:
+		artifact = cfg.Artifacts.OCI
+	default:
+		return false
+	}
+	switch outcome {
+	case objects.OutcomeFailed:
+		return artifact.SkipFailedRuns
+	case objects.OutcomeCancelled:
+		return artifact.SkipCancelledRuns
+	case objects.OutcomeTimedOut:
+		return artifact.SkipTimedOutRuns
+	}
+	return false
+}
+
 // Sign TaskRun and PipelineRun objects, as well as generates attestations for each.
 // Follows process of extract payload, sign payload, store payload and signature.
 func (o *ObjectSigner) Sign(ctx context.Context, tektonObj objects.TektonObject) error {
-	cfg := *config.FromContext(ctx)
 	logger := logging.FromContext(ctx)
+	cfg, sign := o.applyOverrides(ctx, *config.FromContext(ctx), tektonObj)
+	if !sign {
+		logger.Infof("Not signing %s %s/%s annotated with %s", tektonObj.GetGVK(), tektonObj.GetNamespace(), tektonObj.GetName(), annotations.SkipAnnotation)
+		// The marker lets the parent PipelineRun be signed without waiting for it.
+		return annotations.MarkSkipped(ctx, tektonObj, o.Pipelineclientset)
+	}
+	ctx = config.ToContext(ctx, &cfg)
+
+	// The update of the retry annotations is observed right away, so wait for
+	// the backoff of the retry.
+	if delay := retryDelay(cfg.Retry, tektonObj.GetAnnotations()); delay > 0 {
+		logger.Infof("Retrying to sign %s %s/%s in %s", tektonObj.GetGVK(), tektonObj.GetNamespace(), tektonObj.GetName(), delay)
+		return controller.NewRequeueAfter(delay)
+	}
 
 	signableTypes, err := getSignableTypes(ctx, tektonObj)
 	if err != nil {
 		return err
 	}
 
+	if cfg.Spire.ResultsVerification != "" && !spire.Supported(tektonObj) {
+		logger.Infof("Not verifying the results of %s %s/%s, they are not signed with SPIRE", tektonObj.GetGVK(), tektonObj.GetNamespace(), tektonObj.GetName())
+	} else if cfg.Spire.ResultsVerification != "" {
+		verification := spire.Verify(tektonObj, cfg.Spire)
+		if !verification.Verified {
+			logger.Warnf("Results of %s %s/%s are not verified: %s", tektonObj.GetGVK(), tektonObj.GetNamespace(), tektonObj.GetName(), verification.Reason)
+			if cfg.Spire.ResultsVerification == config.SpireResultsVerificationEnforce {
+				if o.Recorder != nil {
+					o.Recorder.RecordErrorMetric(ctx, metrics.ResultsVerificationError)
+				}
+				emitErrorEvent(ctx, tektonObj, metrics.ResultsVerificationError, "Refusing to sign with unverified results: %s", verification.Reason)
+				// The results will not change, so there is no point in retrying.
+				if err := annotations.MarkFailed(ctx, tektonObj, o.Pipelineclientset, nil); err != nil {
+					return err
+				}
+				return fmt.Errorf("refusing to sign %s %s/%s with unverified results: %s", tektonObj.GetGVK(), tektonObj.GetNamespace(), tektonObj.GetName(), verification.Reason)
+			}
+		}
+		ctx = spire.WithVerification(ctx, verification)
+	}
+
 	signers := allSigners(ctx, o.SecretPath, cfg)
 
-	var merr *multierror.Error
 	extraAnnotations := map[string]string{}
+	refuseOCI := false
+	provenance := &artifacts.SignedProvenance{Compliant: true}
+	ctx = artifacts.WithSignedProvenance(ctx, provenance)
+	if len(cfg.Policy.CEL) > 0 {
+		result := evaluatePolicies(ctx, tektonObj, signableTypes, cfg)
+		violations, err := json.Marshal(result.Violations)
+		if err != nil {
+			return err
+		}
+		extraAnnotations[annotations.PolicyResultAnnotation] = result.Outcome()
+		extraAnnotations[annotations.PolicyViolationsAnnotation] = string(violations)
+
+		provenance.Compliant = result.Compliant()
+		if !result.Compliant() {
+			logger.Warnf("%s %s/%s does not comply with the policies: %s", tektonObj.GetGVK(), tektonObj.GetNamespace(), tektonObj.GetName(), strings.Join(result.Violations, "; "))
+			if o.Recorder != nil {
+				o.Recorder.RecordErrorMetric(ctx, metrics.PolicyViolationError)
+			}
+			emitErrorEvent(ctx, tektonObj, metrics.PolicyViolationError, "Does not comply with the policies: %s", strings.Join(result.Violations, "; "))
+			switch cfg.Policy.Enforcement {
+			case config.PolicyEnforcementRefuse:
+				refuseOCI = true
+			case config.PolicyEnforcementNonCompliantKey:
+				signers = allSigners(ctx, cfg.Policy.NonCompliantSecretPath, nonCompliantConfig(cfg))
+			}
+		}
+	}
+
+	outcome, _ := objects.GetOutcome(tektonObj)
+	status := &SigningStatus{}
+
+	var merr *multierror.Error
+	var pendingTlog []pendingTlogEntry
 	for _, signableType := range signableTypes {
 		if !signableType.Enabled(cfg) {
 			continue
 		}
+		if skipsOutcome(cfg, signableType, outcome) {
+			logger.Infof("Not signing %s for %s %s/%s with outcome %s", signableType.Type(), tektonObj.GetGVK(), tektonObj.GetNamespace(), tektonObj.GetName(), outcome)
+			status.skip(signableType.Type(), "outcome", fmt.Sprintf("runs with outcome %s are not signed", outcome))
+			continue
+		}
+		if _, ok := signableType.(*artifacts.OCIArtifact); ok && refuseOCI {
+			logger.Warnf("Refusing to sign the OCI images of non-compliant %s %s/%s", tektonObj.GetGVK(), tektonObj.GetNamespace(), tektonObj.GetName())
+			status.skip(signableType.Type(), string(metrics.PolicyViolationError), "the images of non-compliant runs are not signed")
+			continue
+		}
 		payloadFormat := signableType.PayloadFormat(cfg)
 		// Find the right payload format and format the object
 		payloader, err := formats.GetPayloader(payloadFormat, cfg)
 		if err != nil {
 			logger.Warnf("Format %s configured for %s: %v was not found", payloadFormat, tektonObj.GetGVK(), signableType.Type())
+			status.skip(signableType.Type(), "format", err.Error())
 			continue
 		}
 
@@ -150,7 +279,7 @@ func (o *ObjectSigner) Sign(ctx context.Context, tektonObj objects.TektonObject)
 		var tlogClient rekorClient
 		if shouldUploadTlog(cfg, tektonObj) {
 			var err error
-			tlogClient, err = getRekor(cfg.Transparency.URL)
+			tlogClient, err = getRekor(cfg.Transparency)
 			if err != nil {
 				return err
 			}
@@ -158,12 +287,16 @@ func (o *ObjectSigner) Sign(ctx context.Context, tektonObj objects.TektonObject)
 
 		// Go through each object one at a time.
 		for _, obj := range objects {
+			artifact := status.add(signableType.Type(), signableType.ShortKey(obj))
+			artifact.Format = string(payloadFormat)
 
 			payload, err := payloader.CreatePayload(ctx, obj)
 			if err != nil {
 				logger.Error(err)
 				o.recordError(ctx, signableType, metrics.PayloadCreationError)
-				merr = multierror.Append(merr, fmt.Errorf("creating payload for %s: %w", signableType.Type(), err))
+				err = fmt.Errorf("creating payload for %s: %w", signableType.Type(), err)
+				reportError(ctx, tektonObj, artifact, metrics.PayloadCreationError, err)
+				merr = multierror.Append(merr, err)
 				continue
 			}
 			logger.Infof("Created payload of type %s for %s %s/%s", string(payloadFormat), tektonObj.GetGVK(), tektonObj.GetNamespace(), tektonObj.GetName())
@@ -173,7 +306,9 @@ func (o *ObjectSigner) Sign(ctx context.Context, tektonObj objects.TektonObject)
 			signer, ok := signers[signerType]
 			if !ok {
 				logger.Warnf("No signer %s configured for %s", signerType, signableType.Type())
-				merr = multierror.Append(merr, fmt.Errorf("no signer %s configured for %s", signerType, signableType.Type()))
+				err := fmt.Errorf("no signer %s configured for %s", signerType, signableType.Type())
+				reportError(ctx, tektonObj, artifact, metrics.SigningError, err)
+				merr = multierror.Append(merr, err)
 				break
 			}
 
@@ -191,7 +326,9 @@ func (o *ObjectSigner) Sign(ctx context.Context, tektonObj objects.TektonObject)
 			if err != nil {
 				logger.Warnf("Unable to marshal payload for %s: %v", signerType, err)
 				o.recordError(ctx, signableType, metrics.MarshalPayloadError)
-				merr = multierror.Append(merr, fmt.Errorf("marshalling payload for %s: %w", signableType.Type(), err))
+				err = fmt.Errorf("marshalling payload for %s: %w", signableType.Type(), err)
+				reportError(ctx, tektonObj, artifact, metrics.MarshalPayloadError, err)
+				merr = multierror.Append(merr, err)
 				continue
 			}
 
@@ -199,32 +336,64 @@ func (o *ObjectSigner) Sign(ctx context.Context, tektonObj objects.TektonObject)
 			if err != nil {
 				logger.Error(err)
 				o.recordError(ctx, signableType, metrics.SigningError)
-				merr = multierror.Append(merr, fmt.Errorf("signing payload for %s: %w", signableType.Type(), err))
+				err = fmt.Errorf("signing payload for %s: %w", signableType.Type(), err)
+				reportError(ctx, tektonObj, artifact, metrics.SigningError, err)
+				merr = multierror.Append(merr, err)
 				continue
 			}
 			measureMetrics(ctx, metrics.SignedMessagesCount, o.Recorder)
 
+			switch signableType.(type) {
+			case *artifacts.TaskRunArtifact, *artifacts.PipelineRunArtifact, *artifacts.CustomRunArtifact:
+				// Only in-toto provenance has subjects to summarize the verification of.
+				if _, ok := formats.IntotoAttestationSet[payloadFormat]; ok {
+					if err := provenance.Add(signableType.FullKey(obj), rawPayload, signature); err != nil {
+						logger.Warnf("Unable to record the signed provenance for verification summaries: %v", err)
+					}
+				}
+			}
+
 			// Upload to Rekor before storage so the bundle is available for OCI attestation annotations.
 			// On upload failure, storage proceeds but the bundle annotation will be absent —
 			// consumers that rely on the bundle for offline verification will get an attestation without it.
 			var rekorBundle *cbundle.RekorBundle
-			var storageEntry *models.LogEntryAnon
+			var storageEntry *protorekor.TransparencyLogEntry
 			if tlogClient != nil {
-				entry, err := tlogClient.UploadTlog(ctx, signer, signature, rawPayload, signer.Cert(), string(payloadFormat))
+				entry, err := tlogClient.UploadTlog(ctx, signer, signature, rawPayload, signer.Cert(), string(payloadFormat), signableType.TlogEntryType(cfg))
 				if err != nil {
 					logger.Warnf("error uploading entry to tlog: %v", err)
 					o.recordError(ctx, signableType, metrics.TlogError)
-					merr = multierror.Append(merr, err)
-				} else {
-					logger.Infof("Uploaded entry to %s with index %d", cfg.Transparency.URL, *entry.LogIndex)
-					extraAnnotations[annotations.ChainsTransparencyAnnotation] = fmt.Sprintf("%s/api/v1/log/entries?logIndex=%d", cfg.Transparency.URL, *entry.LogIndex)
-					rekorBundle = cbundle.EntryToBundle(entry)
-					if rekorBundle != nil {
-						logger.Infof("Resolved Rekor bundle for offline verification (logIndex: %d)", rekorBundle.Payload.LogIndex)
+					artifact.Tlog = tlogFailed
+					if o.TlogQueue == nil {
+						reportError(ctx, tektonObj, artifact, metrics.TlogError, err)
+						merr = multierror.Append(merr, err)
+					} else if p, pErr := newPendingTlogEntry(cfg, signableType, obj, signer, signature, rawPayload, payloadFormat); pErr != nil {
+						reportError(ctx, tektonObj, artifact, metrics.TlogError, err)
+						merr = multierror.Append(merr, err, pErr)
+					} else if sizeErr := o.fitsPendingTlog(ctx, tektonObj, extraAnnotations, append(slices.Clip(pendingTlog), p), p.tektonStorageSize()); sizeErr != nil {
+						reportError(ctx, tektonObj, artifact, metrics.TlogError, err)
+						merr = multierror.Append(merr, err, sizeErr)
 					} else {
-						logger.Warn("Rekor entry missing verification data, skipping bundle for offline verification")
+						logger.Infof("Deferring upload of %s to tlog", signableType.ShortKey(obj))
+						artifact.Tlog = tlogPending
+						emitErrorEvent(ctx, tektonObj, metrics.TlogError, "Uploading %s to the transparency log failed, retrying in the background: %v", artifact.Key, err)
+						pendingTlog = append(pendingTlog, p)
+					}
+				} else {
+					logger.Infof("Uploaded entry to %s with index %d", cfg.Transparency.URL, entry.GetLogIndex())
+					artifact.Tlog = tlogUploaded
+					extraAnnotations[annotations.ChainsTransparencyAnnotation] = transparencyAnnotation(cfg.Transparency, entry)
+					// Rekor v2 entries carry no signed entry timestamp to build the
+					// cosign bundle from, only their inclusion proof.
+					if anon := rekorEntry(entry); anon != nil {
+						rekorBundle = cbundle.EntryToBundle(anon)
+						if rekorBundle != nil {
+							logger.Infof("Resolved Rekor bundle for offline verification (logIndex: %d)", rekorBundle.Payload.LogIndex)
+						} else {
+							logger.Warn("Rekor entry missing verification data, skipping bundle for offline verification")
+						}
 					}
-					// Preserve the raw entry so storage backends building a Sigstore protobuf
+					// Preserve the entry so storage backends building a Sigstore protobuf
 					// bundle (OCI sigstore-bundle mode) can embed the tlog entry inline.
 					storageEntry = entry
 					measureMetrics(ctx, metrics.PayloadUploadedCount, o.Recorder)
@@ -248,6 +417,7 @@ func (o *ObjectSigner) Sign(ctx context.Context, tektonObj objects.TektonObject)
 					backendErr := fmt.Errorf("could not find backend '%s' in configured backends (%v) while trying sign: %s/%s", backend, maps.Keys(o.Backends), tektonObj.GetKindName(), tektonObj.GetName())
 					logger.Error(backendErr)
 					o.recordError(ctx, signableType, metrics.StorageError)
+					reportStorageError(ctx, tektonObj, artifact, backend, backendErr)
 					merr = multierror.Append(merr, backendErr)
 					continue
 				}
@@ -260,32 +430,70 @@ func (o *ObjectSigner) Sign(ctx context.Context, tektonObj objects.TektonObject)
 					PublicKey:     pubKey,
 					PayloadFormat: payloadFormat,
 					RekorBundle:   rekorBundle,
-					RekorEntry:    storageEntry,
+					TlogEntry:     storageEntry,
 				}
 				if err := b.StorePayload(ctx, tektonObj, rawPayload, string(signature), storageOpts); err != nil {
 					logger.Error(err)
 					o.recordError(ctx, signableType, metrics.StorageError)
+					reportStorageError(ctx, tektonObj, artifact, backend, err)
 					merr = multierror.Append(merr, err)
 				} else {
+					artifact.setStorage(backend, backendStored)
 					measureMetrics(ctx, metrics.SignsStoredCount, o.Recorder)
 				}
 			}
 
 		}
 		if merr.ErrorOrNil() != nil {
-			if retryErr := annotations.HandleRetry(ctx, tektonObj, o.Pipelineclientset, extraAnnotations); retryErr != nil {
+			if err := status.annotate(cfg.Status.Annotation, extraAnnotations); err != nil {
+				logger.Warnf("Unable to record the signing status: %v", err)
+			}
+			maxRetries := cfg.Retry.MaxRetriesFor(status.errorTypes())
+			retry := annotations.RetryAvailable(tektonObj, maxRetries)
+			if retryErr := annotations.HandleRetry(ctx, tektonObj, o.Pipelineclientset, extraAnnotations, maxRetries); retryErr != nil {
 				logger.Warnf("error handling retry: %v", retryErr)
 				merr = multierror.Append(merr, retryErr)
 			}
+			if !retry {
+				emitEvent(ctx, tektonObj, corev1.EventTypeWarning, EventReasonFailed, "Signing failed after %d retries: %v", maxRetries, merr.ErrorOrNil())
+				return merr
+			}
+			emitEvent(ctx, tektonObj, corev1.EventTypeWarning, EventReasonRetryScheduled, "Signing failed, retrying: %v", merr.ErrorOrNil())
+			if backoff := cfg.Retry.Backoff(nextRetry(tektonObj.GetAnnotations())); backoff > 0 {
+				return multierror.Append(merr, controller.NewRequeueAfter(backoff))
+			}
 			return merr
 		}
 	}
 
+	if len(pendingTlog) > 0 {
+		budget, err := o.pendingTlogBudget(ctx, tektonObj, extraAnnotations, 0)
+		if err != nil {
+			return err
+		}
+		encoded, err := encodePendingTlogEntries(pendingTlog, budget)
+		if err != nil {
+			return err
+		}
+		extraAnnotations[annotations.TransparencyPendingAnnotation] = encoded
+	}
+
+	if err := status.annotate(cfg.Status.Annotation, extraAnnotations); err != nil {
+		logger.Warnf("Unable to record the signing status: %v", err)
+	}
+
 	// Now mark the TektonObject as signed
 	if err := annotations.MarkSigned(ctx, tektonObj, o.Pipelineclientset, extraAnnotations); err != nil {
 		return err
 	}
 	measureMetrics(ctx, metrics.MarkedAsSignedCount, o.Recorder)
+	emitEvent(ctx, tektonObj, corev1.EventTypeNormal, EventReasonSigned, "Signed %s", status.summary())
+
+	if len(pendingTlog) > 0 {
+		logger.Infof("%s %s/%s signed with %d transparency log uploads pending", tektonObj.GetGVK(), tektonObj.GetNamespace(), tektonObj.GetName(), len(pendingTlog))
+		measureMetrics(ctx, metrics.TlogPendingCount, o.Recorder)
+		o.TlogQueue.Enqueue(ctx, tektonObj)
+	}
 	return nil
 }
 
@@ -295,12 +503,12 @@ func measureMetrics(ctx context.Context, metrictype metrics.Metric, mtr metrics.
 	}
 }
 
-// recordError calls RecordErrorMetric when the signable type is a TaskRun or
-// PipelineRun artifact. OCI artifacts are excluded because they share the same
+// recordError calls RecordErrorMetric when the signable type is a TaskRun,
+// PipelineRun or CustomRun artifact. OCI artifacts are excluded because they share the same
 // counter namespace (and the recorder is bound per-run-type).
 func (o *ObjectSigner) recordError(ctx context.Context, signable artifacts.Signable, errType metrics.MetricErrorType) {
 	switch signable.(type) {
-	case *artifacts.TaskRunArtifact, *artifacts.PipelineRunArtifact:
+	case *artifacts.TaskRunArtifact, *artifacts.PipelineRunArtifact, *artifacts.CustomRunArtifact:
 		if o.Recorder != nil {
 			o.Recorder.RecordErrorMetric(ctx, errType)
 		}
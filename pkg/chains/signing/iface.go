@@ -17,7 +17,7 @@ import (
 	"crypto"
 
 	"github.com/sigstore/cosign/v2/pkg/cosign/bundle"
-	"github.com/sigstore/rekor/pkg/generated/models"
+	protorekor "github.com/sigstore/protobuf-specs/gen/pb-go/rekor/v1"
 	"github.com/sigstore/sigstore/pkg/signature"
 )
 
@@ -47,10 +47,10 @@ type Bundle struct {
 	Chain []byte
 	// RekorBundle is an optional Rekor transparency log bundle, populated when transparency is enabled.
 	RekorBundle *bundle.RekorBundle
-	// RekorEntry is the raw Rekor transparency log entry, populated when transparency is enabled.
-	// Storage backends that build a Sigstore protobuf bundle (e.g. OCI sigstore-bundle mode) need
-	// the raw entry to embed tlog data inline; the converted RekorBundle alone is insufficient.
-	RekorEntry *models.LogEntryAnon
+	// TlogEntry is the transparency log entry in the Sigstore bundle v0.3 format, populated when
+	// transparency is enabled. Storage backends that build a Sigstore protobuf bundle (e.g. OCI
+	// sigstore-bundle mode) embed it inline; the RekorBundle alone is insufficient.
+	TlogEntry *protorekor.TransparencyLogEntry
 	// PublicKey is the public key from the signer.
 	// Available for storage backends that need direct access to the key material
 	// (e.g. to create a cosign protobuf bundle without a certificate).
@@ -16,32 +16,52 @@ package chains
 import (
 	"bytes"
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
 	"encoding/json"
+	"encoding/pem"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	intoto "github.com/in-toto/attestation/go/v1"
-	"github.com/sigstore/rekor/pkg/generated/models"
+	protocommon "github.com/sigstore/protobuf-specs/gen/pb-go/common/v1"
+	protorekor "github.com/sigstore/protobuf-specs/gen/pb-go/rekor/v1"
 	"github.com/tektoncd/chains/pkg/chains/annotations"
 	"github.com/tektoncd/chains/pkg/chains/objects"
 	"github.com/tektoncd/chains/pkg/chains/signing"
 	"github.com/tektoncd/chains/pkg/chains/storage"
 	"github.com/tektoncd/chains/pkg/config"
+	"github.com/tektoncd/chains/pkg/internal/mockrecorder"
+	"github.com/tektoncd/chains/pkg/metrics"
 	"github.com/tektoncd/chains/pkg/test/tekton"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	fakepipelineclient "github.com/tektoncd/pipeline/pkg/client/injection/client/fake"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/controller"
 	rtesting "knative.dev/pkg/reconciler/testing"
 
 	_ "github.com/tektoncd/chains/pkg/chains/formats/all"
 )
 
 const (
-	testLogID    = "test-log-id"
+	testLogID    = "c0d23d6ad406973f9559f3ba2d1ca01f84147d8ffc5b8445c224f98b9591801d"
 	testRekorURL = "https://rekor.example.com"
 )
 
@@ -70,6 +90,7 @@ func TestSigner_Sign(t *testing.T) {
 				Signer:         "x509",
 			},
 		},
+		Retry: config.RetryConfig{MaxRetries: 3},
 	}
 
 	pcfg := &config.Config{
@@ -80,6 +101,7 @@ func TestSigner_Sign(t *testing.T) {
 				Signer:         "x509",
 			},
 		},
+		Retry: config.RetryConfig{MaxRetries: 3},
 	}
 
 	tests := []struct {
@@ -551,6 +573,1189 @@ func TestSigner_NoRekorBundleWithoutTransparency(t *testing.T) {
 	}
 }
 
+func TestSigner_TlogEntryType(t *testing.T) {
+	rekor := &mockRekor{}
+	cleanup := setupMocks(rekor)
+	defer cleanup()
+
+	cfg := &config.Config{
+		Artifacts: config.ArtifactConfigs{
+			TaskRuns: config.Artifact{
+				Format:                "slsa/v1",
+				StorageBackend:        sets.New[string]("mock"),
+				Signer:                "x509",
+				TransparencyEntryType: config.TlogEntryTypeIntoto,
+			},
+		},
+		Transparency: config.TransparencyConfig{
+			Enabled:   true,
+			URL:       testRekorURL,
+			EntryType: config.TlogEntryTypeDSSE,
+		},
+	}
+
+	ctx, _ := rtesting.SetupFakeContext(t)
+	ps := fakepipelineclient.Get(ctx)
+	ctx = config.ToContext(ctx, cfg.DeepCopy())
+
+	os := &ObjectSigner{
+		Backends:          fakeAllBackends([]*mockBackend{{backendType: "mock"}}),
+		SecretPath:        "./signing/x509/testdata/",
+		Pipelineclientset: ps,
+	}
+
+	obj := objects.NewTaskRunObjectV1(&v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-tlog-entry-type"},
+	})
+	tekton.CreateObject(t, ctx, ps, obj)
+
+	if err := os.Sign(ctx, obj); err != nil {
+		t.Fatalf("Signer.Sign() error = %v", err)
+	}
+	if diff := cmp.Diff([]string{config.TlogEntryTypeIntoto}, rekor.entryTypes); diff != "" {
+		t.Errorf("unexpected entry types (-want +got): %s", diff)
+	}
+}
+
+func TestSigner_SpireResultsVerification(t *testing.T) {
+	tests := []struct {
+		name         string
+		verification string
+		wantErr      bool
+	}{{
+		name:         "flag",
+		verification: config.SpireResultsVerificationFlag,
+	}, {
+		name:         "enforce",
+		verification: config.SpireResultsVerificationEnforce,
+		wantErr:      true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend := &mockBackend{backendType: "mock"}
+			cfg := &config.Config{
+				Artifacts: config.ArtifactConfigs{
+					TaskRuns: config.Artifact{
+						Format:         "slsa/v1",
+						StorageBackend: sets.New[string]("mock"),
+						Signer:         "x509",
+					},
+				},
+				// The results of the TaskRun are not signed, and there is no trust bundle to verify them anyway.
+				Spire: config.SpireConfig{ResultsVerification: tt.verification},
+			}
+
+			ctx, _ := rtesting.SetupFakeContext(t)
+			ps := fakepipelineclient.Get(ctx)
+			ctx = config.ToContext(ctx, cfg.DeepCopy())
+
+			os := &ObjectSigner{
+				Backends:          fakeAllBackends([]*mockBackend{backend}),
+				SecretPath:        "./signing/x509/testdata/",
+				Pipelineclientset: ps,
+			}
+			obj := objects.NewTaskRunObjectV1(&v1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-spire-" + tt.name},
+			})
+			tekton.CreateObject(t, ctx, ps, obj)
+
+			err := os.Sign(ctx, obj)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Signer.Sign() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			signed, err := tekton.GetObject(t, ctx, ps, obj)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.wantErr {
+				if backend.storedPayload != nil {
+					t.Error("expected no payload to be stored for a refused TaskRun")
+				}
+				if got := signed.GetAnnotations()[annotations.ChainsAnnotation]; got != "failed" {
+					t.Errorf("expected the refused TaskRun to be marked as failed, got %q", got)
+				}
+				return
+			}
+			// protojson output is not stable, so compact it before looking for the outcome.
+			var payload bytes.Buffer
+			if err := json.Compact(&payload, backend.storedPayload); err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(payload.String(), `"spire-results-verification":{"reason":"no SPIRE trust bundle configured","verified":"false"}`) {
+				t.Errorf("expected the verification outcome in the provenance, got %s", backend.storedPayload)
+			}
+		})
+	}
+}
+
+func TestSigner_SpireResultsVerification_CustomRun(t *testing.T) {
+	backend := &mockBackend{backendType: "mock"}
+	cfg := &config.Config{
+		Artifacts: config.ArtifactConfigs{
+			CustomRuns: config.Artifact{
+				Format:         "slsa/v1.1",
+				StorageBackend: sets.New[string]("mock"),
+				Signer:         "x509",
+			},
+		},
+		// The results of CustomRuns are not signed with SPIRE, so they are not verified.
+		Spire: config.SpireConfig{ResultsVerification: config.SpireResultsVerificationEnforce},
+	}
+
+	ctx, _ := rtesting.SetupFakeContext(t)
+	ps := fakepipelineclient.Get(ctx)
+	ctx = config.ToContext(ctx, cfg.DeepCopy())
+
+	os := &ObjectSigner{
+		Backends:          fakeAllBackends([]*mockBackend{backend}),
+		SecretPath:        "./signing/x509/testdata/",
+		Pipelineclientset: ps,
+	}
+	obj := objects.NewCustomRunObjectV1beta1(&v1beta1.CustomRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-spire-customrun", Namespace: "default"},
+	})
+	tekton.CreateObject(t, ctx, ps, obj)
+
+	if err := os.Sign(ctx, obj); err != nil {
+		t.Fatalf("Signer.Sign() error = %v", err)
+	}
+	signed, err := tekton.GetObject(t, ctx, ps, obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := signed.GetAnnotations()[annotations.ChainsAnnotation]; got != "true" {
+		t.Errorf("expected the CustomRun to be signed, got %q", got)
+	}
+	if backend.storedPayload == nil {
+		t.Error("expected the provenance of the CustomRun to be stored")
+	}
+}
+
+func TestSigner_CustomRunArtifacts(t *testing.T) {
+	tests := []struct {
+		name       string
+		storageErr bool
+		wantVSA    bool
+		wantErrors map[metrics.MetricErrorType]int
+	}{{
+		name:    "signed",
+		wantVSA: true,
+	}, {
+		name:       "storage error",
+		storageErr: true,
+		wantErrors: map[metrics.MetricErrorType]int{metrics.StorageError: 1},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			crBackend := &mockBackend{backendType: "mock", shouldErr: tt.storageErr}
+			vsaBackend := &mockBackend{backendType: "vsamock"}
+			cfg := &config.Config{
+				Artifacts: config.ArtifactConfigs{
+					CustomRuns: config.Artifact{
+						Format:         "slsa/v1.1",
+						StorageBackend: sets.New[string]("mock"),
+						Signer:         "x509",
+					},
+					VSA: config.Artifact{
+						Format:         "vsa",
+						StorageBackend: sets.New[string]("vsamock"),
+						Signer:         "x509",
+					},
+				},
+				Builder: config.BuilderConfig{ID: "https://chains.example.com"},
+				Policy: config.PolicyConfig{
+					// The policy only holds when the statement of the CustomRun is evaluated.
+					CEL:         map[string]string{"subject": `statement.subject.size() == 1`},
+					Enforcement: config.PolicyEnforcementRefuse,
+				},
+				VSA: config.VSAConfig{PolicyURI: "https://policies.example.com/release"},
+			}
+
+			ctx, _ := rtesting.SetupFakeContext(t)
+			ps := fakepipelineclient.Get(ctx)
+			ctx = config.ToContext(ctx, cfg.DeepCopy())
+
+			recorder := &mockrecorder.Recorder{}
+			os := &ObjectSigner{
+				Backends:          fakeAllBackends([]*mockBackend{crBackend, vsaBackend}),
+				SecretPath:        "./signing/x509/testdata/",
+				Pipelineclientset: ps,
+				Recorder:          recorder,
+			}
+			obj := objects.NewCustomRunObjectV1beta1(&v1beta1.CustomRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-customrun-" + strings.ReplaceAll(tt.name, " ", "-"), Namespace: "default", UID: "uid"},
+				Status: v1beta1.CustomRunStatus{
+					CustomRunStatusFields: v1beta1.CustomRunStatusFields{
+						Results: []v1beta1.CustomRunResult{{
+							Name:  "IMAGE_URL",
+							Value: "gcr.io/my/remote-image",
+						}, {
+							Name:  "IMAGE_DIGEST",
+							Value: "sha256:586789aa031fafc7d78a5393cdc772e0b55107ea54bb8bcf3f2cdac6c6da51ee",
+						}},
+					},
+				},
+			})
+			tekton.CreateObject(t, ctx, ps, obj)
+
+			if err := os.Sign(ctx, obj); (err != nil) != tt.storageErr {
+				t.Fatalf("Signer.Sign() error = %v, wantErr %v", err, tt.storageErr)
+			}
+			signed, err := tekton.GetObject(t, ctx, ps, obj)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := signed.GetAnnotations()[annotations.PolicyResultAnnotation]; got != "compliant" {
+				t.Errorf("expected the CustomRun to be compliant, got %q with violations %s", got, signed.GetAnnotations()[annotations.PolicyViolationsAnnotation])
+			}
+			if d := cmp.Diff(tt.wantErrors, recorder.Errors); d != "" {
+				t.Errorf("unexpected error metrics (-want +got): %s", d)
+			}
+			if !tt.wantVSA {
+				return
+			}
+			if vsaBackend.storedPayload == nil {
+				t.Fatal("expected the verification summary of the CustomRun to be stored")
+			}
+			if got, want := vsaBackend.storedOpts.FullKey, "gcr.io/my/remote-image@sha256:586789aa031fafc7d78a5393cdc772e0b55107ea54bb8bcf3f2cdac6c6da51ee"; got != want {
+				t.Errorf("expected the verification summary of %q, got %q", want, got)
+			}
+		})
+	}
+}
+
+func TestSigner_Policy(t *testing.T) {
+	// The TaskRun is not one of the scan task.
+	scanTask := `object.metadata.?labels[?"tekton.dev/task"].orValue("") == "scan"`
+
+	nonCompliantDir := t.TempDir()
+	nonCompliantKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(nonCompliantKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(nonCompliantDir, "x509.pem"), pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name             string
+		policy           string
+		enforcement      string
+		wantResult       string
+		wantViolations   string
+		wantOCI          bool
+		wantNonCompliant bool
+	}{{
+		name:           "compliant",
+		policy:         `statement.subject.size() == 1`,
+		enforcement:    config.PolicyEnforcementRefuse,
+		wantResult:     "compliant",
+		wantViolations: `[]`,
+		wantOCI:        true,
+	}, {
+		name:           "annotate",
+		policy:         scanTask,
+		enforcement:    config.PolicyEnforcementAnnotate,
+		wantResult:     "non-compliant",
+		wantViolations: `["scan-task: not satisfied"]`,
+		wantOCI:        true,
+	}, {
+		name:           "refuse",
+		policy:         scanTask,
+		enforcement:    config.PolicyEnforcementRefuse,
+		wantResult:     "non-compliant",
+		wantViolations: `["scan-task: not satisfied"]`,
+	}, {
+		name:             "noncompliant key",
+		policy:           scanTask,
+		enforcement:      config.PolicyEnforcementNonCompliantKey,
+		wantResult:       "non-compliant",
+		wantViolations:   `["scan-task: not satisfied"]`,
+		wantOCI:          true,
+		wantNonCompliant: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trBackend := &mockBackend{backendType: "mock"}
+			ociBackend := &mockBackend{backendType: "ocimock"}
+			cfg := &config.Config{
+				Artifacts: config.ArtifactConfigs{
+					TaskRuns: config.Artifact{
+						Format:         "slsa/v1",
+						StorageBackend: sets.New[string]("mock"),
+						Signer:         "x509",
+					},
+					OCI: config.Artifact{
+						Format:         "simplesigning",
+						StorageBackend: sets.New[string]("ocimock"),
+						Signer:         "x509",
+					},
+				},
+				Policy: config.PolicyConfig{
+					CEL:                    map[string]string{"scan-task": tt.policy},
+					Enforcement:            tt.enforcement,
+					NonCompliantSecretPath: nonCompliantDir,
+				},
+			}
+
+			ctx, _ := rtesting.SetupFakeContext(t)
+			ps := fakepipelineclient.Get(ctx)
+			ctx = config.ToContext(ctx, cfg.DeepCopy())
+
+			os := &ObjectSigner{
+				Backends:          fakeAllBackends([]*mockBackend{trBackend, ociBackend}),
+				SecretPath:        "./signing/x509/testdata/",
+				Pipelineclientset: ps,
+			}
+			obj := objects.NewTaskRunObjectV1(&v1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-policy-" + strings.ReplaceAll(tt.name, " ", "-")},
+				Status: v1.TaskRunStatus{
+					TaskRunStatusFields: v1.TaskRunStatusFields{
+						Results: []v1.TaskRunResult{{
+							Name:  "IMAGES",
+							Value: *v1.NewStructuredValues("gcr.io/foo/bar@sha256:05f95b26ed10668b7183c1e2da98610e91372fa9f510046d4ce5812addad86b5"),
+						}},
+					},
+				},
+			})
+			tekton.CreateObject(t, ctx, ps, obj)
+
+			if err := os.Sign(ctx, obj); err != nil {
+				t.Fatalf("Signer.Sign() error = %v", err)
+			}
+			signed, err := tekton.GetObject(t, ctx, ps, obj)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := signed.GetAnnotations()[annotations.PolicyResultAnnotation]; got != tt.wantResult {
+				t.Errorf("expected policy result %q, got %q", tt.wantResult, got)
+			}
+			if got := signed.GetAnnotations()[annotations.PolicyViolationsAnnotation]; got != tt.wantViolations {
+				t.Errorf("expected policy violations %s, got %s", tt.wantViolations, got)
+			}
+			if trBackend.storedPayload == nil {
+				t.Error("expected the TaskRun attestation to be stored")
+			}
+			if got := ociBackend.storedPayload != nil; got != tt.wantOCI {
+				t.Errorf("expected the OCI image to be signed: %v, got %v", tt.wantOCI, got)
+			}
+			if got := nonCompliantKey.PublicKey.Equal(trBackend.storedOpts.PublicKey); got != tt.wantNonCompliant {
+				t.Errorf("expected the attestation to be signed with the non-compliant key: %v, got %v", tt.wantNonCompliant, got)
+			}
+		})
+	}
+}
+
+func TestSigner_UnsuccessfulRuns(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      corev1.ConditionStatus
+		reason      string
+		taskRuns    config.Artifact
+		oci         config.Artifact
+		wantTaskRun bool
+		wantOCI     bool
+	}{{
+		name:        "succeeded",
+		status:      corev1.ConditionTrue,
+		reason:      "Succeeded",
+		taskRuns:    config.Artifact{SkipFailedRuns: true, SkipCancelledRuns: true, SkipTimedOutRuns: true},
+		oci:         config.Artifact{SkipFailedRuns: true, SkipCancelledRuns: true, SkipTimedOutRuns: true},
+		wantTaskRun: true,
+		wantOCI:     true,
+	}, {
+		name:        "failed images skipped",
+		status:      corev1.ConditionFalse,
+		reason:      "Failed",
+		oci:         config.Artifact{SkipFailedRuns: true},
+		wantTaskRun: true,
+	}, {
+		name:     "cancelled skipped",
+		status:   corev1.ConditionFalse,
+		reason:   v1.TaskRunReasonCancelled.String(),
+		taskRuns: config.Artifact{SkipCancelledRuns: true},
+		oci:      config.Artifact{SkipFailedRuns: true, SkipTimedOutRuns: true},
+		wantOCI:  true,
+	}, {
+		name:        "timed out signed",
+		status:      corev1.ConditionFalse,
+		reason:      v1.TaskRunReasonTimedOut.String(),
+		taskRuns:    config.Artifact{SkipFailedRuns: true, SkipCancelledRuns: true},
+		oci:         config.Artifact{SkipFailedRuns: true, SkipCancelledRuns: true},
+		wantTaskRun: true,
+		wantOCI:     true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trBackend := &mockBackend{backendType: "mock"}
+			ociBackend := &mockBackend{backendType: "ocimock"}
+			taskRuns := tt.taskRuns
+			taskRuns.Format = "slsa/v1"
+			taskRuns.StorageBackend = sets.New[string]("mock")
+			taskRuns.Signer = "x509"
+			oci := tt.oci
+			oci.Format = "simplesigning"
+			oci.StorageBackend = sets.New[string]("ocimock")
+			oci.Signer = "x509"
+			cfg := &config.Config{Artifacts: config.ArtifactConfigs{TaskRuns: taskRuns, OCI: oci}}
+
+			ctx, _ := rtesting.SetupFakeContext(t)
+			ps := fakepipelineclient.Get(ctx)
+			ctx = config.ToContext(ctx, cfg.DeepCopy())
+
+			os := &ObjectSigner{
+				Backends:          fakeAllBackends([]*mockBackend{trBackend, ociBackend}),
+				SecretPath:        "./signing/x509/testdata/",
+				Pipelineclientset: ps,
+			}
+			obj := objects.NewTaskRunObjectV1(&v1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-outcome-" + strings.ReplaceAll(tt.name, " ", "-")},
+				Status: v1.TaskRunStatus{
+					Status: duckv1.Status{
+						Conditions: duckv1.Conditions{{Type: apis.ConditionSucceeded, Status: tt.status, Reason: tt.reason}},
+					},
+					TaskRunStatusFields: v1.TaskRunStatusFields{
+						Results: []v1.TaskRunResult{{
+							Name:  "IMAGES",
+							Value: *v1.NewStructuredValues("gcr.io/foo/bar@sha256:05f95b26ed10668b7183c1e2da98610e91372fa9f510046d4ce5812addad86b5"),
+						}},
+					},
+				},
+			})
+			tekton.CreateObject(t, ctx, ps, obj)
+
+			if err := os.Sign(ctx, obj); err != nil {
+				t.Fatalf("Signer.Sign() error = %v", err)
+			}
+			if got := trBackend.storedPayload != nil; got != tt.wantTaskRun {
+				t.Errorf("expected the TaskRun attestation to be stored: %v, got %v", tt.wantTaskRun, got)
+			}
+			if got := ociBackend.storedPayload != nil; got != tt.wantOCI {
+				t.Errorf("expected the OCI image to be signed: %v, got %v", tt.wantOCI, got)
+			}
+		})
+	}
+}
+
+func TestSigner_Overrides(t *testing.T) {
+	allOverrides := sets.New[string](config.OverrideSkip, config.OverrideFormat, config.OverrideStorage, config.OverrideTransparency)
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		allowed     sets.Set[string]
+		wantBackend string
+		wantFormat  config.PayloadType
+	}{{
+		name:        "skipped",
+		annotations: map[string]string{annotations.SkipAnnotation: "true"},
+		allowed:     allOverrides,
+	}, {
+		name:        "skip not allowed",
+		annotations: map[string]string{annotations.SkipAnnotation: "true"},
+		allowed:     sets.New[string](config.OverrideFormat),
+		wantBackend: "mock",
+		wantFormat:  "slsa/v1",
+	}, {
+		name:        "format",
+		annotations: map[string]string{annotations.FormatAnnotation: "slsa/v1.1"},
+		allowed:     allOverrides,
+		wantBackend: "mock",
+		wantFormat:  "slsa/v1.1",
+	}, {
+		name:        "format not allowed",
+		annotations: map[string]string{annotations.FormatAnnotation: "slsa/v1.1"},
+		wantBackend: "mock",
+		wantFormat:  "slsa/v1",
+	}, {
+		name:        "unsupported format",
+		annotations: map[string]string{annotations.FormatAnnotation: "simplesigning"},
+		allowed:     allOverrides,
+		wantBackend: "mock",
+		wantFormat:  "slsa/v1",
+	}, {
+		name: "re-sign format",
+		annotations: map[string]string{
+			annotations.ResignAnnotation:       "new format",
+			annotations.ResignedAnnotation:     "new format",
+			annotations.ResignFormatAnnotation: "slsa/v1.1",
+		},
+		allowed:     allOverrides,
+		wantBackend: "mock",
+		wantFormat:  "slsa/v1.1",
+	}, {
+		name: "re-sign format not allowed",
+		annotations: map[string]string{
+			annotations.ResignAnnotation:       "new format",
+			annotations.ResignedAnnotation:     "new format",
+			annotations.ResignFormatAnnotation: "slsa/v1.1",
+		},
+		allowed:     sets.New[string](config.OverrideSkip),
+		wantBackend: "mock",
+		wantFormat:  "slsa/v1",
+	}, {
+		name: "re-sign format of another request",
+		annotations: map[string]string{
+			annotations.ResignAnnotation:       "new format",
+			annotations.ResignFormatAnnotation: "slsa/v1.1",
+		},
+		allowed:     allOverrides,
+		wantBackend: "mock",
+		wantFormat:  "slsa/v1",
+	}, {
+		name:        "storage",
+		annotations: map[string]string{annotations.StorageAnnotation: "other"},
+		allowed:     allOverrides,
+		wantBackend: "other",
+		wantFormat:  "slsa/v1",
+	}, {
+		name:        "unconfigured storage",
+		annotations: map[string]string{annotations.StorageAnnotation: "other,gcs"},
+		allowed:     allOverrides,
+		wantBackend: "mock",
+		wantFormat:  "slsa/v1",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backends := []*mockBackend{{backendType: "mock"}, {backendType: "other"}}
+			cfg := &config.Config{
+				Artifacts: config.ArtifactConfigs{
+					TaskRuns: config.Artifact{
+						Format:         "slsa/v1",
+						StorageBackend: sets.New[string]("mock"),
+						Signer:         "x509",
+					},
+				},
+				Overrides: config.OverridesConfig{Allowed: tt.allowed},
+			}
+
+			ctx, _ := rtesting.SetupFakeContext(t)
+			ps := fakepipelineclient.Get(ctx)
+			ctx = config.ToContext(ctx, cfg.DeepCopy())
+
+			os := &ObjectSigner{
+				Backends:          fakeAllBackends(backends),
+				SecretPath:        "./signing/x509/testdata/",
+				Pipelineclientset: ps,
+			}
+			obj := objects.NewTaskRunObjectV1(&v1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-overrides-" + strings.ReplaceAll(tt.name, " ", "-"),
+					Annotations: tt.annotations,
+				},
+			})
+			tekton.CreateObject(t, ctx, ps, obj)
+
+			if err := os.Sign(ctx, obj); err != nil {
+				t.Fatalf("Signer.Sign() error = %v", err)
+			}
+			for _, b := range backends {
+				stored := b.storedPayload != nil
+				if stored != (b.backendType == tt.wantBackend) {
+					t.Errorf("expected the attestation to be stored in %q, stored in %s: %v", tt.wantBackend, b.backendType, stored)
+				}
+				if stored && b.storedOpts.PayloadFormat != tt.wantFormat {
+					t.Errorf("expected the attestation to be formatted as %s, got %s", tt.wantFormat, b.storedOpts.PayloadFormat)
+				}
+			}
+			signed, err := tekton.GetObject(t, ctx, ps, obj)
+			if err != nil {
+				t.Fatal(err)
+			}
+			wantMarker := "true"
+			if tt.wantBackend == "" {
+				wantMarker = annotations.SkippedMarker
+			}
+			if got := signed.GetAnnotations()[annotations.ChainsAnnotation]; got != wantMarker {
+				t.Errorf("expected the TaskRun to be marked as %q, got %q", wantMarker, got)
+			}
+			if !annotations.Reconciled(ctx, ps, signed) {
+				t.Error("expected the TaskRun to be reconciled")
+			}
+		})
+	}
+}
+
+func TestSigner_Events(t *testing.T) {
+	tests := []struct {
+		name        string
+		retries     string
+		storageErr  bool
+		wantEvents  []string
+		wantOutcome string
+		wantStorage map[string]string
+	}{{
+		name:        "signed",
+		wantEvents:  []string{"Normal Signed Signed 1 artifacts, skipped 0"},
+		wantOutcome: "signed",
+		wantStorage: map[string]string{"mock": "stored"},
+	}, {
+		name:       "retry scheduled",
+		storageErr: true,
+		wantEvents: []string{
+			"Warning StorageError Storing tekton taskrun-test-events-uid in mock failed: mock error storing",
+			"Warning RetryScheduled Signing failed, retrying: 1 error occurred:\n\t* mock error storing\n\n",
+		},
+		wantOutcome: "failed",
+		wantStorage: map[string]string{"mock": "mock error storing"},
+	}, {
+		name:       "permanently failed",
+		retries:    "3",
+		storageErr: true,
+		wantEvents: []string{
+			"Warning StorageError Storing tekton taskrun-test-events-uid in mock failed: mock error storing",
+			"Warning SigningFailed Signing failed after 3 retries: 1 error occurred:\n\t* mock error storing\n\n",
+		},
+		wantOutcome: "failed",
+		wantStorage: map[string]string{"mock": "mock error storing"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				Artifacts: config.ArtifactConfigs{
+					TaskRuns: config.Artifact{
+						Format:         "slsa/v1",
+						StorageBackend: sets.New[string]("mock"),
+						Signer:         "x509",
+					},
+					OCI:         config.Artifact{Signer: "none"},
+					SBOM:        config.Artifact{Signer: "none"},
+					TestResults: config.Artifact{Signer: "none"},
+					Vuln:        config.Artifact{Signer: "none"},
+				},
+				Status: config.StatusConfig{Annotation: true},
+				Retry:  config.RetryConfig{MaxRetries: 3},
+			}
+
+			ctx, _ := rtesting.SetupFakeContext(t)
+			ps := fakepipelineclient.Get(ctx)
+			ctx = config.ToContext(ctx, cfg.DeepCopy())
+			events := record.NewFakeRecorder(10)
+			ctx = controller.WithEventRecorder(ctx, events)
+
+			os := &ObjectSigner{
+				Backends:          fakeAllBackends([]*mockBackend{{backendType: "mock", shouldErr: tt.storageErr}}),
+				SecretPath:        "./signing/x509/testdata/",
+				Pipelineclientset: ps,
+			}
+			tr := &v1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-events-" + strings.ReplaceAll(tt.name, " ", "-"),
+					UID:  "test-events-uid",
+				},
+			}
+			if tt.retries != "" {
+				tr.Annotations = map[string]string{annotations.RetryAnnotation: tt.retries}
+			}
+			obj := objects.NewTaskRunObjectV1(tr)
+			tekton.CreateObject(t, ctx, ps, obj)
+
+			if err := os.Sign(ctx, obj); (err != nil) != tt.storageErr {
+				t.Fatalf("Signer.Sign() error = %v", err)
+			}
+			close(events.Events)
+			var got []string
+			for event := range events.Events {
+				got = append(got, event)
+			}
+			if d := cmp.Diff(tt.wantEvents, got); d != "" {
+				t.Errorf("events (-want, +got):\n%s", d)
+			}
+
+			signed, err := tekton.GetObject(t, ctx, ps, obj)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var status SigningStatus
+			if err := json.Unmarshal([]byte(signed.GetAnnotations()[annotations.StatusAnnotation]), &status); err != nil {
+				t.Fatalf("invalid status annotation: %v", err)
+			}
+			if len(status.Artifacts) != 1 {
+				t.Fatalf("expected the status of a single artifact, got %d", len(status.Artifacts))
+			}
+			artifact := status.Artifacts[0]
+			if artifact.Type != "tekton" || artifact.Format != "slsa/v1" || artifact.Outcome != tt.wantOutcome {
+				t.Errorf("unexpected status of the artifact: %+v", artifact)
+			}
+			if d := cmp.Diff(tt.wantStorage, artifact.Storage); d != "" {
+				t.Errorf("storage status (-want, +got):\n%s", d)
+			}
+		})
+	}
+}
+
+func TestSigner_RetryBackoff(t *testing.T) {
+	ctx, _ := rtesting.SetupFakeContext(t)
+	ps := fakepipelineclient.Get(ctx)
+	ctx = config.ToContext(ctx, &config.Config{
+		Artifacts: config.ArtifactConfigs{
+			TaskRuns: config.Artifact{
+				Format:         "slsa/v1",
+				StorageBackend: sets.New[string]("mock"),
+				Signer:         "x509",
+			},
+		},
+		Retry: config.RetryConfig{
+			MaxRetries:  3,
+			BackoffBase: time.Minute,
+			BackoffCap:  time.Hour,
+		},
+	})
+	defer func() { now = time.Now }()
+
+	os := &ObjectSigner{
+		Backends:          fakeAllBackends([]*mockBackend{{backendType: "mock", shouldErr: true}}),
+		SecretPath:        "./signing/x509/testdata/",
+		Pipelineclientset: ps,
+	}
+	var obj objects.TektonObject = objects.NewTaskRunObjectV1(&v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "retry-backoff", Namespace: "default"},
+	})
+	tekton.CreateObject(t, ctx, ps, obj)
+
+	sign := func(wantRetry string, wantDelay time.Duration) objects.TektonObject {
+		t.Helper()
+		err := os.Sign(ctx, obj)
+		if ok, delay := controller.IsRequeueKey(err); !ok || delay != wantDelay {
+			t.Fatalf("Signer.Sign() error = %v, want a requeue after %s", err, wantDelay)
+		}
+		retried, err := tekton.GetObject(t, ctx, ps, obj)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := retried.GetAnnotations()[annotations.RetryAnnotation]; got != wantRetry {
+			t.Fatalf("expected retry %s to be scheduled, got %q", wantRetry, got)
+		}
+		return retried
+	}
+
+	obj = sign("0", time.Minute)
+
+	// The retry is not due yet.
+	if ok, delay := controller.IsRequeueKey(os.Sign(ctx, obj)); !ok || delay <= 0 || delay > time.Minute {
+		t.Fatalf("Signer.Sign() requeue = %v after %s, want a requeue within the backoff", ok, delay)
+	}
+	waiting, err := tekton.GetObject(t, ctx, ps, obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := waiting.GetAnnotations()[annotations.RetryAnnotation]; got != "0" {
+		t.Fatalf("expected the run not to be signed before the backoff, got retry %q", got)
+	}
+
+	// The retry is due, and the next backoff is doubled.
+	now = func() time.Time { return time.Now().Add(time.Minute) }
+	sign("1", 2*time.Minute)
+}
+
+func TestEventReason(t *testing.T) {
+	for errType, want := range map[metrics.MetricErrorType]string{
+		metrics.StorageError:         "StorageError",
+		metrics.PayloadCreationError: "PayloadCreationError",
+		metrics.PolicyViolationError: "PolicyViolationError",
+	} {
+		if got := eventReason(errType); got != want {
+			t.Errorf("eventReason(%s) = %s, want %s", errType, got, want)
+		}
+	}
+}
+
+func TestApplyOverrides_Transparency(t *testing.T) {
+	tests := []struct {
+		name        string
+		upload      string
+		allowed     sets.Set[string]
+		enabled     bool
+		wantEnabled bool
+		wantVerify  bool
+	}{{
+		name:        "enabled by the run",
+		upload:      "true",
+		allowed:     sets.New[string](config.OverrideTransparency),
+		wantEnabled: true,
+	}, {
+		name:    "disabled by the run",
+		upload:  "false",
+		allowed: sets.New[string](config.OverrideTransparency),
+		enabled: true,
+	}, {
+		name:        "not allowed",
+		upload:      "false",
+		enabled:     true,
+		wantEnabled: true,
+		wantVerify:  true,
+	}, {
+		name:        "invalid value",
+		upload:      "maybe",
+		allowed:     sets.New[string](config.OverrideTransparency),
+		enabled:     true,
+		wantEnabled: true,
+		wantVerify:  true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := rtesting.SetupFakeContext(t)
+			cfg := config.Config{
+				Transparency: config.TransparencyConfig{Enabled: tt.enabled, VerifyAnnotation: true},
+				Overrides:    config.OverridesConfig{Allowed: tt.allowed},
+			}
+			obj := objects.NewTaskRunObjectV1(&v1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{RekorAnnotation: tt.upload}},
+			})
+			got, sign := (&ObjectSigner{}).applyOverrides(ctx, cfg, obj)
+			if !sign {
+				t.Fatal("expected the run to be signed")
+			}
+			if got.Transparency.Enabled != tt.wantEnabled || got.Transparency.VerifyAnnotation != tt.wantVerify {
+				t.Errorf("expected transparency enabled %v and verify annotation %v, got %v and %v", tt.wantEnabled, tt.wantVerify, got.Transparency.Enabled, got.Transparency.VerifyAnnotation)
+			}
+		})
+	}
+}
+
+func TestSigner_SBOM(t *testing.T) {
+	sbomBackend := &mockBackend{backendType: "sbommock"}
+	cfg := &config.Config{
+		Artifacts: config.ArtifactConfigs{
+			SBOM: config.Artifact{
+				Format:         "sbom",
+				StorageBackend: sets.New[string]("sbommock"),
+				Signer:         "x509",
+			},
+		},
+	}
+
+	ctx, _ := rtesting.SetupFakeContext(t)
+	ps := fakepipelineclient.Get(ctx)
+	ctx = config.ToContext(ctx, cfg.DeepCopy())
+
+	os := &ObjectSigner{
+		Backends:          fakeAllBackends([]*mockBackend{sbomBackend}),
+		SecretPath:        "./signing/x509/testdata/",
+		Pipelineclientset: ps,
+	}
+	obj := objects.NewTaskRunObjectV1(&v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-sbom"},
+		Status: v1.TaskRunStatus{
+			TaskRunStatusFields: v1.TaskRunStatusFields{
+				Results: []v1.TaskRunResult{{
+					Name:  "IMAGE_URL",
+					Value: *v1.NewStructuredValues("gcr.io/foo/bar"),
+				}, {
+					Name:  "IMAGE_DIGEST",
+					Value: *v1.NewStructuredValues("sha256:05f95b26ed10668b7183c1e2da98610e91372fa9f510046d4ce5812addad86b5"),
+				}, {
+					Name:  "SBOM",
+					Value: *v1.NewStructuredValues(`{"bomFormat": "CycloneDX", "specVersion": "1.5"}`),
+				}},
+			},
+		},
+	})
+	tekton.CreateObject(t, ctx, ps, obj)
+
+	if err := os.Sign(ctx, obj); err != nil {
+		t.Fatalf("Signer.Sign() error = %v", err)
+	}
+	if sbomBackend.storedPayload == nil {
+		t.Fatal("expected the SBOM attestation to be stored")
+	}
+	if got := sbomBackend.storedOpts.PayloadFormat; got != "sbom" {
+		t.Errorf("expected the sbom payload format, got %s", got)
+	}
+
+	var statement struct {
+		PredicateType string                       `json:"predicateType"`
+		Subject       []*intoto.ResourceDescriptor `json:"subject"`
+		Predicate     map[string]interface{}       `json:"predicate"`
+	}
+	if err := json.Unmarshal(sbomBackend.storedPayload, &statement); err != nil {
+		t.Fatal(err)
+	}
+	if statement.PredicateType != "https://cyclonedx.org/bom" {
+		t.Errorf("unexpected predicate type %s", statement.PredicateType)
+	}
+	if len(statement.Subject) != 1 || statement.Subject[0].GetName() != "gcr.io/foo/bar" {
+		t.Errorf("expected the image to be the subject, got %v", statement.Subject)
+	}
+	if statement.Predicate["bomFormat"] != "CycloneDX" {
+		t.Errorf("expected the SBOM to be the predicate, got %v", statement.Predicate)
+	}
+}
+
+func TestSigner_VSA(t *testing.T) {
+	tests := []struct {
+		name       string
+		vsaStorage sets.Set[string]
+		policy     string
+		wantResult string
+		wantLevels []interface{}
+	}{{
+		name:       "passed",
+		vsaStorage: sets.New[string]("vsamock"),
+		wantResult: "PASSED",
+		wantLevels: []interface{}{"SLSA_BUILD_LEVEL_2"},
+	}, {
+		name:       "failed policies",
+		vsaStorage: sets.New[string]("vsamock"),
+		policy:     `false`,
+		wantResult: "FAILED",
+		wantLevels: []interface{}{},
+	}, {
+		name: "disabled",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trBackend := &mockBackend{backendType: "mock"}
+			vsaBackend := &mockBackend{backendType: "vsamock"}
+			cfg := &config.Config{
+				Artifacts: config.ArtifactConfigs{
+					TaskRuns: config.Artifact{
+						Format:         "slsa/v1",
+						StorageBackend: sets.New[string]("mock"),
+						Signer:         "x509",
+					},
+					VSA: config.Artifact{
+						Format:         "vsa",
+						StorageBackend: tt.vsaStorage,
+						Signer:         "x509",
+					},
+				},
+				Builder: config.BuilderConfig{ID: "https://chains.example.com"},
+				VSA:     config.VSAConfig{PolicyURI: "https://policies.example.com/release"},
+			}
+			if tt.policy != "" {
+				cfg.Policy.CEL = map[string]string{"release": tt.policy}
+			}
+
+			ctx, _ := rtesting.SetupFakeContext(t)
+			ps := fakepipelineclient.Get(ctx)
+			ctx = config.ToContext(ctx, cfg.DeepCopy())
+
+			os := &ObjectSigner{
+				Backends:          fakeAllBackends([]*mockBackend{trBackend, vsaBackend}),
+				SecretPath:        "./signing/x509/testdata/",
+				Pipelineclientset: ps,
+			}
+			obj := objects.NewTaskRunObjectV1(&v1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-vsa-" + strings.ReplaceAll(tt.name, " ", "-"), UID: "uid"},
+				Status: v1.TaskRunStatus{
+					TaskRunStatusFields: v1.TaskRunStatusFields{
+						Results: []v1.TaskRunResult{{
+							Name:  "IMAGES",
+							Value: *v1.NewStructuredValues("gcr.io/foo/bar@sha256:05f95b26ed10668b7183c1e2da98610e91372fa9f510046d4ce5812addad86b5"),
+						}},
+					},
+				},
+			})
+			tekton.CreateObject(t, ctx, ps, obj)
+
+			if err := os.Sign(ctx, obj); err != nil {
+				t.Fatalf("Signer.Sign() error = %v", err)
+			}
+			if tt.wantResult == "" {
+				if vsaBackend.storedPayload != nil {
+					t.Errorf("expected no verification summary to be stored, got %s", vsaBackend.storedPayload)
+				}
+				return
+			}
+			if vsaBackend.storedPayload == nil {
+				t.Fatal("expected the verification summary to be stored")
+			}
+			if got, want := vsaBackend.storedOpts.ShortKey, "vsa-05f95b26ed10"; got != want {
+				t.Errorf("expected the short key %q, got %q", want, got)
+			}
+
+			var statement struct {
+				PredicateType string                       `json:"predicateType"`
+				Subject       []*intoto.ResourceDescriptor `json:"subject"`
+				Predicate     map[string]interface{}       `json:"predicate"`
+			}
+			if err := json.Unmarshal(vsaBackend.storedPayload, &statement); err != nil {
+				t.Fatal(err)
+			}
+			if statement.PredicateType != "https://slsa.dev/verification_summary/v1" {
+				t.Errorf("unexpected predicate type %s", statement.PredicateType)
+			}
+			if len(statement.Subject) != 1 || statement.Subject[0].GetName() != "gcr.io/foo/bar" {
+				t.Errorf("expected the image to be the subject, got %v", statement.Subject)
+			}
+			envelope := sha256.Sum256([]byte(trBackend.storedSignature))
+			want := map[string]interface{}{
+				"verifier":    map[string]interface{}{"id": "https://chains.example.com"},
+				"resourceUri": "gcr.io/foo/bar",
+				"policy":      map[string]interface{}{"uri": "https://policies.example.com/release"},
+				"inputAttestations": []interface{}{map[string]interface{}{
+					"name":   "tekton.dev-v1-TaskRun-uid",
+					"digest": map[string]interface{}{"sha256": hex.EncodeToString(envelope[:])},
+				}},
+				"verificationResult": tt.wantResult,
+				"verifiedLevels":     tt.wantLevels,
+				"slsaVersion":        "1.0",
+			}
+			delete(statement.Predicate, "timeVerified")
+			if d := cmp.Diff(want, statement.Predicate); d != "" {
+				t.Errorf("unexpected predicate (-want +got): %s", d)
+			}
+		})
+	}
+}
+
+func TestSigner_TestAndScanResults(t *testing.T) {
+	trBackend := &mockBackend{backendType: "mock"}
+	testBackend := &mockBackend{backendType: "testmock"}
+	vulnBackend := &mockBackend{backendType: "vulnmock"}
+	cfg := &config.Config{
+		Artifacts: config.ArtifactConfigs{
+			TaskRuns: config.Artifact{
+				Format:         "slsa/v1",
+				StorageBackend: sets.New[string]("mock"),
+				Signer:         "x509",
+			},
+			TestResults: config.Artifact{
+				Format:         "test-result",
+				StorageBackend: sets.New[string]("testmock"),
+				Signer:         "x509",
+			},
+			Vuln: config.Artifact{
+				Format:         "vuln",
+				StorageBackend: sets.New[string]("vulnmock"),
+				Signer:         "x509",
+			},
+		},
+	}
+
+	ctx, _ := rtesting.SetupFakeContext(t)
+	ps := fakepipelineclient.Get(ctx)
+	ctx = config.ToContext(ctx, cfg.DeepCopy())
+
+	os := &ObjectSigner{
+		Backends:          fakeAllBackends([]*mockBackend{trBackend, testBackend, vulnBackend}),
+		SecretPath:        "./signing/x509/testdata/",
+		Pipelineclientset: ps,
+	}
+	obj := objects.NewTaskRunObjectV1(&v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-evidence", UID: "uid"},
+		Status: v1.TaskRunStatus{
+			TaskRunStatusFields: v1.TaskRunStatusFields{
+				Results: []v1.TaskRunResult{{
+					Name:  "IMAGES",
+					Value: *v1.NewStructuredValues("gcr.io/foo/bar@sha256:05f95b26ed10668b7183c1e2da98610e91372fa9f510046d4ce5812addad86b5"),
+				}, {
+					Name:  "TEST_RESULTS",
+					Value: *v1.NewObject(map[string]string{"passed": "TestA,TestB"}),
+				}, {
+					Name:  "SCAN_RESULTS",
+					Value: *v1.NewObject(map[string]string{"scanner": "grype", "high": "2"}),
+				}},
+			},
+		},
+	})
+	tekton.CreateObject(t, ctx, ps, obj)
+
+	if err := os.Sign(ctx, obj); err != nil {
+		t.Fatalf("Signer.Sign() error = %v", err)
+	}
+
+	for _, tc := range []struct {
+		backend       *mockBackend
+		predicateType string
+	}{
+		{testBackend, "https://in-toto.io/attestation/test-result/v0.1"},
+		{vulnBackend, "https://cosign.sigstore.dev/attestation/vuln/v1"},
+	} {
+		if tc.backend.storedPayload == nil {
+			t.Fatalf("expected the %s attestation to be stored", tc.predicateType)
+		}
+		var statement struct {
+			PredicateType string                       `json:"predicateType"`
+			Subject       []*intoto.ResourceDescriptor `json:"subject"`
+		}
+		if err := json.Unmarshal(tc.backend.storedPayload, &statement); err != nil {
+			t.Fatal(err)
+		}
+		if statement.PredicateType != tc.predicateType {
+			t.Errorf("unexpected predicate type %s, want %s", statement.PredicateType, tc.predicateType)
+		}
+		if len(statement.Subject) != 1 || statement.Subject[0].GetName() != "gcr.io/foo/bar" {
+			t.Errorf("expected the subject of the provenance, got %v", statement.Subject)
+		}
+	}
+}
+
+func TestSigner_Links(t *testing.T) {
+	linkBackend := &mockBackend{backendType: "linkmock"}
+	cfg := &config.Config{
+		Artifacts: config.ArtifactConfigs{
+			Links: config.Artifact{
+				Format:         "in-toto-link",
+				StorageBackend: sets.New[string]("linkmock"),
+				Signer:         "x509",
+			},
+		},
+	}
+
+	ctx, _ := rtesting.SetupFakeContext(t)
+	ps := fakepipelineclient.Get(ctx)
+	ctx = config.ToContext(ctx, cfg.DeepCopy())
+
+	os := &ObjectSigner{
+		Backends:          fakeAllBackends([]*mockBackend{linkBackend}),
+		SecretPath:        "./signing/x509/testdata/",
+		Pipelineclientset: ps,
+	}
+	obj := objects.NewTaskRunObjectV1(&v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-links", UID: "uid"},
+		Status: v1.TaskRunStatus{
+			TaskRunStatusFields: v1.TaskRunStatusFields{
+				TaskSpec: &v1.TaskSpec{Steps: []v1.Step{{Name: "build", Command: []string{"go", "build"}}}},
+				Steps: []v1.StepState{{
+					Name:    "build",
+					ImageID: "gcr.io/foo/go@sha256:05f95b26ed10668b7183c1e2da98610e91372fa9f510046d4ce5812addad86b5",
+					Results: []v1.TaskRunStepResult{{
+						Name: "image",
+						Type: v1.ResultsTypeObject,
+						Value: *v1.NewObject(map[string]string{
+							"uri":    "gcr.io/foo/bar",
+							"digest": "sha256:586789aa031fafc7d78a5393cdc772e0b55107ea54bb8bcf3f2cdac6c6da51ee",
+						}),
+					}},
+				}},
+			},
+		},
+	})
+	tekton.CreateObject(t, ctx, ps, obj)
+
+	if err := os.Sign(ctx, obj); err != nil {
+		t.Fatalf("Signer.Sign() error = %v", err)
+	}
+	if linkBackend.storedPayload == nil {
+		t.Fatal("expected the link to be stored")
+	}
+
+	var statement struct {
+		PredicateType string                       `json:"predicateType"`
+		Subject       []*intoto.ResourceDescriptor `json:"subject"`
+		Predicate     map[string]interface{}       `json:"predicate"`
+	}
+	if err := json.Unmarshal(linkBackend.storedPayload, &statement); err != nil {
+		t.Fatal(err)
+	}
+	if statement.PredicateType != "https://in-toto.io/attestation/link/v0.3" {
+		t.Errorf("unexpected predicate type %s", statement.PredicateType)
+	}
+	if len(statement.Subject) != 1 || statement.Subject[0].GetName() != "gcr.io/foo/bar" {
+		t.Errorf("expected the product of the step to be the subject, got %v", statement.Subject)
+	}
+	if statement.Predicate["name"] != "build" {
+		t.Errorf("expected the link of the build step, got %v", statement.Predicate)
+	}
+}
+
 func TestSigningObjects(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -775,7 +1980,7 @@ func TestObjectSigner_Sign_OCIDisabled(t *testing.T) {
 
 func setupMocks(rekor rekorClient) func() {
 	oldRekor := getRekor
-	getRekor = func(_ string) (rekorClient, error) {
+	getRekor = func(_ config.TransparencyConfig) (rekorClient, error) {
 		return rekor, nil
 	}
 	return func() {
@@ -784,30 +1989,31 @@ func setupMocks(rekor rekorClient) func() {
 }
 
 type mockRekor struct {
-	entries [][]byte
+	entries    [][]byte
+	entryTypes []string
 }
 
-func (r *mockRekor) UploadTlog(ctx context.Context, signer signing.Signer, signature, rawPayload []byte, cert, payloadFormat string) (*models.LogEntryAnon, error) {
+func (r *mockRekor) UploadTlog(ctx context.Context, signer signing.Signer, signature, rawPayload []byte, cert, payloadFormat, entryType string) (*protorekor.TransparencyLogEntry, error) {
 	r.entries = append(r.entries, signature)
-	index := int64(len(r.entries) - 1)
-	logID := testLogID
-	integratedTime := int64(1234567890)
-	return &models.LogEntryAnon{
-		LogIndex:       &index,
-		LogID:          &logID,
-		IntegratedTime: &integratedTime,
-		Body:           "dGVzdC1ib2R5", // base64("test-body")
-		Verification: &models.LogEntryAnonVerification{
+	r.entryTypes = append(r.entryTypes, entryType)
+	logID, _ := hex.DecodeString(testLogID)
+	return &protorekor.TransparencyLogEntry{
+		LogIndex:          int64(len(r.entries) - 1),
+		LogId:             &protocommon.LogId{KeyId: logID},
+		IntegratedTime:    1234567890,
+		CanonicalizedBody: []byte("test-body"),
+		InclusionPromise: &protorekor.InclusionPromise{
 			SignedEntryTimestamp: []byte("signed-entry-timestamp"),
 		},
 	}, nil
 }
 
 type mockBackend struct {
-	storedPayload []byte
-	storedOpts    config.StorageOpts
-	shouldErr     bool
-	backendType   string
+	storedPayload   []byte
+	storedSignature string
+	storedOpts      config.StorageOpts
+	shouldErr       bool
+	backendType     string
 }
 
 // StorePayload implements the Payloader interface.
@@ -816,6 +2022,7 @@ func (b *mockBackend) StorePayload(ctx context.Context, _ objects.TektonObject,
 		return errors.New("mock error storing")
 	}
 	b.storedPayload = rawPayload
+	b.storedSignature = signature
 	b.storedOpts = opts
 	return nil
 }
@@ -836,16 +2043,13 @@ type mockRekorNilVerification struct {
 	entries [][]byte
 }
 
-func (r *mockRekorNilVerification) UploadTlog(_ context.Context, _ signing.Signer, signature, _ []byte, _, _ string) (*models.LogEntryAnon, error) {
+func (r *mockRekorNilVerification) UploadTlog(_ context.Context, _ signing.Signer, signature, _ []byte, _, _, _ string) (*protorekor.TransparencyLogEntry, error) {
 	r.entries = append(r.entries, signature)
-	index := int64(len(r.entries) - 1)
-	logID := testLogID
-	integratedTime := int64(1234567890)
-	return &models.LogEntryAnon{
-		LogIndex:       &index,
-		LogID:          &logID,
-		IntegratedTime: &integratedTime,
-		Body:           "dGVzdC1ib2R5",
-		Verification:   nil,
+	logID, _ := hex.DecodeString(testLogID)
+	return &protorekor.TransparencyLogEntry{
+		LogIndex:          int64(len(r.entries) - 1),
+		LogId:             &protocommon.LogId{KeyId: logID},
+		IntegratedTime:    1234567890,
+		CanonicalizedBody: []byte("test-body"),
 	}, nil
 }
@@ -0,0 +1,272 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package spire verifies the TaskRun results signed by Tekton Pipelines with
+// the SPIRE SVID of the TaskRun, when non-falsifiability is enforced with SPIRE.
+package spire
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/tektoncd/chains/pkg/chains/objects"
+	"github.com/tektoncd/chains/pkg/config"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+const (
+	// KeySVID is the result holding the PEM encoded SVID of the TaskRun.
+	KeySVID = "SVID"
+	// KeySignatureSuffix is the suffix of the results holding the signature of
+	// the result of the same name.
+	KeySignatureSuffix = ".sig"
+	// KeyResultManifest is the result holding the comma separated names of the
+	// signed results.
+	KeyResultManifest = "RESULT_MANIFEST"
+
+	// ProvenanceKey is the key of the verification outcome in the provenance.
+	ProvenanceKey = "spire-results-verification"
+)
+
+// Verification is the outcome of the verification of the results of a run.
+type Verification struct {
+	Verified bool   `json:"verified"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+type verificationKey struct{}
+
+// WithVerification returns a context holding the verification outcome of the
+// results of the run being signed.
+func WithVerification(ctx context.Context, v *Verification) context.Context {
+	return context.WithValue(ctx, verificationKey{}, v)
+}
+
+// VerificationFromContext returns the verification outcome in the context, or
+// nil when the results were not verified.
+func VerificationFromContext(ctx context.Context) *Verification {
+	v, _ := ctx.Value(verificationKey{}).(*Verification)
+	return v
+}
+
+// Supported returns true for the runs whose results are signed by the Tekton
+// entrypoint: TaskRuns and PipelineRuns. The results of CustomRuns are written
+// by their own controller, so there is nothing to verify.
+func Supported(obj objects.TektonObject) bool {
+	switch obj.(type) {
+	case *objects.TaskRunObjectV1, *objects.PipelineRunObjectV1:
+		return true
+	}
+	return false
+}
+
+// Verify verifies the results of a TaskRun, or of the child TaskRuns of a
+// PipelineRun, against the trust bundle in the configuration.
+func Verify(obj objects.TektonObject, cfg config.SpireConfig) *Verification {
+	roots, err := loadTrustBundle(cfg.TrustBundlePath)
+	if err != nil {
+		return &Verification{Reason: err.Error()}
+	}
+
+	switch o := obj.(type) {
+	case *objects.TaskRunObjectV1:
+		err = VerifyTaskRun(o.TaskRun, roots, cfg.TrustDomain)
+	case *objects.PipelineRunObjectV1:
+		for _, tr := range o.GetTaskRuns() {
+			if err = VerifyTaskRun(tr, roots, cfg.TrustDomain); err != nil {
+				err = errors.Wrapf(err, "taskrun %s", tr.Name)
+				break
+			}
+		}
+	default:
+		err = fmt.Errorf("unsupported object %T", obj.GetObject())
+	}
+	if err != nil {
+		return &Verification{Reason: err.Error()}
+	}
+	return &Verification{Verified: true}
+}
+
+// VerifyTaskRun checks that the results of the TaskRun are all signed by an
+// SVID issued to the TaskRun by the trusted SPIRE server.
+func VerifyTaskRun(tr *v1.TaskRun, roots *x509.CertPool, trustDomain string) error {
+	results := map[string]v1.TaskRunResult{}
+	for _, r := range tr.Status.Results {
+		results[r.Name] = r
+	}
+
+	svid, ok := results[KeySVID]
+	if !ok {
+		return errors.New("no SVID found in the results")
+	}
+	cert, err := verifySVID(svid.Value.StringVal, roots)
+	if err != nil {
+		return err
+	}
+	if err := verifyIdentity(cert, tr, trustDomain); err != nil {
+		return err
+	}
+
+	manifest, ok := results[KeyResultManifest]
+	if !ok {
+		return errors.New("no result manifest found in the results")
+	}
+	if err := verifyResult(cert.PublicKey, KeyResultManifest, results); err != nil {
+		return err
+	}
+	signed := map[string]bool{}
+	for _, name := range strings.Split(manifest.Value.StringVal, ",") {
+		if name == "" {
+			continue
+		}
+		if _, ok := results[name]; !ok {
+			return fmt.Errorf("result %s in the manifest is missing", name)
+		}
+		if err := verifyResult(cert.PublicKey, name, results); err != nil {
+			return err
+		}
+		signed[name] = true
+	}
+
+	// A result missing from the manifest was not written by the TaskRun steps.
+	for name := range results {
+		if name == KeySVID || name == KeyResultManifest || strings.HasSuffix(name, KeySignatureSuffix) {
+			continue
+		}
+		if !signed[name] {
+			return fmt.Errorf("result %s is not in the manifest", name)
+		}
+	}
+	return nil
+}
+
+func loadTrustBundle(path string) (*x509.CertPool, error) {
+	if path == "" {
+		return nil, errors.New("no SPIRE trust bundle configured")
+	}
+	bundle, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading SPIRE trust bundle")
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(bundle) {
+		return nil, fmt.Errorf("no certificates found in SPIRE trust bundle %s", path)
+	}
+	return roots, nil
+}
+
+func verifySVID(svid string, roots *x509.CertPool) (*x509.Certificate, error) {
+	block, _ := pem.Decode([]byte(svid))
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, errors.New("no certificate found in the SVID")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing SVID")
+	}
+	if _, err := cert.Verify(x509.VerifyOptions{
+		Roots:     roots,
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}); err != nil {
+		return nil, errors.Wrap(err, "verifying SVID")
+	}
+	return cert, nil
+}
+
+// verifyIdentity checks that the SVID was issued to the TaskRun, so that the
+// signed results of a TaskRun cannot be replayed in another one.
+func verifyIdentity(cert *x509.Certificate, tr *v1.TaskRun, trustDomain string) error {
+	if len(cert.URIs) != 1 {
+		return fmt.Errorf("expected one SPIFFE ID in the SVID, got %d", len(cert.URIs))
+	}
+	id := cert.URIs[0]
+	want := fmt.Sprintf("/ns/%s/taskrun/%s", tr.Namespace, tr.Name)
+	if id.Scheme != "spiffe" || id.Path != want || (trustDomain != "" && id.Host != trustDomain) {
+		return fmt.Errorf("SVID issued to %s, not to taskrun %s/%s", id, tr.Namespace, tr.Name)
+	}
+	return nil
+}
+
+func verifyResult(pub crypto.PublicKey, name string, results map[string]v1.TaskRunResult) error {
+	sigResult, ok := results[name+KeySignatureSuffix]
+	if !ok {
+		return fmt.Errorf("no signature found for result %s", name)
+	}
+	sig, err := base64.StdEncoding.DecodeString(sigResult.Value.StringVal)
+	if err != nil {
+		return errors.Wrapf(err, "decoding signature of result %s", name)
+	}
+	value, err := resultValue(results[name])
+	if err != nil {
+		return err
+	}
+	if err := verifySignature(pub, sig, value); err != nil {
+		return errors.Wrapf(err, "verifying signature of result %s", name)
+	}
+	return nil
+}
+
+// resultValue returns the value of the result as it was signed by the
+// entrypoint of the TaskRun steps: arrays are joined with commas, and objects
+// are flattened to their sorted keys and values joined with commas.
+func resultValue(r v1.TaskRunResult) ([]byte, error) {
+	switch r.Value.Type {
+	case v1.ParamTypeString:
+		return []byte(r.Value.StringVal), nil
+	case v1.ParamTypeArray:
+		return []byte(strings.Join(r.Value.ArrayVal, ",")), nil
+	case v1.ParamTypeObject:
+		keys := make([]string, 0, len(r.Value.ObjectVal))
+		for k := range r.Value.ObjectVal {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		values := make([]string, 0, 2*len(keys))
+		for _, k := range keys {
+			values = append(values, k, r.Value.ObjectVal[k])
+		}
+		return []byte(strings.Join(values, ",")), nil
+	}
+	return nil, fmt.Errorf("invalid type %q of result %s", r.Value.Type, r.Name)
+}
+
+func verifySignature(pub crypto.PublicKey, sig, value []byte) error {
+	digest := sha256.Sum256(value)
+	switch key := pub.(type) {
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(key, digest[:], sig) {
+			return errors.New("invalid signature")
+		}
+		return nil
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], sig)
+	case ed25519.PublicKey:
+		if !ed25519.Verify(key, value, sig) {
+			return errors.New("invalid signature")
+		}
+		return nil
+	}
+	return fmt.Errorf("unsupported SVID key type %T", pub)
+}
@@ -0,0 +1,356 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spire
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tektoncd/chains/pkg/chains/objects"
+	"github.com/tektoncd/chains/pkg/config"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const testTrustDomain = "example.org"
+
+// testCA issues SVIDs and signs TaskRun results like Tekton Pipelines does.
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "spire"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testCA{cert: cert, key: key}
+}
+
+func (ca *testCA) bundle(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "bundle.crt")
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func (ca *testCA) pool() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+	return pool
+}
+
+// sign adds the SVID, result signatures and manifest to the TaskRun results.
+func (ca *testCA) sign(t *testing.T, tr *v1.TaskRun, spiffeID string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, err := url.Parse(spiffeID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		URIs:         []*url.URL{id},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}, ca.cert, key.Public(), ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signResult := func(name string, value []byte) v1.TaskRunResult {
+		digest := sha256.Sum256(value)
+		sig, err := key.Sign(rand.Reader, digest[:], crypto.SHA256)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v1.TaskRunResult{Name: name + KeySignatureSuffix, Value: *v1.NewStructuredValues(base64.StdEncoding.EncodeToString(sig))}
+	}
+
+	var names []string
+	var signed []v1.TaskRunResult
+	for _, r := range tr.Status.Results {
+		value, ok := entrypointValues[r.Name]
+		if !ok {
+			t.Fatalf("no value signed by the entrypoint for result %s", r.Name)
+		}
+		names = append(names, r.Name)
+		signed = append(signed, signResult(r.Name, []byte(value)))
+	}
+	manifest := strings.Join(names, ",")
+	tr.Status.Results = append(tr.Status.Results, signed...)
+	tr.Status.Results = append(tr.Status.Results,
+		v1.TaskRunResult{Name: KeySVID, Value: *v1.NewStructuredValues(string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})))},
+		v1.TaskRunResult{Name: KeyResultManifest, Value: *v1.NewStructuredValues(manifest)},
+		signResult(KeyResultManifest, []byte(manifest)),
+	)
+}
+
+// entrypointValues are the values of the results of testTaskRun as the Tekton
+// entrypoint signs them.
+var entrypointValues = map[string]string{
+	"IMAGE_URL":            "registry.example.com/app",
+	"IMAGE_DIGEST":         "sha256:05f95b26ed10668b7183c1e2da98610e91372fa9f510046d4ce5812addad86b5",
+	"IMAGES":               "a,b",
+	"img_ARTIFACT_OUTPUTS": "digest,sha256:05f95b26ed10668b7183c1e2da98610e91372fa9f510046d4ce5812addad86b5,uri,registry.example.com/app",
+}
+
+func testTaskRun(name string) *v1.TaskRun {
+	return &v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Status: v1.TaskRunStatus{
+			TaskRunStatusFields: v1.TaskRunStatusFields{
+				Results: []v1.TaskRunResult{{
+					Name:  "IMAGE_URL",
+					Value: *v1.NewStructuredValues("registry.example.com/app"),
+				}, {
+					Name:  "IMAGE_DIGEST",
+					Value: *v1.NewStructuredValues("sha256:05f95b26ed10668b7183c1e2da98610e91372fa9f510046d4ce5812addad86b5"),
+				}, {
+					Name:  "IMAGES",
+					Value: *v1.NewStructuredValues("a", "b"),
+				}, {
+					Name:  "img_ARTIFACT_OUTPUTS",
+					Value: *v1.NewObject(map[string]string{"uri": "registry.example.com/app", "digest": "sha256:05f95b26ed10668b7183c1e2da98610e91372fa9f510046d4ce5812addad86b5"}),
+				}},
+			},
+		},
+	}
+}
+
+func TestVerifyTaskRun(t *testing.T) {
+	ca := newTestCA(t)
+	otherCA := newTestCA(t)
+	spiffeID := "spiffe://" + testTrustDomain + "/ns/default/taskrun/build"
+
+	tests := []struct {
+		name        string
+		tr          func(t *testing.T) *v1.TaskRun
+		trustDomain string
+		wantErr     string
+	}{{
+		name: "verified",
+		tr: func(t *testing.T) *v1.TaskRun {
+			tr := testTaskRun("build")
+			ca.sign(t, tr, spiffeID)
+			return tr
+		},
+		trustDomain: testTrustDomain,
+	}, {
+		name: "not signed",
+		tr: func(t *testing.T) *v1.TaskRun {
+			return testTaskRun("build")
+		},
+		wantErr: "no SVID",
+	}, {
+		name: "tampered result",
+		tr: func(t *testing.T) *v1.TaskRun {
+			tr := testTaskRun("build")
+			ca.sign(t, tr, spiffeID)
+			tr.Status.Results[1].Value = *v1.NewStructuredValues("sha256:0000000000000000000000000000000000000000000000000000000000000000")
+			return tr
+		},
+		wantErr: "verifying signature of result IMAGE_DIGEST",
+	}, {
+		name: "tampered array result",
+		tr: func(t *testing.T) *v1.TaskRun {
+			tr := testTaskRun("build")
+			ca.sign(t, tr, spiffeID)
+			tr.Status.Results[2].Value = *v1.NewStructuredValues("a", "evil")
+			return tr
+		},
+		wantErr: "verifying signature of result IMAGES",
+	}, {
+		name: "tampered object result",
+		tr: func(t *testing.T) *v1.TaskRun {
+			tr := testTaskRun("build")
+			ca.sign(t, tr, spiffeID)
+			tr.Status.Results[3].Value = *v1.NewObject(map[string]string{"uri": "registry.example.com/evil", "digest": "sha256:05f95b26ed10668b7183c1e2da98610e91372fa9f510046d4ce5812addad86b5"})
+			return tr
+		},
+		wantErr: "verifying signature of result img_ARTIFACT_OUTPUTS",
+	}, {
+		name: "result not in the manifest",
+		tr: func(t *testing.T) *v1.TaskRun {
+			tr := testTaskRun("build")
+			ca.sign(t, tr, spiffeID)
+			tr.Status.Results = append(tr.Status.Results, v1.TaskRunResult{Name: "ARTIFACT_URI", Value: *v1.NewStructuredValues("evil")})
+			return tr
+		},
+		wantErr: "result ARTIFACT_URI is not in the manifest",
+	}, {
+		name: "untrusted SVID",
+		tr: func(t *testing.T) *v1.TaskRun {
+			tr := testTaskRun("build")
+			otherCA.sign(t, tr, spiffeID)
+			return tr
+		},
+		wantErr: "verifying SVID",
+	}, {
+		name: "SVID of another taskrun",
+		tr: func(t *testing.T) *v1.TaskRun {
+			tr := testTaskRun("build")
+			ca.sign(t, tr, "spiffe://"+testTrustDomain+"/ns/default/taskrun/other")
+			return tr
+		},
+		wantErr: "not to taskrun default/build",
+	}, {
+		name: "SVID of another trust domain",
+		tr: func(t *testing.T) *v1.TaskRun {
+			tr := testTaskRun("build")
+			ca.sign(t, tr, "spiffe://other.org/ns/default/taskrun/build")
+			return tr
+		},
+		trustDomain: testTrustDomain,
+		wantErr:     "not to taskrun default/build",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyTaskRun(tt.tr(t), ca.pool(), tt.trustDomain)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("VerifyTaskRun() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("VerifyTaskRun() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestResultValue(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		value v1.ResultValue
+		want  string
+	}{{
+		name:  "string",
+		value: *v1.NewStructuredValues("registry.example.com/app"),
+		want:  "registry.example.com/app",
+	}, {
+		name:  "array",
+		value: *v1.NewStructuredValues("a", "b", "c"),
+		want:  "a,b,c",
+	}, {
+		name:  "object",
+		value: *v1.NewObject(map[string]string{"uri": "registry.example.com/app", "digest": "sha256:abc", "b": "2"}),
+		want:  "b,2,digest,sha256:abc,uri,registry.example.com/app",
+	}, {
+		name:  "empty array",
+		value: v1.ResultValue{Type: v1.ParamTypeArray},
+		want:  "",
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resultValue(v1.TaskRunResult{Name: "RESULT", Value: tt.value})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("resultValue() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	ca := newTestCA(t)
+	cfg := config.SpireConfig{TrustDomain: testTrustDomain, TrustBundlePath: ca.bundle(t)}
+
+	signed := testTaskRun("build")
+	ca.sign(t, signed, "spiffe://"+testTrustDomain+"/ns/default/taskrun/build")
+	unsigned := testTaskRun("test")
+
+	if v := Verify(objects.NewTaskRunObjectV1(signed), cfg); !v.Verified {
+		t.Errorf("expected the signed taskrun to be verified, got %q", v.Reason)
+	}
+	if v := Verify(objects.NewTaskRunObjectV1(unsigned), cfg); v.Verified {
+		t.Error("expected the unsigned taskrun not to be verified")
+	}
+	if v := Verify(objects.NewTaskRunObjectV1(signed), config.SpireConfig{}); v.Verified || !strings.Contains(v.Reason, "no SPIRE trust bundle") {
+		t.Errorf("expected a missing trust bundle to fail the verification, got %+v", v)
+	}
+
+	pro := objects.NewPipelineRunObjectV1(&v1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "pipeline", Namespace: "default"}})
+	pro.AppendTaskRun(signed)
+	if v := Verify(pro, cfg); !v.Verified {
+		t.Errorf("expected the pipelinerun to be verified, got %q", v.Reason)
+	}
+	pro.AppendTaskRun(unsigned)
+	if v := Verify(pro, cfg); v.Verified || !strings.Contains(v.Reason, "taskrun test") {
+		t.Errorf("expected the pipelinerun with an unsigned taskrun not to be verified, got %+v", v)
+	}
+}
+
+func TestVerificationFromContext(t *testing.T) {
+	ctx := context.Background()
+	if v := VerificationFromContext(ctx); v != nil {
+		t.Errorf("expected no verification, got %+v", v)
+	}
+	want := &Verification{Reason: "no SVID found in the results"}
+	if got := VerificationFromContext(WithVerification(ctx, want)); got != want {
+		t.Errorf("VerificationFromContext() = %+v, want %+v", got, want)
+	}
+}
+
+func TestSupported(t *testing.T) {
+	if !Supported(objects.NewTaskRunObjectV1(&v1.TaskRun{})) || !Supported(objects.NewPipelineRunObjectV1(&v1.PipelineRun{})) {
+		t.Error("expected the results of TaskRuns and PipelineRuns to be verified")
+	}
+	if Supported(objects.NewCustomRunObjectV1beta1(&v1beta1.CustomRun{})) {
+		t.Error("expected the results of CustomRuns not to be verified")
+	}
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chains
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/tektoncd/chains/pkg/chains/annotations"
+	"github.com/tektoncd/chains/pkg/chains/objects"
+	"github.com/tektoncd/chains/pkg/metrics"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/controller"
+)
+
+const (
+	// EventReasonSigned is the reason of the event emitted when a run is signed.
+	EventReasonSigned = "Signed"
+	// EventReasonRetryScheduled is the reason of the event emitted when signing
+	// a run failed and is retried.
+	EventReasonRetryScheduled = "RetryScheduled"
+	// EventReasonFailed is the reason of the event emitted when signing a run
+	// failed and is not retried anymore.
+	EventReasonFailed = "SigningFailed"
+
+	// Outcomes of the artifacts in the status annotation.
+	artifactSigned  = "signed"
+	artifactSkipped = "skipped"
+	artifactFailed  = "failed"
+
+	// Outcomes of the transparency log uploads in the status annotation.
+	tlogUploaded = "uploaded"
+	tlogPending  = "pending"
+	tlogFailed   = "failed"
+
+	// backendStored is the outcome of a storage backend that stored the artifact.
+	backendStored = "stored"
+)
+
+// SigningStatus is the summary of the outcome of signing a run, stored as JSON
+// in the status annotation.
+type SigningStatus struct {
+	Artifacts []*ArtifactStatus `json:"artifacts"`
+}
+
+// ArtifactStatus is the outcome of signing an artifact of a run.
+type ArtifactStatus struct {
+	// Type is the type of the artifact, e.g. tekton or oci.
+	Type string `json:"type"`
+	// Key identifies the artifact among the ones of the same type, if there are several.
+	Key    string `json:"key,omitempty"`
+	Format string `json:"format,omitempty"`
+	// Outcome is either signed, skipped or failed.
+	Outcome string `json:"outcome"`
+	// Reason is the error type of a failure, as in the metrics, or why the artifact was skipped.
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+	// Tlog is the outcome of the transparency log upload, if any: uploaded, pending or failed.
+	Tlog string `json:"tlog,omitempty"`
+	// Storage holds the outcome of each storage backend: stored, or the error.
+	Storage map[string]string `json:"storage,omitempty"`
+
+	// errorTypes holds the types of all the errors of the artifact, Reason only
+	// holding the last one.
+	errorTypes []metrics.MetricErrorType
+}
+
+// add records the outcome of an artifact and returns it.
+func (s *SigningStatus) add(artifactType, key string) *ArtifactStatus {
+	a := &ArtifactStatus{Type: artifactType, Key: key, Outcome: artifactSigned}
+	s.Artifacts = append(s.Artifacts, a)
+	return a
+}
+
+// skip records an artifact that was not signed.
+func (s *SigningStatus) skip(artifactType, reason, message string) {
+	a := s.add(artifactType, "")
+	a.Outcome, a.Reason, a.Message = artifactSkipped, reason, message
+}
+
+// fail records the failure of the artifact.
+func (a *ArtifactStatus) fail(errType metrics.MetricErrorType, err error) {
+	a.Outcome, a.Reason, a.Message = artifactFailed, string(errType), err.Error()
+	a.errorTypes = append(a.errorTypes, errType)
+}
+
+// setStorage records the outcome of a storage backend.
+func (a *ArtifactStatus) setStorage(backend, outcome string) {
+	if a.Storage == nil {
+		a.Storage = map[string]string{}
+	}
+	a.Storage[backend] = outcome
+}
+
+// name returns the name of the artifact in the events.
+func (a *ArtifactStatus) name() string {
+	if a.Key == "" {
+		return a.Type
+	}
+	return fmt.Sprintf("%s %s", a.Type, a.Key)
+}
+
+// summary returns the number of artifacts signed and skipped, for the events.
+func (s *SigningStatus) summary() string {
+	signed, skipped := 0, 0
+	for _, a := range s.Artifacts {
+		switch a.Outcome {
+		case artifactSigned:
+			signed++
+		case artifactSkipped:
+			skipped++
+		}
+	}
+	return fmt.Sprintf("%d artifacts, skipped %d", signed, skipped)
+}
+
+// errorTypes returns the types of the errors of the artifacts.
+func (s *SigningStatus) errorTypes() sets.Set[string] {
+	errorTypes := sets.New[string]()
+	for _, a := range s.Artifacts {
+		for _, errType := range a.errorTypes {
+			errorTypes.Insert(string(errType))
+		}
+	}
+	return errorTypes
+}
+
+// annotate adds the status annotation to the annotations, when it is enabled.
+func (s *SigningStatus) annotate(enabled bool, runAnnotations map[string]string) error {
+	if !enabled {
+		return nil
+	}
+	status, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	runAnnotations[annotations.StatusAnnotation] = string(status)
+	return nil
+}
+
+// eventReason returns the reason of the events reporting an error, mirroring
+// its metric error type, e.g. StorageError for storage.
+func eventReason(errType metrics.MetricErrorType) string {
+	parts := strings.Split(string(errType), "_")
+	for i, part := range parts {
+		if part != "" {
+			parts[i] = strings.ToUpper(part[:1]) + part[1:]
+		}
+	}
+	return strings.Join(parts, "") + "Error"
+}
+
+// emitEvent emits an event on the run, when the context holds an event recorder.
+func emitEvent(ctx context.Context, obj objects.TektonObject, eventType, reason, messageFmt string, args ...interface{}) {
+	recorder := controller.GetEventRecorder(ctx)
+	if recorder == nil {
+		return
+	}
+	// The event refers to the Tekton object, not to its wrapper.
+	run, ok := obj.GetObject().(runtime.Object)
+	if !ok {
+		return
+	}
+	recorder.Eventf(run, eventType, reason, messageFmt, args...)
+}
+
+// emitErrorEvent emits a warning event for an error, with the reason mirroring
+// its metric error type.
+func emitErrorEvent(ctx context.Context, obj objects.TektonObject, errType metrics.MetricErrorType, messageFmt string, args ...interface{}) {
+	emitEvent(ctx, obj, corev1.EventTypeWarning, eventReason(errType), messageFmt, args...)
+}
+
+// reportError records the failure of the artifact in the status, and emits an
+// event for it.
+func reportError(ctx context.Context, obj objects.TektonObject, artifact *ArtifactStatus, errType metrics.MetricErrorType, err error) {
+	artifact.fail(errType, err)
+	emitErrorEvent(ctx, obj, errType, "Signing %s failed: %v", artifact.name(), err)
+}
+
+// reportStorageError records the failure of a storage backend in the status,
+// and emits an event for it.
+func reportStorageError(ctx context.Context, obj objects.TektonObject, artifact *ArtifactStatus, backend string, err error) {
+	artifact.setStorage(backend, err.Error())
+	artifact.fail(metrics.StorageError, err)
+	emitErrorEvent(ctx, obj, metrics.StorageError, "Storing %s in %s failed: %v", artifact.name(), backend, err)
+}
@@ -24,6 +24,7 @@ import (
 	"strings"
 
 	"github.com/fsnotify/fsnotify"
+	protorekor "github.com/sigstore/protobuf-specs/gen/pb-go/rekor/v1"
 	"github.com/tektoncd/chains/pkg/chains/objects"
 	"github.com/tektoncd/chains/pkg/config"
 	"gocloud.dev/docstore"
@@ -31,6 +32,7 @@ import (
 	_ "gocloud.dev/docstore/gcpfirestore"
 	"gocloud.dev/docstore/mongodocstore"
 	_ "gocloud.dev/docstore/mongodocstore"
+	"google.golang.org/protobuf/encoding/protojson"
 	"knative.dev/pkg/logging"
 )
 
@@ -55,6 +57,8 @@ type SignedDocument struct {
 	Chain     string
 	Object    interface{}
 	Name      string
+	// TlogEntry is the transparency log entry of the signature in the JSON encoded Sigstore bundle format, if it was uploaded.
+	TlogEntry []byte
 }
 
 // NewStorageBackend returns a new Tekton StorageBackend that stores signatures on a TaskRun
@@ -220,6 +224,13 @@ func (b *Backend) StorePayload(ctx context.Context, _ objects.TektonObject, rawP
 		Cert:      opts.Cert,
 		Chain:     opts.Chain,
 	}
+	if opts.TlogEntry != nil {
+		tlogEntry, err := protojson.Marshal(opts.TlogEntry)
+		if err != nil {
+			return err
+		}
+		entry.TlogEntry = tlogEntry
+	}
 
 	if err := b.coll.Put(ctx, &entry); err != nil {
 		return err
@@ -265,6 +276,27 @@ func (b *Backend) RetrievePayloads(ctx context.Context, _ objects.TektonObject,
 	return m, nil
 }
 
+// RetrieveTlogEntries retrieves the transparency log entries stored with the documents, keyed like RetrievePayloads.
+func (b *Backend) RetrieveTlogEntries(ctx context.Context, _ objects.TektonObject, opts config.StorageOpts) (map[string]*protorekor.TransparencyLogEntry, error) {
+	documents, err := b.retrieveDocuments(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	m := make(map[string]*protorekor.TransparencyLogEntry)
+	for _, d := range documents {
+		if len(d.TlogEntry) == 0 {
+			continue
+		}
+		entry := &protorekor.TransparencyLogEntry{}
+		if err := protojson.Unmarshal(d.TlogEntry, entry); err != nil {
+			return nil, err
+		}
+		m[d.Name] = entry
+	}
+	return m, nil
+}
+
 func (b *Backend) retrieveDocuments(ctx context.Context, opts config.StorageOpts) ([]SignedDocument, error) {
 	d := SignedDocument{Name: opts.ShortKey}
 	if err := b.coll.Get(ctx, &d); err != nil {
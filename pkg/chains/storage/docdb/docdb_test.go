@@ -21,11 +21,14 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
+	protorekor "github.com/sigstore/protobuf-specs/gen/pb-go/rekor/v1"
 	"github.com/tektoncd/chains/pkg/chains/objects"
 	"github.com/tektoncd/chains/pkg/config"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"gocloud.dev/docstore"
 	_ "gocloud.dev/docstore/memdocstore"
+	"google.golang.org/protobuf/testing/protocmp"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/logging"
 	logtesting "knative.dev/pkg/logging/testing"
@@ -65,6 +68,7 @@ func TestBackend_StorePayload(t *testing.T) {
 		rawPayload interface{}
 		signature  string
 		key        string
+		tlogEntry  *protorekor.TransparencyLogEntry
 	}
 	tests := []struct {
 		name    string
@@ -87,6 +91,18 @@ func TestBackend_StorePayload(t *testing.T) {
 				key:        "moo",
 			},
 		},
+		{
+			name: "no error - transparency log entry",
+			args: args{
+				rawPayload: &v1.TaskRun{ObjectMeta: metav1.ObjectMeta{UID: "foo"}},
+				signature:  "signature",
+				key:        "tlog",
+				tlogEntry: &protorekor.TransparencyLogEntry{
+					CanonicalizedBody: []byte("body"),
+					InclusionPromise:  &protorekor.InclusionPromise{SignedEntryTimestamp: []byte("set")},
+				},
+			},
+		},
 	}
 
 	memURL := "mem://chains/name"
@@ -109,7 +125,7 @@ func TestBackend_StorePayload(t *testing.T) {
 			}
 
 			// Store the document.
-			opts := config.StorageOpts{ShortKey: tt.args.key}
+			opts := config.StorageOpts{ShortKey: tt.args.key, TlogEntry: tt.args.tlogEntry}
 			tektonObj, err := objects.NewTektonObject(tt.args.rawPayload)
 			if err != nil {
 				t.Fatal(err)
@@ -145,6 +161,15 @@ func TestBackend_StorePayload(t *testing.T) {
 			if payloads[obj.Name] != string(sb) {
 				t.Errorf("wrong payload, expected %s, got %s", tt.args.rawPayload, payloads[obj.Name])
 			}
+
+			// Check the transparency log entry.
+			entries, err := b.RetrieveTlogEntries(ctx, tektonObj, opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.args.tlogEntry, entries[obj.Name], protocmp.Transform()); diff != "" {
+				t.Errorf("wrong tlog entry (-want +got): %s", diff)
+			}
 		})
 	}
 }
@@ -15,6 +15,7 @@ package gcs
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 
@@ -22,11 +23,13 @@ import (
 	"knative.dev/pkg/logging"
 
 	"github.com/in-toto/in-toto-golang/in_toto"
+	protorekor "github.com/sigstore/protobuf-specs/gen/pb-go/rekor/v1"
 	"github.com/tektoncd/chains/pkg/chains/objects"
 	"github.com/tektoncd/chains/pkg/chains/signing"
 	"github.com/tektoncd/chains/pkg/chains/storage/api"
 	"github.com/tektoncd/chains/pkg/config"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"google.golang.org/protobuf/encoding/protojson"
 )
 
 const (
@@ -34,9 +37,11 @@ const (
 	// taskrun-$namespace-$name/$key.<type>
 	SignatureNameFormatTaskRun = "taskrun-%s-%s/%s.signature"
 	PayloadNameFormatTaskRun   = "taskrun-%s-%s/%s.payload"
+	TlogEntryNameFormatTaskRun = "taskrun-%s-%s/%s.tlogentry"
 	// pipelinerun-$namespace-$name/$key.<type>
 	SignatureNameFormatPipelineRun = "pipelinerun-%s-%s/%s.signature"
 	PayloadNameFormatPipelineRun   = "pipelinerun-%s-%s/%s.payload"
+	TlogEntryNameFormatPipelineRun = "pipelinerun-%s-%s/%s.tlogentry"
 )
 
 // Backend is a storage backend that stores signed payloads in the TaskRun metadata as an annotation.
@@ -81,6 +86,7 @@ func (b *Backend) StorePayload(ctx context.Context, obj objects.TektonObject, ra
 				Signature: []byte(signature),
 				Cert:      []byte(opts.Cert),
 				Chain:     []byte(opts.Chain),
+				TlogEntry: opts.TlogEntry,
 			},
 		}); err != nil {
 			logger.Errorf("error writing to GCS: %w", err)
@@ -101,6 +107,7 @@ func (b *Backend) StorePayload(ctx context.Context, obj objects.TektonObject, ra
 				Signature: []byte(signature),
 				Cert:      []byte(opts.Cert),
 				Chain:     []byte(opts.Chain),
+				TlogEntry: opts.TlogEntry,
 			},
 		}); err != nil {
 			logger.Errorf("error writing to GCS: %w", err)
@@ -186,6 +193,37 @@ func (b *Backend) RetrievePayloads(ctx context.Context, obj objects.TektonObject
 	return m, nil
 }
 
+// RetrieveTlogEntries retrieves the transparency log entry stored next to the payload, keyed like RetrievePayloads.
+func (b *Backend) RetrieveTlogEntries(ctx context.Context, obj objects.TektonObject, opts config.StorageOpts) (map[string]*protorekor.TransparencyLogEntry, error) {
+	var object, payloadObject string
+
+	switch t := obj.GetObject().(type) {
+	case *v1.TaskRun:
+		object = fmt.Sprintf(TlogEntryNameFormatTaskRun, t.Namespace, t.Name, opts.ShortKey)
+		payloadObject = taskRunPayloadNameV1(t, opts)
+	case *v1.PipelineRun:
+		object = fmt.Sprintf(TlogEntryNameFormatPipelineRun, t.Namespace, t.Name, opts.ShortKey)
+		payloadObject = pipelineRunPayloadNameV1(t, opts)
+	default:
+		return nil, fmt.Errorf("unsupported TektonObject type: %T", t)
+	}
+
+	m := make(map[string]*protorekor.TransparencyLogEntry)
+	raw, err := b.retrieveObject(ctx, object)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		// The payload was not uploaded to a transparency log.
+		return m, nil
+	} else if err != nil {
+		return nil, err
+	}
+	entry := &protorekor.TransparencyLogEntry{}
+	if err := protojson.Unmarshal([]byte(raw), entry); err != nil {
+		return nil, err
+	}
+	m[payloadObject] = entry
+	return m, nil
+}
+
 func (b *Backend) retrieveObject(ctx context.Context, object string) (string, error) {
 	reader, err := b.reader.GetReader(ctx, object)
 	if err != nil {
@@ -243,7 +281,7 @@ func (s *TaskRunStorer) Store(ctx context.Context, req *api.StoreRequest[*v1.Tas
 	prefix := fmt.Sprintf("%s-%s-%s/%s", "taskrun", tr.GetNamespace(), tr.GetName(), key)
 
 	return store(ctx, s.writer, prefix,
-		req.Bundle.Signature, req.Bundle.Content, req.Bundle.Cert, req.Bundle.Chain)
+		req.Bundle.Signature, req.Bundle.Content, req.Bundle.Cert, req.Bundle.Chain, req.Bundle.TlogEntry)
 }
 
 // PipelineRunStorer stores PipelineRuns in GCS.
@@ -267,11 +305,11 @@ func (s *PipelineRunStorer) Store(ctx context.Context, req *api.StoreRequest[*v1
 	prefix := fmt.Sprintf("%s-%s-%s/%s", "pipelinerun", pr.GetNamespace(), pr.GetName(), key)
 
 	return store(ctx, s.writer, prefix,
-		req.Bundle.Signature, req.Bundle.Content, req.Bundle.Cert, req.Bundle.Chain)
+		req.Bundle.Signature, req.Bundle.Content, req.Bundle.Cert, req.Bundle.Chain, req.Bundle.TlogEntry)
 }
 
 func store(ctx context.Context, writer gcsWriter, prefix string,
-	signature, content, cert, chain []byte) (*api.StoreResponse, error) {
+	signature, content, cert, chain []byte, tlogEntry *protorekor.TransparencyLogEntry) (*api.StoreResponse, error) {
 	logger := logging.FromContext(ctx)
 
 	// Write signature
@@ -287,6 +325,17 @@ func store(ctx context.Context, writer gcsWriter, prefix string,
 		return nil, err
 	}
 
+	// Only write the transparency log entry if the payload was uploaded.
+	if tlogEntry != nil {
+		rawEntry, err := protojson.Marshal(tlogEntry)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := write(ctx, writer, prefix+".tlogentry", rawEntry); err != nil {
+			return nil, err
+		}
+	}
+
 	// Only write cert+chain if it is present.
 	if cert == nil {
 		return nil, nil
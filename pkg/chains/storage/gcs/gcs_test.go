@@ -19,11 +19,14 @@ import (
 	"io"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+	protorekor "github.com/sigstore/protobuf-specs/gen/pb-go/rekor/v1"
 	"github.com/tektoncd/chains/pkg/chains/formats"
 	"github.com/tektoncd/chains/pkg/chains/objects"
 
 	"github.com/tektoncd/chains/pkg/config"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"google.golang.org/protobuf/testing/protocmp"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	rtesting "knative.dev/pkg/reconciler/testing"
@@ -61,7 +64,10 @@ func TestBackend_StorePayload(t *testing.T) {
 				},
 				signed:    []byte("signed"),
 				signature: "signature",
-				opts:      config.StorageOpts{ShortKey: "foo.uuid", PayloadFormat: formats.PayloadTypeSlsav1},
+				opts: config.StorageOpts{ShortKey: "foo.uuid", PayloadFormat: formats.PayloadTypeSlsav1, TlogEntry: &protorekor.TransparencyLogEntry{
+					CanonicalizedBody: []byte("body"),
+					InclusionPromise:  &protorekor.InclusionPromise{SignedEntryTimestamp: []byte("set")},
+				}},
 			},
 		},
 		{
@@ -119,6 +125,15 @@ func TestBackend_StorePayload(t *testing.T) {
 			if gotPayload[objectPayload] != string(tt.args.signed) {
 				t.Errorf("wrong signature, expected %s, got %s", tt.args.signed, gotPayload[objectPayload])
 			}
+			if tt.args.opts.TlogEntry != nil {
+				gotEntries, err := b.RetrieveTlogEntries(ctx, trObj, tt.args.opts)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if diff := cmp.Diff(tt.args.opts.TlogEntry, gotEntries[objectPayload], protocmp.Transform()); diff != "" {
+					t.Errorf("wrong tlog entry (-want +got): %s", diff)
+				}
+			}
 
 			prObj := objects.NewPipelineRunObjectV1(tt.args.pr)
 			if err := b.StorePayload(ctx, prObj, tt.args.signed, tt.args.signature, tt.args.opts); (err != nil) != tt.wantErr {
@@ -32,8 +32,11 @@ import (
 	ociremote "github.com/sigstore/cosign/v2/pkg/oci/remote"
 	"github.com/sigstore/cosign/v2/pkg/oci/static"
 	"github.com/sigstore/cosign/v2/pkg/types"
+	protobundle "github.com/sigstore/protobuf-specs/gen/pb-go/bundle/v1"
+	protorekor "github.com/sigstore/protobuf-specs/gen/pb-go/rekor/v1"
 	"github.com/tektoncd/chains/pkg/chains/storage/api"
 	"github.com/tektoncd/chains/pkg/config"
+	"google.golang.org/protobuf/encoding/protojson"
 	"knative.dev/pkg/logging"
 )
 
@@ -176,10 +179,14 @@ func (s *AttestationStorer) storeWithProtobufBundle(ctx context.Context, req *ap
 		signerBytes = req.Bundle.Cert
 	}
 
-	bundleBytes, err := cbundle.MakeNewBundle(pubKey, req.Bundle.RekorEntry, req.Bundle.Content, req.Bundle.Signature, signerBytes, timestampBytes)
+	bundleBytes, err := cbundle.MakeNewBundle(pubKey, nil, req.Bundle.Content, req.Bundle.Signature, signerBytes, timestampBytes)
 	if err != nil {
 		return nil, errors.Wrap(err, "creating protobuf bundle")
 	}
+	bundleBytes, err = withTlogEntry(bundleBytes, req.Bundle.TlogEntry)
+	if err != nil {
+		return nil, err
+	}
 
 	// Dedup scan: O(referrers × layers) serial registry calls.
 	// Acceptable for typical bundle counts (1–3 per artifact); the scan
@@ -250,3 +257,24 @@ func resolvePubKey(explicit crypto.PublicKey, certPEM []byte) (crypto.PublicKey,
 	}
 	return cert.PublicKey, nil
 }
+
+// withTlogEntry adds the transparency log entry to the verification material of
+// a serialized bundle. cosign only builds it from Rekor v1 entries.
+func withTlogEntry(bundleBytes []byte, tlogEntry *protorekor.TransparencyLogEntry) ([]byte, error) {
+	if tlogEntry == nil {
+		return bundleBytes, nil
+	}
+	bundle := &protobundle.Bundle{}
+	if err := protojson.Unmarshal(bundleBytes, bundle); err != nil {
+		return nil, errors.Wrap(err, "unmarshaling protobuf bundle")
+	}
+	if bundle.VerificationMaterial == nil {
+		bundle.VerificationMaterial = &protobundle.VerificationMaterial{}
+	}
+	bundle.VerificationMaterial.TlogEntries = []*protorekor.TransparencyLogEntry{tlogEntry}
+	out, err := protojson.Marshal(bundle)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshaling protobuf bundle")
+	}
+	return out, nil
+}
@@ -185,12 +185,12 @@ func (b *Backend) uploadSignature(ctx context.Context, format simple.SimpleConta
 		Artifact: ref,
 		Payload:  format,
 		Bundle: &signing.Bundle{
-			Content:    rawPayload,
-			Signature:  []byte(signature),
-			Cert:       []byte(storageOpts.Cert),
-			Chain:      []byte(storageOpts.Chain),
-			PublicKey:  storageOpts.PublicKey,
-			RekorEntry: storageOpts.RekorEntry,
+			Content:   rawPayload,
+			Signature: []byte(signature),
+			Cert:      []byte(storageOpts.Cert),
+			Chain:     []byte(storageOpts.Chain),
+			PublicKey: storageOpts.PublicKey,
+			TlogEntry: storageOpts.TlogEntry,
 		},
 	}); err != nil {
 		return err
@@ -235,7 +235,7 @@ func (b *Backend) uploadAttestation(ctx context.Context, attestation *intoto.Sta
 				Chain:       []byte(storageOpts.Chain),
 				PublicKey:   storageOpts.PublicKey,
 				RekorBundle: storageOpts.RekorBundle,
-				RekorEntry:  storageOpts.RekorEntry,
+				TlogEntry:   storageOpts.TlogEntry,
 			},
 		}); err != nil {
 			return err
@@ -34,7 +34,7 @@ import (
 	"github.com/sigstore/cosign/v2/pkg/types"
 	protobundle "github.com/sigstore/protobuf-specs/gen/pb-go/bundle/v1"
 	protodsse "github.com/sigstore/protobuf-specs/gen/pb-go/dsse"
-	"github.com/sigstore/rekor/pkg/generated/models"
+	protorekor "github.com/sigstore/protobuf-specs/gen/pb-go/rekor/v1"
 	"github.com/tektoncd/chains/pkg/chains/formats/simple"
 	"github.com/tektoncd/chains/pkg/chains/storage/api"
 	"github.com/tektoncd/chains/pkg/config"
@@ -160,7 +160,7 @@ func (s *SimpleStorer) storeWithSigstoreBundle(ctx context.Context, req *api.Sto
 	logger := logging.FromContext(ctx).With("image", req.Artifact.String())
 	logger.Info("Using sigstore bundle format for signature storage")
 
-	bundleBytes, err := makeSigBundleBytes(req.Bundle.PublicKey, req.Bundle.Cert, req.Bundle.Content, req.Bundle.Signature, req.Bundle.RekorEntry)
+	bundleBytes, err := makeSigBundleBytes(req.Bundle.PublicKey, req.Bundle.Cert, req.Bundle.Content, req.Bundle.Signature, req.Bundle.TlogEntry)
 	if err != nil {
 		return nil, errors.Wrap(err, "creating signature bundle")
 	}
@@ -223,7 +223,7 @@ func (s *SimpleStorer) storeWithSigstoreBundle(ctx context.Context, req *api.Sto
 // We build the bundle directly (rather than calling MakeNewBundle) to avoid a
 // nil-pubkey crash in the test path: MakeNewBundle calls x509.MarshalPKIXPublicKey
 // unconditionally when no cert is provided, which panics with a nil key.
-func makeSigBundleBytes(pubKey interface{}, certPEM []byte, payload []byte, rawSig []byte, rekorEntry *models.LogEntryAnon) ([]byte, error) {
+func makeSigBundleBytes(pubKey interface{}, certPEM []byte, payload []byte, rawSig []byte, tlogEntry *protorekor.TransparencyLogEntry) ([]byte, error) {
 	var hint string
 	var rawCert []byte
 
@@ -244,10 +244,16 @@ func makeSigBundleBytes(pubKey interface{}, certPEM []byte, payload []byte, rawS
 		}
 	}
 
-	bundle, err := cbundle.MakeProtobufBundle(hint, rawCert, rekorEntry, nil)
+	bundle, err := cbundle.MakeProtobufBundle(hint, rawCert, nil, nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "creating protobuf bundle")
 	}
+	if tlogEntry != nil {
+		if bundle.VerificationMaterial == nil {
+			bundle.VerificationMaterial = &protobundle.VerificationMaterial{}
+		}
+		bundle.VerificationMaterial.TlogEntries = []*protorekor.TransparencyLogEntry{tlogEntry}
+	}
 
 	bundle.Content = &protobundle.Bundle_DsseEnvelope{
 		DsseEnvelope: &protodsse.Envelope{
@@ -21,6 +21,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/google/go-containerregistry/pkg/registry"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
@@ -29,10 +30,14 @@ import (
 	"github.com/google/go-containerregistry/pkg/v1/types"
 	ociremote "github.com/sigstore/cosign/v2/pkg/oci/remote"
 	cosigntypes "github.com/sigstore/cosign/v2/pkg/types"
+	protobundle "github.com/sigstore/protobuf-specs/gen/pb-go/bundle/v1"
+	protorekor "github.com/sigstore/protobuf-specs/gen/pb-go/rekor/v1"
 	"github.com/tektoncd/chains/pkg/chains/formats/simple"
 	"github.com/tektoncd/chains/pkg/chains/signing"
 	"github.com/tektoncd/chains/pkg/chains/storage/api"
 	"github.com/tektoncd/chains/pkg/config"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/testing/protocmp"
 	logtesting "knative.dev/pkg/logging/testing"
 )
 
@@ -459,15 +464,15 @@ func TestSimpleStorer_Store_SigstoreBundle_Dedup(t *testing.T) {
 }
 
 // TestMakeSigBundleBytes_TlogEntries verifies that makeSigBundleBytes embeds
-// tlogEntries when a non-nil RekorEntry is passed, and omits them when nil.
+// tlogEntries when a non-nil TlogEntry is passed, and omits them when nil.
 // This guards the fix for the transparency-log omission bug in the signature
-// bundle path (legacy.go uploadSignature was not forwarding storageOpts.RekorEntry
-// into the Bundle, so req.Bundle.RekorEntry arrived as nil here).
+// bundle path (legacy.go uploadSignature was not forwarding storageOpts.TlogEntry
+// into the Bundle, so req.Bundle.TlogEntry arrived as nil here).
 func TestMakeSigBundleBytes_TlogEntries(t *testing.T) {
-	// nil rekorEntry → tlogEntries must be absent/empty in the serialized bundle.
+	// nil tlogEntry → tlogEntries must be absent/empty in the serialized bundle.
 	bundleBytes, err := makeSigBundleBytes(nil, nil, []byte("payload"), []byte("sig"), nil)
 	if err != nil {
-		t.Fatalf("makeSigBundleBytes with nil rekorEntry failed: %v", err)
+		t.Fatalf("makeSigBundleBytes with nil tlogEntry failed: %v", err)
 	}
 	var got map[string]interface{}
 	if err := json.Unmarshal(bundleBytes, &got); err != nil {
@@ -478,8 +483,26 @@ func TestMakeSigBundleBytes_TlogEntries(t *testing.T) {
 		if entries, ok := vm["tlogEntries"]; ok {
 			// tlogEntries key present — must be empty or nil.
 			if arr, ok := entries.([]interface{}); ok && len(arr) > 0 {
-				t.Errorf("expected empty tlogEntries with nil rekorEntry, got %d entries", len(arr))
+				t.Errorf("expected empty tlogEntries with nil tlogEntry, got %d entries", len(arr))
 			}
 		}
 	}
+
+	// Entries of Rekor v2 logs have no inclusion promise, and are embedded all the same.
+	entry := &protorekor.TransparencyLogEntry{
+		LogIndex:          1,
+		CanonicalizedBody: []byte("body"),
+		InclusionProof:    &protorekor.InclusionProof{LogIndex: 1, TreeSize: 2},
+	}
+	bundleBytes, err = makeSigBundleBytes(nil, nil, []byte("payload"), []byte("sig"), entry)
+	if err != nil {
+		t.Fatalf("makeSigBundleBytes with tlogEntry failed: %v", err)
+	}
+	bundle := &protobundle.Bundle{}
+	if err := protojson.Unmarshal(bundleBytes, bundle); err != nil {
+		t.Fatalf("failed to unmarshal bundle: %v", err)
+	}
+	if diff := cmp.Diff([]*protorekor.TransparencyLogEntry{entry}, bundle.GetVerificationMaterial().GetTlogEntries(), protocmp.Transform()); diff != "" {
+		t.Errorf("unexpected tlogEntries: (-want, +got): %s", diff)
+	}
 }
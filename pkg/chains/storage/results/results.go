@@ -0,0 +1,258 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package results
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	protorekor "github.com/sigstore/protobuf-specs/gen/pb-go/rekor/v1"
+	"github.com/tektoncd/chains/pkg/chains/objects"
+	"github.com/tektoncd/chains/pkg/chains/tektonresults"
+	"github.com/tektoncd/chains/pkg/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"google.golang.org/protobuf/encoding/protojson"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/logging"
+)
+
+const (
+	// StorageBackendResults is the name of the Tekton Results storage backend.
+	StorageBackendResults = "results"
+
+	// AttestationType is the type of the records holding the payloads signed by Chains.
+	AttestationType = "chains.tekton.dev/v1.Attestation"
+
+	// ResultAnnotation is set by the Tekton Results watcher to the name of the result of a run.
+	ResultAnnotation = "results.tekton.dev/result"
+)
+
+// Backend is a storage backend that stores the signed payloads as records of Tekton
+// Results, under the result of the run, so that they are kept along with the run history.
+type Backend struct {
+	client *tektonresults.Client
+}
+
+// Attestation is the value of the records stored by the backend.
+type Attestation struct {
+	// Run is the UID of the run the payload was signed for.
+	Run string `json:"run"`
+	// Key and FullKey identify the signed artifact, see config.StorageOpts.
+	Key     string `json:"key"`
+	FullKey string `json:"fullKey"`
+	// PayloadFormat is the format of the payload, e.g. slsa/v1 or simplesigning.
+	PayloadFormat string `json:"payloadFormat"`
+	// PayloadType is the type of the payload of the DSSE envelope, if the signature is one.
+	PayloadType string `json:"payloadType,omitempty"`
+	// Subjects are the artifacts the payload is about.
+	Subjects  []Subject `json:"subjects,omitempty"`
+	Payload   []byte    `json:"payload"`
+	Signature string    `json:"signature"`
+	Cert      string    `json:"cert,omitempty"`
+	Chain     string    `json:"chain,omitempty"`
+	// TlogEntry is the transparency log entry of the signature in the JSON encoded Sigstore bundle format, if it was uploaded.
+	TlogEntry json.RawMessage `json:"tlogEntry,omitempty"`
+}
+
+// Subject is an artifact a payload is about, with its digests.
+type Subject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+// NewStorageBackend returns a new Tekton Results StorageBackend that stores signatures as records
+func NewStorageBackend(cfg config.Config) (*Backend, error) {
+	client, err := tektonresults.NewClient(cfg.Results)
+	if err != nil {
+		return nil, err
+	}
+	return &Backend{client: client}, nil
+}
+
+// StorePayload implements the storage.Backend interface.
+func (b *Backend) StorePayload(ctx context.Context, obj objects.TektonObject, rawPayload []byte, signature string, opts config.StorageOpts) error {
+	logger := logging.FromContext(ctx)
+
+	attestation := Attestation{
+		Run:           string(obj.GetUID()),
+		Key:           opts.ShortKey,
+		FullKey:       opts.FullKey,
+		PayloadFormat: string(opts.PayloadFormat),
+		PayloadType:   envelopePayloadType(signature),
+		Subjects:      subjects(rawPayload),
+		Payload:       rawPayload,
+		Signature:     signature,
+		Cert:          opts.Cert,
+		Chain:         opts.Chain,
+	}
+	if opts.TlogEntry != nil {
+		tlogEntry, err := protojson.Marshal(opts.TlogEntry)
+		if err != nil {
+			return err
+		}
+		attestation.TlogEntry = tlogEntry
+	}
+	value, err := json.Marshal(attestation)
+	if err != nil {
+		return err
+	}
+
+	result := resultID(obj)
+	id := recordID(obj, opts.ShortKey)
+	logger.Infof("Storing signature as Tekton Results record %s/results/%s/records/%s", obj.GetNamespace(), result, id)
+	return b.client.WriteRecord(ctx, obj.GetNamespace(), result, id, tektonresults.RecordData{
+		Type:  AttestationType,
+		Value: value,
+	})
+}
+
+func (b *Backend) Type() string {
+	return StorageBackendResults
+}
+
+// RetrieveSignatures implements the storage.Backend interface.
+func (b *Backend) RetrieveSignatures(ctx context.Context, obj objects.TektonObject, opts config.StorageOpts) (map[string][]string, error) {
+	attestations, err := b.retrieveAttestations(ctx, obj, opts)
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[string][]string)
+	for name, a := range attestations {
+		m[name] = []string{a.Signature}
+	}
+	return m, nil
+}
+
+// RetrievePayloads implements the storage.Backend interface.
+func (b *Backend) RetrievePayloads(ctx context.Context, obj objects.TektonObject, opts config.StorageOpts) (map[string]string, error) {
+	attestations, err := b.retrieveAttestations(ctx, obj, opts)
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[string]string)
+	for name, a := range attestations {
+		m[name] = string(a.Payload)
+	}
+	return m, nil
+}
+
+// RetrieveTlogEntries retrieves the transparency log entries stored with the records, keyed like RetrievePayloads.
+func (b *Backend) RetrieveTlogEntries(ctx context.Context, obj objects.TektonObject, opts config.StorageOpts) (map[string]*protorekor.TransparencyLogEntry, error) {
+	attestations, err := b.retrieveAttestations(ctx, obj, opts)
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[string]*protorekor.TransparencyLogEntry)
+	for name, a := range attestations {
+		if len(a.TlogEntry) == 0 {
+			continue
+		}
+		entry := &protorekor.TransparencyLogEntry{}
+		if err := protojson.Unmarshal(a.TlogEntry, entry); err != nil {
+			return nil, err
+		}
+		m[name] = entry
+	}
+	return m, nil
+}
+
+// retrieveAttestations lists the records of the result of the run holding the
+// payloads signed for the artifact, keyed by the short key of the artifact.
+func (b *Backend) retrieveAttestations(ctx context.Context, obj objects.TektonObject, opts config.StorageOpts) (map[string]Attestation, error) {
+	filter := fmt.Sprintf("data_type == %q", AttestationType)
+	records, err := b.client.ListRecords(ctx, obj.GetNamespace(), resultID(obj), filter)
+	if err != nil {
+		return nil, err
+	}
+	attestations := make(map[string]Attestation)
+	for _, record := range records {
+		var a Attestation
+		if err := json.Unmarshal(record.Data.Value, &a); err != nil {
+			return nil, fmt.Errorf("decoding record %s: %w", record.Name, err)
+		}
+		if a.Run != string(obj.GetUID()) || a.Key != opts.ShortKey {
+			continue
+		}
+		attestations[a.Key] = a
+	}
+	return attestations, nil
+}
+
+// resultID returns the ID of the result of the run: the one the Tekton Results
+// watcher annotated the run with, otherwise the UID of the PipelineRun
+// controlling the run, or the UID of the run itself, like the watcher does.
+func resultID(obj objects.TektonObject) string {
+	if result, ok := obj.GetAnnotations()[ResultAnnotation]; ok && result != "" {
+		return path.Base(result)
+	}
+	if owner := metav1.GetControllerOf(obj); owner != nil && owner.Kind == pipeline.PipelineRunControllerName {
+		return string(owner.UID)
+	}
+	return string(obj.GetUID())
+}
+
+// recordID derives the ID of the record from the run and the key of the
+// artifact, so that storing a new signature of the artifact replaces the record.
+func recordID(obj objects.TektonObject, key string) string {
+	sum := sha256.Sum256([]byte(string(obj.GetUID()) + "/" + key))
+	id := hex.EncodeToString(sum[:16])
+	return strings.Join([]string{id[:8], id[8:12], id[12:16], id[16:20], id[20:]}, "-")
+}
+
+// envelopePayloadType returns the payload type of the signature when it is a
+// DSSE envelope.
+func envelopePayloadType(signature string) string {
+	var envelope struct {
+		PayloadType string `json:"payloadType"`
+	}
+	if err := json.Unmarshal([]byte(signature), &envelope); err != nil {
+		return ""
+	}
+	return envelope.PayloadType
+}
+
+// subjects returns the subjects of an in-toto statement, or the image of a
+// simple signing payload.
+func subjects(rawPayload []byte) []Subject {
+	var payload struct {
+		Subject  []Subject `json:"subject"`
+		Critical struct {
+			Identity struct {
+				DockerReference string `json:"docker-reference"`
+			} `json:"identity"`
+			Image struct {
+				DockerManifestDigest string `json:"docker-manifest-digest"`
+			} `json:"image"`
+		} `json:"critical"`
+	}
+	if err := json.Unmarshal(rawPayload, &payload); err != nil {
+		return nil
+	}
+	if len(payload.Subject) > 0 {
+		return payload.Subject
+	}
+	alg, digest, ok := strings.Cut(payload.Critical.Image.DockerManifestDigest, ":")
+	if !ok {
+		return nil
+	}
+	return []Subject{{
+		Name:   payload.Critical.Identity.DockerReference,
+		Digest: map[string]string{alg: digest},
+	}}
+}
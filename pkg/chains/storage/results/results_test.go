@@ -0,0 +1,193 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package results
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	protorekor "github.com/sigstore/protobuf-specs/gen/pb-go/rekor/v1"
+	"github.com/tektoncd/chains/pkg/chains/objects"
+	"github.com/tektoncd/chains/pkg/config"
+	testresults "github.com/tektoncd/chains/pkg/test/results"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"google.golang.org/protobuf/testing/protocmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+const (
+	statement = `{"_type":"https://in-toto.io/Statement/v1","subject":[{"name":"registry/image","digest":{"sha256":"abc"}}],"predicateType":"https://slsa.dev/provenance/v1"}`
+	envelope  = `{"payloadType":"application/vnd.in-toto+json","payload":"e30=","signatures":[{"sig":"c2ln"}]}`
+)
+
+func newBackend(t *testing.T) (*Backend, *testresults.Server) {
+	t.Helper()
+	server := testresults.NewServer(t)
+	backend, err := NewStorageBackend(config.Config{Results: config.ResultsConfig{URL: server.URL()}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return backend, server
+}
+
+func TestBackend_StorePayload(t *testing.T) {
+	ctx := logtesting.TestContextWithLogger(t)
+	backend, server := newBackend(t)
+
+	tr := &v1.TaskRun{ObjectMeta: metav1.ObjectMeta{
+		Namespace: "default",
+		Name:      "build",
+		UID:       "taskrun-uid",
+		OwnerReferences: []metav1.OwnerReference{{
+			APIVersion: "tekton.dev/v1",
+			Kind:       "PipelineRun",
+			Name:       "pipeline",
+			UID:        "pipelinerun-uid",
+			Controller: &[]bool{true}[0],
+		}},
+	}}
+	obj := objects.NewTaskRunObjectV1(tr)
+	tlogEntry := &protorekor.TransparencyLogEntry{LogIndex: 42, IntegratedTime: 1700000000}
+	opts := config.StorageOpts{
+		ShortKey:      "taskrun-taskrun-uid",
+		FullKey:       "taskrun-taskrun-uid",
+		Cert:          "cert",
+		Chain:         "chain",
+		PayloadFormat: "slsa/v1",
+		TlogEntry:     tlogEntry,
+	}
+	if err := backend.StorePayload(ctx, obj, []byte(statement), envelope, opts); err != nil {
+		t.Fatalf("StorePayload() error = %v", err)
+	}
+	// Signing again replaces the record.
+	if err := backend.StorePayload(ctx, obj, []byte(statement), envelope, opts); err != nil {
+		t.Fatalf("StorePayload() error = %v", err)
+	}
+
+	records := server.Records("default")
+	if len(records) != 1 {
+		t.Fatalf("expected a single record, got %d", len(records))
+	}
+	record := records[0]
+	if !strings.HasPrefix(record.Name, "default/results/pipelinerun-uid/records/") {
+		t.Errorf("record %s is not in the result of the PipelineRun", record.Name)
+	}
+	if record.Data.Type != AttestationType {
+		t.Errorf("record has type %s, want %s", record.Data.Type, AttestationType)
+	}
+	var got Attestation
+	if err := json.Unmarshal(record.Data.Value, &got); err != nil {
+		t.Fatal(err)
+	}
+	got.TlogEntry = nil
+	want := Attestation{
+		Run:           "taskrun-uid",
+		Key:           "taskrun-taskrun-uid",
+		FullKey:       "taskrun-taskrun-uid",
+		PayloadFormat: "slsa/v1",
+		PayloadType:   "application/vnd.in-toto+json",
+		Subjects:      []Subject{{Name: "registry/image", Digest: map[string]string{"sha256": "abc"}}},
+		Payload:       []byte(statement),
+		Signature:     envelope,
+		Cert:          "cert",
+		Chain:         "chain",
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("attestation (-want, +got):\n%s", d)
+	}
+
+	payloads, err := backend.RetrievePayloads(ctx, obj, opts)
+	if err != nil {
+		t.Fatalf("RetrievePayloads() error = %v", err)
+	}
+	if d := cmp.Diff(map[string]string{opts.ShortKey: statement}, payloads); d != "" {
+		t.Errorf("payloads (-want, +got):\n%s", d)
+	}
+	signatures, err := backend.RetrieveSignatures(ctx, obj, opts)
+	if err != nil {
+		t.Fatalf("RetrieveSignatures() error = %v", err)
+	}
+	if d := cmp.Diff(map[string][]string{opts.ShortKey: {envelope}}, signatures); d != "" {
+		t.Errorf("signatures (-want, +got):\n%s", d)
+	}
+	entries, err := backend.RetrieveTlogEntries(ctx, obj, opts)
+	if err != nil {
+		t.Fatalf("RetrieveTlogEntries() error = %v", err)
+	}
+	if d := cmp.Diff(map[string]*protorekor.TransparencyLogEntry{opts.ShortKey: tlogEntry}, entries, protocmp.Transform()); d != "" {
+		t.Errorf("tlog entries (-want, +got):\n%s", d)
+	}
+
+	// The payloads of another artifact of the run are not retrieved.
+	other := opts
+	other.ShortKey = "other"
+	payloads, err = backend.RetrievePayloads(ctx, obj, other)
+	if err != nil {
+		t.Fatalf("RetrievePayloads() error = %v", err)
+	}
+	if len(payloads) != 0 {
+		t.Errorf("expected no payload for another artifact, got %v", payloads)
+	}
+}
+
+func TestResultID(t *testing.T) {
+	tests := []struct {
+		name string
+		meta metav1.ObjectMeta
+		want string
+	}{{
+		name: "annotated by the watcher",
+		meta: metav1.ObjectMeta{
+			UID:         "taskrun-uid",
+			Annotations: map[string]string{ResultAnnotation: "default/results/annotated-uid"},
+		},
+		want: "annotated-uid",
+	}, {
+		name: "controlled by a PipelineRun",
+		meta: metav1.ObjectMeta{
+			UID:             "taskrun-uid",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "PipelineRun", UID: "pipelinerun-uid", Controller: &[]bool{true}[0]}},
+		},
+		want: "pipelinerun-uid",
+	}, {
+		name: "standalone",
+		meta: metav1.ObjectMeta{UID: "taskrun-uid"},
+		want: "taskrun-uid",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := resultID(objects.NewTaskRunObjectV1(&v1.TaskRun{ObjectMeta: tt.meta}))
+			if got != tt.want {
+				t.Errorf("resultID() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubjects(t *testing.T) {
+	simpleSigning := `{"critical":{"identity":{"docker-reference":"registry/image"},"image":{"docker-manifest-digest":"sha256:abc"},"type":"cosign container image signature"}}`
+	want := []Subject{{Name: "registry/image", Digest: map[string]string{"sha256": "abc"}}}
+	if d := cmp.Diff(want, subjects([]byte(simpleSigning))); d != "" {
+		t.Errorf("simple signing subjects (-want, +got):\n%s", d)
+	}
+	if d := cmp.Diff(want, subjects([]byte(statement))); d != "" {
+		t.Errorf("in-toto subjects (-want, +got):\n%s", d)
+	}
+	if got := subjects([]byte("not json")); got != nil {
+		t.Errorf("expected no subject, got %v", got)
+	}
+}
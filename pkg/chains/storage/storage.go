@@ -17,6 +17,7 @@ import (
 	"context"
 	"errors"
 
+	protorekor "github.com/sigstore/protobuf-specs/gen/pb-go/rekor/v1"
 	"github.com/tektoncd/chains/pkg/chains/objects"
 	"github.com/tektoncd/chains/pkg/chains/storage/archivista"
 	"github.com/tektoncd/chains/pkg/chains/storage/docdb"
@@ -24,6 +25,7 @@ import (
 	"github.com/tektoncd/chains/pkg/chains/storage/grafeas"
 	"github.com/tektoncd/chains/pkg/chains/storage/oci"
 	"github.com/tektoncd/chains/pkg/chains/storage/pubsub"
+	"github.com/tektoncd/chains/pkg/chains/storage/results"
 	"github.com/tektoncd/chains/pkg/chains/storage/tekton"
 	"github.com/tektoncd/chains/pkg/config"
 	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
@@ -44,6 +46,14 @@ type Backend interface {
 	Type() string
 }
 
+// TlogEntryRetriever is implemented by the backends which store the transparency
+// log entry (inclusion proof, checkpoint and signed entry timestamp) of the
+// signatures, so they can be verified without calling the transparency log.
+type TlogEntryRetriever interface {
+	// RetrieveTlogEntries maps [ref]:[tlog entry] for a TaskRun, using the same refs as RetrievePayloads
+	RetrieveTlogEntries(ctx context.Context, obj objects.TektonObject, opts config.StorageOpts) (map[string]*protorekor.TransparencyLogEntry, error)
+}
+
 // InitializeBackends creates and initializes every configured storage backend.
 func InitializeBackends(ctx context.Context, ps versioned.Interface, kc kubernetes.Interface, cfg config.Config) (map[string]Backend, error) {
 	logger := logging.FromContext(ctx)
@@ -59,6 +69,24 @@ func InitializeBackends(ctx context.Context, ps versioned.Interface, kc kubernet
 	if cfg.Artifacts.PipelineRuns.Enabled() {
 		configuredBackends = append(configuredBackends, sets.List[string](cfg.Artifacts.PipelineRuns.StorageBackend)...)
 	}
+	if cfg.Artifacts.CustomRuns.Enabled() {
+		configuredBackends = append(configuredBackends, sets.List[string](cfg.Artifacts.CustomRuns.StorageBackend)...)
+	}
+	if cfg.Artifacts.SBOM.Enabled() {
+		configuredBackends = append(configuredBackends, sets.List[string](cfg.Artifacts.SBOM.StorageBackend)...)
+	}
+	if cfg.Artifacts.TestResults.Enabled() {
+		configuredBackends = append(configuredBackends, sets.List[string](cfg.Artifacts.TestResults.StorageBackend)...)
+	}
+	if cfg.Artifacts.Vuln.Enabled() {
+		configuredBackends = append(configuredBackends, sets.List[string](cfg.Artifacts.Vuln.StorageBackend)...)
+	}
+	if cfg.Artifacts.Links.Enabled() {
+		configuredBackends = append(configuredBackends, sets.List[string](cfg.Artifacts.Links.StorageBackend)...)
+	}
+	if cfg.Artifacts.VSA.Enabled() {
+		configuredBackends = append(configuredBackends, sets.List[string](cfg.Artifacts.VSA.StorageBackend)...)
+	}
 	logger.Infof("configured backends from config: %v", configuredBackends)
 
 	// Now only initialize and return the configured ones.
@@ -100,6 +128,12 @@ func InitializeBackends(ctx context.Context, ps versioned.Interface, kc kubernet
 				return nil, err
 			}
 			backends[backendType] = archivistaBackend
+		case results.StorageBackendResults:
+			resultsBackend, err := results.NewStorageBackend(cfg)
+			if err != nil {
+				return nil, err
+			}
+			backends[backendType] = resultsBackend
 		}
 	}
 
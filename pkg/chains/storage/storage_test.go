@@ -60,11 +60,37 @@ func TestInitializeBackends(t *testing.T) {
 		// 	want: []string{"grafeas"},
 		// 	cfg:  config.Config{Artifacts: config.ArtifactConfigs{TaskRuns: config.Artifact{StorageBackend: sets.New[string]("grafeas")}}},
 		// },
+		{
+			name: "results",
+			want: []string{"results"},
+			cfg: config.Config{
+				Artifacts: config.ArtifactConfigs{TaskRuns: config.Artifact{StorageBackend: sets.New[string]("results")}},
+				Results:   config.ResultsConfig{URL: "https://tekton-results-api-service:8080"},
+			},
+		},
 		{
 			name: "multi",
 			want: []string{"oci", "tekton"},
 			cfg:  config.Config{Artifacts: config.ArtifactConfigs{TaskRuns: config.Artifact{StorageBackend: sets.New[string]("oci", "tekton")}}},
 		},
+		{
+			name: "vsa",
+			want: []string{"tekton"},
+			cfg:  config.Config{Artifacts: config.ArtifactConfigs{VSA: config.Artifact{StorageBackend: sets.New[string]("tekton")}}},
+		},
+		{
+			name: "test and scan results",
+			want: []string{"oci", "tekton"},
+			cfg: config.Config{Artifacts: config.ArtifactConfigs{
+				TestResults: config.Artifact{StorageBackend: sets.New[string]("tekton")},
+				Vuln:        config.Artifact{StorageBackend: sets.New[string]("oci")},
+			}},
+		},
+		{
+			name: "links",
+			want: []string{"tekton"},
+			cfg:  config.Config{Artifacts: config.ArtifactConfigs{Links: config.Artifact{StorageBackend: sets.New[string]("tekton")}}},
+		},
 		{
 			name: "pubsub",
 			want: []string{"pubsub"},
@@ -19,11 +19,13 @@ import (
 	"fmt"
 
 	intoto "github.com/in-toto/attestation/go/v1"
+	protorekor "github.com/sigstore/protobuf-specs/gen/pb-go/rekor/v1"
 	"github.com/tektoncd/chains/pkg/chains/annotations"
 	"github.com/tektoncd/chains/pkg/chains/objects"
 	"github.com/tektoncd/chains/pkg/chains/signing"
 	"github.com/tektoncd/chains/pkg/chains/storage/api"
 	"github.com/tektoncd/chains/pkg/config"
+	"google.golang.org/protobuf/encoding/protojson"
 	"knative.dev/pkg/logging"
 
 	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
@@ -37,6 +39,7 @@ const (
 	SignatureAnnotationFormat = annotations.ChainsAnnotationPrefix + "signature-%s"
 	CertAnnotationsFormat     = annotations.ChainsAnnotationPrefix + "cert-%s"
 	ChainAnnotationFormat     = annotations.ChainsAnnotationPrefix + "chain-%s"
+	TlogEntryAnnotationFormat = annotations.ChainsAnnotationPrefix + "tlog-entry-%s"
 )
 
 // Backend is a storage backend that stores signed payloads in the TaskRun metadata as an annotation.
@@ -71,6 +74,7 @@ func (b *Backend) StorePayload(ctx context.Context, obj objects.TektonObject, ra
 			Signature: []byte(signature),
 			Cert:      []byte(opts.Cert),
 			Chain:     []byte(opts.Chain),
+			TlogEntry: opts.TlogEntry,
 		},
 	}); err != nil {
 		logger.Errorf("error writing to Tekton object: %w", err)
@@ -112,17 +116,16 @@ func (b *Backend) retrieveAnnotationValue(ctx context.Context, obj objects.Tekto
 	return annotationValue, nil
 }
 
-// RetrieveSignatures retrieve the signature stored in the taskrun.
+// RetrieveSignatures retrieve the signature stored in the taskrun, keyed like RetrievePayloads.
 func (b *Backend) RetrieveSignatures(ctx context.Context, obj objects.TektonObject, opts config.StorageOpts) (map[string][]string, error) {
 	logger := logging.FromContext(ctx)
 	logger.Infof("Retrieving signature on %s/%s/%s", obj.GetGVK(), obj.GetNamespace(), obj.GetName())
-	signatureAnnotation := sigName(opts)
-	signature, err := b.retrieveAnnotationValue(ctx, obj, signatureAnnotation, true)
+	signature, err := b.retrieveAnnotationValue(ctx, obj, sigName(opts), true)
 	if err != nil {
 		return nil, err
 	}
 	m := make(map[string][]string)
-	m[signatureAnnotation] = []string{signature}
+	m[payloadName(opts)] = []string{signature}
 	return m, nil
 }
 
@@ -140,6 +143,26 @@ func (b *Backend) RetrievePayloads(ctx context.Context, obj objects.TektonObject
 	return m, nil
 }
 
+// RetrieveTlogEntries retrieve the transparency log entry stored in the taskrun, keyed like RetrievePayloads.
+func (b *Backend) RetrieveTlogEntries(ctx context.Context, obj objects.TektonObject, opts config.StorageOpts) (map[string]*protorekor.TransparencyLogEntry, error) {
+	logger := logging.FromContext(ctx)
+	logger.Infof("Retrieving tlog entry on %s/%s/%s", obj.GetGVK(), obj.GetNamespace(), obj.GetName())
+	raw, err := b.retrieveAnnotationValue(ctx, obj, fmt.Sprintf(TlogEntryAnnotationFormat, opts.ShortKey), true)
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[string]*protorekor.TransparencyLogEntry)
+	if raw == "" {
+		return m, nil
+	}
+	entry := &protorekor.TransparencyLogEntry{}
+	if err := protojson.Unmarshal([]byte(raw), entry); err != nil {
+		return nil, fmt.Errorf("error unmarshalling the tlog entry: %w", err)
+	}
+	m[payloadName(opts)] = entry
+	return m, nil
+}
+
 func sigName(opts config.StorageOpts) string {
 	return fmt.Sprintf(SignatureAnnotationFormat, opts.ShortKey)
 }
@@ -176,6 +199,13 @@ func (s *Storer) Store(ctx context.Context, req *api.StoreRequest[objects.Tekton
 		fmt.Sprintf(CertAnnotationsFormat, key):     base64.StdEncoding.EncodeToString(req.Bundle.Cert),
 		fmt.Sprintf(ChainAnnotationFormat, key):     base64.StdEncoding.EncodeToString(req.Bundle.Chain),
 	}
+	if req.Bundle.TlogEntry != nil {
+		entry, err := protojson.Marshal(req.Bundle.TlogEntry)
+		if err != nil {
+			return nil, err
+		}
+		storedAnnotations[fmt.Sprintf(TlogEntryAnnotationFormat, key)] = base64.StdEncoding.EncodeToString(entry)
+	}
 
 	if err := annotations.AddAnnotations(ctx, obj, s.client, storedAnnotations); err != nil {
 		return nil, err
@@ -19,6 +19,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	intoto "github.com/in-toto/attestation/go/v1"
+	protorekor "github.com/sigstore/protobuf-specs/gen/pb-go/rekor/v1"
 	"github.com/tektoncd/chains/pkg/chains/objects"
 	"github.com/tektoncd/chains/pkg/chains/signing"
 	"github.com/tektoncd/chains/pkg/chains/storage/api"
@@ -26,6 +27,7 @@ import (
 	"github.com/tektoncd/chains/pkg/test/tekton"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	fakepipelineclient "github.com/tektoncd/pipeline/pkg/client/injection/client/fake"
+	"google.golang.org/protobuf/testing/protocmp"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	rtesting "knative.dev/pkg/reconciler/testing"
 )
@@ -121,13 +123,15 @@ func TestBackend_StorePayload(t *testing.T) {
 				t.Errorf("unexpected payload: (-want, +got): %s", diff)
 			}
 
-			// Compare the signature.
-			signatureAnnotation := sigName(opts)
+			// Compare the signature, keyed like the payload.
 			sigs, err := b.RetrieveSignatures(ctx, tt.object, opts)
 			if err != nil {
 				t.Fatal(err)
 			}
-			if diff := cmp.Diff(mockSignature, sigs[signatureAnnotation][0]); diff != "" {
+			if len(sigs[payloadAnnotation]) != 1 {
+				t.Fatalf("expected the signature keyed like the payload, got %v", sigs)
+			}
+			if diff := cmp.Diff(mockSignature, sigs[payloadAnnotation][0]); diff != "" {
 				t.Errorf("unexpected signature: (-want, +got): %s", diff)
 			}
 
@@ -135,6 +139,56 @@ func TestBackend_StorePayload(t *testing.T) {
 	}
 }
 
+func TestBackend_TlogEntry(t *testing.T) {
+	ctx, _ := rtesting.SetupFakeContext(t)
+	c := fakepipelineclient.Get(ctx)
+	obj := objects.NewTaskRunObjectV1(&v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: "bar",
+		},
+	})
+	tekton.CreateObject(t, ctx, c, obj)
+	b := &Backend{
+		pipelineclientset: c,
+	}
+	opts := config.StorageOpts{ShortKey: "mockpayload"}
+
+	// Nothing is stored without a transparency log entry.
+	if err := b.StorePayload(ctx, obj, []byte("{}"), "mocksignature", opts); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := b.RetrieveTlogEntries(ctx, obj, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("expected no tlog entries, got %v", entries)
+	}
+
+	opts.TlogEntry = &protorekor.TransparencyLogEntry{
+		LogIndex:          1,
+		CanonicalizedBody: []byte("body"),
+		InclusionProof: &protorekor.InclusionProof{
+			LogIndex:   1,
+			RootHash:   []byte{0xab, 0xcd},
+			TreeSize:   2,
+			Hashes:     [][]byte{{0xef, 0x01}},
+			Checkpoint: &protorekor.Checkpoint{Envelope: "rekor.sigstore.dev - 1234\n2\nq6s=\n"},
+		},
+	}
+	if err := b.StorePayload(ctx, obj, []byte("{}"), "mocksignature", opts); err != nil {
+		t.Fatal(err)
+	}
+	entries, err = b.RetrieveTlogEntries(ctx, obj, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(map[string]*protorekor.TransparencyLogEntry{payloadName(opts): opts.TlogEntry}, entries, protocmp.Transform()); diff != "" {
+		t.Errorf("unexpected tlog entries: (-want, +got): %s", diff)
+	}
+}
+
 // Just a simple struct to serialize
 type mockPayload struct {
 	A string
@@ -0,0 +1,289 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tektonresults reads and writes the records of Tekton Results: the runs
+// it archives, so that the runs pruned from the cluster can still be recorded in
+// provenance, and the attestations stored next to them.
+package tektonresults
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/tektoncd/chains/pkg/config"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+)
+
+const (
+	// TaskRunType and TaskRunV1beta1Type are the types of the records of the
+	// TaskRuns archived by Tekton Results.
+	TaskRunType        = "tekton.dev/v1.TaskRun"
+	TaskRunV1beta1Type = "tekton.dev/v1beta1.TaskRun"
+
+	// ResultsPath is the path of the results of a namespace.
+	ResultsPath = "/apis/results.tekton.dev/v1alpha2/parents/%s/results"
+	// RecordsPath is the path of the records of a result of a namespace. The
+	// records of all the results of the namespace are listed with the "-" result.
+	RecordsPath = ResultsPath + "/%s/records"
+	// RecordPath is the path of a record.
+	RecordPath = RecordsPath + "/%s"
+
+	// AllResults lists the records across all the results of a namespace.
+	AllResults = "-"
+
+	defaultTokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token" // #nosec G101
+
+	// requestTimeout bounds the requests to Tekton Results, so that a hung API
+	// does not block the reconciler workers.
+	requestTimeout = 30 * time.Second
+)
+
+// Client reads and writes the records of Tekton Results through its REST API.
+type Client struct {
+	url        string
+	tokenPath  string
+	httpClient *http.Client
+}
+
+// Record is a record of Tekton Results, holding an archived run or attestation.
+type Record struct {
+	Name string     `json:"name"`
+	Data RecordData `json:"data"`
+}
+
+// RecordData is the archived object of a record, with its type.
+type RecordData struct {
+	Type string `json:"type"`
+	// Value is the JSON serialization of the object.
+	Value []byte `json:"value"`
+}
+
+// ListRecordsResponse is a page of the records matching a filter.
+type ListRecordsResponse struct {
+	Records       []Record `json:"records"`
+	NextPageToken string   `json:"nextPageToken"`
+}
+
+// Result groups the records of a run and of its child runs.
+type Result struct {
+	Name string `json:"name"`
+}
+
+// StatusError is the error returned when Tekton Results answers a request with
+// an unexpected status.
+type StatusError struct {
+	StatusCode int
+	Status     string
+	Message    string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Status, e.Message)
+}
+
+// IsStatus returns true when err is a StatusError with the given status code.
+func IsStatus(err error, statusCode int) bool {
+	var statusErr *StatusError
+	return errors.As(err, &statusErr) && statusErr.StatusCode == statusCode
+}
+
+// NewClient returns a client of the Tekton Results API configured in cfg.
+func NewClient(cfg config.ResultsConfig) (*Client, error) {
+	if cfg.URL == "" {
+		return nil, errors.New("missing Tekton Results URL")
+	}
+	tokenPath := cfg.TokenPath
+	if tokenPath == "" {
+		tokenPath = defaultTokenPath
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if cfg.CAPath != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		pemCerts, err := os.ReadFile(cfg.CAPath)
+		if err != nil {
+			return nil, fmt.Errorf("reading Tekton Results CA certificates: %w", err)
+		}
+		if !pool.AppendCertsFromPEM(pemCerts) {
+			return nil, fmt.Errorf("no PEM encoded certificate found in %s", cfg.CAPath)
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+	return &Client{
+		url:        strings.TrimSuffix(cfg.URL, "/"),
+		tokenPath:  tokenPath,
+		httpClient: &http.Client{Transport: transport, Timeout: requestTimeout},
+	}, nil
+}
+
+// CloseIdleConnections closes the idle connections of the client, once it is
+// replaced.
+func (c *Client) CloseIdleConnections() {
+	c.httpClient.CloseIdleConnections()
+}
+
+// GetTaskRuns returns the archived TaskRuns with the given name in the namespace.
+// Several TaskRuns are returned when the name was reused, and none when Tekton
+// Results has no record of it.
+func (c *Client) GetTaskRuns(ctx context.Context, namespace, name string) ([]*v1.TaskRun, error) {
+	filter := fmt.Sprintf(`data_type in [%q, %q] && data.metadata.name == %q`, TaskRunType, TaskRunV1beta1Type, name)
+	records, err := c.ListRecords(ctx, namespace, AllResults, filter)
+	if err != nil {
+		return nil, err
+	}
+	taskRuns := make([]*v1.TaskRun, 0, len(records))
+	for _, record := range records {
+		tr, err := taskRunFromRecord(ctx, record)
+		if err != nil {
+			return nil, fmt.Errorf("decoding record %s: %w", record.Name, err)
+		}
+		taskRuns = append(taskRuns, tr)
+	}
+	return taskRuns, nil
+}
+
+// ListRecords returns the records of a result of the namespace matching the
+// filter, a CEL expression over the records.
+func (c *Client) ListRecords(ctx context.Context, namespace, result, filter string) ([]Record, error) {
+	var records []Record
+	pageToken := ""
+	for {
+		query := url.Values{"filter": {filter}}
+		if pageToken != "" {
+			query.Set("page_token", pageToken)
+		}
+		endpoint := c.url + fmt.Sprintf(RecordsPath, url.PathEscape(namespace), url.PathEscape(result)) + "?" + query.Encode()
+		var page ListRecordsResponse
+		if err := c.do(ctx, http.MethodGet, endpoint, nil, &page); err != nil {
+			return nil, fmt.Errorf("listing Tekton Results records: %w", err)
+		}
+		records = append(records, page.Records...)
+		if page.NextPageToken == "" {
+			return records, nil
+		}
+		pageToken = page.NextPageToken
+	}
+}
+
+// WriteRecord creates or replaces the record with the given ID in a result of
+// the namespace. The result is created when it doesn't exist yet.
+func (c *Client) WriteRecord(ctx context.Context, namespace, result, id string, data RecordData) error {
+	record := Record{
+		Name: fmt.Sprintf("%s/results/%s/records/%s", namespace, result, id),
+		Data: data,
+	}
+	recordsEndpoint := c.url + fmt.Sprintf(RecordsPath, url.PathEscape(namespace), url.PathEscape(result))
+	err := c.do(ctx, http.MethodPost, recordsEndpoint, record, nil)
+	if IsStatus(err, http.StatusNotFound) {
+		if err := c.createResult(ctx, namespace, result); err != nil {
+			return err
+		}
+		err = c.do(ctx, http.MethodPost, recordsEndpoint, record, nil)
+	}
+	if IsStatus(err, http.StatusConflict) {
+		recordEndpoint := c.url + fmt.Sprintf(RecordPath, url.PathEscape(namespace), url.PathEscape(result), url.PathEscape(id))
+		err = c.do(ctx, http.MethodPut, recordEndpoint, record, nil)
+	}
+	if err != nil {
+		return fmt.Errorf("writing Tekton Results record %s: %w", record.Name, err)
+	}
+	return nil
+}
+
+func (c *Client) createResult(ctx context.Context, namespace, result string) error {
+	endpoint := c.url + fmt.Sprintf(ResultsPath, url.PathEscape(namespace))
+	err := c.do(ctx, http.MethodPost, endpoint, Result{Name: fmt.Sprintf("%s/results/%s", namespace, result)}, nil)
+	// The result may have been created concurrently, e.g. by the Tekton Results watcher.
+	if err != nil && !IsStatus(err, http.StatusConflict) {
+		return fmt.Errorf("creating Tekton Results result %s/results/%s: %w", namespace, result, err)
+	}
+	return nil
+}
+
+func (c *Client) do(ctx context.Context, method, endpoint string, body, into any) error {
+	var reqBody io.Reader
+	if body != nil {
+		raw, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(raw)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	token, err := os.ReadFile(c.tokenPath)
+	switch {
+	case err == nil:
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	case !errors.Is(err, os.ErrNotExist):
+		return fmt.Errorf("reading Tekton Results token: %w", err)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return &StatusError{StatusCode: resp.StatusCode, Status: resp.Status, Message: strings.TrimSpace(string(respBody))}
+	}
+	if into == nil {
+		return nil
+	}
+	return json.Unmarshal(respBody, into)
+}
+
+func taskRunFromRecord(ctx context.Context, record Record) (*v1.TaskRun, error) {
+	switch record.Data.Type {
+	case TaskRunType:
+		tr := &v1.TaskRun{}
+		if err := json.Unmarshal(record.Data.Value, tr); err != nil {
+			return nil, err
+		}
+		return tr, nil
+	case TaskRunV1beta1Type:
+		trV1beta1 := &v1beta1.TaskRun{}
+		if err := json.Unmarshal(record.Data.Value, trV1beta1); err != nil {
+			return nil, err
+		}
+		tr := &v1.TaskRun{}
+		if err := trV1beta1.ConvertTo(ctx, tr); err != nil {
+			return nil, err
+		}
+		return tr, nil
+	}
+	return nil, fmt.Errorf("unexpected record type %q", record.Data.Type)
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tektonresults_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/chains/pkg/chains/tektonresults"
+	"github.com/tektoncd/chains/pkg/config"
+	"github.com/tektoncd/chains/pkg/test/results"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func taskRun(namespace, name, uid string) *v1.TaskRun {
+	return &v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   namespace,
+			Name:        name,
+			UID:         types.UID("uid-" + uid),
+			Annotations: map[string]string{"chains.tekton.dev/signed": "true"},
+		},
+	}
+}
+
+func writeToken(t *testing.T, token string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(path, []byte(token+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestGetTaskRuns(t *testing.T) {
+	server := results.NewServer(t)
+	server.Token = "results-token"
+	server.AddTaskRun(t, taskRun("default", "build", "1"))
+	server.AddTaskRun(t, taskRun("default", "build", "2"))
+	server.AddTaskRun(t, taskRun("default", "test", "3"))
+	server.AddTaskRun(t, taskRun("other", "build", "4"))
+
+	client, err := tektonresults.NewClient(config.ResultsConfig{
+		URL:       server.URL() + "/",
+		TokenPath: writeToken(t, "results-token"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		namespace string
+		taskRun   string
+		wantUIDs  []string
+	}{{
+		name:      "name reused",
+		namespace: "default",
+		taskRun:   "build",
+		wantUIDs:  []string{"uid-1", "uid-2"},
+	}, {
+		name:      "single record",
+		namespace: "other",
+		taskRun:   "build",
+		wantUIDs:  []string{"uid-4"},
+	}, {
+		name:      "no record",
+		namespace: "default",
+		taskRun:   "missing",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.GetTaskRuns(context.Background(), tt.namespace, tt.taskRun)
+			if err != nil {
+				t.Fatalf("GetTaskRuns() error = %v", err)
+			}
+			if len(got) != len(tt.wantUIDs) {
+				t.Fatalf("GetTaskRuns() returned %d TaskRuns, want %d", len(got), len(tt.wantUIDs))
+			}
+			for i, tr := range got {
+				if string(tr.UID) != tt.wantUIDs[i] {
+					t.Errorf("TaskRun %d has uid %s, want %s", i, tr.UID, tt.wantUIDs[i])
+				}
+				if tr.Name != tt.taskRun || tr.Annotations["chains.tekton.dev/signed"] != "true" {
+					t.Errorf("unexpected TaskRun %s with annotations %v", tr.Name, tr.Annotations)
+				}
+			}
+		})
+	}
+}
+
+func TestGetTaskRuns_Unauthenticated(t *testing.T) {
+	server := results.NewServer(t)
+	server.Token = "results-token"
+
+	client, err := tektonresults.NewClient(config.ResultsConfig{
+		URL:       server.URL(),
+		TokenPath: writeToken(t, "wrong-token"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetTaskRuns(context.Background(), "default", "build"); err == nil {
+		t.Error("expected an error for a request with the wrong token")
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	if _, err := tektonresults.NewClient(config.ResultsConfig{}); err == nil {
+		t.Error("expected an error without URL")
+	}
+	if _, err := tektonresults.NewClient(config.ResultsConfig{URL: "https://results", CAPath: filepath.Join(t.TempDir(), "missing")}); err == nil {
+		t.Error("expected an error for a missing CA file")
+	}
+	caPath := writeToken(t, "not a certificate")
+	if _, err := tektonresults.NewClient(config.ResultsConfig{URL: "https://results", CAPath: caPath}); err == nil {
+		t.Error("expected an error for a CA file without certificates")
+	}
+}
+
+func TestWriteRecord(t *testing.T) {
+	server := results.NewServer(t)
+	client, err := tektonresults.NewClient(config.ResultsConfig{URL: server.URL()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	const recordType = "example.dev/v1.Record"
+
+	// The result is created along with the first record.
+	if err := client.WriteRecord(ctx, "default", "run-uid", "record-1", tektonresults.RecordData{Type: recordType, Value: []byte(`"first"`)}); err != nil {
+		t.Fatalf("WriteRecord() error = %v", err)
+	}
+	if err := client.WriteRecord(ctx, "default", "run-uid", "record-2", tektonresults.RecordData{Type: recordType, Value: []byte(`"second"`)}); err != nil {
+		t.Fatalf("WriteRecord() error = %v", err)
+	}
+	// An existing record is replaced.
+	if err := client.WriteRecord(ctx, "default", "run-uid", "record-1", tektonresults.RecordData{Type: recordType, Value: []byte(`"updated"`)}); err != nil {
+		t.Fatalf("WriteRecord() error = %v", err)
+	}
+
+	records, err := client.ListRecords(ctx, "default", "run-uid", fmt.Sprintf("data_type == %q", recordType))
+	if err != nil {
+		t.Fatalf("ListRecords() error = %v", err)
+	}
+	got := map[string]string{}
+	for _, record := range records {
+		got[record.Name] = string(record.Data.Value)
+	}
+	want := map[string]string{
+		"default/results/run-uid/records/record-1": `"updated"`,
+		"default/results/run-uid/records/record-2": `"second"`,
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("records (-want, +got):\n%s", d)
+	}
+
+	other, err := client.ListRecords(ctx, "default", "other-uid", fmt.Sprintf("data_type == %q", recordType))
+	if err != nil {
+		t.Fatalf("ListRecords() error = %v", err)
+	}
+	if len(other) != 0 {
+		t.Errorf("expected no record in another result, got %v", other)
+	}
+}
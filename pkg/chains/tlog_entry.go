@@ -0,0 +1,319 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chains
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"github.com/go-openapi/swag"
+	"github.com/in-toto/in-toto-golang/in_toto"
+	"github.com/pkg/errors"
+	"github.com/secure-systems-lab/go-securesystemslib/dsse"
+	"github.com/sigstore/cosign/v2/pkg/cosign"
+	protocommon "github.com/sigstore/protobuf-specs/gen/pb-go/common/v1"
+	protorekor "github.com/sigstore/protobuf-specs/gen/pb-go/rekor/v1"
+	"github.com/sigstore/rekor-tiles/v2/pkg/note"
+	tilesverify "github.com/sigstore/rekor-tiles/v2/pkg/verify"
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/verify"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/tektoncd/chains/pkg/chains/formats"
+	"github.com/tektoncd/chains/pkg/config"
+)
+
+// tlogEntryFromAnon converts a Rekor v1 entry to the Sigstore bundle format.
+func tlogEntryFromAnon(anon *models.LogEntryAnon) (*protorekor.TransparencyLogEntry, error) {
+	logID, err := hex.DecodeString(swag.StringValue(anon.LogID))
+	if err != nil {
+		return nil, errors.Wrap(err, "decoding log ID")
+	}
+	encoded, ok := anon.Body.(string)
+	if !ok {
+		return nil, fmt.Errorf("tlog entry body must be a string, was %T", anon.Body)
+	}
+	body, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, errors.Wrap(err, "decoding tlog entry body")
+	}
+	kindVersion := struct {
+		Kind       string `json:"kind"`
+		APIVersion string `json:"apiVersion"`
+	}{}
+	if err := json.Unmarshal(body, &kindVersion); err != nil {
+		return nil, errors.Wrap(err, "unmarshalling tlog entry body")
+	}
+
+	entry := &protorekor.TransparencyLogEntry{
+		LogIndex:          swag.Int64Value(anon.LogIndex),
+		LogId:             &protocommon.LogId{KeyId: logID},
+		KindVersion:       &protorekor.KindVersion{Kind: kindVersion.Kind, Version: kindVersion.APIVersion},
+		IntegratedTime:    swag.Int64Value(anon.IntegratedTime),
+		CanonicalizedBody: body,
+	}
+	if anon.Verification == nil {
+		return entry, nil
+	}
+	entry.InclusionPromise = &protorekor.InclusionPromise{SignedEntryTimestamp: anon.Verification.SignedEntryTimestamp}
+	if proof := anon.Verification.InclusionProof; proof != nil {
+		rootHash, err := hex.DecodeString(swag.StringValue(proof.RootHash))
+		if err != nil {
+			return nil, errors.Wrap(err, "decoding inclusion proof root hash")
+		}
+		hashes := make([][]byte, 0, len(proof.Hashes))
+		for _, h := range proof.Hashes {
+			hash, err := hex.DecodeString(h)
+			if err != nil {
+				return nil, errors.Wrap(err, "decoding inclusion proof hash")
+			}
+			hashes = append(hashes, hash)
+		}
+		entry.InclusionProof = &protorekor.InclusionProof{
+			LogIndex:   swag.Int64Value(proof.LogIndex),
+			RootHash:   rootHash,
+			TreeSize:   swag.Int64Value(proof.TreeSize),
+			Hashes:     hashes,
+			Checkpoint: &protorekor.Checkpoint{Envelope: swag.StringValue(proof.Checkpoint)},
+		}
+	}
+	return entry, nil
+}
+
+// rekorEntry returns the Rekor v1 form of the entry, used to build the cosign
+// bundle annotations. Entries of Rekor v2 logs have none: they carry no promise
+// of inclusion signed by the log.
+func rekorEntry(entry *protorekor.TransparencyLogEntry) *models.LogEntryAnon {
+	if entry.GetInclusionPromise() == nil {
+		return nil
+	}
+	anon := &models.LogEntryAnon{
+		Body:           base64.StdEncoding.EncodeToString(entry.GetCanonicalizedBody()),
+		IntegratedTime: swag.Int64(entry.GetIntegratedTime()),
+		LogID:          swag.String(hex.EncodeToString(entry.GetLogId().GetKeyId())),
+		LogIndex:       swag.Int64(entry.GetLogIndex()),
+		Verification: &models.LogEntryAnonVerification{
+			SignedEntryTimestamp: entry.GetInclusionPromise().GetSignedEntryTimestamp(),
+		},
+	}
+	if proof := entry.GetInclusionProof(); proof != nil {
+		hashes := make([]string, 0, len(proof.GetHashes()))
+		for _, h := range proof.GetHashes() {
+			hashes = append(hashes, hex.EncodeToString(h))
+		}
+		anon.Verification.InclusionProof = &models.InclusionProof{
+			Checkpoint: swag.String(proof.GetCheckpoint().GetEnvelope()),
+			Hashes:     hashes,
+			LogIndex:   swag.Int64(proof.GetLogIndex()),
+			RootHash:   swag.String(hex.EncodeToString(proof.GetRootHash())),
+			TreeSize:   swag.Int64(proof.GetTreeSize()),
+		}
+	}
+	return anon
+}
+
+// transparencyAnnotation returns the location of the entry recorded in the
+// ChainsTransparencyAnnotation. Rekor v2 logs have no API to look up an entry,
+// so only the log URL and the entry index are recorded for them.
+func transparencyAnnotation(cfg config.TransparencyConfig, entry *protorekor.TransparencyLogEntry) string {
+	if cfg.APIVersion == config.TransparencyAPIVersionV2 {
+		return fmt.Sprintf("%s#%d", cfg.URL, entry.GetLogIndex())
+	}
+	return fmt.Sprintf("%s/api/v1/log/entries?logIndex=%d", cfg.URL, entry.GetLogIndex())
+}
+
+// rekorVerifiers parses the PEM encoded public keys of the trusted transparency
+// logs, indexed by their Rekor v1 log ID.
+func rekorVerifiers(pemKeys string) (map[string]signature.Verifier, error) {
+	verifiers := map[string]signature.Verifier{}
+	rest := []byte(pemKeys)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, errors.Wrap(err, "parsing transparency log public key")
+		}
+		logID, err := cosign.GetTransparencyLogID(pub)
+		if err != nil {
+			return nil, errors.Wrap(err, "computing transparency log ID")
+		}
+		verifier, err := signature.LoadVerifier(pub, crypto.SHA256)
+		if err != nil {
+			return nil, errors.Wrap(err, "loading transparency log public key")
+		}
+		verifiers[logID] = verifier
+	}
+	if len(verifiers) == 0 {
+		return nil, errors.New("no transparency log public key found")
+	}
+	return verifiers, nil
+}
+
+// verifyTlogEntryOffline verifies the inclusion proof and checkpoint of a stored
+// transparency log entry against the trusted log keys, along with the signed
+// entry timestamp of Rekor v1 entries, and that the entry is of the configured
+// kind and was made for the given payload.
+func verifyTlogEntryOffline(ctx context.Context, entry *protorekor.TransparencyLogEntry, payload []byte, payloadFormat, entryType string, verifiers map[string]signature.Verifier) error {
+	if entry.GetInclusionProof().GetCheckpoint().GetEnvelope() == "" {
+		return errors.New("tlog entry has no inclusion proof")
+	}
+	if anon := rekorEntry(entry); anon != nil {
+		logID := *anon.LogID
+		verifier, ok := verifiers[logID]
+		if !ok {
+			return fmt.Errorf("no trusted transparency log public key for log ID %s", logID)
+		}
+		if err := verify.VerifyLogEntry(ctx, anon, verifier); err != nil {
+			return errors.Wrap(err, "verifying tlog entry")
+		}
+	} else if err := verifyTlogEntryV2(entry, verifiers); err != nil {
+		return err
+	}
+	return verifyTlogEntryPayload(entry, payload, payloadFormat, entryType)
+}
+
+// verifyTlogEntryV2 verifies the inclusion proof and checkpoint of an entry of a
+// Rekor v2 log. Its log ID is derived from the checkpoint origin for some key
+// types, so the trusted key is looked up by computing it for each of them.
+func verifyTlogEntryV2(entry *protorekor.TransparencyLogEntry, verifiers map[string]signature.Verifier) error {
+	origin, _, _ := strings.Cut(entry.GetInclusionProof().GetCheckpoint().GetEnvelope(), "\n")
+	for _, verifier := range verifiers {
+		pub, err := verifier.PublicKey()
+		if err != nil {
+			return errors.Wrap(err, "getting transparency log public key")
+		}
+		_, logID, err := note.KeyHash(origin, pub)
+		if err != nil || !bytes.Equal(logID, entry.GetLogId().GetKeyId()) {
+			continue
+		}
+		noteVerifier, err := note.NewNoteVerifier(origin, verifier)
+		if err != nil {
+			return errors.Wrap(err, "loading checkpoint verifier")
+		}
+		if err := tilesverify.VerifyLogEntry(entry, noteVerifier); err != nil {
+			return errors.Wrap(err, "verifying tlog entry")
+		}
+		return nil
+	}
+	return fmt.Errorf("no trusted transparency log public key for log ID %x", entry.GetLogId().GetKeyId())
+}
+
+// tlogEntryHash is a digest recorded in Rekor v1 entries.
+type tlogEntryHash struct {
+	Algorithm string `json:"algorithm"`
+	Value     string `json:"value"`
+}
+
+func (h *tlogEntryHash) sha256() ([]byte, error) {
+	if h.Algorithm != "sha256" {
+		return nil, fmt.Errorf("unsupported tlog entry digest algorithm %q", h.Algorithm)
+	}
+	return hex.DecodeString(h.Value)
+}
+
+// tlogEntryDigest is a digest recorded in Rekor v2 entries.
+type tlogEntryDigest struct {
+	Algorithm string `json:"algorithm"`
+	Digest    []byte `json:"digest"`
+}
+
+func (d *tlogEntryDigest) sha256() ([]byte, error) {
+	if d.Algorithm != protocommon.HashAlgorithm_SHA2_256.String() {
+		return nil, fmt.Errorf("unsupported tlog entry digest algorithm %q", d.Algorithm)
+	}
+	return d.Digest, nil
+}
+
+// tlogEntryBody holds the parts of the transparency log entry kinds used by
+// Chains which bind an entry to the signed payload.
+type tlogEntryBody struct {
+	Kind string `json:"kind"`
+	Spec struct {
+		// Rekor v1 hashedrekord
+		Data *struct {
+			Hash tlogEntryHash `json:"hash"`
+		} `json:"data"`
+		// Rekor v1 dsse
+		PayloadHash *tlogEntryHash `json:"payloadHash"`
+		// Rekor v1 intoto
+		Content *struct {
+			PayloadHash *tlogEntryHash `json:"payloadHash"`
+		} `json:"content"`
+		// Rekor v2 hashedrekord
+		HashedRekordV002 *struct {
+			Data *tlogEntryDigest `json:"data"`
+		} `json:"hashedRekordV002"`
+		// Rekor v2 dsse
+		DSSEV002 *struct {
+			PayloadHash *tlogEntryDigest `json:"payloadHash"`
+		} `json:"dsseV002"`
+	} `json:"spec"`
+}
+
+// payloadDigest returns the SHA-256 digest recorded in the entry body.
+func (b *tlogEntryBody) payloadDigest() ([]byte, error) {
+	spec := b.Spec
+	switch {
+	case spec.Data != nil:
+		return spec.Data.Hash.sha256()
+	case spec.PayloadHash != nil:
+		return spec.PayloadHash.sha256()
+	case spec.Content != nil && spec.Content.PayloadHash != nil:
+		return spec.Content.PayloadHash.sha256()
+	case spec.HashedRekordV002 != nil && spec.HashedRekordV002.Data != nil:
+		return spec.HashedRekordV002.Data.sha256()
+	case spec.DSSEV002 != nil && spec.DSSEV002.PayloadHash != nil:
+		return spec.DSSEV002.PayloadHash.sha256()
+	}
+	return nil, fmt.Errorf("tlog entry of kind %q has no payload digest", b.Kind)
+}
+
+// verifyTlogEntryPayload checks that the entry is of the kind configured for the
+// payload and records its digest.
+func verifyTlogEntryPayload(entry *protorekor.TransparencyLogEntry, payload []byte, payloadFormat, entryType string) error {
+	body := tlogEntryBody{}
+	if err := json.Unmarshal(entry.GetCanonicalizedBody(), &body); err != nil {
+		return errors.Wrap(err, "unmarshalling tlog entry body")
+	}
+
+	kind := tlogEntryKind(payloadFormat, entryType)
+	if body.Kind != kind {
+		return fmt.Errorf("expected a %s tlog entry, got %q", kind, body.Kind)
+	}
+	recorded, err := body.payloadDigest()
+	if err != nil {
+		return err
+	}
+	if _, ok := formats.IntotoAttestationSet[config.PayloadType(payloadFormat)]; ok && kind == config.TlogEntryTypeHashedRekord {
+		// The entry signature is the one from the DSSE envelope.
+		payload = dsse.PAE(in_toto.PayloadType, payload)
+	}
+	digest := sha256.Sum256(payload)
+	if !bytes.Equal(recorded, digest[:]) {
+		return errors.New("tlog entry does not match the payload")
+	}
+	return nil
+}
@@ -0,0 +1,368 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chains
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/cyberphone/json-canonicalization/go/src/webpki.org/jsoncanonicalizer"
+	"github.com/google/go-cmp/cmp"
+	"github.com/in-toto/in-toto-golang/in_toto"
+	"github.com/secure-systems-lab/go-securesystemslib/dsse"
+	"github.com/sigstore/cosign/v2/pkg/cosign"
+	protorekor "github.com/sigstore/protobuf-specs/gen/pb-go/rekor/v1"
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/util"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/tektoncd/chains/pkg/config"
+	"github.com/transparency-dev/merkle/rfc6962"
+)
+
+func TestTlogEntryFromAnon(t *testing.T) {
+	logKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	anon := fakeRekorEntry(t, logKey, `{"apiVersion":"0.0.1","kind":"hashedrekord","spec":{}}`)
+
+	entry, err := tlogEntryFromAnon(anon)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := entry.GetKindVersion(); got.GetKind() != "hashedrekord" || got.GetVersion() != "0.0.1" {
+		t.Errorf("unexpected kind and version: %v", got)
+	}
+	if diff := cmp.Diff(anon, rekorEntry(entry)); diff != "" {
+		t.Errorf("unexpected round trip (-want +got): %s", diff)
+	}
+
+	// Without an inclusion proof, only the promise of the log is kept.
+	anon.Verification.InclusionProof = nil
+	if entry, err = tlogEntryFromAnon(anon); err != nil {
+		t.Fatal(err)
+	}
+	if entry.GetInclusionProof() != nil {
+		t.Errorf("expected no inclusion proof, got %v", entry.GetInclusionProof())
+	}
+	if diff := cmp.Diff(anon, rekorEntry(entry)); diff != "" {
+		t.Errorf("unexpected round trip (-want +got): %s", diff)
+	}
+
+	anon.Body = 42
+	if _, err := tlogEntryFromAnon(anon); err == nil {
+		t.Error("expected an error for a body which is not a string")
+	}
+}
+
+func TestRekorEntry(t *testing.T) {
+	// Entries of Rekor v2 logs carry no inclusion promise.
+	entry := &protorekor.TransparencyLogEntry{
+		LogIndex:          1,
+		CanonicalizedBody: []byte("{}"),
+		InclusionProof:    &protorekor.InclusionProof{LogIndex: 1, TreeSize: 2},
+	}
+	if got := rekorEntry(entry); got != nil {
+		t.Errorf("expected no Rekor v1 entry, got %v", got)
+	}
+}
+
+func TestTransparencyAnnotation(t *testing.T) {
+	entry := &protorekor.TransparencyLogEntry{LogIndex: 42}
+	tests := []struct {
+		name string
+		cfg  config.TransparencyConfig
+		want string
+	}{{
+		name: "v1",
+		cfg:  config.TransparencyConfig{URL: "https://rekor.sigstore.dev"},
+		want: "https://rekor.sigstore.dev/api/v1/log/entries?logIndex=42",
+	}, {
+		name: "explicit v1",
+		cfg:  config.TransparencyConfig{URL: "https://rekor.sigstore.dev", APIVersion: config.TransparencyAPIVersionV1},
+		want: "https://rekor.sigstore.dev/api/v1/log/entries?logIndex=42",
+	}, {
+		name: "v2",
+		cfg:  config.TransparencyConfig{URL: "https://log2025-1.rekor.sigstore.dev", APIVersion: config.TransparencyAPIVersionV2},
+		want: "https://log2025-1.rekor.sigstore.dev#42",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := transparencyAnnotation(tt.cfg, entry); got != tt.want {
+				t.Errorf("transparencyAnnotation() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifyTlogEntryOffline(t *testing.T) {
+	ctx := context.Background()
+	logKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pemKey, err := cryptoutils.MarshalPublicKeyToPEM(logKey.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifiers, err := rekorVerifiers(string(pemKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherPEM, err := cryptoutils.MarshalPublicKeyToPEM(otherKey.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherVerifiers, err := rekorVerifiers(string(otherPEM))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	payload := []byte(`{"_type":"https://in-toto.io/Statement/v0.1"}`)
+	digest := sha256.Sum256(payload)
+	paeDigest := sha256.Sum256(dsse.PAE(in_toto.PayloadType, payload))
+	hashedrekord := fmt.Sprintf(`{"kind":"hashedrekord","spec":{"data":{"hash":{"algorithm":"sha256","value":"%x"}}}}`, digest)
+
+	tests := []struct {
+		name          string
+		entry         func() *protorekor.TransparencyLogEntry
+		payload       []byte
+		payloadFormat string
+		entryType     string
+		verifiers     map[string]signature.Verifier
+		wantErr       bool
+	}{{
+		name: "hashedrekord",
+		entry: func() *protorekor.TransparencyLogEntry {
+			return fakeTlogEntry(t, logKey, hashedrekord)
+		},
+		payload:       payload,
+		payloadFormat: "simplesigning",
+		verifiers:     verifiers,
+	}, {
+		name: "hashedrekord for an in-toto attestation",
+		entry: func() *protorekor.TransparencyLogEntry {
+			return fakeTlogEntry(t, logKey, fmt.Sprintf(`{"kind":"hashedrekord","spec":{"data":{"hash":{"algorithm":"sha256","value":"%x"}}}}`, paeDigest))
+		},
+		payload:       payload,
+		payloadFormat: "slsa/v1",
+		entryType:     config.TlogEntryTypeHashedRekord,
+		verifiers:     verifiers,
+	}, {
+		name: "dsse",
+		entry: func() *protorekor.TransparencyLogEntry {
+			return fakeTlogEntry(t, logKey, fmt.Sprintf(`{"kind":"dsse","spec":{"payloadHash":{"algorithm":"sha256","value":"%x"}}}`, digest))
+		},
+		payload:       payload,
+		payloadFormat: "slsa/v1",
+		verifiers:     verifiers,
+	}, {
+		name: "intoto",
+		entry: func() *protorekor.TransparencyLogEntry {
+			return fakeTlogEntry(t, logKey, fmt.Sprintf(`{"apiVersion":"0.0.2","kind":"intoto","spec":{"content":{"payloadHash":{"algorithm":"sha256","value":"%x"}}}}`, digest))
+		},
+		payload:       payload,
+		payloadFormat: "slsa/v1",
+		entryType:     config.TlogEntryTypeIntoto,
+		verifiers:     verifiers,
+	}, {
+		name: "kind mismatch",
+		entry: func() *protorekor.TransparencyLogEntry {
+			return fakeTlogEntry(t, logKey, fmt.Sprintf(`{"kind":"dsse","spec":{"payloadHash":{"algorithm":"sha256","value":"%x"}}}`, digest))
+		},
+		payload:       payload,
+		payloadFormat: "slsa/v1",
+		entryType:     config.TlogEntryTypeIntoto,
+		verifiers:     verifiers,
+		wantErr:       true,
+	}, {
+		name: "payload mismatch",
+		entry: func() *protorekor.TransparencyLogEntry {
+			return fakeTlogEntry(t, logKey, hashedrekord)
+		},
+		payload:       []byte("tampered"),
+		payloadFormat: "simplesigning",
+		verifiers:     verifiers,
+		wantErr:       true,
+	}, {
+		name: "untrusted log",
+		entry: func() *protorekor.TransparencyLogEntry {
+			return fakeTlogEntry(t, logKey, hashedrekord)
+		},
+		payload:       payload,
+		payloadFormat: "simplesigning",
+		verifiers:     otherVerifiers,
+		wantErr:       true,
+	}, {
+		name: "tampered signed entry timestamp",
+		entry: func() *protorekor.TransparencyLogEntry {
+			e := fakeTlogEntry(t, logKey, hashedrekord)
+			e.IntegratedTime++
+			return e
+		},
+		payload:       payload,
+		payloadFormat: "simplesigning",
+		verifiers:     verifiers,
+		wantErr:       true,
+	}, {
+		name: "missing inclusion proof",
+		entry: func() *protorekor.TransparencyLogEntry {
+			e := fakeTlogEntry(t, logKey, hashedrekord)
+			e.InclusionProof = nil
+			return e
+		},
+		payload:       payload,
+		payloadFormat: "simplesigning",
+		verifiers:     verifiers,
+		wantErr:       true,
+	}, {
+		name: "unsupported kind",
+		entry: func() *protorekor.TransparencyLogEntry {
+			return fakeTlogEntry(t, logKey, `{"kind":"rekord","spec":{}}`)
+		},
+		payload:       payload,
+		payloadFormat: "simplesigning",
+		verifiers:     verifiers,
+		wantErr:       true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verifyTlogEntryOffline(ctx, tt.entry(), tt.payload, tt.payloadFormat, tt.entryType, tt.verifiers)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("verifyTlogEntryOffline() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRekorVerifiers(t *testing.T) {
+	var pems []string
+	var ids []string
+	for i := 0; i < 2; i++ {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pemKey, err := cryptoutils.MarshalPublicKeyToPEM(key.Public())
+		if err != nil {
+			t.Fatal(err)
+		}
+		logID, err := cosign.GetTransparencyLogID(key.Public())
+		if err != nil {
+			t.Fatal(err)
+		}
+		pems = append(pems, string(pemKey))
+		ids = append(ids, logID)
+	}
+
+	verifiers, err := rekorVerifiers(strings.Join(pems, "\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range ids {
+		if _, ok := verifiers[id]; !ok {
+			t.Errorf("expected a verifier for log ID %s", id)
+		}
+	}
+	if _, err := rekorVerifiers("not a key"); err == nil {
+		t.Error("expected an error without any PEM key")
+	}
+}
+
+// fakeTlogEntry returns an entry with the given body, logged as the only leaf of
+// a Rekor v1 transparency log signed by logKey.
+func fakeTlogEntry(t *testing.T, logKey *ecdsa.PrivateKey, body string) *protorekor.TransparencyLogEntry {
+	t.Helper()
+	entry, err := tlogEntryFromAnon(fakeRekorEntry(t, logKey, body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return entry
+}
+
+// fakeRekorEntry is like fakeTlogEntry, in the format of the Rekor v1 API.
+func fakeRekorEntry(t *testing.T, logKey *ecdsa.PrivateKey, body string) *models.LogEntryAnon {
+	t.Helper()
+	ctx := context.Background()
+	signer, err := signature.LoadECDSASignerVerifier(logKey, crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logID, err := cosign.GetTransparencyLogID(logKey.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootHash := rfc6962.DefaultHasher.HashLeaf([]byte(body))
+	checkpoint, err := util.CreateAndSignCheckpoint(ctx, "rekor.example.com", 1, 1, rootHash, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encodedBody := base64.StdEncoding.EncodeToString([]byte(body))
+	integratedTime := int64(1700000000)
+	logIndex := int64(0)
+	treeSize := int64(1)
+	set, err := json.Marshal(map[string]interface{}{
+		"body":           encodedBody,
+		"integratedTime": integratedTime,
+		"logIndex":       logIndex,
+		"logID":          logID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	canonicalized, err := jsoncanonicalizer.Transform(set)
+	if err != nil {
+		t.Fatal(err)
+	}
+	setSig, err := signer.SignMessage(strings.NewReader(string(canonicalized)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hexRoot := hex.EncodeToString(rootHash)
+	cp := string(checkpoint)
+	return &models.LogEntryAnon{
+		Body:           encodedBody,
+		IntegratedTime: &integratedTime,
+		LogIndex:       &logIndex,
+		LogID:          &logID,
+		Verification: &models.LogEntryAnonVerification{
+			SignedEntryTimestamp: setSig,
+			InclusionProof: &models.InclusionProof{
+				Checkpoint: &cp,
+				Hashes:     []string{},
+				LogIndex:   &logIndex,
+				RootHash:   &hexRoot,
+				TreeSize:   &treeSize,
+			},
+		},
+	}
+}
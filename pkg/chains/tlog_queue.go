@@ -0,0 +1,377 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chains
+
+import (
+	"context"
+	"crypto"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+	cbundle "github.com/sigstore/cosign/v2/pkg/cosign/bundle"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/tektoncd/chains/pkg/artifacts"
+	"github.com/tektoncd/chains/pkg/chains/annotations"
+	"github.com/tektoncd/chains/pkg/chains/objects"
+	"github.com/tektoncd/chains/pkg/chains/signing"
+	"github.com/tektoncd/chains/pkg/chains/storage/docdb"
+	"github.com/tektoncd/chains/pkg/chains/storage/gcs"
+	"github.com/tektoncd/chains/pkg/chains/storage/oci"
+	"github.com/tektoncd/chains/pkg/chains/storage/tekton"
+	"github.com/tektoncd/chains/pkg/config"
+	"github.com/tektoncd/chains/pkg/metrics"
+	"k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/workqueue"
+	"knative.dev/pkg/logging"
+)
+
+const (
+	tlogQueueBaseDelay = 5 * time.Second
+	tlogQueueMaxDelay  = 10 * time.Minute
+
+	// maxTlogQueueAttempts bounds the in-memory retries for an object. Once it is
+	// reached the object is dropped from the queue, but its pending uploads stay
+	// recorded on the object and are queued again the next time it is reconciled.
+	maxTlogQueueAttempts = 15
+)
+
+// maxPendingTlogSize bounds the size of the TransparencyPendingAnnotation: the
+// pending entries hold whole payloads and signatures, while all the annotations
+// of an object must fit in 256KiB. The annotation is further limited to the room
+// left by the other annotations of the object. The uploads which would not fit
+// are not deferred, they fail like when there is no queue.
+var maxPendingTlogSize = 128 * 1024
+
+// tlogBundleBackends are the storage backends that store transparency log data
+// next to the signature, and therefore need to be updated once a deferred upload
+// succeeds.
+var tlogBundleBackends = sets.New[string](
+	oci.StorageBackendOCI,
+	tekton.StorageBackendTekton,
+	gcs.StorageBackendGCS,
+	docdb.StorageTypeDocDB,
+)
+
+// pendingTlogEntry is a signature whose transparency log upload failed. It holds
+// everything needed to upload it later and to refresh the storage backends that
+// embed the resulting Rekor bundle.
+type pendingTlogEntry struct {
+	PayloadFormat string `json:"payloadFormat"`
+	EntryType     string `json:"entryType,omitempty"`
+	Payload       []byte `json:"payload"`
+	Signature     []byte `json:"signature"`
+	Cert          string `json:"cert,omitempty"`
+	Chain         string `json:"chain,omitempty"`
+	// PublicKey is the PEM encoded public key of the signer, set when there is no Cert.
+	PublicKey []byte   `json:"publicKey,omitempty"`
+	ShortKey  string   `json:"shortKey"`
+	FullKey   string   `json:"fullKey"`
+	Backends  []string `json:"backends,omitempty"`
+}
+
+func newPendingTlogEntry(cfg config.Config, signable artifacts.Signable, obj interface{}, signer signing.Signer, signature, rawPayload []byte, payloadFormat config.PayloadType) (pendingTlogEntry, error) {
+	entry := pendingTlogEntry{
+		PayloadFormat: string(payloadFormat),
+		EntryType:     signable.TlogEntryType(cfg),
+		Payload:       rawPayload,
+		Signature:     signature,
+		Cert:          signer.Cert(),
+		Chain:         signer.Chain(),
+		ShortKey:      signable.ShortKey(obj),
+		FullKey:       signable.FullKey(obj),
+		Backends:      sets.List[string](signable.StorageBackend(cfg).Intersection(tlogBundleBackends)),
+	}
+	if entry.Cert == "" {
+		pem, err := publicKeyOrCert(signer, "")
+		if err != nil {
+			return pendingTlogEntry{}, err
+		}
+		entry.PublicKey = pem
+	}
+	return entry, nil
+}
+
+// verifier returns the PEM encoded certificate or public key to upload with the entry.
+func (p pendingTlogEntry) verifier() string {
+	if p.Cert != "" {
+		return p.Cert
+	}
+	return string(p.PublicKey)
+}
+
+// tektonStorageSize returns the size of the annotations the tekton storage
+// backend writes for the entry, once its upload is deferred.
+func (p pendingTlogEntry) tektonStorageSize() int {
+	if !slices.Contains(p.Backends, tekton.StorageBackendTekton) {
+		return 0
+	}
+	size := 0
+	for format, value := range map[string][]byte{
+		tekton.PayloadAnnotationFormat:   p.Payload,
+		tekton.SignatureAnnotationFormat: p.Signature,
+		tekton.CertAnnotationsFormat:     []byte(p.Cert),
+		tekton.ChainAnnotationFormat:     []byte(p.Chain),
+	} {
+		size += len(fmt.Sprintf(format, p.ShortKey)) + base64.StdEncoding.EncodedLen(len(value))
+	}
+	return size
+}
+
+// pendingTlogBudget returns the size left for the TransparencyPendingAnnotation
+// once the given annotations, and the reserved size, are written along the
+// latest annotations of the object.
+func (o *ObjectSigner) pendingTlogBudget(ctx context.Context, obj objects.TektonObject, written map[string]string, reserved int) (int, error) {
+	latest, err := obj.GetLatestAnnotations(ctx, o.Pipelineclientset)
+	if err != nil {
+		return 0, err
+	}
+	merged := maps.Clone(latest)
+	if merged == nil {
+		merged = map[string]string{}
+	}
+	maps.Copy(merged, written)
+	delete(merged, annotations.TransparencyPendingAnnotation)
+	size := reserved + len(annotations.TransparencyPendingAnnotation)
+	for k, v := range merged {
+		size += len(k) + len(v)
+	}
+	return min(maxPendingTlogSize, validation.TotalAnnotationSizeLimitB-size), nil
+}
+
+// fitsPendingTlog returns an error when the entries do not fit in the
+// TransparencyPendingAnnotation of the object, see pendingTlogBudget.
+func (o *ObjectSigner) fitsPendingTlog(ctx context.Context, obj objects.TektonObject, written map[string]string, entries []pendingTlogEntry, reserved int) error {
+	budget, err := o.pendingTlogBudget(ctx, obj, written, reserved)
+	if err != nil {
+		return err
+	}
+	_, err = encodePendingTlogEntries(entries, budget)
+	return err
+}
+
+// encodePendingTlogEntries returns the TransparencyPendingAnnotation recording
+// the entries, and an error when it takes more than the budget.
+func encodePendingTlogEntries(entries []pendingTlogEntry, budget int) (string, error) {
+	if len(entries) == 0 {
+		return "", nil
+	}
+	raw, err := json.Marshal(entries)
+	if err != nil {
+		return "", errors.Wrap(err, "marshalling pending transparency log entries")
+	}
+	encoded := base64.StdEncoding.EncodeToString(raw)
+	if len(encoded) > budget {
+		return "", fmt.Errorf("%d pending transparency log entries take %d bytes, more than the %d bytes left in the annotations", len(entries), len(encoded), max(budget, 0))
+	}
+	return encoded, nil
+}
+
+func decodePendingTlogEntries(val string) ([]pendingTlogEntry, error) {
+	if val == "" {
+		return nil, nil
+	}
+	raw, err := base64.StdEncoding.DecodeString(val)
+	if err != nil {
+		return nil, errors.Wrap(err, "decoding pending transparency log entries")
+	}
+	var entries []pendingTlogEntry
+	if err := json.Unmarshal(raw, &entries); err != nil {
+		return nil, errors.Wrap(err, "unmarshalling pending transparency log entries")
+	}
+	return entries, nil
+}
+
+// uploadPendingTlog uploads the transparency log entries recorded as pending on
+// the object. The storage backends embedding the Rekor bundle are refreshed for
+// each successful upload, and the entries that failed again are written back.
+func (o *ObjectSigner) uploadPendingTlog(ctx context.Context, obj objects.TektonObject) error {
+	cfg := *config.FromContext(ctx)
+	logger := logging.FromContext(ctx)
+
+	latest, err := obj.GetLatestAnnotations(ctx, o.Pipelineclientset)
+	if err != nil {
+		return err
+	}
+	if !annotations.TlogPending(latest) {
+		return nil
+	}
+	pending, err := decodePendingTlogEntries(latest[annotations.TransparencyPendingAnnotation])
+	if err != nil {
+		// Retrying cannot fix a corrupted annotation, so drop it.
+		logger.Errorf("Dropping pending transparency log uploads for %s %s/%s: %v", obj.GetGVK(), obj.GetNamespace(), obj.GetName(), err)
+		return annotations.AddAnnotations(ctx, obj, o.Pipelineclientset, map[string]string{annotations.TransparencyPendingAnnotation: ""})
+	}
+
+	tlogClient, err := getRekor(cfg.Transparency)
+	if err != nil {
+		return err
+	}
+
+	var merr *multierror.Error
+	var remaining []pendingTlogEntry
+	extraAnnotations := map[string]string{}
+	for _, p := range pending {
+		entry, err := tlogClient.UploadTlog(ctx, nil, p.Signature, p.Payload, p.verifier(), p.PayloadFormat, p.EntryType)
+		if err != nil {
+			logger.Warnf("error uploading pending entry %s to tlog: %v", p.ShortKey, err)
+			if o.Recorder != nil {
+				o.Recorder.RecordErrorMetric(ctx, metrics.TlogError)
+			}
+			merr = multierror.Append(merr, err)
+			remaining = append(remaining, p)
+			continue
+		}
+		logger.Infof("Uploaded pending entry to %s with index %d", cfg.Transparency.URL, entry.GetLogIndex())
+		extraAnnotations[annotations.ChainsTransparencyAnnotation] = transparencyAnnotation(cfg.Transparency, entry)
+		measureMetrics(ctx, metrics.PayloadUploadedCount, o.Recorder)
+
+		var rekorBundle *cbundle.RekorBundle
+		if anon := rekorEntry(entry); anon != nil {
+			if rekorBundle = cbundle.EntryToBundle(anon); rekorBundle == nil {
+				logger.Warn("Rekor entry missing verification data, skipping bundle for offline verification")
+				continue
+			}
+		}
+		var pubKey crypto.PublicKey
+		if len(p.PublicKey) > 0 {
+			if pubKey, err = cryptoutils.UnmarshalPEMToPublicKey(p.PublicKey); err != nil {
+				logger.Warnf("Could not parse public key of pending entry %s: %v", p.ShortKey, err)
+			}
+		}
+		for _, backend := range p.Backends {
+			b, ok := o.Backends[backend]
+			if !ok {
+				logger.Warnf("could not find backend '%s' to store the Rekor bundle of %s", backend, p.FullKey)
+				continue
+			}
+			storageOpts := config.StorageOpts{
+				ShortKey:      p.ShortKey,
+				FullKey:       p.FullKey,
+				Cert:          p.Cert,
+				Chain:         p.Chain,
+				PublicKey:     pubKey,
+				PayloadFormat: config.PayloadType(p.PayloadFormat),
+				RekorBundle:   rekorBundle,
+				TlogEntry:     entry,
+			}
+			// The entry is already in the log; uploading it again would only
+			// duplicate it, so a storage failure is not retried.
+			if err := b.StorePayload(ctx, obj, p.Payload, string(p.Signature), storageOpts); err != nil {
+				logger.Errorf("error storing Rekor bundle of %s in %s: %v", p.FullKey, backend, err)
+				if o.Recorder != nil {
+					o.Recorder.RecordErrorMetric(ctx, metrics.StorageError)
+				}
+			}
+		}
+	}
+
+	// The remaining entries are already recorded on the object, and take less room.
+	encoded, err := encodePendingTlogEntries(remaining, maxPendingTlogSize)
+	if err != nil {
+		return err
+	}
+	extraAnnotations[annotations.TransparencyPendingAnnotation] = encoded
+	if err := annotations.AddAnnotations(ctx, obj, o.Pipelineclientset, extraAnnotations); err != nil {
+		merr = multierror.Append(merr, err)
+	}
+	return merr.ErrorOrNil()
+}
+
+// TlogQueue retries, in the background, the transparency log uploads that failed
+// while signing. The uploads are persisted on the Tekton object in the
+// TransparencyPendingAnnotation, so they survive controller restarts: the
+// reconcilers queue again any signed object which still has pending uploads.
+type TlogQueue struct {
+	signer *ObjectSigner
+	queue  workqueue.TypedRateLimitingInterface[string]
+
+	mu    sync.Mutex
+	items map[string]tlogQueueItem
+}
+
+type tlogQueueItem struct {
+	obj objects.TektonObject
+	cfg *config.Config
+}
+
+// NewTlogQueue returns a TlogQueue uploading pending entries with the given signer.
+func NewTlogQueue(signer *ObjectSigner) *TlogQueue {
+	return &TlogQueue{
+		signer: signer,
+		queue: workqueue.NewTypedRateLimitingQueueWithConfig(
+			workqueue.NewTypedItemExponentialFailureRateLimiter[string](tlogQueueBaseDelay, tlogQueueMaxDelay),
+			workqueue.TypedRateLimitingQueueConfig[string]{Name: "chains-tlog-uploads"},
+		),
+		items: map[string]tlogQueueItem{},
+	}
+}
+
+// Enqueue adds the object to the queue, along with the configuration in ctx.
+func (q *TlogQueue) Enqueue(ctx context.Context, obj objects.TektonObject) {
+	key := fmt.Sprintf("%s/%s/%s", obj.GetGVK(), obj.GetNamespace(), obj.GetName())
+	q.mu.Lock()
+	q.items[key] = tlogQueueItem{obj: obj, cfg: config.FromContext(ctx)}
+	q.mu.Unlock()
+	q.queue.Add(key)
+}
+
+// Run processes the queue until ctx is done.
+func (q *TlogQueue) Run(ctx context.Context) {
+	go func() {
+		<-ctx.Done()
+		q.queue.ShutDown()
+	}()
+	for q.processNextItem(ctx) {
+	}
+}
+
+func (q *TlogQueue) processNextItem(ctx context.Context) bool {
+	key, shutdown := q.queue.Get()
+	if shutdown {
+		return false
+	}
+	defer q.queue.Done(key)
+	logger := logging.FromContext(ctx)
+
+	q.mu.Lock()
+	item, ok := q.items[key]
+	q.mu.Unlock()
+	if !ok {
+		q.queue.Forget(key)
+		return true
+	}
+
+	err := q.signer.uploadPendingTlog(config.ToContext(ctx, item.cfg), item.obj)
+	if err != nil && q.queue.NumRequeues(key) < maxTlogQueueAttempts {
+		logger.Warnf("Retrying pending transparency log uploads for %s: %v", key, err)
+		q.queue.AddRateLimited(key)
+		return true
+	}
+	if err != nil {
+		logger.Errorf("Giving up on pending transparency log uploads for %s until it is reconciled again: %v", key, err)
+	}
+	q.queue.Forget(key)
+	q.mu.Lock()
+	delete(q.items, key)
+	q.mu.Unlock()
+	return true
+}
@@ -0,0 +1,316 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chains
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	protorekor "github.com/sigstore/protobuf-specs/gen/pb-go/rekor/v1"
+	"github.com/tektoncd/chains/pkg/chains/annotations"
+	"github.com/tektoncd/chains/pkg/chains/objects"
+	"github.com/tektoncd/chains/pkg/chains/signing"
+	"github.com/tektoncd/chains/pkg/config"
+	"github.com/tektoncd/chains/pkg/test/tekton"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	fakepipelineclient "github.com/tektoncd/pipeline/pkg/client/injection/client/fake"
+	"k8s.io/apimachinery/pkg/api/validation"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+func TestSigner_DeferredTlogUpload(t *testing.T) {
+	failing := &mockFailingRekor{}
+	cleanup := setupMocks(failing)
+	defer cleanup()
+
+	ctx, _ := rtesting.SetupFakeContext(t)
+	ps := fakepipelineclient.Get(ctx)
+	ctx = config.ToContext(ctx, &config.Config{
+		Artifacts: config.ArtifactConfigs{
+			TaskRuns: config.Artifact{
+				Format:         "slsa/v1",
+				StorageBackend: sets.New[string]("oci", "mock"),
+				Signer:         "x509",
+			},
+		},
+		Transparency: config.TransparencyConfig{
+			Enabled: true,
+			URL:     testRekorURL,
+		},
+	})
+
+	ociBackend := &mockBackend{backendType: "oci"}
+	mock := &mockBackend{backendType: "mock"}
+	os := &ObjectSigner{
+		Backends:          fakeAllBackends([]*mockBackend{ociBackend, mock}),
+		SecretPath:        "./signing/x509/testdata/",
+		Pipelineclientset: ps,
+	}
+	os.TlogQueue = NewTlogQueue(os)
+
+	obj := objects.NewTaskRunObjectV1(&v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "deferred-tlog", Namespace: "default"},
+	})
+	tekton.CreateObject(t, ctx, ps, obj)
+
+	if err := os.Sign(ctx, obj); err != nil {
+		t.Fatalf("Signer.Sign() error = %v", err)
+	}
+	if failing.attempts != 1 {
+		t.Fatalf("expected 1 upload attempt, got %d", failing.attempts)
+	}
+	if ociBackend.storedOpts.RekorBundle != nil {
+		t.Error("expected no RekorBundle while the upload is pending")
+	}
+
+	signed, err := tekton.GetObject(t, ctx, ps, obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := signed.GetAnnotations()[annotations.ChainsAnnotation]; got != "true" {
+		t.Errorf("expected %s to be true, got %q", annotations.ChainsAnnotation, got)
+	}
+	if !annotations.TlogPending(signed.GetAnnotations()) {
+		t.Fatalf("expected pending tlog uploads, got annotations %v", signed.GetAnnotations())
+	}
+	pending, err := decodePendingTlogEntries(signed.GetAnnotations()[annotations.TransparencyPendingAnnotation])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 1 {
+		t.Fatalf("expected 1 pending entry, got %d", len(pending))
+	}
+	if diff := cmp.Diff([]string{"oci"}, pending[0].Backends); diff != "" {
+		t.Errorf("unexpected backends (-want +got): %s", diff)
+	}
+
+	// The log is back: the queue uploads the entry and refreshes the OCI storage.
+	rekor := &mockRekor{}
+	setupMocks(rekor)
+	if !os.TlogQueue.processNextItem(ctx) {
+		t.Fatal("queue unexpectedly shut down")
+	}
+	if len(rekor.entries) != 1 {
+		t.Fatalf("expected 1 transparency log entry, got %d", len(rekor.entries))
+	}
+
+	uploaded, err := tekton.GetObject(t, ctx, ps, obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if annotations.TlogPending(uploaded.GetAnnotations()) {
+		t.Errorf("expected no pending tlog uploads, got annotations %v", uploaded.GetAnnotations())
+	}
+	if got, want := uploaded.GetAnnotations()[annotations.ChainsTransparencyAnnotation], testRekorURL+"/api/v1/log/entries?logIndex=0"; got != want {
+		t.Errorf("expected transparency annotation %q, got %q", want, got)
+	}
+	if ociBackend.storedOpts.RekorBundle == nil {
+		t.Error("expected the OCI backend to be updated with the Rekor bundle")
+	}
+	if mock.storedOpts.RekorBundle != nil {
+		t.Error("expected the non-OCI backend not to be updated")
+	}
+	if len(os.TlogQueue.items) != 0 {
+		t.Errorf("expected the queue to be drained, got %d items", len(os.TlogQueue.items))
+	}
+}
+
+func TestSigner_TlogFailureWithoutQueue(t *testing.T) {
+	cleanup := setupMocks(&mockFailingRekor{})
+	defer cleanup()
+
+	ctx, _ := rtesting.SetupFakeContext(t)
+	ps := fakepipelineclient.Get(ctx)
+	ctx = config.ToContext(ctx, &config.Config{
+		Artifacts: config.ArtifactConfigs{
+			TaskRuns: config.Artifact{
+				Format:         "slsa/v1",
+				StorageBackend: sets.New[string]("mock"),
+				Signer:         "x509",
+			},
+		},
+		Transparency: config.TransparencyConfig{
+			Enabled: true,
+			URL:     testRekorURL,
+		},
+		Retry: config.RetryConfig{MaxRetries: 3},
+	})
+
+	os := &ObjectSigner{
+		Backends:          fakeAllBackends([]*mockBackend{{backendType: "mock"}}),
+		SecretPath:        "./signing/x509/testdata/",
+		Pipelineclientset: ps,
+	}
+	obj := objects.NewTaskRunObjectV1(&v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "tlog-failure", Namespace: "default"},
+	})
+	tekton.CreateObject(t, ctx, ps, obj)
+
+	if err := os.Sign(ctx, obj); err == nil {
+		t.Fatal("expected Signer.Sign() to fail without a TlogQueue")
+	}
+	got, err := tekton.GetObject(t, ctx, ps, obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.GetAnnotations()[annotations.RetryAnnotation] != "0" {
+		t.Errorf("expected a retry to be scheduled, got annotations %v", got.GetAnnotations())
+	}
+}
+
+func TestSigner_PendingTlogTooLarge(t *testing.T) {
+	cleanup := setupMocks(&mockFailingRekor{})
+	defer cleanup()
+
+	tests := []struct {
+		name        string
+		maxSize     int
+		annotations map[string]string
+	}{{
+		name:    "larger than the limit",
+		maxSize: 16,
+	}, {
+		// The payload stored by the tekton backend for a previous format leaves
+		// too little room in the annotations of the object.
+		name:    "larger than the room left in the annotations",
+		maxSize: maxPendingTlogSize,
+		annotations: map[string]string{
+			"chains.tekton.dev/payload-taskrun-previous": strings.Repeat("a", validation.TotalAnnotationSizeLimitB-1024),
+		},
+	}}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			maxSize := maxPendingTlogSize
+			maxPendingTlogSize = tt.maxSize
+			defer func() { maxPendingTlogSize = maxSize }()
+
+			ctx, _ := rtesting.SetupFakeContext(t)
+			ps := fakepipelineclient.Get(ctx)
+			ctx = config.ToContext(ctx, &config.Config{
+				Artifacts: config.ArtifactConfigs{
+					TaskRuns: config.Artifact{
+						Format:         "slsa/v1",
+						StorageBackend: sets.New[string]("mock"),
+						Signer:         "x509",
+					},
+				},
+				Transparency: config.TransparencyConfig{
+					Enabled: true,
+					URL:     testRekorURL,
+				},
+				Retry: config.RetryConfig{MaxRetries: 3},
+			})
+
+			os := &ObjectSigner{
+				Backends:          fakeAllBackends([]*mockBackend{{backendType: "mock"}}),
+				SecretPath:        "./signing/x509/testdata/",
+				Pipelineclientset: ps,
+			}
+			os.TlogQueue = NewTlogQueue(os)
+			obj := objects.NewTaskRunObjectV1(&v1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("tlog-too-large-%d", i), Namespace: "default", Annotations: tt.annotations},
+			})
+			tekton.CreateObject(t, ctx, ps, obj)
+
+			// The upload does not fit in the annotation, so it fails instead of being
+			// deferred, and the run is signed again.
+			if err := os.Sign(ctx, obj); err == nil {
+				t.Fatal("expected Signer.Sign() to fail when the pending upload is too large")
+			}
+			got, err := tekton.GetObject(t, ctx, ps, obj)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if annotations.TlogPending(got.GetAnnotations()) {
+				t.Errorf("expected no pending tlog uploads, got annotations %v", got.GetAnnotations())
+			}
+			if got.GetAnnotations()[annotations.RetryAnnotation] != "0" {
+				t.Errorf("expected a retry to be scheduled, got annotations %v", got.GetAnnotations())
+			}
+			if len(os.TlogQueue.items) != 0 {
+				t.Errorf("expected nothing to be queued, got %d items", len(os.TlogQueue.items))
+			}
+		})
+	}
+}
+
+func TestPendingTlogEntry_TektonStorageSize(t *testing.T) {
+	p := pendingTlogEntry{
+		Payload:   []byte(`{"foo":"bar"}`),
+		Signature: []byte("sig"),
+		Cert:      "cert",
+		ShortKey:  "taskrun-uid",
+		Backends:  []string{"oci"},
+	}
+	if got := p.tektonStorageSize(); got != 0 {
+		t.Errorf("tektonStorageSize() without the tekton backend = %d, want 0", got)
+	}
+	p.Backends = append(p.Backends, "tekton")
+	// The keys of the payload, signature, cert and chain annotations, and their base64 encoded values.
+	want := len("chains.tekton.dev/payload-taskrun-uid") + 20 +
+		len("chains.tekton.dev/signature-taskrun-uid") + 4 +
+		len("chains.tekton.dev/cert-taskrun-uid") + 8 +
+		len("chains.tekton.dev/chain-taskrun-uid")
+	if got := p.tektonStorageSize(); got != want {
+		t.Errorf("tektonStorageSize() = %d, want %d", got, want)
+	}
+}
+
+func TestPendingTlogEntriesRoundTrip(t *testing.T) {
+	entries := []pendingTlogEntry{{
+		PayloadFormat: "slsa/v1",
+		Payload:       []byte(`{"foo":"bar"}`),
+		Signature:     []byte("sig"),
+		PublicKey:     []byte("-----BEGIN PUBLIC KEY-----"),
+		ShortKey:      "taskrun-uid",
+		FullKey:       "tekton.dev-v1-TaskRun-uid",
+		Backends:      []string{"oci"},
+	}}
+	encoded, err := encodePendingTlogEntries(entries, maxPendingTlogSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := decodePendingTlogEntries(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(entries, decoded); diff != "" {
+		t.Errorf("unexpected entries (-want +got): %s", diff)
+	}
+	if decoded[0].verifier() != "-----BEGIN PUBLIC KEY-----" {
+		t.Errorf("expected the public key to be used as verifier, got %q", decoded[0].verifier())
+	}
+
+	if encoded, err := encodePendingTlogEntries(nil, maxPendingTlogSize); err != nil || encoded != "" {
+		t.Errorf("expected empty encoding, got %q, %v", encoded, err)
+	}
+	if _, err := decodePendingTlogEntries("not base64!"); err == nil {
+		t.Error("expected an error decoding an invalid annotation")
+	}
+}
+
+type mockFailingRekor struct {
+	attempts int
+}
+
+func (r *mockFailingRekor) UploadTlog(_ context.Context, _ signing.Signer, _, _ []byte, _, _, _ string) (*protorekor.TransparencyLogEntry, error) {
+	r.attempts++
+	return nil, errors.New("rekor unavailable")
+}
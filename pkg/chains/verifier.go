@@ -14,11 +14,21 @@ limitations under the License.
 package chains
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
 	"strings"
 
+	"github.com/secure-systems-lab/go-securesystemslib/dsse"
+	"github.com/sigstore/sigstore/pkg/signature"
 	"github.com/tektoncd/chains/pkg/artifacts"
+	"github.com/tektoncd/chains/pkg/chains/formats"
 	"github.com/tektoncd/chains/pkg/chains/objects"
+	"github.com/tektoncd/chains/pkg/chains/signing"
 	"github.com/tektoncd/chains/pkg/chains/storage"
 	"github.com/tektoncd/chains/pkg/config"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
@@ -59,6 +69,16 @@ func (tv *TaskRunVerifier) VerifyTaskRun(ctx context.Context, tr *v1.TaskRun) er
 	}
 	signers := allSigners(ctx, tv.SecretPath, cfg)
 
+	// Stored transparency log entries are verified offline when the trusted log keys are configured,
+	// and the signatures uploaded to the log must all have their entry stored with them.
+	var tlogVerifiers map[string]signature.Verifier
+	if cfg.Transparency.PublicKeys != "" && uploadedToTlog(cfg, trObj) {
+		tlogVerifiers, err = rekorVerifiers(cfg.Transparency.PublicKeys)
+		if err != nil {
+			return err
+		}
+	}
+
 	for _, signableType := range enabledSignableTypes {
 		if !signableType.Enabled(cfg) {
 			continue
@@ -71,19 +91,57 @@ func (tv *TaskRunVerifier) VerifyTaskRun(ctx context.Context, tr *v1.TaskRun) er
 			continue
 		}
 
-		for _, backend := range sets.List[string](signableType.StorageBackend(cfg)) {
-			b := allBackends[backend]
-			signatures, err := b.RetrieveSignatures(ctx, trObj, config.StorageOpts{})
-			if err != nil {
-				return err
-			}
-			payload, err := b.RetrievePayloads(ctx, trObj, config.StorageOpts{})
-			if err != nil {
-				return err
+		payloadFormat := signableType.PayloadFormat(cfg)
+		payloader, err := formats.GetPayloader(payloadFormat, cfg)
+		if err != nil {
+			return err
+		}
+		for _, obj := range signableType.ExtractObjects(ctx, trObj) {
+			// The keys the artifact was stored with when it was signed.
+			opts := config.StorageOpts{
+				ShortKey:      signableType.ShortKey(obj),
+				FullKey:       signableType.FullKey(obj),
+				PayloadFormat: payloadFormat,
 			}
-			for image, sigs := range signatures {
-				for _, sig := range sigs {
-					if err := signer.VerifySignature(strings.NewReader(sig), strings.NewReader(payload[image])); err != nil {
+			for _, backend := range sets.List[string](signableType.StorageBackend(cfg)) {
+				b := allBackends[backend]
+				signatures, err := b.RetrieveSignatures(ctx, trObj, opts)
+				if err != nil {
+					return err
+				}
+				payload, err := b.RetrievePayloads(ctx, trObj, opts)
+				if err != nil {
+					return err
+				}
+				for image, sigs := range signatures {
+					for _, sig := range sigs {
+						if payloader.Wrap() {
+							err = verifyEnvelope(signer, sig, payload[image])
+						} else {
+							err = signer.VerifySignature(strings.NewReader(sig), strings.NewReader(payload[image]))
+						}
+						if err != nil {
+							return err
+						}
+					}
+				}
+				if tlogVerifiers == nil || len(signatures) == 0 {
+					continue
+				}
+				r, ok := b.(storage.TlogEntryRetriever)
+				if !ok {
+					return fmt.Errorf("storage backend %s does not store the transparency log entries of %s", backend, opts.ShortKey)
+				}
+				entries, err := r.RetrieveTlogEntries(ctx, trObj, opts)
+				if err != nil {
+					return err
+				}
+				for ref := range signatures {
+					entry, ok := entries[ref]
+					if !ok {
+						return fmt.Errorf("no transparency log entry stored in %s for %s", backend, ref)
+					}
+					if err := verifyTlogEntryOffline(ctx, entry, []byte(payload[ref]), string(payloadFormat), signableType.TlogEntryType(cfg), tlogVerifiers); err != nil {
 						return err
 					}
 				}
@@ -93,3 +151,44 @@ func (tv *TaskRunVerifier) VerifyTaskRun(ctx context.Context, tr *v1.TaskRun) er
 
 	return nil
 }
+
+// uploadedToTlog returns true when the signatures of the run are uploaded to the
+// transparency log, with the annotation of the run overriding the configuration
+// like when it is signed.
+func uploadedToTlog(cfg config.Config, obj objects.TektonObject) bool {
+	upload := obj.GetAnnotations()[RekorAnnotation]
+	if enabled, err := strconv.ParseBool(upload); err == nil && cfg.Overrides.Allowed.Has(config.OverrideTransparency) {
+		return enabled
+	}
+	return cfg.Transparency.Enabled && (!cfg.Transparency.VerifyAnnotation || upload == "true")
+}
+
+// verifyEnvelope verifies the signatures of the DSSE envelope stored as the
+// signature of a wrapped payload, and that it envelops the stored payload.
+func verifyEnvelope(signer signing.Signer, sig, payload string) error {
+	env := dsse.Envelope{}
+	if err := json.Unmarshal([]byte(sig), &env); err != nil {
+		return fmt.Errorf("decoding the envelope: %w", err)
+	}
+	enveloped, err := env.DecodeB64Payload()
+	if err != nil {
+		return fmt.Errorf("decoding the payload of the envelope: %w", err)
+	}
+	if string(enveloped) != payload {
+		return errors.New("the envelope does not hold the stored payload")
+	}
+	if len(env.Signatures) == 0 {
+		return errors.New("no signature in the envelope")
+	}
+	pae := dsse.PAE(env.PayloadType, enveloped)
+	for _, s := range env.Signatures {
+		raw, err := base64.StdEncoding.DecodeString(s.Sig)
+		if err != nil {
+			return fmt.Errorf("decoding the signature of the envelope: %w", err)
+		}
+		if err := signer.VerifySignature(bytes.NewReader(raw), bytes.NewReader(pae)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
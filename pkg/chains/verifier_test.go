@@ -0,0 +1,124 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chains
+
+import (
+	"encoding/base64"
+	"fmt"
+	"testing"
+
+	protorekor "github.com/sigstore/protobuf-specs/gen/pb-go/rekor/v1"
+	"github.com/tektoncd/chains/pkg/chains/annotations"
+	"github.com/tektoncd/chains/pkg/chains/objects"
+	"github.com/tektoncd/chains/pkg/chains/storage"
+	"github.com/tektoncd/chains/pkg/chains/storage/tekton"
+	"github.com/tektoncd/chains/pkg/config"
+	testrekor "github.com/tektoncd/chains/pkg/test/rekor"
+	testtekton "github.com/tektoncd/chains/pkg/test/tekton"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	fakepipelineclient "github.com/tektoncd/pipeline/pkg/client/injection/client/fake"
+	"google.golang.org/protobuf/encoding/protojson"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	fakekubeclient "knative.dev/pkg/client/injection/kube/client/fake"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+func TestTaskRunVerifier_TlogEntries(t *testing.T) {
+	log := testrekor.NewLog(t)
+	cfg := &config.Config{
+		Artifacts: config.ArtifactConfigs{
+			TaskRuns: config.Artifact{
+				Format:         "slsa/v1",
+				StorageBackend: sets.New[string]("tekton"),
+				Signer:         "x509",
+			},
+			OCI: config.Artifact{Signer: "none"},
+		},
+		Transparency: config.TransparencyConfig{
+			Enabled:    true,
+			URL:        log.URL(),
+			APIVersion: config.TransparencyAPIVersionV2,
+			PublicKeys: log.PublicKeyPEM(t),
+		},
+	}
+
+	ctx, _ := rtesting.SetupFakeContext(t)
+	ps := fakepipelineclient.Get(ctx)
+	kc := fakekubeclient.Get(ctx)
+	ctx = config.ToContext(ctx, cfg.DeepCopy())
+
+	backends, err := storage.InitializeBackends(ctx, ps, kc, *cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	os := &ObjectSigner{
+		Backends:          backends,
+		SecretPath:        "./signing/x509/testdata/",
+		Pipelineclientset: ps,
+	}
+	tr := &v1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "verified", Namespace: "default", UID: "verified-uid"}}
+	obj := objects.NewTaskRunObjectV1(tr)
+	testtekton.CreateObject(t, ctx, ps, obj)
+	if err := os.Sign(ctx, obj); err != nil {
+		t.Fatalf("Signer.Sign() error = %v", err)
+	}
+
+	verifier := &TaskRunVerifier{
+		KubeClient:        kc,
+		Pipelineclientset: ps,
+		SecretPath:        "./signing/x509/testdata/",
+	}
+	if err := verifier.VerifyTaskRun(ctx, tr); err != nil {
+		t.Fatalf("VerifyTaskRun() error = %v", err)
+	}
+
+	// Tamper with the stored entry, so that its inclusion proof no longer holds.
+	signed, err := testtekton.GetObject(t, ctx, ps, obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := fmt.Sprintf(tekton.TlogEntryAnnotationFormat, "taskrun-verified-uid")
+	raw, err := base64.StdEncoding.DecodeString(signed.GetAnnotations()[key])
+	if err != nil || len(raw) == 0 {
+		t.Fatalf("expected the tlog entry to be stored in %s, got %q: %v", key, raw, err)
+	}
+	entry := &protorekor.TransparencyLogEntry{}
+	if err := protojson.Unmarshal(raw, entry); err != nil {
+		t.Fatal(err)
+	}
+	entry.CanonicalizedBody = []byte(`{"kind":"dsse","spec":{}}`)
+	tampered, err := protojson.Marshal(entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := annotations.AddAnnotations(ctx, signed, ps, map[string]string{key: base64.StdEncoding.EncodeToString(tampered)}); err != nil {
+		t.Fatal(err)
+	}
+	if err := verifier.VerifyTaskRun(ctx, tr); err == nil {
+		t.Error("expected VerifyTaskRun() to fail with a tampered tlog entry")
+	}
+
+	// Drop the stored entry, so that the signature can no longer be verified offline.
+	signed, err = testtekton.GetObject(t, ctx, ps, obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := annotations.AddAnnotations(ctx, signed, ps, map[string]string{key: ""}); err != nil {
+		t.Fatal(err)
+	}
+	if err := verifier.VerifyTaskRun(ctx, tr); err == nil {
+		t.Error("expected VerifyTaskRun() to fail without a stored tlog entry")
+	}
+}
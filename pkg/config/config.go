@@ -18,11 +18,14 @@ package config
 
 import (
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/sigstore/sigstore/pkg/tuf"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/sets"
 	cm "knative.dev/pkg/configmap"
 )
@@ -35,12 +38,50 @@ type Config struct {
 	Transparency    TransparencyConfig
 	BuildDefinition BuildDefinitionConfig
 	Filter          FilterConfig
+	Spire           SpireConfig
+	// TrustedProducers holds the Tasks and Pipelines whose type-hinted results
+	// are honoured as the artifacts produced by a run.
+	TrustedProducers TrustedProducersConfig
+	// Policy holds the policies evaluated before signing a run.
+	Policy PolicyConfig
+	// VSA holds the verifier details recorded in verification summary attestations.
+	VSA VSAConfig
+	// Redaction holds the rules redacting sensitive values from SLSA provenance.
+	Redaction RedactionConfig
+	// Results holds the Tekton Results API the runs pruned from the cluster are
+	// fetched from.
+	Results ResultsConfig
+	// Overrides holds the settings tenants may override for their runs with
+	// annotations.
+	Overrides OverridesConfig
+	// Status configures how the outcome of signing is reported on the runs.
+	Status StatusConfig
+	// Retry configures how the runs that failed to be signed are retried.
+	Retry RetryConfig
 }
 
 // FilterConfig holds configuration for filtering which runs
 // Chains should process.
 type FilterConfig struct {
 	ManagedByValues sets.Set[string]
+	// OptIn ignores the runs that are not opted in with an annotation or label.
+	OptIn bool
+	// NamespaceSelector is the label selector of the namespaces whose runs are
+	// processed, all of them when empty.
+	NamespaceSelector string
+	// RunSelector is the label selector of the runs processed, all of them when
+	// empty.
+	RunSelector string
+	// PipelineInclude and PipelineExclude hold the globs matched against the
+	// names of the Pipelines of the PipelineRuns. When PipelineInclude is set,
+	// only the matching PipelineRuns are processed, and the ones matching
+	// PipelineExclude never are.
+	PipelineInclude sets.Set[string]
+	PipelineExclude sets.Set[string]
+	// TaskInclude and TaskExclude are the same for the names of the Tasks of
+	// the TaskRuns.
+	TaskInclude sets.Set[string]
+	TaskExclude sets.Set[string]
 }
 
 // ArtifactConfigs contains the configuration for how to sign/store/format the signatures for each artifact type
@@ -48,6 +89,25 @@ type ArtifactConfigs struct {
 	OCI          Artifact
 	PipelineRuns Artifact
 	TaskRuns     Artifact
+	// CustomRuns configures the provenance signed for the CustomRuns of custom
+	// task controllers.
+	CustomRuns Artifact
+	// SBOM configures the attestations of the SBOMs produced for the images built
+	// by a run.
+	SBOM Artifact
+	// TestResults configures the attestations of the outcome of the tests run by
+	// a run, for the subjects of its provenance.
+	TestResults Artifact
+	// Vuln configures the attestations of the outcome of the vulnerability scans
+	// run by a run, for the subjects of its provenance.
+	Vuln Artifact
+	// Links configures the in-toto links signed for each step of a TaskRun. They
+	// are only generated when a storage backend is configured.
+	Links Artifact
+	// VSA configures the verification summary attestations generated for the
+	// subjects of the signed provenance. They are only generated when a storage
+	// backend is configured.
+	VSA Artifact
 }
 
 // Artifact contains the configuration for how to sign/store/format the signatures for a single artifact
@@ -56,6 +116,16 @@ type Artifact struct {
 	StorageBackend        sets.Set[string]
 	Signer                string
 	DeepInspectionEnabled bool
+	// TransparencyEntryType overrides the transparency log entry type for this artifact.
+	TransparencyEntryType string
+	// SkipFailedRuns, SkipCancelledRuns and SkipTimedOutRuns prevent signing the
+	// artifact for the runs that failed, were cancelled or timed out.
+	SkipFailedRuns    bool
+	SkipCancelledRuns bool
+	SkipTimedOutRuns  bool
+	// RecordOutcome records the outcome of the run in the byproducts of its SLSA
+	// provenance.
+	RecordOutcome bool
 }
 
 // StorageConfigs contains the configuration to instantiate different storage providers
@@ -77,6 +147,15 @@ type SignerConfigs struct {
 
 type BuilderConfig struct {
 	ID string
+	// PipelinesVersion and ChainsVersion are the versions of Tekton Pipelines and
+	// Chains recorded as the builder version in SLSA v1.1 provenance.
+	PipelinesVersion string
+	ChainsVersion    string
+	// ControllerImage and EntrypointImage are the images of the Tekton Pipelines
+	// controller and entrypoint, pinned to their digest, recorded as the builder
+	// dependencies in SLSA v1.1 provenance.
+	ControllerImage string
+	EntrypointImage string
 }
 
 type BuildDefinitionConfig struct {
@@ -167,6 +246,175 @@ type TransparencyConfig struct {
 	Enabled          bool
 	VerifyAnnotation bool
 	URL              string
+	// PublicKeys holds the PEM encoded public keys of the trusted transparency logs,
+	// used to verify the stored log entries offline.
+	PublicKeys string
+	// APIVersion is the Rekor API served at URL: the REST API of Rekor v1 logs, used
+	// when empty, or the tile-based API of Rekor v2 logs.
+	APIVersion string
+	// EntryType is the kind of entry uploaded to the transparency log. When empty,
+	// in-toto attestations are uploaded as dsse entries and other payloads as
+	// hashedrekord entries.
+	EntryType string
+}
+
+// SpireConfig holds the configuration for verifying the TaskRun results signed
+// by Tekton Pipelines with SPIRE.
+type SpireConfig struct {
+	// ResultsVerification is how runs with unverified results are handled:
+	// they are either refused or signed with the outcome recorded in their
+	// provenance. The results are not verified when it is empty.
+	ResultsVerification string
+	// TrustDomain is the SPIFFE trust domain of the TaskRun identities.
+	TrustDomain string
+	// TrustBundlePath is the path to the PEM encoded SPIRE trust bundle.
+	TrustBundlePath string
+}
+
+// TrustedProducersConfig holds the allowlists of the Tasks and Pipelines trusted
+// to produce artifacts, configured per namespace.
+type TrustedProducersConfig struct {
+	// Namespaces maps a namespace, or TrustedProducersAllNamespaces, to the
+	// references of the Tasks and Pipelines trusted in it. A reference is either
+	// a URI, trusting any revision, or a URI pinned to a digest as <uri>@<alg>:<hex>.
+	Namespaces map[string]sets.Set[string]
+}
+
+// Allowlist returns the references trusted to produce artifacts in the namespace,
+// and false when the producers of the namespace are not restricted.
+func (tp *TrustedProducersConfig) Allowlist(namespace string) (sets.Set[string], bool) {
+	if refs, ok := tp.Namespaces[namespace]; ok {
+		return refs, true
+	}
+	refs, ok := tp.Namespaces[TrustedProducersAllNamespaces]
+	return refs, ok
+}
+
+// PolicyConfig holds the policies runs must comply with before their artifacts
+// are signed.
+type PolicyConfig struct {
+	// CEL maps the name of each policy to its CEL expression.
+	CEL map[string]string
+	// Enforcement is what happens to non-compliant runs, which are only
+	// annotated with their violations when it is empty.
+	Enforcement string
+	// NonCompliantSecretPath is the path to the signing secrets holding the key
+	// signing non-compliant runs.
+	NonCompliantSecretPath string
+	// NonCompliantKMSRef is the KMS key signing non-compliant runs, when they are
+	// signed with the kms signer.
+	NonCompliantKMSRef string
+}
+
+// VSAConfig holds the verifier details recorded in verification summary
+// attestations.
+type VSAConfig struct {
+	// VerifierID identifies the verifier, defaulting to the builder ID.
+	VerifierID string
+	// PolicyURI identifies the policy the provenance was verified against,
+	// defaulting to the verifier ID.
+	PolicyURI string
+	// VerifiedLevels are the levels recorded for the subjects of signed runs,
+	// defaulting to SLSA_BUILD_LEVEL_2.
+	VerifiedLevels sets.Set[string]
+	// SpireVerifiedLevels are the levels recorded instead when the results of
+	// the run were verified to be signed with SPIRE, defaulting to VerifiedLevels.
+	SpireVerifiedLevels sets.Set[string]
+}
+
+// RedactionConfig holds the rules redacting sensitive values from the predicate
+// of SLSA provenance before it is signed.
+type RedactionConfig struct {
+	// Params holds the globs matched against the names of params and
+	// environment variables whose values are redacted.
+	Params sets.Set[string]
+	// Values maps the name of each rule to a regular expression. The string
+	// values it matches are redacted wherever they are in the predicate.
+	Values map[string]string
+	// Fields holds the JSONPaths of the fields removed from the predicate.
+	Fields sets.Set[string]
+}
+
+// ResultsConfig holds the Tekton Results API archiving the runs of the cluster.
+type ResultsConfig struct {
+	// URL is the address of the REST API of Tekton Results. Archived runs are
+	// not fetched when it is empty.
+	URL string
+	// TokenPath is the path to the bearer token authenticating to Tekton Results,
+	// defaulting to the token of the service account of the controller.
+	TokenPath string
+	// CAPath is the path to the PEM encoded certificates of the CAs trusted to
+	// serve Tekton Results, in addition to the system ones.
+	CAPath string
+}
+
+// OverridesConfig holds the settings tenants may override for their runs with
+// annotations.
+type OverridesConfig struct {
+	// Allowed holds the overrides honoured: OverrideSkip, OverrideFormat,
+	// OverrideStorage and OverrideTransparency.
+	Allowed sets.Set[string]
+}
+
+// StatusConfig configures how the outcome of signing is reported on the runs.
+type StatusConfig struct {
+	// Annotation records the outcome of each artifact and storage backend as
+	// JSON in an annotation of the run.
+	Annotation bool
+}
+
+// RetryConfig configures how the runs that failed to be signed are retried.
+type RetryConfig struct {
+	// MaxRetries is the number of retries before a run is marked as failed.
+	MaxRetries int
+	// BackoffBase is the delay before the first retry, doubled for each of the
+	// next ones up to BackoffCap. The runs are retried right away when it is zero.
+	BackoffBase time.Duration
+	BackoffCap  time.Duration
+	// ErrorMaxRetries overrides MaxRetries for the failures of an error type,
+	// as in the metrics: payload_creation, marshal_payload, signing, storage,
+	// tlog, results_verification or policy_violation.
+	ErrorMaxRetries map[string]int
+}
+
+// MaxRetriesFor returns the number of retries of a run that failed with errors
+// of the given types: the lowest of their limits, so that a run is not retried
+// for an error that is never retried.
+func (r RetryConfig) MaxRetriesFor(errorTypes sets.Set[string]) int {
+	if errorTypes.Len() == 0 {
+		return r.MaxRetries
+	}
+	maxRetries := -1
+	for errorType := range errorTypes {
+		limit, ok := r.ErrorMaxRetries[errorType]
+		if !ok {
+			limit = r.MaxRetries
+		}
+		if maxRetries < 0 || limit < maxRetries {
+			maxRetries = limit
+		}
+	}
+	return maxRetries
+}
+
+// Backoff returns the delay before the given retry, counted from zero. Without
+// a cap, the delay saturates at the longest duration instead of overflowing.
+func (r RetryConfig) Backoff(retry int) time.Duration {
+	if r.BackoffBase <= 0 {
+		return 0
+	}
+	backoffCap := r.BackoffCap
+	if backoffCap <= 0 {
+		backoffCap = math.MaxInt64
+	}
+	backoff := r.BackoffBase
+	for i := 0; i < retry && backoff < backoffCap; i++ {
+		if backoff > backoffCap/2 {
+			return backoffCap
+		}
+		backoff *= 2
+	}
+	return min(backoff, backoffCap)
 }
 
 // ArchivistaStorageConfig holds configuration for the Archivista storage backend.
@@ -176,18 +424,66 @@ type ArchivistaStorageConfig struct {
 }
 
 const (
-	taskrunFormatKey  = "artifacts.taskrun.format"
-	taskrunStorageKey = "artifacts.taskrun.storage"
-	taskrunSignerKey  = "artifacts.taskrun.signer"
+	taskrunFormatKey        = "artifacts.taskrun.format"
+	taskrunStorageKey       = "artifacts.taskrun.storage"
+	taskrunSignerKey        = "artifacts.taskrun.signer"
+	taskrunTlogEntryTypeKey = "artifacts.taskrun.transparency.entry-type"
+	taskrunSignFailedKey    = "artifacts.taskrun.sign-failed-runs"
+	taskrunSignCancelledKey = "artifacts.taskrun.sign-cancelled-runs"
+	taskrunSignTimedOutKey  = "artifacts.taskrun.sign-timed-out-runs"
+	taskrunRecordOutcomeKey = "artifacts.taskrun.record-outcome"
+
+	customrunFormatKey        = "artifacts.customrun.format"
+	customrunStorageKey       = "artifacts.customrun.storage"
+	customrunSignerKey        = "artifacts.customrun.signer"
+	customrunTlogEntryTypeKey = "artifacts.customrun.transparency.entry-type"
+	customrunSignFailedKey    = "artifacts.customrun.sign-failed-runs"
+	customrunSignCancelledKey = "artifacts.customrun.sign-cancelled-runs"
+	customrunSignTimedOutKey  = "artifacts.customrun.sign-timed-out-runs"
+	customrunRecordOutcomeKey = "artifacts.customrun.record-outcome"
 
 	pipelinerunFormatKey               = "artifacts.pipelinerun.format"
 	pipelinerunStorageKey              = "artifacts.pipelinerun.storage"
 	pipelinerunSignerKey               = "artifacts.pipelinerun.signer"
 	pipelinerunEnableDeepInspectionKey = "artifacts.pipelinerun.enable-deep-inspection"
-
-	ociFormatKey  = "artifacts.oci.format"
-	ociStorageKey = "artifacts.oci.storage"
-	ociSignerKey  = "artifacts.oci.signer"
+	pipelinerunTlogEntryTypeKey        = "artifacts.pipelinerun.transparency.entry-type"
+	pipelinerunSignFailedKey           = "artifacts.pipelinerun.sign-failed-runs"
+	pipelinerunSignCancelledKey        = "artifacts.pipelinerun.sign-cancelled-runs"
+	pipelinerunSignTimedOutKey         = "artifacts.pipelinerun.sign-timed-out-runs"
+	pipelinerunRecordOutcomeKey        = "artifacts.pipelinerun.record-outcome"
+
+	ociFormatKey        = "artifacts.oci.format"
+	ociStorageKey       = "artifacts.oci.storage"
+	ociSignerKey        = "artifacts.oci.signer"
+	ociTlogEntryTypeKey = "artifacts.oci.transparency.entry-type"
+	ociSignFailedKey    = "artifacts.oci.sign-failed-runs"
+	ociSignCancelledKey = "artifacts.oci.sign-cancelled-runs"
+	ociSignTimedOutKey  = "artifacts.oci.sign-timed-out-runs"
+
+	sbomFormatKey        = "artifacts.sbom.format"
+	sbomStorageKey       = "artifacts.sbom.storage"
+	sbomSignerKey        = "artifacts.sbom.signer"
+	sbomTlogEntryTypeKey = "artifacts.sbom.transparency.entry-type"
+
+	testResultsFormatKey        = "artifacts.testresults.format"
+	testResultsStorageKey       = "artifacts.testresults.storage"
+	testResultsSignerKey        = "artifacts.testresults.signer"
+	testResultsTlogEntryTypeKey = "artifacts.testresults.transparency.entry-type"
+
+	vulnFormatKey        = "artifacts.vuln.format"
+	vulnStorageKey       = "artifacts.vuln.storage"
+	vulnSignerKey        = "artifacts.vuln.signer"
+	vulnTlogEntryTypeKey = "artifacts.vuln.transparency.entry-type"
+
+	linksFormatKey        = "artifacts.links.format"
+	linksStorageKey       = "artifacts.links.storage"
+	linksSignerKey        = "artifacts.links.signer"
+	linksTlogEntryTypeKey = "artifacts.links.transparency.entry-type"
+
+	vsaFormatKey        = "artifacts.vsa.format"
+	vsaStorageKey       = "artifacts.vsa.storage"
+	vsaSignerKey        = "artifacts.vsa.signer"
+	vsaTlogEntryTypeKey = "artifacts.vsa.transparency.entry-type"
 
 	gcsBucketKey               = "storage.gcs.bucket"
 	ociRepositoryKey           = "storage.oci.repository"
@@ -233,16 +529,73 @@ const (
 	x509SignerTUFMirrorURL      = "signers.x509.tuf.mirror.url"
 
 	// Builder config
-	builderIDKey = "builder.id"
-
-	transparencyEnabledKey = "transparency.enabled"
-	transparencyURLKey     = "transparency.url"
+	builderIDKey               = "builder.id"
+	builderPipelinesVersionKey = "builder.version.pipelines"
+	builderChainsVersionKey    = "builder.version.chains"
+	builderControllerImageKey  = "builder.dependencies.controller"
+	builderEntrypointImageKey  = "builder.dependencies.entrypoint"
+
+	transparencyEnabledKey    = "transparency.enabled"
+	transparencyURLKey        = "transparency.url"
+	transparencyPubKeysKey    = "transparency.public-keys"
+	transparencyEntryTypeKey  = "transparency.entry-type"
+	transparencyAPIVersionKey = "transparency.api-version"
 
 	// Build type
 	buildTypeKey = "builddefinition.buildtype"
 
 	// Filter
 	filterManagedByKey = "filter.managed-by"
+	filterOptInKey     = "filter.opt-in"
+
+	filterNamespaceSelectorKey = "filter.namespace-selector"
+	filterRunSelectorKey       = "filter.run-selector"
+	filterPipelineIncludeKey   = "filter.pipeline.include"
+	filterPipelineExcludeKey   = "filter.pipeline.exclude"
+	filterTaskIncludeKey       = "filter.task.include"
+	filterTaskExcludeKey       = "filter.task.exclude"
+
+	// Per-run overrides
+	overridesAllowedKey = "overrides.allowed"
+
+	// Signing status
+	statusAnnotationKey = "status.annotation"
+
+	// Retries
+	retryMaxRetriesKey      = "retry.max-retries"
+	retryErrorMaxRetriesKey = "retry.max-retries."
+	retryBackoffBaseKey     = "retry.backoff.base"
+	retryBackoffCapKey      = "retry.backoff.cap"
+
+	spireResultsVerificationKey = "spire.results.verification"
+	spireTrustDomainKey         = "spire.trust-domain"
+	spireTrustBundlePathKey     = "spire.trust-bundle-path"
+
+	// Trusted producers, followed by the namespace
+	trustedProducersKeyPrefix = "trusted-producers."
+
+	// Policy
+	policyCELKeyPrefix           = "policy.cel."
+	policyRegoKeyPrefix          = "policy.rego."
+	policyEnforcementKey         = "policy.enforcement"
+	policyNonCompliantSecretPath = "policy.noncompliant.secret-path" // #nosec G101
+	policyNonCompliantKMSRef     = "policy.noncompliant.kmsref"
+
+	// Verification summary attestations
+	vsaVerifierIDKey          = "vsa.verifier.id"
+	vsaPolicyURIKey           = "vsa.policy.uri"
+	vsaVerifiedLevelsKey      = "vsa.verified-levels"
+	vsaSpireVerifiedLevelsKey = "vsa.verified-levels.spire"
+
+	// Redaction of SLSA provenance
+	redactionParamsKey       = "redaction.params"
+	redactionValuesKeyPrefix = "redaction.values."
+	redactionFieldsKey       = "redaction.fields"
+
+	// Tekton Results
+	resultsURLKey       = "results.url"
+	resultsTokenPathKey = "results.token-path" // #nosec G101
+	resultsCAPathKey    = "results.ca-path"
 
 	ChainsConfig = "chains-config"
 
@@ -251,6 +604,61 @@ const (
 	// OCIEncodingFormatSigstoreBundle uses the Sigstore protobuf-bundle format stored
 	// via the OCI 1.1 Referrers API, reducing tag proliferation.
 	OCIEncodingFormatSigstoreBundle = "sigstore-bundle"
+
+	// TlogEntryTypeHashedRekord logs the digest and signature of the payload.
+	TlogEntryTypeHashedRekord = "hashedrekord"
+	// TlogEntryTypeIntoto logs an intoto v0.0.2 entry, storing the attestation in
+	// the transparency log.
+	TlogEntryTypeIntoto = "intoto"
+	// TlogEntryTypeDSSE logs a dsse entry, which only records the payload digest.
+	TlogEntryTypeDSSE = "dsse"
+
+	// TransparencyAPIVersionV1 is the REST API of Rekor v1 logs.
+	TransparencyAPIVersionV1 = "v1"
+	// TransparencyAPIVersionV2 is the tile-based API of Rekor v2 logs, which return
+	// the entry along with its inclusion proof when it is uploaded.
+	TransparencyAPIVersionV2 = "v2"
+
+	// SpireResultsVerificationFlag signs runs with unverified results, recording
+	// the verification outcome in their provenance.
+	SpireResultsVerificationFlag = "flag"
+	// SpireResultsVerificationEnforce refuses to sign runs with unverified results.
+	SpireResultsVerificationEnforce = "enforce"
+
+	// TrustedProducersAllNamespaces is the namespace of the allowlist applying to
+	// the namespaces without their own.
+	TrustedProducersAllNamespaces = "*"
+
+	// PolicyEnforcementAnnotate signs non-compliant runs as usual, annotating them
+	// with their violations.
+	PolicyEnforcementAnnotate = "annotate"
+	// PolicyEnforcementRefuse refuses to sign the OCI images of non-compliant runs.
+	PolicyEnforcementRefuse = "refuse"
+	// PolicyEnforcementNonCompliantKey signs non-compliant runs with a separate key.
+	PolicyEnforcementNonCompliantKey = "noncompliant-key"
+
+	// OverrideSkip lets tenants skip signing their runs.
+	OverrideSkip = "skip"
+	// OverrideFormat lets tenants override the format of the provenance of their runs.
+	OverrideFormat = "format"
+	// OverrideStorage lets tenants override the storage backends of the provenance
+	// of their runs, among the ones configured.
+	OverrideStorage = "storage"
+	// OverrideTransparency lets tenants enable or disable the transparency log
+	// uploads of their runs.
+	OverrideTransparency = "transparency"
+)
+
+var (
+	// TaskRunFormats, PipelineRunFormats and CustomRunFormats are the formats of
+	// the provenance of each kind of run.
+	TaskRunFormats     = []string{"in-toto", "slsa/v1", "slsa/v2alpha3", "slsa/v2alpha4", "slsa/v1.1"}
+	PipelineRunFormats = []string{"in-toto", "slsa/v1", "slsa/v2alpha3", "slsa/v2alpha4", "slsa/v1.1"}
+	CustomRunFormats   = []string{"slsa/v2alpha4", "slsa/v1.1"}
+
+	// RetryErrorTypes are the error types whose retries can be configured, as in
+	// the metrics.
+	RetryErrorTypes = sets.New[string]("payload_creation", "marshal_payload", "signing", "storage", "tlog", "results_verification", "policy_violation")
 )
 
 func (artifact *Artifact) Enabled() bool {
@@ -261,6 +669,15 @@ func (artifact *Artifact) Enabled() bool {
 	return !(artifact.StorageBackend.Len() == 1 && artifact.StorageBackend.Has(""))
 }
 
+// TlogEntryType returns the transparency log entry type for the artifact, falling
+// back to the one configured for the transparency log.
+func (artifact *Artifact) TlogEntryType(transparency TransparencyConfig) string {
+	if artifact.TransparencyEntryType != "" {
+		return artifact.TransparencyEntryType
+	}
+	return transparency.EntryType
+}
+
 func defaultConfig() *Config {
 	return &Config{
 		Artifacts: ArtifactConfigs{
@@ -269,6 +686,11 @@ func defaultConfig() *Config {
 				StorageBackend: sets.New[string]("tekton"),
 				Signer:         "x509",
 			},
+			CustomRuns: Artifact{
+				Format:         "slsa/v1.1",
+				StorageBackend: sets.New[string]("tekton"),
+				Signer:         "x509",
+			},
 			PipelineRuns: Artifact{
 				Format:                "in-toto",
 				StorageBackend:        sets.New[string]("tekton"),
@@ -280,6 +702,29 @@ func defaultConfig() *Config {
 				StorageBackend: sets.New[string]("oci"),
 				Signer:         "x509",
 			},
+			SBOM: Artifact{
+				Format:         "sbom",
+				StorageBackend: sets.New[string]("oci"),
+				Signer:         "x509",
+			},
+			TestResults: Artifact{
+				Format:         "test-result",
+				StorageBackend: sets.New[string]("tekton"),
+				Signer:         "x509",
+			},
+			Vuln: Artifact{
+				Format:         "vuln",
+				StorageBackend: sets.New[string]("tekton"),
+				Signer:         "x509",
+			},
+			Links: Artifact{
+				Format: "in-toto-link",
+				Signer: "x509",
+			},
+			VSA: Artifact{
+				Format: "vsa",
+				Signer: "x509",
+			},
 		},
 		Transparency: TransparencyConfig{
 			URL: "https://rekor.sigstore.dev",
@@ -304,6 +749,11 @@ func defaultConfig() *Config {
 		BuildDefinition: BuildDefinitionConfig{
 			BuildType: "https://tekton.dev/chains/v2/slsa",
 		},
+		Retry: RetryConfig{
+			MaxRetries:  3,
+			BackoffBase: 10 * time.Second,
+			BackoffCap:  5 * time.Minute,
+		},
 	}
 }
 
@@ -315,20 +765,75 @@ func NewConfigFromMap(data map[string]string) (*Config, error) {
 		data,
 		// Artifact-specific configs
 		// TaskRuns
-		asString(taskrunFormatKey, &cfg.Artifacts.TaskRuns.Format, "in-toto", "slsa/v1", "slsa/v2alpha3", "slsa/v2alpha4"),
-		asStringSet(taskrunStorageKey, &cfg.Artifacts.TaskRuns.StorageBackend, sets.New[string]("tekton", "oci", "gcs", "docdb", "grafeas", "kafka", "archivista")),
+		asString(taskrunFormatKey, &cfg.Artifacts.TaskRuns.Format, TaskRunFormats...),
+		asStringSet(taskrunStorageKey, &cfg.Artifacts.TaskRuns.StorageBackend, sets.New[string]("tekton", "oci", "gcs", "docdb", "grafeas", "kafka", "archivista", "results")),
 		asString(taskrunSignerKey, &cfg.Artifacts.TaskRuns.Signer, "x509", "kms", "none"),
+		asString(taskrunTlogEntryTypeKey, &cfg.Artifacts.TaskRuns.TransparencyEntryType, TlogEntryTypeHashedRekord, TlogEntryTypeIntoto, TlogEntryTypeDSSE),
+		asNegatedBool(taskrunSignFailedKey, &cfg.Artifacts.TaskRuns.SkipFailedRuns),
+		asNegatedBool(taskrunSignCancelledKey, &cfg.Artifacts.TaskRuns.SkipCancelledRuns),
+		asNegatedBool(taskrunSignTimedOutKey, &cfg.Artifacts.TaskRuns.SkipTimedOutRuns),
+		asBool(taskrunRecordOutcomeKey, &cfg.Artifacts.TaskRuns.RecordOutcome),
+
+		// CustomRuns
+		asString(customrunFormatKey, &cfg.Artifacts.CustomRuns.Format, CustomRunFormats...),
+		asStringSet(customrunStorageKey, &cfg.Artifacts.CustomRuns.StorageBackend, sets.New[string]("tekton", "oci", "gcs", "docdb", "grafeas", "archivista", "results")),
+		asString(customrunSignerKey, &cfg.Artifacts.CustomRuns.Signer, "x509", "kms", "none"),
+		asString(customrunTlogEntryTypeKey, &cfg.Artifacts.CustomRuns.TransparencyEntryType, TlogEntryTypeHashedRekord, TlogEntryTypeIntoto, TlogEntryTypeDSSE),
+		asNegatedBool(customrunSignFailedKey, &cfg.Artifacts.CustomRuns.SkipFailedRuns),
+		asNegatedBool(customrunSignCancelledKey, &cfg.Artifacts.CustomRuns.SkipCancelledRuns),
+		asNegatedBool(customrunSignTimedOutKey, &cfg.Artifacts.CustomRuns.SkipTimedOutRuns),
+		asBool(customrunRecordOutcomeKey, &cfg.Artifacts.CustomRuns.RecordOutcome),
 
 		// PipelineRuns
-		asString(pipelinerunFormatKey, &cfg.Artifacts.PipelineRuns.Format, "in-toto", "slsa/v1", "slsa/v2alpha3", "slsa/v2alpha4"),
-		asStringSet(pipelinerunStorageKey, &cfg.Artifacts.PipelineRuns.StorageBackend, sets.New[string]("tekton", "oci", "gcs", "docdb", "grafeas", "archivista")),
+		asString(pipelinerunFormatKey, &cfg.Artifacts.PipelineRuns.Format, PipelineRunFormats...),
+		asStringSet(pipelinerunStorageKey, &cfg.Artifacts.PipelineRuns.StorageBackend, sets.New[string]("tekton", "oci", "gcs", "docdb", "grafeas", "archivista", "results")),
 		asString(pipelinerunSignerKey, &cfg.Artifacts.PipelineRuns.Signer, "x509", "kms", "none"),
 		asBool(pipelinerunEnableDeepInspectionKey, &cfg.Artifacts.PipelineRuns.DeepInspectionEnabled),
+		asString(pipelinerunTlogEntryTypeKey, &cfg.Artifacts.PipelineRuns.TransparencyEntryType, TlogEntryTypeHashedRekord, TlogEntryTypeIntoto, TlogEntryTypeDSSE),
+		asNegatedBool(pipelinerunSignFailedKey, &cfg.Artifacts.PipelineRuns.SkipFailedRuns),
+		asNegatedBool(pipelinerunSignCancelledKey, &cfg.Artifacts.PipelineRuns.SkipCancelledRuns),
+		asNegatedBool(pipelinerunSignTimedOutKey, &cfg.Artifacts.PipelineRuns.SkipTimedOutRuns),
+		asBool(pipelinerunRecordOutcomeKey, &cfg.Artifacts.PipelineRuns.RecordOutcome),
 
 		// OCI
 		asString(ociFormatKey, &cfg.Artifacts.OCI.Format, "simplesigning"),
-		asStringSet(ociStorageKey, &cfg.Artifacts.OCI.StorageBackend, sets.New[string]("tekton", "oci", "gcs", "docdb", "grafeas", "kafka", "archivista")),
+		asStringSet(ociStorageKey, &cfg.Artifacts.OCI.StorageBackend, sets.New[string]("tekton", "oci", "gcs", "docdb", "grafeas", "kafka", "archivista", "results")),
 		asString(ociSignerKey, &cfg.Artifacts.OCI.Signer, "x509", "kms", "none"),
+		// simplesigning payloads are not DSSE envelopes, so they can only be logged as hashedrekord.
+		asString(ociTlogEntryTypeKey, &cfg.Artifacts.OCI.TransparencyEntryType, TlogEntryTypeHashedRekord),
+		asNegatedBool(ociSignFailedKey, &cfg.Artifacts.OCI.SkipFailedRuns),
+		asNegatedBool(ociSignCancelledKey, &cfg.Artifacts.OCI.SkipCancelledRuns),
+		asNegatedBool(ociSignTimedOutKey, &cfg.Artifacts.OCI.SkipTimedOutRuns),
+
+		// SBOM
+		asString(sbomFormatKey, &cfg.Artifacts.SBOM.Format, "sbom"),
+		asStringSet(sbomStorageKey, &cfg.Artifacts.SBOM.StorageBackend, sets.New[string]("tekton", "oci", "gcs", "docdb", "grafeas", "archivista", "results")),
+		asString(sbomSignerKey, &cfg.Artifacts.SBOM.Signer, "x509", "kms", "none"),
+		asString(sbomTlogEntryTypeKey, &cfg.Artifacts.SBOM.TransparencyEntryType, TlogEntryTypeHashedRekord, TlogEntryTypeIntoto, TlogEntryTypeDSSE),
+
+		// Test results
+		asString(testResultsFormatKey, &cfg.Artifacts.TestResults.Format, "test-result"),
+		asStringSet(testResultsStorageKey, &cfg.Artifacts.TestResults.StorageBackend, sets.New[string]("tekton", "oci", "gcs", "docdb", "grafeas", "archivista", "results")),
+		asString(testResultsSignerKey, &cfg.Artifacts.TestResults.Signer, "x509", "kms", "none"),
+		asString(testResultsTlogEntryTypeKey, &cfg.Artifacts.TestResults.TransparencyEntryType, TlogEntryTypeHashedRekord, TlogEntryTypeIntoto, TlogEntryTypeDSSE),
+
+		// Vulnerability scans
+		asString(vulnFormatKey, &cfg.Artifacts.Vuln.Format, "vuln"),
+		asStringSet(vulnStorageKey, &cfg.Artifacts.Vuln.StorageBackend, sets.New[string]("tekton", "oci", "gcs", "docdb", "grafeas", "archivista", "results")),
+		asString(vulnSignerKey, &cfg.Artifacts.Vuln.Signer, "x509", "kms", "none"),
+		asString(vulnTlogEntryTypeKey, &cfg.Artifacts.Vuln.TransparencyEntryType, TlogEntryTypeHashedRekord, TlogEntryTypeIntoto, TlogEntryTypeDSSE),
+
+		// Step links
+		asString(linksFormatKey, &cfg.Artifacts.Links.Format, "in-toto-link"),
+		asStringSet(linksStorageKey, &cfg.Artifacts.Links.StorageBackend, sets.New[string]("tekton", "oci", "gcs", "docdb", "archivista", "results")),
+		asString(linksSignerKey, &cfg.Artifacts.Links.Signer, "x509", "kms", "none"),
+		asString(linksTlogEntryTypeKey, &cfg.Artifacts.Links.TransparencyEntryType, TlogEntryTypeHashedRekord, TlogEntryTypeIntoto, TlogEntryTypeDSSE),
+
+		// VSA
+		asString(vsaFormatKey, &cfg.Artifacts.VSA.Format, "vsa"),
+		asStringSet(vsaStorageKey, &cfg.Artifacts.VSA.StorageBackend, sets.New[string]("tekton", "oci", "gcs", "docdb", "archivista", "results")),
+		asString(vsaSignerKey, &cfg.Artifacts.VSA.Signer, "x509", "kms", "none"),
+		asString(vsaTlogEntryTypeKey, &cfg.Artifacts.VSA.TransparencyEntryType, TlogEntryTypeHashedRekord, TlogEntryTypeIntoto, TlogEntryTypeDSSE),
 
 		// PubSub - General
 		asString(pubsubProvider, &cfg.Storage.PubSub.Provider, "inmemory", "kafka"),
@@ -356,6 +861,9 @@ func NewConfigFromMap(data map[string]string) (*Config, error) {
 		oneOf(transparencyEnabledKey, &cfg.Transparency.Enabled, "true", "manual"),
 		oneOf(transparencyEnabledKey, &cfg.Transparency.VerifyAnnotation, "manual"),
 		asString(transparencyURLKey, &cfg.Transparency.URL),
+		asString(transparencyPubKeysKey, &cfg.Transparency.PublicKeys),
+		asString(transparencyEntryTypeKey, &cfg.Transparency.EntryType, TlogEntryTypeHashedRekord, TlogEntryTypeIntoto, TlogEntryTypeDSSE),
+		asString(transparencyAPIVersionKey, &cfg.Transparency.APIVersion, TransparencyAPIVersionV1, TransparencyAPIVersionV2),
 
 		asString(kmsSignerKMSRef, &cfg.Signers.KMS.KMSRef),
 		asString(kmsAuthAddress, &cfg.Signers.KMS.Auth.Address),
@@ -377,19 +885,111 @@ func NewConfigFromMap(data map[string]string) (*Config, error) {
 
 		// Build config
 		asString(builderIDKey, &cfg.Builder.ID),
+		asString(builderPipelinesVersionKey, &cfg.Builder.PipelinesVersion),
+		asString(builderChainsVersionKey, &cfg.Builder.ChainsVersion),
+		asString(builderControllerImageKey, &cfg.Builder.ControllerImage),
+		asString(builderEntrypointImageKey, &cfg.Builder.EntrypointImage),
 
 		// Build type
 		asString(buildTypeKey, &cfg.BuildDefinition.BuildType, "https://tekton.dev/chains/v2/slsa", "https://tekton.dev/chains/v2/slsa-tekton"),
 
 		// Filter
 		asStringSet(filterManagedByKey, &cfg.Filter.ManagedByValues, nil),
+		asBool(filterOptInKey, &cfg.Filter.OptIn),
+		asLabelSelector(filterNamespaceSelectorKey, &cfg.Filter.NamespaceSelector),
+		asLabelSelector(filterRunSelectorKey, &cfg.Filter.RunSelector),
+		asStringSet(filterPipelineIncludeKey, &cfg.Filter.PipelineInclude, nil),
+		asStringSet(filterPipelineExcludeKey, &cfg.Filter.PipelineExclude, nil),
+		asStringSet(filterTaskIncludeKey, &cfg.Filter.TaskInclude, nil),
+		asStringSet(filterTaskExcludeKey, &cfg.Filter.TaskExclude, nil),
+
+		// Per-run overrides
+		asStringSet(overridesAllowedKey, &cfg.Overrides.Allowed, sets.New[string](OverrideSkip, OverrideFormat, OverrideStorage, OverrideTransparency)),
+
+		// Signing status
+		asBool(statusAnnotationKey, &cfg.Status.Annotation),
+
+		// Retries
+		asNonNegativeInt(retryMaxRetriesKey, &cfg.Retry.MaxRetries),
+		asErrorMaxRetries(retryErrorMaxRetriesKey, &cfg.Retry.ErrorMaxRetries),
+		asNonNegativeDuration(retryBackoffBaseKey, &cfg.Retry.BackoffBase),
+		asNonNegativeDuration(retryBackoffCapKey, &cfg.Retry.BackoffCap),
+
+		// SPIRE
+		asString(spireResultsVerificationKey, &cfg.Spire.ResultsVerification, SpireResultsVerificationFlag, SpireResultsVerificationEnforce),
+		asString(spireTrustDomainKey, &cfg.Spire.TrustDomain),
+		asString(spireTrustBundlePathKey, &cfg.Spire.TrustBundlePath),
+
+		// Trusted producers
+		asTrustedProducers(trustedProducersKeyPrefix, &cfg.TrustedProducers),
+
+		// Policy
+		asStringMap(policyCELKeyPrefix, &cfg.Policy.CEL),
+		// Rejected rather than ignored, so that a policy is never silently not enforced.
+		unsupportedPrefix(policyRegoKeyPrefix, "Rego policies are not supported, use CEL policies instead"),
+		asString(policyEnforcementKey, &cfg.Policy.Enforcement, PolicyEnforcementAnnotate, PolicyEnforcementRefuse, PolicyEnforcementNonCompliantKey),
+		asString(policyNonCompliantSecretPath, &cfg.Policy.NonCompliantSecretPath),
+		asString(policyNonCompliantKMSRef, &cfg.Policy.NonCompliantKMSRef),
+
+		// Verification summary attestations
+		asString(vsaVerifierIDKey, &cfg.VSA.VerifierID),
+		asString(vsaPolicyURIKey, &cfg.VSA.PolicyURI),
+		asStringSet(vsaVerifiedLevelsKey, &cfg.VSA.VerifiedLevels, nil),
+		asStringSet(vsaSpireVerifiedLevelsKey, &cfg.VSA.SpireVerifiedLevels, nil),
+
+		// Redaction
+		asStringSet(redactionParamsKey, &cfg.Redaction.Params, nil),
+		asStringMap(redactionValuesKeyPrefix, &cfg.Redaction.Values),
+		asStringSet(redactionFieldsKey, &cfg.Redaction.Fields, nil),
+
+		// Tekton Results
+		asString(resultsURLKey, &cfg.Results.URL),
+		asString(resultsTokenPathKey, &cfg.Results.TokenPath),
+		asString(resultsCAPathKey, &cfg.Results.CAPath),
 	); err != nil {
 		return nil, fmt.Errorf("failed to parse data: %w", err)
 	}
 
+	if err := validateNonCompliantKey(cfg); err != nil {
+		return nil, err
+	}
+
 	return cfg, nil
 }
 
+// validateNonCompliantKey checks that the non-compliant key is configured for
+// every signer of the enabled artifacts when it is enforced: signing the
+// non-compliant runs with the regular key would make them indistinguishable
+// from the compliant ones.
+func validateNonCompliantKey(cfg *Config) error {
+	if cfg.Policy.Enforcement != PolicyEnforcementNonCompliantKey {
+		return nil
+	}
+	signers := sets.New[string]()
+	for _, artifact := range []Artifact{
+		cfg.Artifacts.TaskRuns,
+		cfg.Artifacts.PipelineRuns,
+		cfg.Artifacts.CustomRuns,
+		cfg.Artifacts.OCI,
+		cfg.Artifacts.SBOM,
+		cfg.Artifacts.TestResults,
+		cfg.Artifacts.Vuln,
+		cfg.Artifacts.Links,
+		cfg.Artifacts.VSA,
+	} {
+		if artifact.StorageBackend.Len() > 0 && artifact.Enabled() {
+			signers.Insert(artifact.Signer)
+		}
+	}
+	if signers.Has("x509") && cfg.Policy.NonCompliantSecretPath == "" {
+		return fmt.Errorf("%s %q requires %s for the x509 signer", policyEnforcementKey, PolicyEnforcementNonCompliantKey, policyNonCompliantSecretPath)
+	}
+	if signers.Has("kms") && cfg.Policy.NonCompliantKMSRef == "" {
+		return fmt.Errorf("%s %q requires %s for the kms signer", policyEnforcementKey, PolicyEnforcementNonCompliantKey, policyNonCompliantKMSRef)
+	}
+	return nil
+}
+
 // NewConfigFromConfigMap creates a Config from the supplied ConfigMap
 func NewConfigFromConfigMap(configMap *corev1.ConfigMap) (*Config, error) {
 	return NewConfigFromMap(configMap.Data)
@@ -431,6 +1031,20 @@ func asBool(key string, target *bool) cm.ParseFunc {
 	}
 }
 
+// asNegatedBool parses the value at key as a bool, into the target negated, if it exists.
+func asNegatedBool(key string, target *bool) cm.ParseFunc {
+	return func(data map[string]string) error {
+		raw, ok := data[key]
+		if !ok {
+			return nil
+		}
+		if val, err := strconv.ParseBool(raw); err == nil {
+			*target = !val
+		}
+		return nil
+	}
+}
+
 // asString passes the value at key through into the target, if it exists.
 // TODO(mattmoor): This might be a nice variation on cm.AsString to upstream.
 func asString(key string, target *string, values ...string) cm.ParseFunc {
@@ -474,3 +1088,134 @@ func asStringSet(key string, target *sets.Set[string], allowed sets.Set[string])
 		return nil
 	}
 }
+
+// asLabelSelector passes the value at key through into the target, if it exists
+// and is a valid label selector.
+func asLabelSelector(key string, target *string) cm.ParseFunc {
+	return func(data map[string]string) error {
+		raw, ok := data[key]
+		if !ok {
+			return nil
+		}
+		if _, err := labels.Parse(raw); err != nil {
+			return fmt.Errorf("invalid label selector %q for %s: %w", raw, key, err)
+		}
+		*target = raw
+		return nil
+	}
+}
+
+// asTrustedProducers parses the keys made of the prefix and a namespace, each
+// holding the comma separated allowlist of the namespace, into the target.
+func asNonNegativeInt(key string, target *int) cm.ParseFunc {
+	return func(data map[string]string) error {
+		raw, ok := data[key]
+		if !ok {
+			return nil
+		}
+		val, err := strconv.Atoi(strings.TrimSpace(raw))
+		if err != nil || val < 0 {
+			return fmt.Errorf("invalid value %q for %s, expected a non-negative integer", raw, key)
+		}
+		*target = val
+		return nil
+	}
+}
+
+func asNonNegativeDuration(key string, target *time.Duration) cm.ParseFunc {
+	return func(data map[string]string) error {
+		raw, ok := data[key]
+		if !ok {
+			return nil
+		}
+		val, err := time.ParseDuration(strings.TrimSpace(raw))
+		if err != nil || val < 0 {
+			return fmt.Errorf("invalid value %q for %s, expected a non-negative duration", raw, key)
+		}
+		*target = val
+		return nil
+	}
+}
+
+// asErrorMaxRetries parses the retries of the error types, keyed by the error
+// type after the prefix.
+func asErrorMaxRetries(prefix string, target *map[string]int) cm.ParseFunc {
+	return func(data map[string]string) error {
+		for key := range data {
+			errorType, ok := strings.CutPrefix(key, prefix)
+			if !ok {
+				continue
+			}
+			if !RetryErrorTypes.Has(errorType) {
+				return fmt.Errorf("unsupported error type %q in key %q", errorType, key)
+			}
+			var val int
+			if err := asNonNegativeInt(key, &val)(data); err != nil {
+				return err
+			}
+			if *target == nil {
+				*target = map[string]int{}
+			}
+			(*target)[errorType] = val
+		}
+		return nil
+	}
+}
+
+func asTrustedProducers(prefix string, target *TrustedProducersConfig) cm.ParseFunc {
+	return func(data map[string]string) error {
+		for key, raw := range data {
+			namespace, ok := strings.CutPrefix(key, prefix)
+			if !ok {
+				continue
+			}
+			if namespace == "" {
+				return fmt.Errorf("missing namespace in key %q", key)
+			}
+			refs := sets.New[string]()
+			for _, ref := range strings.Split(raw, ",") {
+				if ref = strings.TrimSpace(ref); ref != "" {
+					refs.Insert(ref)
+				}
+			}
+			if target.Namespaces == nil {
+				target.Namespaces = map[string]sets.Set[string]{}
+			}
+			target.Namespaces[namespace] = refs
+		}
+		return nil
+	}
+}
+
+// asStringMap passes the values of the keys made of the prefix and a name into
+// the target, by name.
+func asStringMap(prefix string, target *map[string]string) cm.ParseFunc {
+	return func(data map[string]string) error {
+		for key, raw := range data {
+			name, ok := strings.CutPrefix(key, prefix)
+			if !ok {
+				continue
+			}
+			if name == "" {
+				return fmt.Errorf("missing name in key %q", key)
+			}
+			if *target == nil {
+				*target = map[string]string{}
+			}
+			(*target)[name] = raw
+		}
+		return nil
+	}
+}
+
+// unsupportedPrefix fails the parsing when a key starts with the prefix.
+func unsupportedPrefix(prefix, reason string) cm.ParseFunc {
+	return func(data map[string]string) error {
+		for key := range data {
+			if strings.HasPrefix(key, prefix) {
+				return fmt.Errorf("invalid key %q: %s", key, reason)
+			}
+		}
+		return nil
+	}
+}
@@ -17,7 +17,9 @@ limitations under the License.
 package config
 
 import (
+	"math"
 	"testing"
+	"time"
 
 	"k8s.io/apimachinery/pkg/util/sets"
 )
@@ -137,3 +139,118 @@ func TestOCIEncodingFormatInvalid(t *testing.T) {
 		t.Error("expected error for invalid encoding format, got nil")
 	}
 }
+
+func TestPolicyInvalid(t *testing.T) {
+	for name, data := range map[string]map[string]string{
+		"rego policy":          {policyRegoKeyPrefix + "scan-task": "package chains"},
+		"unknown enforcement":  {policyEnforcementKey: "ignore"},
+		"unnamed policy":       {policyCELKeyPrefix: "true"},
+		"no non-compliant key": {policyEnforcementKey: PolicyEnforcementNonCompliantKey},
+		"kms signer with a non-compliant secret path only": withKMSSigners(map[string]string{
+			policyEnforcementKey:         PolicyEnforcementNonCompliantKey,
+			policyNonCompliantSecretPath: "/etc/noncompliant-signing-secrets",
+		}),
+		"x509 signer with a non-compliant kmsref only": {
+			policyEnforcementKey:     PolicyEnforcementNonCompliantKey,
+			policyNonCompliantKMSRef: "gcpkms://projects/p/locations/l/keyRings/r/cryptoKeys/noncompliant",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := NewConfigFromMap(data); err == nil {
+				t.Error("expected error for invalid policy configuration, got nil")
+			}
+		})
+	}
+}
+
+// withKMSSigners configures the artifacts enabled by default to be signed with kms.
+func withKMSSigners(data map[string]string) map[string]string {
+	for _, key := range []string{taskrunSignerKey, pipelinerunSignerKey, customrunSignerKey, ociSignerKey, sbomSignerKey, testResultsSignerKey, vulnSignerKey} {
+		data[key] = "kms"
+	}
+	return data
+}
+
+func TestPolicyNonCompliantKey(t *testing.T) {
+	cfg, err := NewConfigFromMap(withKMSSigners(map[string]string{
+		policyEnforcementKey:     PolicyEnforcementNonCompliantKey,
+		policyNonCompliantKMSRef: "gcpkms://projects/p/locations/l/keyRings/r/cryptoKeys/noncompliant",
+	}))
+	if err != nil {
+		t.Fatalf("NewConfigFromMap() error: %v", err)
+	}
+	if cfg.Policy.NonCompliantKMSRef == "" {
+		t.Error("expected the non-compliant kmsref to be set")
+	}
+}
+
+func TestFilterSelectorInvalid(t *testing.T) {
+	for _, key := range []string{filterNamespaceSelectorKey, filterRunSelectorKey} {
+		t.Run(key, func(t *testing.T) {
+			if _, err := NewConfigFromMap(map[string]string{key: "team in (payments"}); err == nil {
+				t.Error("expected error for invalid label selector, got nil")
+			}
+		})
+	}
+}
+
+func TestRetryInvalid(t *testing.T) {
+	for name, data := range map[string]map[string]string{
+		"negative retries":   {retryMaxRetriesKey: "-1"},
+		"invalid retries":    {retryMaxRetriesKey: "three"},
+		"unknown error type": {retryErrorMaxRetriesKey + "network": "5"},
+		"invalid backoff":    {retryBackoffBaseKey: "10"},
+		"negative cap":       {retryBackoffCapKey: "-1m"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := NewConfigFromMap(data); err == nil {
+				t.Error("expected error for invalid retry configuration, got nil")
+			}
+		})
+	}
+}
+
+func TestRetryConfig_MaxRetriesFor(t *testing.T) {
+	cfg := RetryConfig{
+		MaxRetries:      3,
+		ErrorMaxRetries: map[string]int{"payload_creation": 0, "storage": 10},
+	}
+	for name, tt := range map[string]struct {
+		errorTypes sets.Set[string]
+		want       int
+	}{
+		"no error type":       {errorTypes: sets.New[string](), want: 3},
+		"default":             {errorTypes: sets.New[string]("signing"), want: 3},
+		"retried longer":      {errorTypes: sets.New[string]("storage"), want: 10},
+		"never retried":       {errorTypes: sets.New[string]("payload_creation", "storage"), want: 0},
+		"lowest of the types": {errorTypes: sets.New[string]("signing", "storage"), want: 3},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if got := cfg.MaxRetriesFor(tt.errorTypes); got != tt.want {
+				t.Errorf("MaxRetriesFor() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryConfig_Backoff(t *testing.T) {
+	cfg := RetryConfig{BackoffBase: 10 * time.Second, BackoffCap: time.Minute}
+	for retry, want := range []time.Duration{10 * time.Second, 20 * time.Second, 40 * time.Second, time.Minute, time.Minute} {
+		if got := cfg.Backoff(retry); got != want {
+			t.Errorf("Backoff(%d) = %s, want %s", retry, got, want)
+		}
+	}
+	if got := (RetryConfig{}).Backoff(2); got != 0 {
+		t.Errorf("Backoff() without a base = %s, want 0", got)
+	}
+
+	uncapped := RetryConfig{BackoffBase: 10 * time.Second}
+	if got, want := uncapped.Backoff(3), 80*time.Second; got != want {
+		t.Errorf("Backoff(3) without a cap = %s, want %s", got, want)
+	}
+	for _, retry := range []int{40, 100, math.MaxInt32} {
+		if got := uncapped.Backoff(retry); got != math.MaxInt64 {
+			t.Errorf("Backoff(%d) without a cap = %s, want the longest duration", retry, got)
+		}
+	}
+}
@@ -20,7 +20,7 @@ import (
 	"crypto"
 
 	"github.com/sigstore/cosign/v2/pkg/cosign/bundle"
-	"github.com/sigstore/rekor/pkg/generated/models"
+	protorekor "github.com/sigstore/protobuf-specs/gen/pb-go/rekor/v1"
 )
 
 // PayloadType specifies the format to store payload in.
@@ -63,8 +63,9 @@ type StorageOpts struct {
 	// RekorBundle is an optional Rekor transparency log bundle for offline verification.
 	RekorBundle *bundle.RekorBundle
 
-	// RekorEntry is the raw Rekor transparency log entry. Storage backends that construct
-	// a Sigstore protobuf bundle (e.g. OCI sigstore-bundle mode) need it to embed the tlog
-	// entry inline; the converted RekorBundle alone does not carry enough data for MakeNewBundle.
-	RekorEntry *models.LogEntryAnon
+	// TlogEntry is the transparency log entry in the Sigstore bundle v0.3 format, set for
+	// both Rekor v1 and v2 logs. Storage backends that construct a Sigstore protobuf bundle
+	// (e.g. OCI sigstore-bundle mode) embed it inline; the RekorBundle alone does not carry
+	// enough data, and is only available for Rekor v1 logs.
+	TlogEntry *protorekor.TransparencyLogEntry
 }
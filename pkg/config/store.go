@@ -33,9 +33,10 @@ type ConfigStore struct {
 
 var _ reconciler.ConfigStore = (*ConfigStore)(nil)
 
-// FromContext fetch config from context.
+// FromContext fetch config from context, or nil when there is none.
 func FromContext(ctx context.Context) *Config {
-	return ctx.Value(cfgKey{}).(*Config)
+	cfg, _ := ctx.Value(cfgKey{}).(*Config)
+	return cfg
 }
 
 // ToContext adds config to given context.
@@ -84,6 +84,48 @@ func TestNewConfigStore(t *testing.T) {
 	}
 }
 
+func TestNewConfigStore_NonCompliantKeyUnset(t *testing.T) {
+	ctx, _ := rtesting.SetupFakeContext(t)
+
+	ns := system.Namespace()
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "chains-config",
+			Namespace: ns,
+		},
+		Data: map[string]string{
+			policyEnforcementKey:         PolicyEnforcementNonCompliantKey,
+			policyNonCompliantSecretPath: "/etc/noncompliant-signing-secrets",
+		},
+	}
+	fakekubeclient := fakek8s.NewSimpleClientset(cm)
+	cmw := informer.NewInformedWatcher(fakekubeclient, system.Namespace())
+
+	cs := NewConfigStore(logtesting.TestLogger(t))
+	cs.WatchConfigs(cmw)
+	if err := cmw.Start(ctx.Done()); err != nil {
+		t.Fatalf("Error starting configmap.Watcher %v", err)
+	}
+	want := PolicyConfig{
+		Enforcement:            PolicyEnforcementNonCompliantKey,
+		NonCompliantSecretPath: "/etc/noncompliant-signing-secrets",
+	}
+	if diff := cmp.Diff(want, cs.Load().Policy); diff != "" {
+		t.Errorf("unexpected policy: %v", diff)
+	}
+
+	// Removing the non-compliant key is rejected, so that non-compliant runs are
+	// never signed with the regular key.
+	delete(cm.Data, policyNonCompliantSecretPath)
+	if _, err := fakekubeclient.CoreV1().ConfigMaps(ns).Update(ctx, cm, metav1.UpdateOptions{}); err != nil {
+		t.Errorf("error updating configmap: %v", err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if diff := cmp.Diff(want, cs.Load().Policy); diff != "" {
+		t.Errorf("expected the previous policy to be kept: %v", diff)
+	}
+}
+
 var defaultSigners = SignerConfigs{
 	X509: X509Signer{
 		FulcioAddr:       "https://fulcio.sigstore.dev",
@@ -108,11 +150,39 @@ var defaultArtifacts = ArtifactConfigs{
 		StorageBackend:        sets.New[string]("tekton"),
 		DeepInspectionEnabled: false,
 	},
+	CustomRuns: Artifact{
+		Format:         "slsa/v1.1",
+		StorageBackend: sets.New[string]("tekton"),
+		Signer:         "x509",
+	},
 	OCI: Artifact{
 		Format:         "simplesigning",
 		StorageBackend: sets.New[string]("oci"),
 		Signer:         "x509",
 	},
+	SBOM: Artifact{
+		Format:         "sbom",
+		StorageBackend: sets.New[string]("oci"),
+		Signer:         "x509",
+	},
+	TestResults: Artifact{
+		Format:         "test-result",
+		StorageBackend: sets.New[string]("tekton"),
+		Signer:         "x509",
+	},
+	Vuln: Artifact{
+		Format:         "vuln",
+		StorageBackend: sets.New[string]("tekton"),
+		Signer:         "x509",
+	},
+	Links: Artifact{
+		Format: "in-toto-link",
+		Signer: "x509",
+	},
+	VSA: Artifact{
+		Format: "vsa",
+		Signer: "x509",
+	},
 }
 
 var defaultStorage = StorageConfigs{
@@ -128,6 +198,12 @@ var defaultTransparency = TransparencyConfig{
 	URL: "https://rekor.sigstore.dev",
 }
 
+var defaultRetry = RetryConfig{
+	MaxRetries:  3,
+	BackoffBase: 10 * time.Second,
+	BackoffCap:  5 * time.Minute,
+}
+
 var defaultBuildDefinition = BuildDefinitionConfig{
 	BuildType: "https://tekton.dev/chains/v2/slsa",
 }
@@ -152,6 +228,7 @@ func TestParse(t *testing.T) {
 				Storage:         defaultStorage,
 				Transparency:    defaultTransparency,
 				BuildDefinition: defaultBuildDefinition,
+				Retry:           defaultRetry,
 			},
 		},
 		{
@@ -163,13 +240,88 @@ func TestParse(t *testing.T) {
 			ociEnbaled:     true,
 			want: Config{
 				Builder: BuilderConfig{
-					"builder-id-test",
+					ID: "builder-id-test",
+				},
+				Artifacts:       defaultArtifacts,
+				Signers:         defaultSigners,
+				Storage:         defaultStorage,
+				Transparency:    defaultTransparency,
+				BuildDefinition: defaultBuildDefinition,
+				Retry:           defaultRetry,
+			},
+		},
+		{
+			name: "builder version and dependencies",
+			data: map[string]string{
+				builderPipelinesVersionKey: "v1.15.0",
+				builderChainsVersionKey:    "v0.26.0",
+				builderControllerImageKey:  "gcr.io/tekton-releases/controller@sha256:05f95b26ed10668b7183c1e2da98610e91372fa9f510046d4ce5812addad86b5",
+				builderEntrypointImageKey:  "gcr.io/tekton-releases/entrypoint@sha256:586789aa031fafc7d78a5393cdc772e0b55107ea54bb8bcf3f2cdac6c6da51ee",
+			},
+			taskrunEnabled: true,
+			ociEnbaled:     true,
+			want: Config{
+				Builder: BuilderConfig{
+					ID:               defaultBuilder.ID,
+					PipelinesVersion: "v1.15.0",
+					ChainsVersion:    "v0.26.0",
+					ControllerImage:  "gcr.io/tekton-releases/controller@sha256:05f95b26ed10668b7183c1e2da98610e91372fa9f510046d4ce5812addad86b5",
+					EntrypointImage:  "gcr.io/tekton-releases/entrypoint@sha256:586789aa031fafc7d78a5393cdc772e0b55107ea54bb8bcf3f2cdac6c6da51ee",
 				},
 				Artifacts:       defaultArtifacts,
 				Signers:         defaultSigners,
 				Storage:         defaultStorage,
 				Transparency:    defaultTransparency,
 				BuildDefinition: defaultBuildDefinition,
+				Retry:           defaultRetry,
+			},
+		},
+		{
+			name: "results configuration",
+			data: map[string]string{
+				resultsURLKey:       "https://tekton-results-api-service.tekton-pipelines.svc.cluster.local:8080",
+				resultsTokenPathKey: "/var/run/secrets/results/token",
+				resultsCAPathKey:    "/etc/tekton-results/ca.crt",
+			},
+			taskrunEnabled: true,
+			ociEnbaled:     true,
+			want: Config{
+				Builder:         defaultBuilder,
+				Artifacts:       defaultArtifacts,
+				Signers:         defaultSigners,
+				Storage:         defaultStorage,
+				Transparency:    defaultTransparency,
+				BuildDefinition: defaultBuildDefinition,
+				Results: ResultsConfig{
+					URL:       "https://tekton-results-api-service.tekton-pipelines.svc.cluster.local:8080",
+					TokenPath: "/var/run/secrets/results/token",
+					CAPath:    "/etc/tekton-results/ca.crt",
+				},
+				Retry: defaultRetry,
+			},
+		},
+		{
+			name: "redaction configuration",
+			data: map[string]string{
+				redactionParamsKey:                  "*TOKEN*, *PASSWORD*",
+				redactionValuesKeyPrefix + "github": "ghp_[A-Za-z0-9]{36}",
+				redactionFieldsKey:                  "$.buildDefinition.internalParameters",
+			},
+			taskrunEnabled: true,
+			ociEnbaled:     true,
+			want: Config{
+				Builder:         defaultBuilder,
+				Artifacts:       defaultArtifacts,
+				Signers:         defaultSigners,
+				Storage:         defaultStorage,
+				Transparency:    defaultTransparency,
+				BuildDefinition: defaultBuildDefinition,
+				Redaction: RedactionConfig{
+					Params: sets.New[string]("*TOKEN*", "*PASSWORD*"),
+					Values: map[string]string{"github": "ghp_[A-Za-z0-9]{36}"},
+					Fields: sets.New[string]("$.buildDefinition.internalParameters"),
+				},
+				Retry: defaultRetry,
 			},
 		},
 		{
@@ -193,6 +345,7 @@ func TestParse(t *testing.T) {
 				},
 				Transparency:    defaultTransparency,
 				BuildDefinition: defaultBuildDefinition,
+				Retry:           defaultRetry,
 			},
 		},
 		{
@@ -214,16 +367,23 @@ func TestParse(t *testing.T) {
 						StorageBackend:        sets.New[string]("tekton"),
 						DeepInspectionEnabled: false,
 					},
+					CustomRuns: defaultArtifacts.CustomRuns,
 					OCI: Artifact{
 						Format:         "simplesigning",
 						StorageBackend: sets.New[string]("oci"),
 						Signer:         "x509",
 					},
+					SBOM:        defaultArtifacts.SBOM,
+					TestResults: defaultArtifacts.TestResults,
+					Vuln:        defaultArtifacts.Vuln,
+					Links:       defaultArtifacts.Links,
+					VSA:         defaultArtifacts.VSA,
 				},
 				Signers:         defaultSigners,
 				Storage:         defaultStorage,
 				Transparency:    defaultTransparency,
 				BuildDefinition: defaultBuildDefinition,
+				Retry:           defaultRetry,
 			},
 		},
 		{
@@ -245,16 +405,23 @@ func TestParse(t *testing.T) {
 						StorageBackend:        sets.New[string]("tekton", "docdb"),
 						DeepInspectionEnabled: false,
 					},
+					CustomRuns: defaultArtifacts.CustomRuns,
 					OCI: Artifact{
 						Format:         "simplesigning",
 						StorageBackend: sets.New[string]("oci"),
 						Signer:         "x509",
 					},
+					SBOM:        defaultArtifacts.SBOM,
+					TestResults: defaultArtifacts.TestResults,
+					Vuln:        defaultArtifacts.Vuln,
+					Links:       defaultArtifacts.Links,
+					VSA:         defaultArtifacts.VSA,
 				},
 				Signers:         defaultSigners,
 				Storage:         defaultStorage,
 				Transparency:    defaultTransparency,
 				BuildDefinition: defaultBuildDefinition,
+				Retry:           defaultRetry,
 			},
 		},
 		{
@@ -276,16 +443,23 @@ func TestParse(t *testing.T) {
 						StorageBackend:        sets.New[string]("tekton"),
 						DeepInspectionEnabled: false,
 					},
+					CustomRuns: defaultArtifacts.CustomRuns,
 					OCI: Artifact{
 						Format:         "simplesigning",
 						StorageBackend: sets.New[string]("oci"),
 						Signer:         "x509",
 					},
+					SBOM:        defaultArtifacts.SBOM,
+					TestResults: defaultArtifacts.TestResults,
+					Vuln:        defaultArtifacts.Vuln,
+					Links:       defaultArtifacts.Links,
+					VSA:         defaultArtifacts.VSA,
 				},
 				Signers:         defaultSigners,
 				Storage:         defaultStorage,
 				Transparency:    defaultTransparency,
 				BuildDefinition: defaultBuildDefinition,
+				Retry:           defaultRetry,
 			},
 		},
 		{
@@ -307,16 +481,23 @@ func TestParse(t *testing.T) {
 						StorageBackend:        sets.New[string]("tekton"),
 						DeepInspectionEnabled: false,
 					},
+					CustomRuns: defaultArtifacts.CustomRuns,
 					OCI: Artifact{
 						Format:         "simplesigning",
 						StorageBackend: sets.New[string]("oci", "tekton"),
 						Signer:         "x509",
 					},
+					SBOM:        defaultArtifacts.SBOM,
+					TestResults: defaultArtifacts.TestResults,
+					Vuln:        defaultArtifacts.Vuln,
+					Links:       defaultArtifacts.Links,
+					VSA:         defaultArtifacts.VSA,
 				},
 				Signers:         defaultSigners,
 				Storage:         defaultStorage,
 				Transparency:    defaultTransparency,
 				BuildDefinition: defaultBuildDefinition,
+				Retry:           defaultRetry,
 			},
 		},
 		{
@@ -338,16 +519,23 @@ func TestParse(t *testing.T) {
 						StorageBackend:        sets.New[string]("tekton"),
 						DeepInspectionEnabled: false,
 					},
+					CustomRuns: defaultArtifacts.CustomRuns,
 					OCI: Artifact{
 						Format:         "simplesigning",
 						StorageBackend: sets.New[string](""),
 						Signer:         "x509",
 					},
+					SBOM:        defaultArtifacts.SBOM,
+					TestResults: defaultArtifacts.TestResults,
+					Vuln:        defaultArtifacts.Vuln,
+					Links:       defaultArtifacts.Links,
+					VSA:         defaultArtifacts.VSA,
 				},
 				Signers:         defaultSigners,
 				Storage:         defaultStorage,
 				Transparency:    defaultTransparency,
 				BuildDefinition: defaultBuildDefinition,
+				Retry:           defaultRetry,
 			},
 		},
 		{
@@ -372,16 +560,23 @@ func TestParse(t *testing.T) {
 						StorageBackend:        sets.New[string]("tekton"),
 						DeepInspectionEnabled: false,
 					},
+					CustomRuns: defaultArtifacts.CustomRuns,
 					OCI: Artifact{
 						Format:         "simplesigning",
 						StorageBackend: sets.New[string](""),
 						Signer:         "x509",
 					},
+					SBOM:        defaultArtifacts.SBOM,
+					TestResults: defaultArtifacts.TestResults,
+					Vuln:        defaultArtifacts.Vuln,
+					Links:       defaultArtifacts.Links,
+					VSA:         defaultArtifacts.VSA,
 				},
 				Signers:         defaultSigners,
 				Storage:         defaultStorage,
 				Transparency:    defaultTransparency,
 				BuildDefinition: defaultBuildDefinition,
+				Retry:           defaultRetry,
 			},
 		},
 		{
@@ -406,16 +601,23 @@ func TestParse(t *testing.T) {
 						StorageBackend:        sets.New[string]("tekton"),
 						DeepInspectionEnabled: false,
 					},
+					CustomRuns: defaultArtifacts.CustomRuns,
 					OCI: Artifact{
 						Format:         "simplesigning",
 						StorageBackend: sets.New[string]("oci", "tekton"),
 						Signer:         "x509",
 					},
+					SBOM:        defaultArtifacts.SBOM,
+					TestResults: defaultArtifacts.TestResults,
+					Vuln:        defaultArtifacts.Vuln,
+					Links:       defaultArtifacts.Links,
+					VSA:         defaultArtifacts.VSA,
 				},
 				Signers:         defaultSigners,
 				Storage:         defaultStorage,
 				Transparency:    defaultTransparency,
 				BuildDefinition: defaultBuildDefinition,
+				Retry:           defaultRetry,
 			},
 		},
 		{
@@ -437,16 +639,23 @@ func TestParse(t *testing.T) {
 						StorageBackend:        sets.New[string]("tekton"),
 						DeepInspectionEnabled: false,
 					},
+					CustomRuns: defaultArtifacts.CustomRuns,
 					OCI: Artifact{
 						Format:         "simplesigning",
 						StorageBackend: sets.New[string]("oci"),
 						Signer:         "x509",
 					},
+					SBOM:        defaultArtifacts.SBOM,
+					TestResults: defaultArtifacts.TestResults,
+					Vuln:        defaultArtifacts.Vuln,
+					Links:       defaultArtifacts.Links,
+					VSA:         defaultArtifacts.VSA,
 				},
 				Signers:         defaultSigners,
 				Storage:         defaultStorage,
 				Transparency:    defaultTransparency,
 				BuildDefinition: defaultBuildDefinition,
+				Retry:           defaultRetry,
 			},
 		},
 		{
@@ -465,6 +674,406 @@ func TestParse(t *testing.T) {
 					URL:              "https://rekor.sigstore.dev",
 				},
 				BuildDefinition: defaultBuildDefinition,
+				Retry:           defaultRetry,
+			},
+		},
+		{
+			name:           "transparency public keys",
+			data:           map[string]string{transparencyEnabledKey: "true", transparencyPubKeysKey: "-----BEGIN PUBLIC KEY-----"},
+			taskrunEnabled: true,
+			ociEnbaled:     true,
+			want: Config{
+				Builder:   defaultBuilder,
+				Artifacts: defaultArtifacts,
+				Signers:   defaultSigners,
+				Storage:   defaultStorage,
+				Transparency: TransparencyConfig{
+					Enabled:    true,
+					URL:        "https://rekor.sigstore.dev",
+					PublicKeys: "-----BEGIN PUBLIC KEY-----",
+				},
+				BuildDefinition: defaultBuildDefinition,
+				Retry:           defaultRetry,
+			},
+		},
+		{
+			name: "transparency entry type",
+			data: map[string]string{
+				transparencyEnabledKey:   "true",
+				transparencyEntryTypeKey: "dsse",
+				taskrunTlogEntryTypeKey:  "intoto",
+			},
+			taskrunEnabled: true,
+			ociEnbaled:     true,
+			want: Config{
+				Builder: defaultBuilder,
+				Artifacts: ArtifactConfigs{
+					TaskRuns: Artifact{
+						Format:                "in-toto",
+						StorageBackend:        sets.New[string]("tekton"),
+						Signer:                "x509",
+						TransparencyEntryType: "intoto",
+					},
+					PipelineRuns: defaultArtifacts.PipelineRuns,
+					CustomRuns:   defaultArtifacts.CustomRuns,
+					OCI:          defaultArtifacts.OCI,
+					SBOM:         defaultArtifacts.SBOM,
+					TestResults:  defaultArtifacts.TestResults,
+					Vuln:         defaultArtifacts.Vuln,
+					Links:        defaultArtifacts.Links,
+					VSA:          defaultArtifacts.VSA,
+				},
+				Signers: defaultSigners,
+				Storage: defaultStorage,
+				Transparency: TransparencyConfig{
+					Enabled:   true,
+					URL:       "https://rekor.sigstore.dev",
+					EntryType: "dsse",
+				},
+				BuildDefinition: defaultBuildDefinition,
+				Retry:           defaultRetry,
+			},
+		},
+		{
+			name: "transparency api version",
+			data: map[string]string{
+				transparencyEnabledKey:    "true",
+				transparencyURLKey:        "https://log2025-1.rekor.sigstore.dev",
+				transparencyAPIVersionKey: "v2",
+			},
+			taskrunEnabled: true,
+			ociEnbaled:     true,
+			want: Config{
+				Builder:   defaultBuilder,
+				Artifacts: defaultArtifacts,
+				Signers:   defaultSigners,
+				Storage:   defaultStorage,
+				Transparency: TransparencyConfig{
+					Enabled:    true,
+					URL:        "https://log2025-1.rekor.sigstore.dev",
+					APIVersion: "v2",
+				},
+				BuildDefinition: defaultBuildDefinition,
+				Retry:           defaultRetry,
+			},
+		},
+		{
+			name: "spire results verification",
+			data: map[string]string{
+				spireResultsVerificationKey: "enforce",
+				spireTrustDomainKey:         "example.org",
+				spireTrustBundlePathKey:     "/etc/spire/bundle.crt",
+			},
+			taskrunEnabled: true,
+			ociEnbaled:     true,
+			want: Config{
+				Builder:      defaultBuilder,
+				Artifacts:    defaultArtifacts,
+				Signers:      defaultSigners,
+				Storage:      defaultStorage,
+				Transparency: defaultTransparency,
+				Spire: SpireConfig{
+					ResultsVerification: SpireResultsVerificationEnforce,
+					TrustDomain:         "example.org",
+					TrustBundlePath:     "/etc/spire/bundle.crt",
+				},
+				BuildDefinition: defaultBuildDefinition,
+				Retry:           defaultRetry,
+			},
+		},
+		{
+			name: "trusted producers",
+			data: map[string]string{
+				trustedProducersKeyPrefix + "build": "git+https://github.com/org/tasks.git, gcr.io/org/bundle@sha256:05f95b26ed10668b7183c1e2da98610e91372fa9f510046d4ce5812addad86b5",
+				trustedProducersKeyPrefix + "*":     "",
+			},
+			taskrunEnabled: true,
+			ociEnbaled:     true,
+			want: Config{
+				Builder:      defaultBuilder,
+				Artifacts:    defaultArtifacts,
+				Signers:      defaultSigners,
+				Storage:      defaultStorage,
+				Transparency: defaultTransparency,
+				TrustedProducers: TrustedProducersConfig{
+					Namespaces: map[string]sets.Set[string]{
+						"build":                       sets.New[string]("git+https://github.com/org/tasks.git", "gcr.io/org/bundle@sha256:05f95b26ed10668b7183c1e2da98610e91372fa9f510046d4ce5812addad86b5"),
+						TrustedProducersAllNamespaces: sets.New[string](),
+					},
+				},
+				BuildDefinition: defaultBuildDefinition,
+				Retry:           defaultRetry,
+			},
+		},
+		{
+			name: "policies",
+			data: map[string]string{
+				policyCELKeyPrefix + "scan-task": `object.status.pipelineSpec.tasks.exists(t, t.name == "scan")`,
+				policyEnforcementKey:             PolicyEnforcementNonCompliantKey,
+				policyNonCompliantSecretPath:     "/etc/noncompliant-signing-secrets",
+				policyNonCompliantKMSRef:         "gcpkms://projects/p/locations/l/keyRings/r/cryptoKeys/noncompliant",
+			},
+			taskrunEnabled: true,
+			ociEnbaled:     true,
+			want: Config{
+				Builder:      defaultBuilder,
+				Artifacts:    defaultArtifacts,
+				Signers:      defaultSigners,
+				Storage:      defaultStorage,
+				Transparency: defaultTransparency,
+				Policy: PolicyConfig{
+					CEL:                    map[string]string{"scan-task": `object.status.pipelineSpec.tasks.exists(t, t.name == "scan")`},
+					Enforcement:            PolicyEnforcementNonCompliantKey,
+					NonCompliantSecretPath: "/etc/noncompliant-signing-secrets",
+					NonCompliantKMSRef:     "gcpkms://projects/p/locations/l/keyRings/r/cryptoKeys/noncompliant",
+				},
+				BuildDefinition: defaultBuildDefinition,
+				Retry:           defaultRetry,
+			},
+		},
+		{
+			name: "customruns",
+			data: map[string]string{
+				customrunFormatKey:        "slsa/v2alpha4",
+				customrunStorageKey:       "oci,tekton",
+				customrunSignerKey:        "kms",
+				customrunTlogEntryTypeKey: TlogEntryTypeIntoto,
+			},
+			taskrunEnabled: true,
+			ociEnbaled:     true,
+			want: Config{
+				Builder: defaultBuilder,
+				Artifacts: ArtifactConfigs{
+					TaskRuns:     defaultArtifacts.TaskRuns,
+					PipelineRuns: defaultArtifacts.PipelineRuns,
+					CustomRuns: Artifact{
+						Format:                "slsa/v2alpha4",
+						StorageBackend:        sets.New[string]("oci", "tekton"),
+						Signer:                "kms",
+						TransparencyEntryType: TlogEntryTypeIntoto,
+					},
+					OCI:         defaultArtifacts.OCI,
+					SBOM:        defaultArtifacts.SBOM,
+					TestResults: defaultArtifacts.TestResults,
+					Vuln:        defaultArtifacts.Vuln,
+					Links:       defaultArtifacts.Links,
+					VSA:         defaultArtifacts.VSA,
+				},
+				Signers:         defaultSigners,
+				Storage:         defaultStorage,
+				Transparency:    defaultTransparency,
+				BuildDefinition: defaultBuildDefinition,
+				Retry:           defaultRetry,
+			},
+		},
+		{
+			name: "unsuccessful runs",
+			data: map[string]string{
+				taskrunSignFailedKey:        "false",
+				taskrunRecordOutcomeKey:     "true",
+				pipelinerunSignCancelledKey: "false",
+				pipelinerunSignTimedOutKey:  "true",
+				customrunSignTimedOutKey:    "false",
+				customrunRecordOutcomeKey:   "true",
+				ociSignFailedKey:            "false",
+				ociSignCancelledKey:         "false",
+				ociSignTimedOutKey:          "false",
+			},
+			taskrunEnabled: true,
+			ociEnbaled:     true,
+			want: Config{
+				Builder: defaultBuilder,
+				Artifacts: ArtifactConfigs{
+					TaskRuns: Artifact{
+						Format:         "in-toto",
+						StorageBackend: sets.New[string]("tekton"),
+						Signer:         "x509",
+						SkipFailedRuns: true,
+						RecordOutcome:  true,
+					},
+					PipelineRuns: Artifact{
+						Format:            "in-toto",
+						StorageBackend:    sets.New[string]("tekton"),
+						Signer:            "x509",
+						SkipCancelledRuns: true,
+					},
+					CustomRuns: Artifact{
+						Format:           "slsa/v1.1",
+						StorageBackend:   sets.New[string]("tekton"),
+						Signer:           "x509",
+						SkipTimedOutRuns: true,
+						RecordOutcome:    true,
+					},
+					OCI: Artifact{
+						Format:            "simplesigning",
+						StorageBackend:    sets.New[string]("oci"),
+						Signer:            "x509",
+						SkipFailedRuns:    true,
+						SkipCancelledRuns: true,
+						SkipTimedOutRuns:  true,
+					},
+					SBOM:        defaultArtifacts.SBOM,
+					TestResults: defaultArtifacts.TestResults,
+					Vuln:        defaultArtifacts.Vuln,
+					Links:       defaultArtifacts.Links,
+					VSA:         defaultArtifacts.VSA,
+				},
+				Signers:         defaultSigners,
+				Storage:         defaultStorage,
+				Transparency:    defaultTransparency,
+				BuildDefinition: defaultBuildDefinition,
+				Retry:           defaultRetry,
+			},
+		},
+		{
+			name: "sboms",
+			data: map[string]string{
+				sbomStorageKey:       "oci,tekton",
+				sbomSignerKey:        "kms",
+				sbomTlogEntryTypeKey: TlogEntryTypeDSSE,
+			},
+			taskrunEnabled: true,
+			ociEnbaled:     true,
+			want: Config{
+				Builder: defaultBuilder,
+				Artifacts: ArtifactConfigs{
+					TaskRuns:     defaultArtifacts.TaskRuns,
+					PipelineRuns: defaultArtifacts.PipelineRuns,
+					CustomRuns:   defaultArtifacts.CustomRuns,
+					OCI:          defaultArtifacts.OCI,
+					SBOM: Artifact{
+						Format:                "sbom",
+						StorageBackend:        sets.New[string]("oci", "tekton"),
+						Signer:                "kms",
+						TransparencyEntryType: TlogEntryTypeDSSE,
+					},
+					TestResults: defaultArtifacts.TestResults,
+					Vuln:        defaultArtifacts.Vuln,
+					Links:       defaultArtifacts.Links,
+					VSA:         defaultArtifacts.VSA,
+				},
+				Signers:         defaultSigners,
+				Storage:         defaultStorage,
+				Transparency:    defaultTransparency,
+				BuildDefinition: defaultBuildDefinition,
+				Retry:           defaultRetry,
+			},
+		},
+		{
+			name: "test and scan results",
+			data: map[string]string{
+				testResultsStorageKey:       "oci,tekton",
+				testResultsSignerKey:        "kms",
+				testResultsTlogEntryTypeKey: TlogEntryTypeDSSE,
+				vulnStorageKey:              "",
+			},
+			taskrunEnabled: true,
+			ociEnbaled:     true,
+			want: Config{
+				Builder: defaultBuilder,
+				Artifacts: ArtifactConfigs{
+					TaskRuns:     defaultArtifacts.TaskRuns,
+					PipelineRuns: defaultArtifacts.PipelineRuns,
+					CustomRuns:   defaultArtifacts.CustomRuns,
+					OCI:          defaultArtifacts.OCI,
+					SBOM:         defaultArtifacts.SBOM,
+					TestResults: Artifact{
+						Format:                "test-result",
+						StorageBackend:        sets.New[string]("oci", "tekton"),
+						Signer:                "kms",
+						TransparencyEntryType: TlogEntryTypeDSSE,
+					},
+					Vuln: Artifact{
+						Format:         "vuln",
+						StorageBackend: sets.New[string](""),
+						Signer:         "x509",
+					},
+					Links: defaultArtifacts.Links,
+					VSA:   defaultArtifacts.VSA,
+				},
+				Signers:         defaultSigners,
+				Storage:         defaultStorage,
+				Transparency:    defaultTransparency,
+				BuildDefinition: defaultBuildDefinition,
+				Retry:           defaultRetry,
+			},
+		},
+		{
+			name: "step links",
+			data: map[string]string{
+				linksStorageKey:       "tekton",
+				linksSignerKey:        "kms",
+				linksTlogEntryTypeKey: TlogEntryTypeDSSE,
+			},
+			taskrunEnabled: true,
+			ociEnbaled:     true,
+			want: Config{
+				Builder: defaultBuilder,
+				Artifacts: ArtifactConfigs{
+					TaskRuns:     defaultArtifacts.TaskRuns,
+					PipelineRuns: defaultArtifacts.PipelineRuns,
+					CustomRuns:   defaultArtifacts.CustomRuns,
+					OCI:          defaultArtifacts.OCI,
+					SBOM:         defaultArtifacts.SBOM,
+					TestResults:  defaultArtifacts.TestResults,
+					Vuln:         defaultArtifacts.Vuln,
+					Links: Artifact{
+						Format:                "in-toto-link",
+						StorageBackend:        sets.New[string]("tekton"),
+						Signer:                "kms",
+						TransparencyEntryType: TlogEntryTypeDSSE,
+					},
+					VSA: defaultArtifacts.VSA,
+				},
+				Signers:         defaultSigners,
+				Storage:         defaultStorage,
+				Transparency:    defaultTransparency,
+				BuildDefinition: defaultBuildDefinition,
+				Retry:           defaultRetry,
+			},
+		},
+		{
+			name: "verification summaries",
+			data: map[string]string{
+				vsaStorageKey:             "oci,tekton",
+				vsaSignerKey:              "kms",
+				vsaTlogEntryTypeKey:       TlogEntryTypeDSSE,
+				vsaVerifierIDKey:          "https://verifier.example.com",
+				vsaPolicyURIKey:           "https://policies.example.com/release",
+				vsaVerifiedLevelsKey:      "SLSA_BUILD_LEVEL_1",
+				vsaSpireVerifiedLevelsKey: "SLSA_BUILD_LEVEL_3, FEDRAMP_LOW",
+			},
+			taskrunEnabled: true,
+			ociEnbaled:     true,
+			want: Config{
+				Builder: defaultBuilder,
+				Artifacts: ArtifactConfigs{
+					TaskRuns:     defaultArtifacts.TaskRuns,
+					PipelineRuns: defaultArtifacts.PipelineRuns,
+					CustomRuns:   defaultArtifacts.CustomRuns,
+					OCI:          defaultArtifacts.OCI,
+					SBOM:         defaultArtifacts.SBOM,
+					TestResults:  defaultArtifacts.TestResults,
+					Vuln:         defaultArtifacts.Vuln,
+					Links:        defaultArtifacts.Links,
+					VSA: Artifact{
+						Format:                "vsa",
+						StorageBackend:        sets.New[string]("oci", "tekton"),
+						Signer:                "kms",
+						TransparencyEntryType: TlogEntryTypeDSSE,
+					},
+				},
+				Signers:      defaultSigners,
+				Storage:      defaultStorage,
+				Transparency: defaultTransparency,
+				VSA: VSAConfig{
+					VerifierID:          "https://verifier.example.com",
+					PolicyURI:           "https://policies.example.com/release",
+					VerifiedLevels:      sets.New[string]("SLSA_BUILD_LEVEL_1"),
+					SpireVerifiedLevels: sets.New[string]("SLSA_BUILD_LEVEL_3", "FEDRAMP_LOW"),
+				},
+				BuildDefinition: defaultBuildDefinition,
+				Retry:           defaultRetry,
 			},
 		},
 		{
@@ -490,16 +1099,23 @@ func TestParse(t *testing.T) {
 						StorageBackend:        sets.New[string]("tekton"),
 						DeepInspectionEnabled: false,
 					},
+					CustomRuns: defaultArtifacts.CustomRuns,
 					OCI: Artifact{
 						Format:         "simplesigning",
 						StorageBackend: sets.New[string]("oci"),
 						Signer:         "x509",
 					},
+					SBOM:        defaultArtifacts.SBOM,
+					TestResults: defaultArtifacts.TestResults,
+					Vuln:        defaultArtifacts.Vuln,
+					Links:       defaultArtifacts.Links,
+					VSA:         defaultArtifacts.VSA,
 				},
 				Signers:         defaultSigners,
 				Storage:         defaultStorage,
 				Transparency:    defaultTransparency,
 				BuildDefinition: defaultBuildDefinition,
+				Retry:           defaultRetry,
 			},
 		},
 		{
@@ -525,11 +1141,17 @@ func TestParse(t *testing.T) {
 						StorageBackend:        sets.New[string]("tekton"),
 						DeepInspectionEnabled: false,
 					},
+					CustomRuns: defaultArtifacts.CustomRuns,
 					OCI: Artifact{
 						Format:         "simplesigning",
 						StorageBackend: sets.New[string]("oci"),
 						Signer:         "x509",
 					},
+					SBOM:        defaultArtifacts.SBOM,
+					TestResults: defaultArtifacts.TestResults,
+					Vuln:        defaultArtifacts.Vuln,
+					Links:       defaultArtifacts.Links,
+					VSA:         defaultArtifacts.VSA,
 				},
 				Signers: SignerConfigs{
 					X509: X509Signer{
@@ -542,6 +1164,7 @@ func TestParse(t *testing.T) {
 				Storage:         defaultStorage,
 				Transparency:    defaultTransparency,
 				BuildDefinition: defaultBuildDefinition,
+				Retry:           defaultRetry,
 			},
 		},
 		{
@@ -567,6 +1190,7 @@ func TestParse(t *testing.T) {
 					URL:     "https://rekor.sigstore.dev",
 				},
 				BuildDefinition: defaultBuildDefinition,
+				Retry:           defaultRetry,
 			},
 		},
 		{
@@ -593,6 +1217,7 @@ func TestParse(t *testing.T) {
 					URL:              "https://rekor.sigstore.dev",
 				},
 				BuildDefinition: defaultBuildDefinition,
+				Retry:           defaultRetry,
 			},
 		},
 		{
@@ -612,6 +1237,103 @@ func TestParse(t *testing.T) {
 				Filter: FilterConfig{
 					ManagedByValues: sets.New[string]("tekton.dev/pipeline", "my-controller"),
 				},
+				Retry: defaultRetry,
+			},
+		},
+		{
+			name: "opt-in and overrides",
+			data: map[string]string{
+				"filter.opt-in":     "true",
+				"overrides.allowed": "format, storage",
+			},
+			taskrunEnabled: true,
+			ociEnbaled:     true,
+			want: Config{
+				Builder:         defaultBuilder,
+				Artifacts:       defaultArtifacts,
+				Signers:         defaultSigners,
+				Storage:         defaultStorage,
+				Transparency:    defaultTransparency,
+				BuildDefinition: defaultBuildDefinition,
+				Filter: FilterConfig{
+					OptIn: true,
+				},
+				Overrides: OverridesConfig{
+					Allowed: sets.New[string]("format", "storage"),
+				},
+				Retry: defaultRetry,
+			},
+		},
+		{
+			name: "filter selectors and names",
+			data: map[string]string{
+				"filter.namespace-selector": "chains.tekton.dev/enabled=true",
+				"filter.run-selector":       "team in (payments, web)",
+				"filter.pipeline.include":   "release-*",
+				"filter.task.exclude":       "lint, test-*",
+			},
+			taskrunEnabled: true,
+			ociEnbaled:     true,
+			want: Config{
+				Builder:         defaultBuilder,
+				Artifacts:       defaultArtifacts,
+				Signers:         defaultSigners,
+				Storage:         defaultStorage,
+				Transparency:    defaultTransparency,
+				BuildDefinition: defaultBuildDefinition,
+				Filter: FilterConfig{
+					NamespaceSelector: "chains.tekton.dev/enabled=true",
+					RunSelector:       "team in (payments, web)",
+					PipelineInclude:   sets.New[string]("release-*"),
+					TaskExclude:       sets.New[string]("lint", "test-*"),
+				},
+				Retry: defaultRetry,
+			},
+		},
+		{
+			name: "status annotation",
+			data: map[string]string{
+				"status.annotation": "true",
+			},
+			taskrunEnabled: true,
+			ociEnbaled:     true,
+			want: Config{
+				Builder:         defaultBuilder,
+				Artifacts:       defaultArtifacts,
+				Signers:         defaultSigners,
+				Storage:         defaultStorage,
+				Transparency:    defaultTransparency,
+				BuildDefinition: defaultBuildDefinition,
+				Status: StatusConfig{
+					Annotation: true,
+				},
+				Retry: defaultRetry,
+			},
+		},
+		{
+			name: "retry policy",
+			data: map[string]string{
+				"retry.max-retries":                  "5",
+				"retry.max-retries.payload_creation": "0",
+				"retry.max-retries.storage":          "10",
+				"retry.backoff.base":                 "30s",
+				"retry.backoff.cap":                  "1h",
+			},
+			taskrunEnabled: true,
+			ociEnbaled:     true,
+			want: Config{
+				Builder:         defaultBuilder,
+				Artifacts:       defaultArtifacts,
+				Signers:         defaultSigners,
+				Storage:         defaultStorage,
+				Transparency:    defaultTransparency,
+				BuildDefinition: defaultBuildDefinition,
+				Retry: RetryConfig{
+					MaxRetries:      5,
+					BackoffBase:     30 * time.Second,
+					BackoffCap:      time.Hour,
+					ErrorMaxRetries: map[string]int{"payload_creation": 0, "storage": 10},
+				},
 			},
 		},
 		{
@@ -630,6 +1352,7 @@ func TestParse(t *testing.T) {
 				BuildDefinition: BuildDefinitionConfig{
 					BuildType: "https://tekton.dev/chains/v2/slsa-tekton",
 				},
+				Retry: defaultRetry,
 			},
 		},
 	}
@@ -753,3 +1476,15 @@ func TestNewConfigFromMap_OCISignerNone(t *testing.T) {
 		})
 	}
 }
+
+func TestArtifactTlogEntryType(t *testing.T) {
+	transparency := TransparencyConfig{EntryType: TlogEntryTypeDSSE}
+	inherited := Artifact{}
+	if got := inherited.TlogEntryType(transparency); got != TlogEntryTypeDSSE {
+		t.Errorf("TlogEntryType() = %q, want %q", got, TlogEntryTypeDSSE)
+	}
+	overridden := Artifact{TransparencyEntryType: TlogEntryTypeIntoto}
+	if got := overridden.TlogEntryType(transparency); got != TlogEntryTypeIntoto {
+		t.Errorf("TlogEntryType() = %q, want %q", got, TlogEntryTypeIntoto)
+	}
+}
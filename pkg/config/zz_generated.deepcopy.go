@@ -52,7 +52,13 @@ func (in *Artifact) DeepCopy() *Artifact {
 func (in *ArtifactConfigs) DeepCopyInto(out *ArtifactConfigs) {
 	*out = *in
 	in.TaskRuns.DeepCopyInto(&out.TaskRuns)
+	in.CustomRuns.DeepCopyInto(&out.CustomRuns)
 	in.OCI.DeepCopyInto(&out.OCI)
+	in.SBOM.DeepCopyInto(&out.SBOM)
+	in.TestResults.DeepCopyInto(&out.TestResults)
+	in.Vuln.DeepCopyInto(&out.Vuln)
+	in.Links.DeepCopyInto(&out.Links)
+	in.VSA.DeepCopyInto(&out.VSA)
 	return
 }
 
@@ -91,6 +97,12 @@ func (in *Config) DeepCopyInto(out *Config) {
 	out.Builder = in.Builder
 	out.Transparency = in.Transparency
 	in.Filter.DeepCopyInto(&out.Filter)
+	in.TrustedProducers.DeepCopyInto(&out.TrustedProducers)
+	in.Policy.DeepCopyInto(&out.Policy)
+	in.VSA.DeepCopyInto(&out.VSA)
+	in.Redaction.DeepCopyInto(&out.Redaction)
+	in.Overrides.DeepCopyInto(&out.Overrides)
+	in.Retry.DeepCopyInto(&out.Retry)
 	return
 }
 
@@ -114,6 +126,34 @@ func (in *FilterConfig) DeepCopyInto(out *FilterConfig) {
 			(*out)[key] = val
 		}
 	}
+	if in.PipelineInclude != nil {
+		in, out := &in.PipelineInclude, &out.PipelineInclude
+		*out = make(sets.Set[string], len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PipelineExclude != nil {
+		in, out := &in.PipelineExclude, &out.PipelineExclude
+		*out = make(sets.Set[string], len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.TaskInclude != nil {
+		in, out := &in.TaskInclude, &out.TaskInclude
+		*out = make(sets.Set[string], len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.TaskExclude != nil {
+		in, out := &in.TaskExclude, &out.TaskExclude
+		*out = make(sets.Set[string], len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
@@ -191,6 +231,112 @@ func (in *OCIStorageConfig) DeepCopy() *OCIStorageConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OverridesConfig) DeepCopyInto(out *OverridesConfig) {
+	*out = *in
+	if in.Allowed != nil {
+		in, out := &in.Allowed, &out.Allowed
+		*out = make(sets.Set[string], len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OverridesConfig.
+func (in *OverridesConfig) DeepCopy() *OverridesConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OverridesConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyConfig) DeepCopyInto(out *PolicyConfig) {
+	*out = *in
+	if in.CEL != nil {
+		in, out := &in.CEL, &out.CEL
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyConfig.
+func (in *PolicyConfig) DeepCopy() *PolicyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedactionConfig) DeepCopyInto(out *RedactionConfig) {
+	*out = *in
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make(sets.Set[string], len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make(sets.Set[string], len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedactionConfig.
+func (in *RedactionConfig) DeepCopy() *RedactionConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RedactionConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryConfig) DeepCopyInto(out *RetryConfig) {
+	*out = *in
+	if in.ErrorMaxRetries != nil {
+		in, out := &in.ErrorMaxRetries, &out.ErrorMaxRetries
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryConfig.
+func (in *RetryConfig) DeepCopy() *RetryConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RetryConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SignerConfigs) DeepCopyInto(out *SignerConfigs) {
 	*out = *in
@@ -277,6 +423,69 @@ func (in *TransparencyConfig) DeepCopy() *TransparencyConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrustedProducersConfig) DeepCopyInto(out *TrustedProducersConfig) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make(map[string]sets.Set[string], len(*in))
+		for key, val := range *in {
+			var outVal map[string]sets.Empty
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make(sets.Set[string], len(*in))
+				for key, val := range *in {
+					(*out)[key] = val
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrustedProducersConfig.
+func (in *TrustedProducersConfig) DeepCopy() *TrustedProducersConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TrustedProducersConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSAConfig) DeepCopyInto(out *VSAConfig) {
+	*out = *in
+	if in.VerifiedLevels != nil {
+		in, out := &in.VerifiedLevels, &out.VerifiedLevels
+		*out = make(sets.Set[string], len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.SpireVerifiedLevels != nil {
+		in, out := &in.SpireVerifiedLevels, &out.SpireVerifiedLevels
+		*out = make(sets.Set[string], len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSAConfig.
+func (in *VSAConfig) DeepCopy() *VSAConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(VSAConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *X509Signer) DeepCopyInto(out *X509Signer) {
 	*out = *in
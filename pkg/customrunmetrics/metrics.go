@@ -0,0 +1,189 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customrunmetrics
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	"knative.dev/pkg/logging"
+
+	common "github.com/tektoncd/chains/pkg/metrics"
+)
+
+const (
+	customRunSignedName      common.Metric = "watcher_customrun_sign_created_total"
+	customRunSignedDesc      string        = "Total number of signed messages for customruns"
+	customRunUploadedName    common.Metric = "watcher_customrun_payload_uploaded_total"
+	customRunUploadedDesc    string        = "Total number of uploaded payloads for customruns"
+	customRunStoredName      common.Metric = "watcher_customrun_payload_stored_total"
+	customRunStoredDesc      string        = "Total number of stored payloads for customruns"
+	customRunMarkedName      common.Metric = "watcher_customrun_marked_signed_total"
+	customRunMarkedDesc      string        = "Total number of objects marked as signed for customruns"
+	customRunTlogPendingName common.Metric = "watcher_customrun_tlog_pending_total"
+	customRunTlogPendingDesc string        = "Total number of customruns signed with transparency log uploads pending"
+	customRunForgedName      common.Metric = "watcher_customrun_forged_marker_total"
+	customRunForgedDesc      string        = "Total number of customruns found with a forged signed marker"
+	customRunErrorCountName  common.Metric = "watcher_customrun_signing_failures_total"
+	customRunErrorCountDesc  string        = "Total number of CustomRun signing failures"
+)
+
+var _ common.Recorder = &Recorder{}
+
+// Recorder is used to actually record CustomRun metrics.
+type Recorder struct {
+	initialized bool
+	sgCount     otelmetric.Int64Counter
+	plCount     otelmetric.Int64Counter
+	stCount     otelmetric.Int64Counter
+	mrCount     otelmetric.Int64Counter
+	tpCount     otelmetric.Int64Counter
+	fmCount     otelmetric.Int64Counter
+	errCount    otelmetric.Int64Counter
+}
+
+// NewRecorder lazily initializes this singleton. Unlike sync.Once, the
+// mutex-based guard allows a retry if initialization fails (e.g. the OTel
+// provider is not yet ready). Only a fully-initialized recorder is stored in r.
+var (
+	mu sync.Mutex
+	r  *Recorder
+)
+
+// NewRecorder creates a new metrics recorder instance
+// to log the CustomRun related metrics.
+func NewRecorder(ctx context.Context) (*Recorder, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	if r != nil && r.initialized {
+		return r, nil
+	}
+
+	logger := logging.FromContext(ctx)
+	newR := &Recorder{}
+	meter := otel.Meter("github.com/tektoncd/chains/pkg/customrunmetrics")
+
+	var err error
+	newR.sgCount, err = meter.Int64Counter(
+		string(customRunSignedName),
+		otelmetric.WithDescription(customRunSignedDesc),
+	)
+	if err != nil {
+		logger.Errorf("Failed to create %s counter: %v", customRunSignedName, err)
+		return nil, err
+	}
+
+	newR.plCount, err = meter.Int64Counter(
+		string(customRunUploadedName),
+		otelmetric.WithDescription(customRunUploadedDesc),
+	)
+	if err != nil {
+		logger.Errorf("Failed to create %s counter: %v", customRunUploadedName, err)
+		return nil, err
+	}
+
+	newR.stCount, err = meter.Int64Counter(
+		string(customRunStoredName),
+		otelmetric.WithDescription(customRunStoredDesc),
+	)
+	if err != nil {
+		logger.Errorf("Failed to create %s counter: %v", customRunStoredName, err)
+		return nil, err
+	}
+
+	newR.mrCount, err = meter.Int64Counter(
+		string(customRunMarkedName),
+		otelmetric.WithDescription(customRunMarkedDesc),
+	)
+	if err != nil {
+		logger.Errorf("Failed to create %s counter: %v", customRunMarkedName, err)
+		return nil, err
+	}
+
+	newR.tpCount, err = meter.Int64Counter(
+		string(customRunTlogPendingName),
+		otelmetric.WithDescription(customRunTlogPendingDesc),
+	)
+	if err != nil {
+		logger.Errorf("Failed to create %s counter: %v", customRunTlogPendingName, err)
+		return nil, err
+	}
+
+	newR.fmCount, err = meter.Int64Counter(
+		string(customRunForgedName),
+		otelmetric.WithDescription(customRunForgedDesc),
+	)
+	if err != nil {
+		logger.Errorf("Failed to create %s counter: %v", customRunForgedName, err)
+		return nil, err
+	}
+
+	newR.errCount, err = meter.Int64Counter(
+		string(customRunErrorCountName),
+		otelmetric.WithDescription(customRunErrorCountDesc),
+	)
+	if err != nil {
+		logger.Errorf("Failed to create %s counter: %v", customRunErrorCountName, err)
+		return nil, err
+	}
+
+	newR.initialized = true
+	r = newR
+	return r, nil
+}
+
+// RecordCountMetrics implements github.com/tektoncd/chains/pkg/metrics.Recorder.RecordCountMetrics
+func (r *Recorder) RecordCountMetrics(ctx context.Context, metricType common.Metric) {
+	if r == nil {
+		return
+	}
+	logger := logging.FromContext(ctx)
+	if !r.initialized {
+		logger.Debugf("Ignoring the metrics recording as recorder not initialized")
+		return
+	}
+	switch mt := metricType; mt {
+	case common.SignedMessagesCount:
+		r.sgCount.Add(ctx, 1)
+	case common.PayloadUploadedCount:
+		r.plCount.Add(ctx, 1)
+	case common.SignsStoredCount:
+		r.stCount.Add(ctx, 1)
+	case common.MarkedAsSignedCount:
+		r.mrCount.Add(ctx, 1)
+	case common.TlogPendingCount:
+		r.tpCount.Add(ctx, 1)
+	case common.ForgedMarkerCount:
+		r.fmCount.Add(ctx, 1)
+	default:
+		logger.Errorf("Ignoring the metrics recording as valid Metric type matching %v was not found", mt)
+	}
+}
+
+// RecordErrorMetric records a CustomRun signing failure with a given error type.
+func (r *Recorder) RecordErrorMetric(ctx context.Context, errType common.MetricErrorType) {
+	if r == nil {
+		return
+	}
+	if !r.initialized {
+		return
+	}
+	r.errCount.Add(ctx, 1, otelmetric.WithAttributes(attribute.String(common.ErrorTypeAttrKey, string(errType))))
+}
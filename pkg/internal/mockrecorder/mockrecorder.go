@@ -0,0 +1,43 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mockrecorder
+
+import (
+	"context"
+
+	"github.com/tektoncd/chains/pkg/metrics"
+)
+
+// Recorder counts the metrics recorded.
+type Recorder struct {
+	Counts map[metrics.Metric]int
+	Errors map[metrics.MetricErrorType]int
+}
+
+func (m *Recorder) RecordCountMetrics(ctx context.Context, metricType metrics.Metric) {
+	if m.Counts == nil {
+		m.Counts = map[metrics.Metric]int{}
+	}
+	m.Counts[metricType]++
+}
+
+func (m *Recorder) RecordErrorMetric(ctx context.Context, errType metrics.MetricErrorType) {
+	if m.Errors == nil {
+		m.Errors = map[metrics.MetricErrorType]int{}
+	}
+	m.Errors[errType]++
+}
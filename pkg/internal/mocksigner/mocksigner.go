@@ -24,9 +24,12 @@ import (
 
 type Signer struct {
 	Signed bool
+	// Object is the last object signed.
+	Object objects.TektonObject
 }
 
 func (m *Signer) Sign(ctx context.Context, obj objects.TektonObject) error {
 	m.Signed = true
+	m.Object = obj
 	return nil
 }
@@ -21,6 +21,7 @@ import (
 	"os"
 
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 )
 
 func TaskRunV1FromFile(f string) (*v1.TaskRun, error) {
@@ -46,3 +47,15 @@ func PipelineRunV1FromFile(f string) (*v1.PipelineRun, error) {
 	}
 	return &pr, nil
 }
+
+func CustomRunV1beta1FromFile(f string) (*v1beta1.CustomRun, error) {
+	contents, err := os.ReadFile(f)
+	if err != nil {
+		return nil, err
+	}
+	var cr v1beta1.CustomRun
+	if err := json.Unmarshal(contents, &cr); err != nil {
+		return nil, err
+	}
+	return &cr, nil
+}
@@ -24,6 +24,8 @@ const (
 	SignsStoredCount     Metric = "stcount"
 	PayloadUploadedCount Metric = "plcount"
 	MarkedAsSignedCount  Metric = "mrcount"
+	TlogPendingCount     Metric = "tpcount"
+	ForgedMarkerCount    Metric = "fmcount"
 )
 
 // MetricErrorType is a string name of a well-known error type. Any error metrics recorded should be faceted by MetricErrorType
@@ -36,6 +38,10 @@ const (
 	SigningError         MetricErrorType = "signing"
 	StorageError         MetricErrorType = "storage"
 	TlogError            MetricErrorType = "tlog"
+	// ResultsVerificationError is recorded when a run is refused because its results could not be verified.
+	ResultsVerificationError MetricErrorType = "results_verification"
+	// PolicyViolationError is recorded when a run does not comply with the policies.
+	PolicyViolationError MetricErrorType = "policy_violation"
 )
 
 // ErrorTypeAttrKey is the OpenTelemetry attribute key used to label error metrics by type.
@@ -29,16 +29,20 @@ import (
 )
 
 const (
-	pipelineRunSignedName     common.Metric = "watcher_pipelinerun_sign_created_total"
-	pipelineRunSignedDesc     string        = "Total number of signed messages for pipelineruns"
-	pipelineRunUploadedName   common.Metric = "watcher_pipelinerun_payload_uploaded_total"
-	pipelineRunUploadedDesc   string        = "Total number of uploaded payloads for pipelineruns"
-	pipelineRunStoredName     common.Metric = "watcher_pipelinerun_payload_stored_total"
-	pipelineRunStoredDesc     string        = "Total number of stored payloads for pipelineruns"
-	pipelineRunMarkedName     common.Metric = "watcher_pipelinerun_marked_signed_total"
-	pipelineRunMarkedDesc     string        = "Total number of objects marked as signed for pipelineruns"
-	pipelineRunErrorCountName common.Metric = "watcher_pipelinerun_signing_failures_total"
-	pipelineRunErrorCountDesc string        = "Total number of PipelineRun signing failures"
+	pipelineRunSignedName      common.Metric = "watcher_pipelinerun_sign_created_total"
+	pipelineRunSignedDesc      string        = "Total number of signed messages for pipelineruns"
+	pipelineRunUploadedName    common.Metric = "watcher_pipelinerun_payload_uploaded_total"
+	pipelineRunUploadedDesc    string        = "Total number of uploaded payloads for pipelineruns"
+	pipelineRunStoredName      common.Metric = "watcher_pipelinerun_payload_stored_total"
+	pipelineRunStoredDesc      string        = "Total number of stored payloads for pipelineruns"
+	pipelineRunMarkedName      common.Metric = "watcher_pipelinerun_marked_signed_total"
+	pipelineRunMarkedDesc      string        = "Total number of objects marked as signed for pipelineruns"
+	pipelineRunTlogPendingName common.Metric = "watcher_pipelinerun_tlog_pending_total"
+	pipelineRunTlogPendingDesc string        = "Total number of pipelineruns signed with transparency log uploads pending"
+	pipelineRunForgedName      common.Metric = "watcher_pipelinerun_forged_marker_total"
+	pipelineRunForgedDesc      string        = "Total number of pipelineruns found with a forged signed marker"
+	pipelineRunErrorCountName  common.Metric = "watcher_pipelinerun_signing_failures_total"
+	pipelineRunErrorCountDesc  string        = "Total number of PipelineRun signing failures"
 )
 
 var _ common.Recorder = &Recorder{}
@@ -50,6 +54,8 @@ type Recorder struct {
 	plCount     otelmetric.Int64Counter
 	stCount     otelmetric.Int64Counter
 	mrCount     otelmetric.Int64Counter
+	tpCount     otelmetric.Int64Counter
+	fmCount     otelmetric.Int64Counter
 	errCount    otelmetric.Int64Counter
 }
 
@@ -111,6 +117,24 @@ func NewRecorder(ctx context.Context) (*Recorder, error) {
 		return nil, err
 	}
 
+	newR.tpCount, err = meter.Int64Counter(
+		string(pipelineRunTlogPendingName),
+		otelmetric.WithDescription(pipelineRunTlogPendingDesc),
+	)
+	if err != nil {
+		logger.Errorf("Failed to create %s counter: %v", pipelineRunTlogPendingName, err)
+		return nil, err
+	}
+
+	newR.fmCount, err = meter.Int64Counter(
+		string(pipelineRunForgedName),
+		otelmetric.WithDescription(pipelineRunForgedDesc),
+	)
+	if err != nil {
+		logger.Errorf("Failed to create %s counter: %v", pipelineRunForgedName, err)
+		return nil, err
+	}
+
 	newR.errCount, err = meter.Int64Counter(
 		string(pipelineRunErrorCountName),
 		otelmetric.WithDescription(pipelineRunErrorCountDesc),
@@ -144,6 +168,10 @@ func (r *Recorder) RecordCountMetrics(ctx context.Context, metricType common.Met
 		r.stCount.Add(ctx, 1)
 	case common.MarkedAsSignedCount:
 		r.mrCount.Add(ctx, 1)
+	case common.TlogPendingCount:
+		r.tpCount.Add(ctx, 1)
+	case common.ForgedMarkerCount:
+		r.fmCount.Add(ctx, 1)
 	default:
 		logger.Errorf("Ignoring the metrics recording as valid Metric type matching %v was not found", mt)
 	}
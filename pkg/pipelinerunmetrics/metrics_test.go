@@ -81,6 +81,8 @@ func TestCountMetrics(t *testing.T) {
 	rec.RecordCountMetrics(ctx, metrics.PayloadUploadedCount)
 	rec.RecordCountMetrics(ctx, metrics.SignsStoredCount)
 	rec.RecordCountMetrics(ctx, metrics.MarkedAsSignedCount)
+	rec.RecordCountMetrics(ctx, metrics.TlogPendingCount)
+	rec.RecordCountMetrics(ctx, metrics.ForgedMarkerCount)
 
 	var rm metricdata.ResourceMetrics
 	if err := reader.Collect(ctx, &rm); err != nil {
@@ -91,6 +93,8 @@ func TestCountMetrics(t *testing.T) {
 	checkCounterValue(t, rm, string(pipelineRunUploadedName))
 	checkCounterValue(t, rm, string(pipelineRunStoredName))
 	checkCounterValue(t, rm, string(pipelineRunMarkedName))
+	checkCounterValue(t, rm, string(pipelineRunTlogPendingName))
+	checkCounterValue(t, rm, string(pipelineRunForgedName))
 }
 
 func TestRecordErrorMetric(t *testing.T) {
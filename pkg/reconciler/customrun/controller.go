@@ -0,0 +1,124 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customrun
+
+import (
+	"context"
+
+	"github.com/tektoncd/chains/pkg/chains"
+	"github.com/tektoncd/chains/pkg/chains/annotations"
+	"github.com/tektoncd/chains/pkg/chains/storage"
+	"github.com/tektoncd/chains/pkg/config"
+	"github.com/tektoncd/chains/pkg/customrunmetrics"
+	"github.com/tektoncd/chains/pkg/reconciler"
+	pipelineclient "github.com/tektoncd/pipeline/pkg/client/injection/client"
+	customruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/customrun"
+	customrunreconciler "github.com/tektoncd/pipeline/pkg/client/injection/reconciler/pipeline/v1beta1/customrun"
+	"k8s.io/client-go/tools/cache"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	namespaceinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/namespace"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+
+	_ "github.com/tektoncd/chains/pkg/chains/formats/all"
+)
+
+// NewNamespacesScopedController returns a new controller implementation where informer is filtered
+// given a list of namespaces
+func NewNamespacesScopedController(namespaces []string) func(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
+	return func(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
+		logger := logging.FromContext(ctx)
+		customRunInformer := customruninformer.Get(ctx)
+		namespaceInformer := namespaceinformer.Get(ctx)
+
+		kubeClient := kubeclient.Get(ctx)
+		pipelineClient := pipelineclient.Get(ctx)
+
+		markerKey, err := annotations.LoadMarkerKey(SecretPath)
+		if err != nil {
+			logger.Fatalf("Failed to load the signed marker key: %v", err)
+		}
+		if markerKey == nil {
+			logger.Warnf("No %s found in %s, the signed markers are only checked against the managed fields", annotations.MarkerKeyFile, SecretPath)
+		}
+
+		crSigner := &chains.ObjectSigner{
+			SecretPath:        SecretPath,
+			Pipelineclientset: pipelineClient,
+			Recorder:          customrunmetrics.Get(ctx),
+		}
+		tlogQueue := chains.NewTlogQueue(crSigner)
+		crSigner.TlogQueue = tlogQueue
+		go tlogQueue.Run(ctx)
+
+		c := &Reconciler{
+			CustomRunSigner:   crSigner,
+			Pipelineclientset: pipelineClient,
+			TlogQueue:         tlogQueue,
+			MarkerKey:         markerKey,
+			Recorder:          customrunmetrics.Get(ctx),
+		}
+
+		watcherStop := make(chan bool)
+		cfgStore := config.NewConfigStore(logger, func(_ string, value interface{}) {
+			select {
+			case watcherStop <- true:
+				logger.Info("sent close event to WatchBackends()...")
+			default:
+				logger.Info("could not send close event to WatchBackends()...")
+			}
+
+			// get updated config
+			cfg := *value.(*config.Config)
+
+			// get all backends for storing provenance
+			backends, err := storage.InitializeBackends(ctx, pipelineClient, kubeClient, cfg)
+			if err != nil {
+				logger.Error(err)
+			}
+			crSigner.Backends = backends
+
+			if err := storage.WatchBackends(ctx, watcherStop, crSigner.Backends, cfg); err != nil {
+				logger.Error(err)
+			}
+		})
+		cfgStore.WatchConfigs(cmw)
+
+		impl := customrunreconciler.NewImpl(ctx, c, func(_ *controller.Impl) controller.Options {
+			return controller.Options{
+				// The chains reconciler shouldn't mutate the customrun's status.
+				SkipStatusUpdates:               true,
+				ConfigStore:                     cfgStore,
+				FinalizerName:                   customRunFinalizerName,
+				UseServerSideApplyForFinalizers: true,
+				FinalizerFieldManager:           "tekton-chains-controller/finalizers",
+			}
+		})
+
+		filterFunc := reconciler.CustomRunInformerFilterFunc(namespaces, cfgStore, namespaceInformer.Lister())
+		if _, err := customRunInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+			FilterFunc: filterFunc,
+			Handler:    controller.HandleAll(impl.Enqueue),
+		}); err != nil {
+			logger.Errorf("adding event handler for customrun controller's customrun informer encountered error: %v", err)
+		}
+
+		if _, err := namespaceInformer.Informer().AddEventHandler(reconciler.NamespaceLabelsHandler(impl, customRunInformer.Informer(), filterFunc)); err != nil {
+			logger.Errorf("adding event handler for customrun controller's namespace informer encountered error: %v", err)
+		}
+
+		return impl
+	}
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customrun
+
+import (
+	"context"
+
+	signing "github.com/tektoncd/chains/pkg/chains"
+	"github.com/tektoncd/chains/pkg/chains/annotations"
+	"github.com/tektoncd/chains/pkg/chains/objects"
+	"github.com/tektoncd/chains/pkg/metrics"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+	customrunreconciler "github.com/tektoncd/pipeline/pkg/client/injection/reconciler/pipeline/v1beta1/customrun"
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+	pkgreconciler "knative.dev/pkg/reconciler"
+)
+
+const (
+	// SecretPath contains the path to the secrets volume that is mounted in.
+	SecretPath = "/etc/signing-secrets"
+
+	customRunFinalizerName = "chains.tekton.dev/customrun"
+)
+
+type Reconciler struct {
+	CustomRunSigner   signing.Signer
+	Pipelineclientset versioned.Interface
+	// TlogQueue, when set, receives the signed CustomRuns with pending transparency log uploads.
+	TlogQueue *signing.TlogQueue
+	// MarkerKey, when set, authenticates the signed markers so that the ones not
+	// written by Chains are ignored.
+	MarkerKey []byte
+	// Recorder records the CustomRuns found with a forged signed marker.
+	Recorder metrics.Recorder
+}
+
+// Check that our Reconciler implements customrunreconciler.Interface and customrunreconciler.Finalizer
+var _ customrunreconciler.Interface = (*Reconciler)(nil)
+var _ customrunreconciler.Finalizer = (*Reconciler)(nil)
+
+// ReconcileKind  handles a changed or created CustomRun.
+// This is the main entrypoint for chains business logic.
+func (r *Reconciler) ReconcileKind(ctx context.Context, cr *v1beta1.CustomRun) pkgreconciler.Event {
+	return r.FinalizeKind(ctx, cr)
+}
+
+// FinalizeKind implements customrunreconciler.Finalizer
+// We utilize finalizers to ensure that we get a crack at signing every customrun
+// that we see flowing through the system.  If we don't add a finalizer, it could
+// get cleaned up before we see the final state and sign it.
+func (r *Reconciler) FinalizeKind(ctx context.Context, cr *v1beta1.CustomRun) pkgreconciler.Event {
+	// Check to make sure the CustomRun is finished.
+	if !cr.IsDone() {
+		logging.FromContext(ctx).Infof("customrun %s/%s is still running", cr.Namespace, cr.Name)
+		return nil
+	}
+
+	ctx = annotations.WithMarkerKey(ctx, r.MarkerKey)
+	obj := objects.NewCustomRunObjectV1beta1(cr)
+
+	// Reset the signing state when the run was requested to be signed again, it
+	// is signed once the update is observed.
+	if annotations.ResignRequested(cr.Annotations) {
+		logging.FromContext(ctx).Infof("customrun %s/%s was requested to be signed again: %s", cr.Namespace, cr.Name, cr.Annotations[annotations.ResignAnnotation])
+		return annotations.ResetForResign(ctx, obj, r.Pipelineclientset)
+	}
+
+	// Check to see if it has already been signed.
+	if annotations.Reconciled(ctx, r.Pipelineclientset, obj) {
+		logging.FromContext(ctx).Infof("customrun %s/%s has been reconciled", cr.Namespace, cr.Name)
+		if r.TlogQueue != nil && annotations.TlogPending(cr.Annotations) {
+			r.TlogQueue.Enqueue(ctx, obj)
+		}
+		return nil
+	}
+	if annotations.ForgedMarker(ctx, obj) {
+		r.reportForgedMarker(ctx, cr)
+	}
+
+	if err := r.CustomRunSigner.Sign(ctx, obj); err != nil {
+		return err
+	}
+	return nil
+}
+
+// reportForgedMarker warns about a signed marker that was not written by Chains.
+// The CustomRun is then signed as if the marker was not there.
+func (r *Reconciler) reportForgedMarker(ctx context.Context, cr *v1beta1.CustomRun) {
+	logging.FromContext(ctx).Warnf("customrun %s/%s has a %s annotation not written by Chains, ignoring it", cr.Namespace, cr.Name, annotations.ChainsAnnotation)
+	if recorder := controller.GetEventRecorder(ctx); recorder != nil {
+		recorder.Eventf(cr, corev1.EventTypeWarning, "ForgedSignedMarker", "Ignoring the %s annotation not written by Chains", annotations.ChainsAnnotation)
+	}
+	if r.Recorder != nil {
+		r.Recorder.RecordCountMetrics(ctx, metrics.ForgedMarkerCount)
+	}
+}
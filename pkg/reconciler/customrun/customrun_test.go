@@ -0,0 +1,231 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customrun
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/tektoncd/chains/pkg/chains/annotations"
+	"github.com/tektoncd/chains/pkg/chains/objects"
+	"github.com/tektoncd/chains/pkg/config"
+	_ "github.com/tektoncd/chains/pkg/customrunmetrics/fake"
+	"github.com/tektoncd/chains/pkg/internal/mockrecorder"
+	"github.com/tektoncd/chains/pkg/internal/mocksigner"
+	"github.com/tektoncd/chains/pkg/metrics"
+	"github.com/tektoncd/chains/pkg/test/tekton"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	informers "github.com/tektoncd/pipeline/pkg/client/informers/externalversions/pipeline/v1beta1"
+	fakepipelineclient "github.com/tektoncd/pipeline/pkg/client/injection/client/fake"
+	fakecustomruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/customrun/fake"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	_ "knative.dev/pkg/client/injection/kube/client/fake"
+	_ "knative.dev/pkg/client/injection/kube/informers/core/v1/namespace/fake"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	pkgreconciler "knative.dev/pkg/reconciler"
+	rtesting "knative.dev/pkg/reconciler/testing"
+	"knative.dev/pkg/system"
+)
+
+func TestReconciler_Reconcile(t *testing.T) {
+	tests := []struct {
+		name       string
+		key        string
+		customRuns []*v1beta1.CustomRun
+	}{
+		{
+			name:       "no customruns",
+			key:        "foo/bar",
+			customRuns: []*v1beta1.CustomRun{},
+		},
+		{
+			name: "found customrun",
+			key:  "foo/bar",
+			customRuns: []*v1beta1.CustomRun{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "bar",
+						Namespace: "foo",
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			ctx, _ := rtesting.SetupFakeContext(t)
+			setupData(ctx, t, tt.customRuns)
+
+			configMapWatcher := configmap.NewStaticWatcher(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: system.Namespace(),
+					Name:      config.ChainsConfig,
+				},
+			})
+
+			namespacedScopedController := NewNamespacesScopedController(nil)
+			ctl := namespacedScopedController(ctx, configMapWatcher)
+
+			if la, ok := ctl.Reconciler.(pkgreconciler.LeaderAware); ok {
+				if err := la.Promote(pkgreconciler.UniversalBucket(), func(pkgreconciler.Bucket, types.NamespacedName) {}); err != nil {
+					t.Fatalf("Promote() = %v", err)
+				}
+			}
+
+			if err := ctl.Reconciler.Reconcile(ctx, tt.key); err != nil {
+				t.Errorf("Reconciler.Reconcile() error = %v", err)
+			}
+		})
+	}
+}
+
+func setupData(ctx context.Context, t *testing.T, runs []*v1beta1.CustomRun) informers.CustomRunInformer {
+	cri := fakecustomruninformer.Get(ctx)
+	c := fakepipelineclient.Get(ctx)
+
+	for _, run := range runs {
+		run := run.DeepCopy() // Avoid assumptions that the informer's copy is modified.
+		if _, err := c.TektonV1beta1().CustomRuns(run.Namespace).Create(ctx, run, metav1.CreateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	c.ClearActions()
+	return cri
+}
+
+func TestReconciler_handleCustomRun(t *testing.T) {
+
+	tests := []struct {
+		name       string
+		cr         *v1beta1.CustomRun
+		shouldSign bool
+	}{
+		{
+			name: "complete, already signed",
+			cr: &v1beta1.CustomRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{annotations.ChainsAnnotation: "true"},
+				},
+				Status: v1beta1.CustomRunStatus{
+					Status: duckv1.Status{
+						Conditions: []apis.Condition{{Type: apis.ConditionSucceeded}},
+					}},
+			},
+			shouldSign: false,
+		},
+		{
+			name: "complete, not already signed",
+			cr: &v1beta1.CustomRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{},
+				},
+				Status: v1beta1.CustomRunStatus{
+					Status: duckv1.Status{
+						Conditions: []apis.Condition{{Type: apis.ConditionSucceeded}},
+					}},
+			},
+			shouldSign: true,
+		},
+		{
+			name: "not complete, not already signed",
+			cr: &v1beta1.CustomRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{},
+				},
+				Status: v1beta1.CustomRunStatus{
+					Status: duckv1.Status{
+						Conditions: []apis.Condition{},
+					}},
+			},
+			shouldSign: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signer := &mocksigner.Signer{}
+			ctx, _ := rtesting.SetupFakeContext(t)
+			c := fakepipelineclient.Get(ctx)
+			tekton.CreateObject(t, ctx, c, objects.NewCustomRunObjectV1beta1(tt.cr))
+
+			r := &Reconciler{
+				CustomRunSigner:   signer,
+				Pipelineclientset: c,
+			}
+			ctx = config.ToContext(ctx, &config.Config{})
+			if err := r.ReconcileKind(ctx, tt.cr); err != nil {
+				t.Errorf("Reconciler.handleCustomRun() error = %v", err)
+			}
+			if signer.Signed != tt.shouldSign {
+				t.Errorf("Reconciler.handleCustomRun() signed = %v, wanted %v", signer.Signed, tt.shouldSign)
+			}
+		})
+	}
+}
+
+func TestReconciler_forgedMarker(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	cr := &v1beta1.CustomRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "forged",
+			Namespace:   "default",
+			UID:         "forged-uid",
+			Annotations: map[string]string{annotations.ChainsAnnotation: "true"},
+		},
+		Status: v1beta1.CustomRunStatus{
+			Status: duckv1.Status{
+				Conditions: []apis.Condition{{Type: apis.ConditionSucceeded}},
+			}},
+	}
+
+	signer := &mocksigner.Signer{}
+	recorder := &mockrecorder.Recorder{}
+	events := record.NewFakeRecorder(1)
+	ctx, _ := rtesting.SetupFakeContext(t)
+	c := fakepipelineclient.Get(ctx)
+	tekton.CreateObject(t, ctx, c, objects.NewCustomRunObjectV1beta1(cr))
+
+	r := &Reconciler{
+		CustomRunSigner:   signer,
+		Pipelineclientset: c,
+		MarkerKey:         key,
+		Recorder:          recorder,
+	}
+	ctx = config.ToContext(ctx, &config.Config{})
+	ctx = controller.WithEventRecorder(ctx, events)
+	if err := r.ReconcileKind(ctx, cr); err != nil {
+		t.Fatalf("Reconciler.ReconcileKind() error = %v", err)
+	}
+	if !signer.Signed {
+		t.Error("expected the CustomRun with a forged marker to be signed")
+	}
+	if got := recorder.Counts[metrics.ForgedMarkerCount]; got != 1 {
+		t.Errorf("expected 1 forged marker recorded, got %d", got)
+	}
+	select {
+	case event := <-events.Events:
+		if !strings.Contains(event, "ForgedSignedMarker") {
+			t.Errorf("unexpected event %q", event)
+		}
+	default:
+		t.Error("expected an event for the forged marker")
+	}
+}
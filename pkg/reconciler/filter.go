@@ -14,12 +14,21 @@ limitations under the License.
 package reconciler
 
 import (
+	"maps"
+	"path"
 	"slices"
 
+	"github.com/tektoncd/chains/pkg/chains/annotations"
 	"github.com/tektoncd/chains/pkg/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/sets"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
 	"knative.dev/pkg/controller"
 )
 
@@ -30,35 +39,129 @@ func isManagedByAllowed(managedBy *string, allowed sets.Set[string]) bool {
 	return allowed.Has(*managedBy)
 }
 
+// isOptedIn returns true when the run is opted in with the opt-in annotation or
+// label, or when Chains processes all the runs.
+func isOptedIn(obj metav1.Object, cfg *config.Config) bool {
+	if !cfg.Filter.OptIn {
+		return true
+	}
+	return obj.GetAnnotations()[annotations.OptInAnnotation] == "true" || obj.GetLabels()[annotations.OptInAnnotation] == "true"
+}
+
+// isSelected returns true when the run and its namespace match the label
+// selectors of the filter configuration.
+func isSelected(obj metav1.Object, namespaceLister corev1listers.NamespaceLister, cfg *config.Config) bool {
+	if cfg.Filter.RunSelector != "" && !matchesSelector(cfg.Filter.RunSelector, obj.GetLabels()) {
+		return false
+	}
+	if cfg.Filter.NamespaceSelector == "" {
+		return true
+	}
+	if namespaceLister == nil {
+		return false
+	}
+	ns, err := namespaceLister.Get(obj.GetNamespace())
+	if err != nil {
+		return false
+	}
+	return matchesSelector(cfg.Filter.NamespaceSelector, ns.Labels)
+}
+
+func matchesSelector(selector string, set map[string]string) bool {
+	s, err := labels.Parse(selector)
+	return err == nil && s.Matches(labels.Set(set))
+}
+
+// isNameIncluded returns true when the name matches one of the include globs,
+// if there are any, and none of the exclude globs.
+func isNameIncluded(name string, include, exclude sets.Set[string]) bool {
+	include = include.Difference(sets.New[string](""))
+	if include.Len() > 0 && !matchesAny(name, include) {
+		return false
+	}
+	return !matchesAny(name, exclude)
+}
+
+func matchesAny(name string, globs sets.Set[string]) bool {
+	for glob := range globs {
+		if glob == "" {
+			continue
+		}
+		if ok, _ := path.Match(glob, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// pipelineName returns the name of the Pipeline of the PipelineRun, from the
+// label set by the Pipelines controller or else from its reference.
+func pipelineName(pr *v1.PipelineRun) string {
+	if name := pr.Labels[pipeline.PipelineLabelKey]; name != "" {
+		return name
+	}
+	if pr.Spec.PipelineRef != nil {
+		return pr.Spec.PipelineRef.Name
+	}
+	return ""
+}
+
+// taskName returns the name of the Task of the TaskRun, from the label set by
+// the Pipelines controller or else from its reference.
+func taskName(tr *v1.TaskRun) string {
+	if name := tr.Labels[pipeline.TaskLabelKey]; name != "" {
+		return name
+	}
+	if tr.Spec.TaskRef != nil {
+		return tr.Spec.TaskRef.Name
+	}
+	return ""
+}
+
+func isPipelineRunAllowed(pr *v1.PipelineRun, namespaces []string, cfg *config.Config) bool {
+	if !isManagedByAllowed(pr.Spec.ManagedBy, cfg.Filter.ManagedByValues) {
+		return false
+	}
+	if len(namespaces) == 0 {
+		return true
+	}
+	return slices.Contains(namespaces, pr.Namespace)
+}
+
 // PipelineRunInformerFilterFunc returns a filter function
-// for PipelineRuns ensuring PipelineRuns are filtered by spec.managedBy value
-// and list of namespaces membership
-func PipelineRunInformerFilterFunc(namespaces []string, cfgStore *config.ConfigStore) func(obj interface{}) bool {
+// for PipelineRuns ensuring PipelineRuns are filtered by spec.managedBy value,
+// opt-in, label selectors, Pipeline name and list of namespaces membership
+func PipelineRunInformerFilterFunc(namespaces []string, cfgStore *config.ConfigStore, namespaceLister corev1listers.NamespaceLister) func(obj interface{}) bool {
 	return func(obj interface{}) bool {
 		pr, ok := obj.(*v1.PipelineRun)
 		if !ok {
 			return false
 		}
-		if !isManagedByAllowed(pr.Spec.ManagedBy, cfgStore.Load().Filter.ManagedByValues) {
+		cfg := cfgStore.Load()
+		if !isOptedIn(pr, cfg) || !isSelected(pr, namespaceLister, cfg) {
 			return false
 		}
-		if len(namespaces) == 0 {
-			return true
+		if !isNameIncluded(pipelineName(pr), cfg.Filter.PipelineInclude, cfg.Filter.PipelineExclude) {
+			return false
 		}
-		return slices.Contains(namespaces, pr.Namespace)
+		return isPipelineRunAllowed(pr, namespaces, cfg)
 	}
 }
 
 // TaskRunInformerFilterFunc returns a filter function
-// for TaskRuns ensuring TaskRuns are filtered by spec.managedBy value
-// and list of namespaces membership
-func TaskRunInformerFilterFunc(namespaces []string, cfgStore *config.ConfigStore) func(obj interface{}) bool {
+// for TaskRuns ensuring TaskRuns are filtered by spec.managedBy value,
+// opt-in, label selectors, Task name and list of namespaces membership
+func TaskRunInformerFilterFunc(namespaces []string, cfgStore *config.ConfigStore, namespaceLister corev1listers.NamespaceLister) func(obj interface{}) bool {
 	return func(obj interface{}) bool {
 		tr, ok := obj.(*v1.TaskRun)
 		if !ok {
 			return false
 		}
-		if !isManagedByAllowed(tr.Spec.ManagedBy, cfgStore.Load().Filter.ManagedByValues) {
+		cfg := cfgStore.Load()
+		if !isOptedIn(tr, cfg) || !isManagedByAllowed(tr.Spec.ManagedBy, cfg.Filter.ManagedByValues) {
+			return false
+		}
+		if !isSelected(tr, namespaceLister, cfg) || !isNameIncluded(taskName(tr), cfg.Filter.TaskInclude, cfg.Filter.TaskExclude) {
 			return false
 		}
 		if len(namespaces) == 0 {
@@ -90,3 +193,82 @@ func TaskRunInformerFilterFuncWithOwnership(namespaces []string, cfgStore *confi
 		return slices.Contains(namespaces, tr.Namespace)
 	}
 }
+
+// PipelineRunInformerFilterFuncWithOwnership returns a filter function
+// for child PipelineRuns ensuring Ownership by a PipelineRun and filtered by spec.managedBy value
+// and list of namespaces membership. The children are not filtered by opt-in, their parent is.
+func PipelineRunInformerFilterFuncWithOwnership(namespaces []string, cfgStore *config.ConfigStore) func(obj interface{}) bool {
+	return func(obj interface{}) bool {
+		// Ownership filter
+		if !controller.FilterController(&v1.PipelineRun{})(obj) {
+			return false
+		}
+		pr, ok := obj.(*v1.PipelineRun)
+		if !ok {
+			return false
+		}
+		return isPipelineRunAllowed(pr, namespaces, cfgStore.Load())
+	}
+}
+
+// CustomRunInformerFilterFunc returns a filter function
+// for CustomRuns ensuring CustomRuns are filtered by opt-in, label selectors
+// and list of namespaces membership
+func CustomRunInformerFilterFunc(namespaces []string, cfgStore *config.ConfigStore, namespaceLister corev1listers.NamespaceLister) func(obj interface{}) bool {
+	return func(obj interface{}) bool {
+		run, ok := obj.(*v1beta1.CustomRun)
+		if !ok {
+			return false
+		}
+		cfg := cfgStore.Load()
+		if !isOptedIn(run, cfg) || !isSelected(run, namespaceLister, cfg) {
+			return false
+		}
+		if len(namespaces) == 0 {
+			return true
+		}
+		return slices.Contains(namespaces, run.Namespace)
+	}
+}
+
+// CustomRunInformerFilterFuncWithOwnership returns a filter function
+// for CustomRuns ensuring Ownership by a PipelineRun and list of namespaces membership
+func CustomRunInformerFilterFuncWithOwnership(namespaces []string) func(obj interface{}) bool {
+	return func(obj interface{}) bool {
+		// Ownership filter
+		if !controller.FilterController(&v1.PipelineRun{})(obj) {
+			return false
+		}
+		run, ok := obj.(*v1beta1.CustomRun)
+		if !ok {
+			return false
+		}
+		if len(namespaces) == 0 {
+			return true
+		}
+		return slices.Contains(namespaces, run.Namespace)
+	}
+}
+
+// NamespaceLabelsHandler returns a handler for the namespace informer that
+// enqueues again the runs of the informer passing the filter in the namespaces
+// whose labels change, so that the namespace selector applies to them without
+// restarting the controller.
+func NamespaceLabelsHandler(impl *controller.Impl, informer cache.SharedInformer, filter func(obj interface{}) bool) cache.ResourceEventHandler {
+	return cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldNs, ok := oldObj.(*corev1.Namespace)
+			if !ok {
+				return
+			}
+			newNs, ok := newObj.(*corev1.Namespace)
+			if !ok || maps.Equal(oldNs.Labels, newNs.Labels) {
+				return
+			}
+			impl.FilteredGlobalResync(func(obj interface{}) bool {
+				run, ok := obj.(metav1.Object)
+				return ok && run.GetNamespace() == newNs.Name && filter(obj)
+			}, informer)
+		},
+	}
+}
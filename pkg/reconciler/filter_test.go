@@ -18,11 +18,15 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/tektoncd/chains/pkg/chains/annotations"
 	"github.com/tektoncd/chains/pkg/config"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/utils/ptr"
 	logtesting "knative.dev/pkg/logging/testing"
 )
@@ -236,7 +240,7 @@ func TestPipelineRunInformerFilterFunc(t *testing.T) {
 			if cfgStore == nil {
 				cfgStore = defaultTestConfigStore(t)
 			}
-			filterFunc := PipelineRunInformerFilterFunc(tt.namespaces, cfgStore)
+			filterFunc := PipelineRunInformerFilterFunc(tt.namespaces, cfgStore, nil)
 			result := filterFunc(tt.obj)
 			if result != tt.expected {
 				t.Errorf("PipelineRunInformerFilterFunc() result = %v, wanted %v", result, tt.expected)
@@ -357,7 +361,7 @@ func TestTaskRunInformerFilterFunc(t *testing.T) {
 			if cfgStore == nil {
 				cfgStore = defaultTestConfigStore(t)
 			}
-			filterFunc := TaskRunInformerFilterFunc(tt.namespaces, cfgStore)
+			filterFunc := TaskRunInformerFilterFunc(tt.namespaces, cfgStore, nil)
 			result := filterFunc(tt.obj)
 			if result != tt.expected {
 				t.Errorf("TaskRunInformerFilterFunc() result = %v, wanted %v", result, tt.expected)
@@ -459,3 +463,337 @@ func TestTaskRunInformerFilterFuncWithOwnership(t *testing.T) {
 		})
 	}
 }
+
+func TestPipelineRunInformerFilterFuncWithOwnership(t *testing.T) {
+	boolValue := true
+	tests := []struct {
+		name       string
+		namespaces []string
+		obj        interface{}
+		expected   bool
+	}{
+		{
+			name:       "Child pipelinerun with matching namespace",
+			namespaces: []string{"default"},
+			obj: &v1.PipelineRun{ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				OwnerReferences: []metav1.OwnerReference{
+					{APIVersion: "tekton.dev/v1", Kind: "PipelineRun", Controller: &boolValue},
+				},
+			}},
+			expected: true,
+		},
+		{
+			name:       "Child pipelinerun with non-matching namespace",
+			namespaces: []string{"test"},
+			obj: &v1.PipelineRun{ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				OwnerReferences: []metav1.OwnerReference{
+					{APIVersion: "tekton.dev/v1", Kind: "PipelineRun", Controller: &boolValue},
+				},
+			}},
+			expected: false,
+		},
+		{
+			name:       "No ownership",
+			namespaces: []string{},
+			obj:        &v1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Namespace: "default"}},
+			expected:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filterFunc := PipelineRunInformerFilterFuncWithOwnership(tt.namespaces, defaultTestConfigStore(t))
+			result := filterFunc(tt.obj)
+			if result != tt.expected {
+				t.Errorf("PipelineRunInformerFilterFuncWithOwnership() result = %v, wanted %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCustomRunInformerFilterFuncWithOwnership(t *testing.T) {
+	boolValue := true
+	tests := []struct {
+		name       string
+		namespaces []string
+		obj        interface{}
+		expected   bool
+	}{
+		{
+			name:       "Empty namespaces and ownership, should match",
+			namespaces: []string{},
+			obj: &v1beta1.CustomRun{ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				OwnerReferences: []metav1.OwnerReference{
+					{APIVersion: "tekton.dev/v1", Kind: "PipelineRun", Controller: &boolValue},
+				},
+			}},
+			expected: true,
+		},
+		{
+			name:       "Non-matching namespace and ownership",
+			namespaces: []string{"test"},
+			obj: &v1beta1.CustomRun{ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				OwnerReferences: []metav1.OwnerReference{
+					{APIVersion: "tekton.dev/v1", Kind: "PipelineRun", Controller: &boolValue},
+				},
+			}},
+			expected: false,
+		},
+		{
+			name:       "No ownership",
+			namespaces: []string{"default"},
+			obj:        &v1beta1.CustomRun{ObjectMeta: metav1.ObjectMeta{Namespace: "default"}},
+			expected:   false,
+		},
+		{
+			name:       "Not a customrun",
+			namespaces: []string{},
+			obj: &v1.TaskRun{ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				OwnerReferences: []metav1.OwnerReference{
+					{APIVersion: "tekton.dev/v1", Kind: "PipelineRun", Controller: &boolValue},
+				},
+			}},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filterFunc := CustomRunInformerFilterFuncWithOwnership(tt.namespaces)
+			result := filterFunc(tt.obj)
+			if result != tt.expected {
+				t.Errorf("CustomRunInformerFilterFuncWithOwnership() result = %v, wanted %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestInformerFilterFuncs_OptIn(t *testing.T) {
+	boolValue := true
+	logger := logtesting.TestLogger(t)
+	optInStore := config.NewConfigStore(logger)
+	optInStore.OnConfigChanged(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: config.ChainsConfig},
+		Data:       map[string]string{"filter.opt-in": "true"},
+	})
+	optedIn := metav1.ObjectMeta{Namespace: "default", Annotations: map[string]string{annotations.OptInAnnotation: "true"}}
+	labelled := metav1.ObjectMeta{Namespace: "default", Labels: map[string]string{annotations.OptInAnnotation: "true"}}
+	optedOut := metav1.ObjectMeta{Namespace: "default", Annotations: map[string]string{annotations.OptInAnnotation: "false"}}
+	child := metav1.ObjectMeta{
+		Namespace:       "default",
+		OwnerReferences: []metav1.OwnerReference{{APIVersion: "tekton.dev/v1", Kind: "PipelineRun", Controller: &boolValue}},
+	}
+
+	tests := []struct {
+		name     string
+		filter   func(obj interface{}) bool
+		obj      interface{}
+		expected bool
+	}{
+		{
+			name:     "TaskRun opted in with an annotation",
+			filter:   TaskRunInformerFilterFunc(nil, optInStore, nil),
+			obj:      &v1.TaskRun{ObjectMeta: optedIn},
+			expected: true,
+		},
+		{
+			name:     "TaskRun opted in with a label",
+			filter:   TaskRunInformerFilterFunc(nil, optInStore, nil),
+			obj:      &v1.TaskRun{ObjectMeta: labelled},
+			expected: true,
+		},
+		{
+			name:     "TaskRun not opted in",
+			filter:   TaskRunInformerFilterFunc(nil, optInStore, nil),
+			obj:      &v1.TaskRun{ObjectMeta: metav1.ObjectMeta{Namespace: "default"}},
+			expected: false,
+		},
+		{
+			name:     "TaskRun opted out",
+			filter:   TaskRunInformerFilterFunc(nil, optInStore, nil),
+			obj:      &v1.TaskRun{ObjectMeta: optedOut},
+			expected: false,
+		},
+		{
+			name:     "TaskRun opted in, in another namespace",
+			filter:   TaskRunInformerFilterFunc([]string{"test"}, optInStore, nil),
+			obj:      &v1.TaskRun{ObjectMeta: optedIn},
+			expected: false,
+		},
+		{
+			name:     "PipelineRun opted in",
+			filter:   PipelineRunInformerFilterFunc(nil, optInStore, nil),
+			obj:      &v1.PipelineRun{ObjectMeta: optedIn},
+			expected: true,
+		},
+		{
+			name:     "PipelineRun not opted in",
+			filter:   PipelineRunInformerFilterFunc(nil, optInStore, nil),
+			obj:      &v1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Namespace: "default"}},
+			expected: false,
+		},
+		{
+			name:     "CustomRun opted in",
+			filter:   CustomRunInformerFilterFunc(nil, optInStore, nil),
+			obj:      &v1beta1.CustomRun{ObjectMeta: optedIn},
+			expected: true,
+		},
+		{
+			name:     "CustomRun not opted in",
+			filter:   CustomRunInformerFilterFunc(nil, optInStore, nil),
+			obj:      &v1beta1.CustomRun{ObjectMeta: metav1.ObjectMeta{Namespace: "default"}},
+			expected: false,
+		},
+		{
+			name:     "child TaskRun tracked without opt-in",
+			filter:   TaskRunInformerFilterFuncWithOwnership(nil, optInStore),
+			obj:      &v1.TaskRun{ObjectMeta: child},
+			expected: true,
+		},
+		{
+			name:     "child PipelineRun tracked without opt-in",
+			filter:   PipelineRunInformerFilterFuncWithOwnership(nil, optInStore),
+			obj:      &v1.PipelineRun{ObjectMeta: child},
+			expected: true,
+		},
+		{
+			name:     "TaskRun without opt-in mode",
+			filter:   TaskRunInformerFilterFunc(nil, productionDefaultConfigStore(t), nil),
+			obj:      &v1.TaskRun{ObjectMeta: metav1.ObjectMeta{Namespace: "default"}},
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.filter(tt.obj); result != tt.expected {
+				t.Errorf("filter result = %v, wanted %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestInformerFilterFuncs_Selectors(t *testing.T) {
+	logger := logtesting.TestLogger(t)
+	store := config.NewConfigStore(logger)
+	store.OnConfigChanged(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: config.ChainsConfig},
+		Data: map[string]string{
+			"filter.namespace-selector": "chains.tekton.dev/enabled=true",
+			"filter.run-selector":       "team notin (sandbox)",
+			"filter.pipeline.include":   "release-*",
+			"filter.pipeline.exclude":   "release-test-*",
+			"filter.task.exclude":       "lint, test-*",
+		},
+	})
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, ns := range []*corev1.Namespace{
+		{ObjectMeta: metav1.ObjectMeta{Name: "enabled", Labels: map[string]string{"chains.tekton.dev/enabled": "true"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "disabled"}},
+	} {
+		if err := indexer.Add(ns); err != nil {
+			t.Fatal(err)
+		}
+	}
+	namespaceLister := corev1listers.NewNamespaceLister(indexer)
+
+	taskRun := func(namespace, task string, labels map[string]string) *v1.TaskRun {
+		return &v1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Labels: labels},
+			Spec:       v1.TaskRunSpec{TaskRef: &v1.TaskRef{Name: task}},
+		}
+	}
+	pipelineRun := func(pipeline string) *v1.PipelineRun {
+		return &v1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "enabled", Labels: map[string]string{"tekton.dev/pipeline": pipeline}},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		filter   func(obj interface{}) bool
+		obj      interface{}
+		expected bool
+	}{
+		{
+			name:     "TaskRun in a selected namespace",
+			filter:   TaskRunInformerFilterFunc(nil, store, namespaceLister),
+			obj:      taskRun("enabled", "build", nil),
+			expected: true,
+		},
+		{
+			name:     "TaskRun in a namespace not selected",
+			filter:   TaskRunInformerFilterFunc(nil, store, namespaceLister),
+			obj:      taskRun("disabled", "build", nil),
+			expected: false,
+		},
+		{
+			name:     "TaskRun in an unknown namespace",
+			filter:   TaskRunInformerFilterFunc(nil, store, namespaceLister),
+			obj:      taskRun("unknown", "build", nil),
+			expected: false,
+		},
+		{
+			name:     "TaskRun not selected",
+			filter:   TaskRunInformerFilterFunc(nil, store, namespaceLister),
+			obj:      taskRun("enabled", "build", map[string]string{"team": "sandbox"}),
+			expected: false,
+		},
+		{
+			name:     "TaskRun of an excluded Task",
+			filter:   TaskRunInformerFilterFunc(nil, store, namespaceLister),
+			obj:      taskRun("enabled", "test-unit", nil),
+			expected: false,
+		},
+		{
+			name:     "TaskRun of an excluded Task from its label",
+			filter:   TaskRunInformerFilterFunc(nil, store, namespaceLister),
+			obj:      taskRun("enabled", "build", map[string]string{"tekton.dev/task": "lint"}),
+			expected: false,
+		},
+		{
+			name:     "PipelineRun of an included Pipeline",
+			filter:   PipelineRunInformerFilterFunc(nil, store, namespaceLister),
+			obj:      pipelineRun("release-app"),
+			expected: true,
+		},
+		{
+			name:     "PipelineRun of a Pipeline not included",
+			filter:   PipelineRunInformerFilterFunc(nil, store, namespaceLister),
+			obj:      pipelineRun("build-app"),
+			expected: false,
+		},
+		{
+			name:     "PipelineRun of an excluded Pipeline",
+			filter:   PipelineRunInformerFilterFunc(nil, store, namespaceLister),
+			obj:      pipelineRun("release-test-app"),
+			expected: false,
+		},
+		{
+			name:     "CustomRun in a namespace not selected",
+			filter:   CustomRunInformerFilterFunc(nil, store, namespaceLister),
+			obj:      &v1beta1.CustomRun{ObjectMeta: metav1.ObjectMeta{Namespace: "disabled"}},
+			expected: false,
+		},
+		{
+			name:     "child TaskRun tracked whatever its namespace",
+			filter:   TaskRunInformerFilterFuncWithOwnership(nil, store),
+			obj:      &v1.TaskRun{ObjectMeta: metav1.ObjectMeta{Namespace: "disabled", OwnerReferences: []metav1.OwnerReference{{APIVersion: "tekton.dev/v1", Kind: "PipelineRun", Controller: ptr.To(true)}}}},
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.filter(tt.obj); result != tt.expected {
+				t.Errorf("filter result = %v, wanted %v", result, tt.expected)
+			}
+		})
+	}
+}
@@ -17,6 +17,7 @@ import (
 	"context"
 
 	"github.com/tektoncd/chains/pkg/chains"
+	"github.com/tektoncd/chains/pkg/chains/annotations"
 	"github.com/tektoncd/chains/pkg/chains/storage"
 	"github.com/tektoncd/chains/pkg/config"
 	"github.com/tektoncd/chains/pkg/pipelinerunmetrics"
@@ -24,9 +25,11 @@ import (
 	pipelineclient "github.com/tektoncd/pipeline/pkg/client/injection/client"
 	pipelineruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1/pipelinerun"
 	taskruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1/taskrun"
+	customruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/customrun"
 	pipelinerunreconciler "github.com/tektoncd/pipeline/pkg/client/injection/reconciler/pipeline/v1/pipelinerun"
 	"k8s.io/client-go/tools/cache"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	namespaceinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/namespace"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
@@ -41,20 +44,38 @@ func NewNamespacesScopedController(namespaces []string) func(ctx context.Context
 		logger := logging.FromContext(ctx)
 		pipelineRunInformer := pipelineruninformer.Get(ctx)
 		taskRunInformer := taskruninformer.Get(ctx)
+		customRunInformer := customruninformer.Get(ctx)
+		namespaceInformer := namespaceinformer.Get(ctx)
 
 		kubeClient := kubeclient.Get(ctx)
 		pipelineClient := pipelineclient.Get(ctx)
 
+		markerKey, err := annotations.LoadMarkerKey(SecretPath)
+		if err != nil {
+			logger.Fatalf("Failed to load the signed marker key: %v", err)
+		}
+		if markerKey == nil {
+			logger.Warnf("No %s found in %s, the signed markers are only checked against the managed fields", annotations.MarkerKeyFile, SecretPath)
+		}
+
 		psSigner := &chains.ObjectSigner{
 			SecretPath:        SecretPath,
 			Pipelineclientset: pipelineClient,
 			Recorder:          pipelinerunmetrics.Get(ctx),
 		}
+		tlogQueue := chains.NewTlogQueue(psSigner)
+		psSigner.TlogQueue = tlogQueue
+		go tlogQueue.Run(ctx)
 
 		c := &Reconciler{
 			PipelineRunSigner: psSigner,
 			Pipelineclientset: pipelineClient,
 			TaskRunLister:     taskRunInformer.Lister(),
+			PipelineRunLister: pipelineRunInformer.Lister(),
+			CustomRunLister:   customRunInformer.Lister(),
+			TlogQueue:         tlogQueue,
+			MarkerKey:         markerKey,
+			Recorder:          pipelinerunmetrics.Get(ctx),
 		}
 
 		watcherStop := make(chan bool)
@@ -81,6 +102,8 @@ func NewNamespacesScopedController(namespaces []string) func(ctx context.Context
 			}
 		})
 		cfgStore.WatchConfigs(cmw)
+		c.TaskRunFilter = reconciler.TaskRunInformerFilterFunc(namespaces, cfgStore, namespaceInformer.Lister())
+		c.PipelineRunFilter = reconciler.PipelineRunInformerFilterFunc(namespaces, cfgStore, namespaceInformer.Lister())
 
 		impl := pipelinerunreconciler.NewImpl(ctx, c, func(_ *controller.Impl) controller.Options {
 			return controller.Options{
@@ -95,13 +118,18 @@ func NewNamespacesScopedController(namespaces []string) func(ctx context.Context
 
 		c.Tracker = impl.Tracker
 
+		filterFunc := c.PipelineRunFilter
 		if _, err := pipelineRunInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
-			FilterFunc: reconciler.PipelineRunInformerFilterFunc(namespaces, cfgStore),
+			FilterFunc: filterFunc,
 			Handler:    controller.HandleAll(impl.Enqueue),
 		}); err != nil {
 			logger.Errorf("adding event handler for pipelinerun controller's pipelinerun informer encountered error: %v", err)
 		}
 
+		if _, err := namespaceInformer.Informer().AddEventHandler(reconciler.NamespaceLabelsHandler(impl, pipelineRunInformer.Informer(), filterFunc)); err != nil {
+			logger.Errorf("adding event handler for pipelinerun controller's namespace informer encountered error: %v", err)
+		}
+
 		if _, err := taskRunInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
 			FilterFunc: reconciler.TaskRunInformerFilterFuncWithOwnership(namespaces, cfgStore),
 			Handler:    controller.HandleAll(impl.EnqueueControllerOf),
@@ -109,6 +137,20 @@ func NewNamespacesScopedController(namespaces []string) func(ctx context.Context
 			logger.Errorf("adding event handler for pipelinerun controller's taskrun informer encountered error: %v", err)
 		}
 
+		if _, err := pipelineRunInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+			FilterFunc: reconciler.PipelineRunInformerFilterFuncWithOwnership(namespaces, cfgStore),
+			Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+		}); err != nil {
+			logger.Errorf("adding event handler for pipelinerun controller's child pipelinerun informer encountered error: %v", err)
+		}
+
+		if _, err := customRunInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+			FilterFunc: reconciler.CustomRunInformerFilterFuncWithOwnership(namespaces),
+			Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+		}); err != nil {
+			logger.Errorf("adding event handler for pipelinerun controller's customrun informer encountered error: %v", err)
+		}
+
 		return impl
 	}
 }
@@ -18,16 +18,24 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sync"
 	"time"
 
 	signing "github.com/tektoncd/chains/pkg/chains"
 	"github.com/tektoncd/chains/pkg/chains/annotations"
 	"github.com/tektoncd/chains/pkg/chains/objects"
+	"github.com/tektoncd/chains/pkg/chains/tektonresults"
+	"github.com/tektoncd/chains/pkg/config"
+	"github.com/tektoncd/chains/pkg/metrics"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
 	pipelinerunreconciler "github.com/tektoncd/pipeline/pkg/client/injection/reconciler/pipeline/v1/pipelinerun"
 	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1"
+	listersv1beta1 "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1beta1"
 	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -48,7 +56,27 @@ type Reconciler struct {
 	PipelineRunSigner signing.Signer
 	Pipelineclientset versioned.Interface
 	TaskRunLister     listers.TaskRunLister
+	PipelineRunLister listers.PipelineRunLister
+	CustomRunLister   listersv1beta1.CustomRunLister
 	Tracker           tracker.Interface
+	// TlogQueue, when set, receives the signed PipelineRuns with pending transparency log uploads.
+	TlogQueue *signing.TlogQueue
+	// MarkerKey, when set, authenticates the signed markers so that the ones not
+	// written by Chains are ignored.
+	MarkerKey []byte
+	// Recorder records the PipelineRuns found with a forged signed marker.
+	Recorder metrics.Recorder
+	// TaskRunFilter and PipelineRunFilter, when set, select the runs signed by
+	// the TaskRun and PipelineRun controllers. The child runs they filter out are
+	// never marked as signed, so they are not waited for.
+	TaskRunFilter     func(obj interface{}) bool
+	PipelineRunFilter func(obj interface{}) bool
+
+	// resultsMu guards the client of Tekton Results, built again when its
+	// configuration changes.
+	resultsMu     sync.Mutex
+	resultsConfig config.ResultsConfig
+	resultsClient *tektonresults.Client
 }
 
 // Check that our Reconciler implements pipelinerunreconciler.Interface and pipelinerunreconciler.Finalizer
@@ -99,62 +127,204 @@ func (r *Reconciler) FinalizeKind(ctx context.Context, pr *v1.PipelineRun) (resu
 		logging.FromContext(ctx).Infof("pipelinerun is still running")
 		return nil
 	}
+	ctx = annotations.WithMarkerKey(ctx, r.MarkerKey)
 	pro := objects.NewPipelineRunObjectV1(pr)
 
+	// Reset the signing state when the run was requested to be signed again, it
+	// is signed once the update is observed.
+	if annotations.ResignRequested(pr.Annotations) {
+		logging.FromContext(ctx).Infof("pipelinerun was requested to be signed again: %s", pr.Annotations[annotations.ResignAnnotation])
+		return annotations.ResetForResign(ctx, pro, r.Pipelineclientset)
+	}
+
 	// Check to see if it has already been signed.
 	if annotations.Reconciled(ctx, r.Pipelineclientset, pro) {
 		logging.FromContext(ctx).Infof("pipelinerun has been reconciled")
+		if r.TlogQueue != nil && annotations.TlogPending(pr.Annotations) {
+			r.TlogQueue.Enqueue(ctx, pro)
+		}
 		return nil
 	}
-
-	// Get TaskRun names depending on whether embeddedstatus feature is set or not
-	var trs []string
-	for _, cr := range pr.Status.ChildReferences {
-		trs = append(trs, cr.Name)
+	if annotations.ForgedMarker(ctx, pro) {
+		r.reportForgedMarker(ctx, pr)
 	}
 
 	// Signing both taskruns and pipelineruns causes a race condition when using oci storage
-	// during the push to the registry. This checks the taskruns to ensure they've been reconciled
-	// before attempting to sign the pippelinerun.
-	for _, name := range trs {
-		tr, err := r.TaskRunLister.TaskRuns(pr.Namespace).Get(name)
-		if err != nil {
-			logging.FromContext(ctx).Errorf("Unable to get reconciled status of taskrun %s within pipelinerun", name)
+	// during the push to the registry. This checks the child runs to ensure they've been
+	// reconciled before attempting to sign the pipelinerun.
+	ready, err := r.appendChildren(ctx, pr, pr, pro)
+	if !ready || err != nil {
+		return err
+	}
+
+	if err := r.PipelineRunSigner.Sign(ctx, pro); err != nil {
+		return err
+	}
+	return nil
+}
+
+// reportForgedMarker warns about a signed marker that was not written by Chains.
+// The PipelineRun is then signed as if the marker was not there.
+func (r *Reconciler) reportForgedMarker(ctx context.Context, pr *v1.PipelineRun) {
+	logging.FromContext(ctx).Warnf("pipelinerun has a %s annotation not written by Chains, ignoring it", annotations.ChainsAnnotation)
+	if recorder := controller.GetEventRecorder(ctx); recorder != nil {
+		recorder.Eventf(pr, corev1.EventTypeWarning, "ForgedSignedMarker", "Ignoring the %s annotation not written by Chains", annotations.ChainsAnnotation)
+	}
+	if r.Recorder != nil {
+		r.Recorder.RecordCountMetrics(ctx, metrics.ForgedMarkerCount)
+	}
+}
+
+// appendChildren adds the child runs of the given PipelineRun to pro, walking the child
+// PipelineRuns recursively. It returns false when the root PipelineRun can't be signed yet,
+// either because a child is still running or waiting to be signed, in which case root tracks
+// it, or because a child is gone.
+func (r *Reconciler) appendChildren(ctx context.Context, root, pr *v1.PipelineRun, pro *objects.PipelineRunObjectV1) (bool, error) {
+	for _, cr := range pr.Status.ChildReferences {
+		switch cr.Kind {
+		case "", pipeline.TaskRunControllerName:
+			tr, err := r.TaskRunLister.TaskRuns(pr.Namespace).Get(cr.Name)
 			if errors.IsNotFound(err) {
-				// Since this is an unrecoverable scenario, returning the error would prevent the
-				// finalizer from being removed, thus preventing the PipelineRun from being deleted.
-				return nil
+				archived, err := r.archivedTaskRun(ctx, pr, cr.Name)
+				if err != nil {
+					return false, err
+				}
+				if archived != nil {
+					pro.AppendTaskRun(archived)
+					continue
+				}
+			}
+			if err != nil {
+				return false, r.childLookupError(ctx, "taskrun", cr.Name, err)
+			}
+			if tr == nil {
+				logging.FromContext(ctx).Infof("taskrun %s within pipelinerun is not found", cr.Name)
+				return false, nil
+			}
+			if tr.Status.CompletionTime == nil {
+				logging.FromContext(ctx).Infof("taskrun %s within pipelinerun is not yet finalized: status is not complete", cr.Name)
+				return false, r.trackChild(root, v1.SchemeGroupVersion.String(), cr.Kind, tr.ObjectMeta)
+			}
+			if !filtered(r.TaskRunFilter, tr) && !annotations.Reconciled(ctx, r.Pipelineclientset, objects.NewTaskRunObjectV1(tr)) {
+				logging.FromContext(ctx).Infof("taskrun %s within pipelinerun is not yet reconciled", cr.Name)
+				return false, r.trackChild(root, v1.SchemeGroupVersion.String(), cr.Kind, tr.ObjectMeta)
+			}
+			pro.AppendTaskRun(tr)
+		case pipeline.PipelineRunControllerName:
+			child, err := r.PipelineRunLister.PipelineRuns(pr.Namespace).Get(cr.Name)
+			if err != nil {
+				return false, r.childLookupError(ctx, "pipelinerun", cr.Name, err)
+			}
+			if !child.IsDone() {
+				logging.FromContext(ctx).Infof("pipelinerun %s within pipelinerun is not yet finalized: status is not complete", cr.Name)
+				return false, r.trackChild(root, v1.SchemeGroupVersion.String(), cr.Kind, child.ObjectMeta)
 			}
-			return err
+			childObj := objects.NewPipelineRunObjectV1(child)
+			if !filtered(r.PipelineRunFilter, child) && !annotations.Reconciled(ctx, r.Pipelineclientset, childObj) {
+				logging.FromContext(ctx).Infof("pipelinerun %s within pipelinerun is not yet reconciled", cr.Name)
+				return false, r.trackChild(root, v1.SchemeGroupVersion.String(), cr.Kind, child.ObjectMeta)
+			}
+			ready, err := r.appendChildren(ctx, root, child, childObj)
+			if !ready || err != nil {
+				return false, err
+			}
+			pro.AppendPipelineRun(childObj)
+		case pipeline.CustomRunControllerName:
+			run, err := r.CustomRunLister.CustomRuns(pr.Namespace).Get(cr.Name)
+			if err != nil {
+				return false, r.childLookupError(ctx, "customrun", cr.Name, err)
+			}
+			if !run.IsDone() {
+				logging.FromContext(ctx).Infof("customrun %s within pipelinerun is not yet finalized: status is not complete", cr.Name)
+				return false, r.trackChild(root, v1beta1.SchemeGroupVersion.String(), cr.Kind, run.ObjectMeta)
+			}
+			pro.AppendCustomRun(run)
+		default:
+			logging.FromContext(ctx).Warnf("skipping child %s of unsupported kind %s within pipelinerun", cr.Name, cr.Kind)
 		}
-		if tr == nil {
-			logging.FromContext(ctx).Infof("taskrun %s within pipelinerun is not found", name)
-			return nil
+	}
+	return true, nil
+}
+
+// filtered returns true when the run is filtered out by the given filter, and
+// thus not signed by Chains.
+func filtered(filter func(obj interface{}) bool, obj interface{}) bool {
+	return filter != nil && !filter(obj)
+}
+
+// archivedTaskRun fetches a child TaskRun pruned from the cluster from Tekton Results, when
+// it is configured. It returns nil when Tekton Results has no complete record of the TaskRun.
+func (r *Reconciler) archivedTaskRun(ctx context.Context, pr *v1.PipelineRun, name string) (*v1.TaskRun, error) {
+	cfg := config.FromContext(ctx)
+	if cfg == nil || cfg.Results.URL == "" {
+		return nil, nil
+	}
+	client, err := r.tektonResultsClient(cfg.Results)
+	if err != nil {
+		return nil, err
+	}
+	taskRuns, err := client.GetTaskRuns(ctx, pr.Namespace, name)
+	if err != nil {
+		return nil, fmt.Errorf("fetching taskrun %s within pipelinerun from Tekton Results: %w", name, err)
+	}
+	for _, tr := range taskRuns {
+		// The name of a pruned TaskRun may have been reused by another run.
+		if !metav1.IsControlledBy(tr, pr) {
+			continue
 		}
 		if tr.Status.CompletionTime == nil {
-			logging.FromContext(ctx).Infof("taskrun %s within pipelinerun is not yet finalized: status is not complete", name)
-			return r.trackTaskRun(tr, pr)
+			logging.FromContext(ctx).Infof("archived taskrun %s within pipelinerun is not complete", name)
+			return nil, nil
 		}
-		reconciled := annotations.Reconciled(ctx, r.Pipelineclientset, objects.NewTaskRunObjectV1(tr))
-		if !reconciled {
-			logging.FromContext(ctx).Infof("taskrun %s within pipelinerun is not yet reconciled", name)
-			return r.trackTaskRun(tr, pr)
+		if !annotations.ReconciledLocally(ctx, objects.NewTaskRunObjectV1(tr)) {
+			logging.FromContext(ctx).Warnf("archived taskrun %s within pipelinerun was not signed by Chains", name)
 		}
-		pro.AppendTaskRun(tr)
+		logging.FromContext(ctx).Infof("taskrun %s within pipelinerun fetched from Tekton Results", name)
+		return tr, nil
 	}
+	logging.FromContext(ctx).Infof("taskrun %s within pipelinerun is not archived by Tekton Results", name)
+	return nil, nil
+}
 
-	if err := r.PipelineRunSigner.Sign(ctx, pro); err != nil {
-		return err
+// tektonResultsClient returns the client of Tekton Results for the given
+// configuration, reusing the one built for the same configuration.
+func (r *Reconciler) tektonResultsClient(cfg config.ResultsConfig) (*tektonresults.Client, error) {
+	r.resultsMu.Lock()
+	defer r.resultsMu.Unlock()
+	if r.resultsClient != nil && r.resultsConfig == cfg {
+		return r.resultsClient, nil
 	}
-	return nil
+	client, err := tektonresults.NewClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if r.resultsClient != nil {
+		r.resultsClient.CloseIdleConnections()
+	}
+	r.resultsConfig, r.resultsClient = cfg, client
+	return client, nil
 }
 
-func (r *Reconciler) trackTaskRun(tr *v1.TaskRun, pr *v1.PipelineRun) error {
+// childLookupError logs a failed lookup of a child run. The error is swallowed when the child
+// doesn't exist anymore: since this is an unrecoverable scenario, returning the error would
+// prevent the finalizer from being removed, thus preventing the PipelineRun from being deleted.
+func (r *Reconciler) childLookupError(ctx context.Context, kind, name string, err error) error {
+	logging.FromContext(ctx).Errorf("Unable to get reconciled status of %s %s within pipelinerun", kind, name)
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+func (r *Reconciler) trackChild(pr *v1.PipelineRun, apiVersion, kind string, child metav1.ObjectMeta) error {
+	if kind == "" {
+		kind = pipeline.TaskRunControllerName
+	}
 	ref := tracker.Reference{
-		APIVersion: "tekton.dev/v1",
-		Kind:       "TaskRun",
-		Namespace:  tr.Namespace,
-		Name:       tr.Name,
+		APIVersion: apiVersion,
+		Kind:       kind,
+		Namespace:  child.Namespace,
+		Name:       child.Name,
 	}
 	return r.Tracker.TrackReference(ref, pr)
 }
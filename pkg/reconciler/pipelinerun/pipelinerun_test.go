@@ -15,27 +15,39 @@ package pipelinerun
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/tektoncd/chains/pkg/chains/annotations"
 	"github.com/tektoncd/chains/pkg/chains/objects"
 	"github.com/tektoncd/chains/pkg/config"
+	"github.com/tektoncd/chains/pkg/internal/mockrecorder"
 	"github.com/tektoncd/chains/pkg/internal/mocksigner"
+	"github.com/tektoncd/chains/pkg/metrics"
+	"github.com/tektoncd/chains/pkg/reconciler"
 	_ "github.com/tektoncd/chains/pkg/pipelinerunmetrics/fake"
+	"github.com/tektoncd/chains/pkg/test/results"
 	"github.com/tektoncd/chains/pkg/test/tekton"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	informers "github.com/tektoncd/pipeline/pkg/client/informers/externalversions/pipeline/v1"
 	fakepipelineclient "github.com/tektoncd/pipeline/pkg/client/injection/client/fake"
 	fakepipelineruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1/pipelinerun/fake"
 	faketaskruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1/taskrun/fake"
+	fakecustomruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/customrun/fake"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	_ "knative.dev/pkg/client/injection/kube/client/fake"
+	_ "knative.dev/pkg/client/injection/kube/informers/core/v1/namespace/fake"
 	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	logtesting "knative.dev/pkg/logging/testing"
 	pkgreconciler "knative.dev/pkg/reconciler"
 	rtesting "knative.dev/pkg/reconciler/testing"
 	"knative.dev/pkg/system"
@@ -110,11 +122,13 @@ func setupData(ctx context.Context, t *testing.T, prs []*v1.PipelineRun) informe
 func TestReconciler_handlePipelineRun(t *testing.T) {
 
 	tests := []struct {
-		name       string
-		pr         *v1.PipelineRun
-		taskruns   []*v1.TaskRun
-		shouldSign bool
-		wantErr    bool
+		name         string
+		pr           *v1.PipelineRun
+		taskruns     []*v1.TaskRun
+		pipelineruns []*v1.PipelineRun
+		customruns   []*v1beta1.CustomRun
+		shouldSign   bool
+		wantErr      bool
 	}{
 		{
 			name: "complete, already signed",
@@ -194,6 +208,49 @@ func TestReconciler_handlePipelineRun(t *testing.T) {
 			shouldSign: true,
 			wantErr:    false,
 		},
+		{
+			name: "skipped taskrun child",
+			pr: &v1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "pipelinerun",
+					Namespace:   "default",
+					Annotations: map[string]string{},
+				},
+				Status: v1.PipelineRunStatus{
+					Status: duckv1.Status{
+						Conditions: []apis.Condition{{Type: apis.ConditionSucceeded}},
+					},
+					PipelineRunStatusFields: v1.PipelineRunStatusFields{
+						ChildReferences: []v1.ChildStatusReference{
+							{
+								Name:             "taskrun1",
+								PipelineTaskName: "task1",
+							},
+						},
+					},
+				},
+			},
+			taskruns: []*v1.TaskRun{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "taskrun1",
+						Namespace: "default",
+						// Marked by the TaskRun signer when skipped with chains.tekton.dev/skip.
+						Annotations: map[string]string{
+							annotations.SkipAnnotation:   "true",
+							annotations.ChainsAnnotation: annotations.SkippedMarker,
+						},
+					},
+					Status: v1.TaskRunStatus{
+						TaskRunStatusFields: v1.TaskRunStatusFields{
+							CompletionTime: &metav1.Time{Time: time.Date(1995, time.December, 24, 6, 12, 12, 24, time.UTC)},
+						},
+					},
+				},
+			},
+			shouldSign: true,
+			wantErr:    false,
+		},
 		{
 			name: "taskruns completed with child references",
 			pr: &v1.PipelineRun{
@@ -293,6 +350,305 @@ func TestReconciler_handlePipelineRun(t *testing.T) {
 			shouldSign: false,
 			wantErr:    false,
 		},
+		{
+			name: "child pipelinerun completed and signed",
+			pr: &v1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "pipelinerun",
+					Namespace:   "default",
+					Annotations: map[string]string{},
+				},
+				Status: v1.PipelineRunStatus{
+					Status: duckv1.Status{
+						Conditions: []apis.Condition{{Type: apis.ConditionSucceeded}},
+					},
+					PipelineRunStatusFields: v1.PipelineRunStatusFields{
+						ChildReferences: []v1.ChildStatusReference{
+							{
+								TypeMeta:         runtime.TypeMeta{Kind: "PipelineRun"},
+								Name:             "child",
+								PipelineTaskName: "task1",
+							},
+						},
+					},
+				},
+			},
+			pipelineruns: []*v1.PipelineRun{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "child",
+						Namespace: "default",
+						Annotations: map[string]string{
+							"chains.tekton.dev/signed": "true",
+						},
+					},
+					Status: v1.PipelineRunStatus{
+						Status: duckv1.Status{
+							Conditions: []apis.Condition{{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue}},
+						},
+					},
+				},
+			},
+			shouldSign: true,
+			wantErr:    false,
+		},
+		{
+			name: "child pipelinerun not yet completed",
+			pr: &v1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "pipelinerun",
+					Namespace:   "default",
+					Annotations: map[string]string{},
+				},
+				Status: v1.PipelineRunStatus{
+					Status: duckv1.Status{
+						Conditions: []apis.Condition{{Type: apis.ConditionSucceeded}},
+					},
+					PipelineRunStatusFields: v1.PipelineRunStatusFields{
+						ChildReferences: []v1.ChildStatusReference{
+							{
+								TypeMeta:         runtime.TypeMeta{Kind: "PipelineRun"},
+								Name:             "child",
+								PipelineTaskName: "task1",
+							},
+						},
+					},
+				},
+			},
+			pipelineruns: []*v1.PipelineRun{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "child",
+						Namespace: "default",
+					},
+					Status: v1.PipelineRunStatus{},
+				},
+			},
+			shouldSign: false,
+			wantErr:    false,
+		},
+		{
+			name: "child pipelinerun not yet signed",
+			pr: &v1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "pipelinerun",
+					Namespace:   "default",
+					Annotations: map[string]string{},
+				},
+				Status: v1.PipelineRunStatus{
+					Status: duckv1.Status{
+						Conditions: []apis.Condition{{Type: apis.ConditionSucceeded}},
+					},
+					PipelineRunStatusFields: v1.PipelineRunStatusFields{
+						ChildReferences: []v1.ChildStatusReference{
+							{
+								TypeMeta:         runtime.TypeMeta{Kind: "PipelineRun"},
+								Name:             "child",
+								PipelineTaskName: "task1",
+							},
+						},
+					},
+				},
+			},
+			pipelineruns: []*v1.PipelineRun{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "child",
+						Namespace: "default",
+					},
+					Status: v1.PipelineRunStatus{
+						Status: duckv1.Status{
+							Conditions: []apis.Condition{{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue}},
+						},
+					},
+				},
+			},
+			shouldSign: false,
+			wantErr:    false,
+		},
+		{
+			name: "taskrun of child pipelinerun not yet completed",
+			pr: &v1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "pipelinerun",
+					Namespace:   "default",
+					Annotations: map[string]string{},
+				},
+				Status: v1.PipelineRunStatus{
+					Status: duckv1.Status{
+						Conditions: []apis.Condition{{Type: apis.ConditionSucceeded}},
+					},
+					PipelineRunStatusFields: v1.PipelineRunStatusFields{
+						ChildReferences: []v1.ChildStatusReference{
+							{
+								TypeMeta:         runtime.TypeMeta{Kind: "PipelineRun"},
+								Name:             "child",
+								PipelineTaskName: "task1",
+							},
+						},
+					},
+				},
+			},
+			pipelineruns: []*v1.PipelineRun{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "child",
+						Namespace: "default",
+						Annotations: map[string]string{
+							"chains.tekton.dev/signed": "true",
+						},
+					},
+					Status: v1.PipelineRunStatus{
+						Status: duckv1.Status{
+							Conditions: []apis.Condition{{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue}},
+						},
+						PipelineRunStatusFields: v1.PipelineRunStatusFields{
+							ChildReferences: []v1.ChildStatusReference{
+								{
+									TypeMeta: runtime.TypeMeta{Kind: "TaskRun"},
+									Name:     "taskrun1",
+								},
+							},
+						},
+					},
+				},
+			},
+			taskruns: []*v1.TaskRun{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "taskrun1",
+						Namespace: "default",
+					},
+				},
+			},
+			shouldSign: false,
+			wantErr:    false,
+		},
+		{
+			name: "missing child pipelinerun",
+			pr: &v1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "pipelinerun",
+					Namespace:   "default",
+					Annotations: map[string]string{},
+				},
+				Status: v1.PipelineRunStatus{
+					Status: duckv1.Status{
+						Conditions: []apis.Condition{{Type: apis.ConditionSucceeded}},
+					},
+					PipelineRunStatusFields: v1.PipelineRunStatusFields{
+						ChildReferences: []v1.ChildStatusReference{
+							{
+								TypeMeta:         runtime.TypeMeta{Kind: "PipelineRun"},
+								Name:             "child",
+								PipelineTaskName: "task1",
+							},
+						},
+					},
+				},
+			},
+			shouldSign: false,
+			wantErr:    false,
+		},
+		{
+			name: "customrun completed",
+			pr: &v1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "pipelinerun",
+					Namespace:   "default",
+					Annotations: map[string]string{},
+				},
+				Status: v1.PipelineRunStatus{
+					Status: duckv1.Status{
+						Conditions: []apis.Condition{{Type: apis.ConditionSucceeded}},
+					},
+					PipelineRunStatusFields: v1.PipelineRunStatusFields{
+						ChildReferences: []v1.ChildStatusReference{
+							{
+								TypeMeta:         runtime.TypeMeta{Kind: "CustomRun"},
+								Name:             "approval",
+								PipelineTaskName: "task1",
+							},
+						},
+					},
+				},
+			},
+			customruns: []*v1beta1.CustomRun{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "approval",
+						Namespace: "default",
+					},
+					Status: v1beta1.CustomRunStatus{
+						Status: duckv1.Status{
+							Conditions: []apis.Condition{{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue}},
+						},
+					},
+				},
+			},
+			shouldSign: true,
+			wantErr:    false,
+		},
+		{
+			name: "customrun not yet completed",
+			pr: &v1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "pipelinerun",
+					Namespace:   "default",
+					Annotations: map[string]string{},
+				},
+				Status: v1.PipelineRunStatus{
+					Status: duckv1.Status{
+						Conditions: []apis.Condition{{Type: apis.ConditionSucceeded}},
+					},
+					PipelineRunStatusFields: v1.PipelineRunStatusFields{
+						ChildReferences: []v1.ChildStatusReference{
+							{
+								TypeMeta:         runtime.TypeMeta{Kind: "CustomRun"},
+								Name:             "approval",
+								PipelineTaskName: "task1",
+							},
+						},
+					},
+				},
+			},
+			customruns: []*v1beta1.CustomRun{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "approval",
+						Namespace: "default",
+					},
+				},
+			},
+			shouldSign: false,
+			wantErr:    false,
+		},
+		{
+			name: "child of unsupported kind",
+			pr: &v1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "pipelinerun",
+					Namespace:   "default",
+					Annotations: map[string]string{},
+				},
+				Status: v1.PipelineRunStatus{
+					Status: duckv1.Status{
+						Conditions: []apis.Condition{{Type: apis.ConditionSucceeded}},
+					},
+					PipelineRunStatusFields: v1.PipelineRunStatusFields{
+						ChildReferences: []v1.ChildStatusReference{
+							{
+								TypeMeta:         runtime.TypeMeta{Kind: "Run"},
+								Name:             "run1",
+								PipelineTaskName: "task1",
+							},
+						},
+					},
+				},
+			},
+			shouldSign: true,
+			wantErr:    false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -301,11 +657,15 @@ func TestReconciler_handlePipelineRun(t *testing.T) {
 			c := fakepipelineclient.Get(ctx)
 			tekton.CreateObject(t, ctx, c, objects.NewPipelineRunObjectV1(tt.pr))
 			tri := faketaskruninformer.Get(ctx)
+			pri := fakepipelineruninformer.Get(ctx)
+			cri := fakecustomruninformer.Get(ctx)
 
 			r := &Reconciler{
 				PipelineRunSigner: signer,
 				Pipelineclientset: c,
 				TaskRunLister:     tri.Lister(),
+				PipelineRunLister: pri.Lister(),
+				CustomRunLister:   cri.Lister(),
 				Tracker:           &rtesting.FakeTracker{},
 			}
 
@@ -320,6 +680,18 @@ func TestReconciler_handlePipelineRun(t *testing.T) {
 				}
 			}
 
+			for _, pr := range tt.pipelineruns {
+				if err := pri.Informer().GetIndexer().Add(pr); err != nil {
+					t.Fatalf("Adding PipelineRun to informer: %v", err)
+				}
+				tekton.CreateObject(t, ctx, c, objects.NewPipelineRunObjectV1(pr))
+			}
+			for _, cr := range tt.customruns {
+				if err := cri.Informer().GetIndexer().Add(cr); err != nil {
+					t.Fatalf("Adding CustomRun to informer: %v", err)
+				}
+			}
+
 			if err := r.ReconcileKind(ctx, tt.pr); err != nil && !tt.wantErr {
 				t.Errorf("Reconciler.handlePipelineRun() error = %v", err)
 			}
@@ -330,6 +702,293 @@ func TestReconciler_handlePipelineRun(t *testing.T) {
 	}
 }
 
+func TestReconciler_filteredChildren(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       map[string]string
+		shouldSign bool
+	}{{
+		name: "children filtered out",
+		data: map[string]string{
+			"filter.task.exclude":     "lint",
+			"filter.pipeline.include": "release",
+		},
+		shouldSign: true,
+	}, {
+		name:       "children waiting to be signed",
+		shouldSign: false,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signer := &mocksigner.Signer{}
+			ctx, _ := rtesting.SetupFakeContext(t)
+			c := fakepipelineclient.Get(ctx)
+			tri := faketaskruninformer.Get(ctx)
+			pri := fakepipelineruninformer.Get(ctx)
+			cri := fakecustomruninformer.Get(ctx)
+
+			cfgStore := config.NewConfigStore(logtesting.TestLogger(t))
+			cfgStore.OnConfigChanged(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: config.ChainsConfig},
+				Data:       tt.data,
+			})
+			r := &Reconciler{
+				PipelineRunSigner: signer,
+				Pipelineclientset: c,
+				TaskRunLister:     tri.Lister(),
+				PipelineRunLister: pri.Lister(),
+				CustomRunLister:   cri.Lister(),
+				Tracker:           &rtesting.FakeTracker{},
+				TaskRunFilter:     reconciler.TaskRunInformerFilterFunc(nil, cfgStore, nil),
+				PipelineRunFilter: reconciler.PipelineRunInformerFilterFunc(nil, cfgStore, nil),
+			}
+
+			done := duckv1.Status{Conditions: []apis.Condition{{Type: apis.ConditionSucceeded}}}
+			pr := &v1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "pipelinerun",
+					Labels: map[string]string{"tekton.dev/pipeline": "release"},
+				},
+				Status: v1.PipelineRunStatus{
+					Status: done,
+					PipelineRunStatusFields: v1.PipelineRunStatusFields{
+						ChildReferences: []v1.ChildStatusReference{
+							{TypeMeta: runtime.TypeMeta{Kind: "TaskRun"}, Name: "lint"},
+							{TypeMeta: runtime.TypeMeta{Kind: "PipelineRun"}, Name: "tests"},
+						},
+					},
+				},
+			}
+			tekton.CreateObject(t, ctx, c, objects.NewPipelineRunObjectV1(pr))
+			// The children completed, but are not signed as they are filtered out.
+			tr := &v1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "lint", Labels: map[string]string{"tekton.dev/task": "lint"}},
+				Status: v1.TaskRunStatus{
+					TaskRunStatusFields: v1.TaskRunStatusFields{CompletionTime: &metav1.Time{}},
+				},
+			}
+			if err := tri.Informer().GetIndexer().Add(tr); err != nil {
+				t.Fatal(err)
+			}
+			tekton.CreateObject(t, ctx, c, objects.NewTaskRunObjectV1(tr))
+			child := &v1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "tests", Labels: map[string]string{"tekton.dev/pipeline": "tests"}},
+				Status:     v1.PipelineRunStatus{Status: done},
+			}
+			if err := pri.Informer().GetIndexer().Add(child); err != nil {
+				t.Fatal(err)
+			}
+			tekton.CreateObject(t, ctx, c, objects.NewPipelineRunObjectV1(child))
+
+			if err := r.ReconcileKind(ctx, pr); err != nil {
+				t.Errorf("ReconcileKind() error = %v", err)
+			}
+			if signer.Signed != tt.shouldSign {
+				t.Errorf("ReconcileKind() signed = %v, wanted %v", signer.Signed, tt.shouldSign)
+			}
+		})
+	}
+}
+
+func TestReconciler_archivedTaskRuns(t *testing.T) {
+	isController := true
+	pr := &v1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "pipelinerun",
+			Namespace:   "default",
+			UID:         "pipelinerun-uid",
+			Annotations: map[string]string{},
+		},
+		Status: v1.PipelineRunStatus{
+			Status: duckv1.Status{
+				Conditions: []apis.Condition{{Type: apis.ConditionSucceeded}},
+			},
+			PipelineRunStatusFields: v1.PipelineRunStatusFields{
+				ChildReferences: []v1.ChildStatusReference{
+					{
+						Name:             "taskrun1",
+						PipelineTaskName: "task1",
+					},
+				},
+			},
+		},
+	}
+	archivedTaskRun := func(ownerUID types.UID, completed bool) *v1.TaskRun {
+		tr := &v1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "taskrun1",
+				Namespace: "default",
+				UID:       types.UID("taskrun1-" + ownerUID),
+				Labels:    map[string]string{objects.PipelineTaskLabel: "task1"},
+				Annotations: map[string]string{
+					"chains.tekton.dev/signed": "true",
+				},
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: "tekton.dev/v1",
+					Kind:       "PipelineRun",
+					Name:       "pipelinerun",
+					UID:        ownerUID,
+					Controller: &isController,
+				}},
+			},
+		}
+		if completed {
+			tr.Status.CompletionTime = &metav1.Time{Time: time.Date(1995, time.December, 24, 6, 12, 12, 24, time.UTC)}
+		}
+		return tr
+	}
+
+	tests := []struct {
+		name          string
+		archived      []*v1.TaskRun
+		resultsURL    func(server *results.Server) string
+		shouldSign    bool
+		wantErr       bool
+		wantTaskRunID types.UID
+	}{
+		{
+			name:          "archived taskrun",
+			archived:      []*v1.TaskRun{archivedTaskRun("other-uid", true), archivedTaskRun(pr.UID, true)},
+			resultsURL:    (*results.Server).URL,
+			shouldSign:    true,
+			wantTaskRunID: "taskrun1-pipelinerun-uid",
+		},
+		{
+			name:       "archived taskrun of another pipelinerun",
+			archived:   []*v1.TaskRun{archivedTaskRun("other-uid", true)},
+			resultsURL: (*results.Server).URL,
+			shouldSign: false,
+		},
+		{
+			name:       "archived taskrun not complete",
+			archived:   []*v1.TaskRun{archivedTaskRun(pr.UID, false)},
+			resultsURL: (*results.Server).URL,
+			shouldSign: false,
+		},
+		{
+			name:       "tekton results not configured",
+			archived:   []*v1.TaskRun{archivedTaskRun(pr.UID, true)},
+			resultsURL: func(*results.Server) string { return "" },
+			shouldSign: false,
+		},
+		{
+			name:       "tekton results unavailable",
+			archived:   []*v1.TaskRun{archivedTaskRun(pr.UID, true)},
+			resultsURL: func(s *results.Server) string { return s.URL() + "/unavailable" },
+			shouldSign: false,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := results.NewServer(t)
+			for _, tr := range tt.archived {
+				server.AddTaskRun(t, tr)
+			}
+
+			signer := &mocksigner.Signer{}
+			ctx, _ := rtesting.SetupFakeContext(t)
+			c := fakepipelineclient.Get(ctx)
+			tekton.CreateObject(t, ctx, c, objects.NewPipelineRunObjectV1(pr))
+			r := &Reconciler{
+				PipelineRunSigner: signer,
+				Pipelineclientset: c,
+				TaskRunLister:     faketaskruninformer.Get(ctx).Lister(),
+				PipelineRunLister: fakepipelineruninformer.Get(ctx).Lister(),
+				CustomRunLister:   fakecustomruninformer.Get(ctx).Lister(),
+				Tracker:           &rtesting.FakeTracker{},
+			}
+			ctx = config.ToContext(ctx, &config.Config{
+				Results: config.ResultsConfig{URL: tt.resultsURL(server)},
+			})
+
+			err := r.ReconcileKind(ctx, pr)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Reconciler.ReconcileKind() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if signer.Signed != tt.shouldSign {
+				t.Fatalf("Reconciler.ReconcileKind() signed = %v, wanted %v", signer.Signed, tt.shouldSign)
+			}
+			if !tt.shouldSign {
+				return
+			}
+			taskRuns := signer.Object.(*objects.PipelineRunObjectV1).GetTaskRunsFromTask("task1")
+			if len(taskRuns) != 1 || taskRuns[0].UID != tt.wantTaskRunID {
+				t.Errorf("signed pipelinerun has taskruns %v, want the archived taskrun %s", taskRuns, tt.wantTaskRunID)
+			}
+		})
+	}
+}
+
+func TestReconciler_tektonResultsClient(t *testing.T) {
+	r := &Reconciler{}
+	cfg := config.ResultsConfig{URL: "https://results"}
+
+	client, err := r.tektonResultsClient(cfg)
+	if err != nil {
+		t.Fatalf("tektonResultsClient() error = %v", err)
+	}
+	if again, err := r.tektonResultsClient(cfg); err != nil || again != client {
+		t.Errorf("tektonResultsClient() = %p, %v, want the cached client %p", again, err, client)
+	}
+
+	cfg.URL = "https://other-results"
+	if other, err := r.tektonResultsClient(cfg); err != nil || other == client {
+		t.Errorf("tektonResultsClient() = %p, %v, want a new client for the changed configuration", other, err)
+	}
+}
+
+func TestReconciler_forgedMarker(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	pr := &v1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "forged",
+			Namespace:   "default",
+			UID:         "forged-uid",
+			Annotations: map[string]string{annotations.ChainsAnnotation: "true"},
+		},
+		Status: v1.PipelineRunStatus{
+			Status: duckv1.Status{
+				Conditions: []apis.Condition{{Type: apis.ConditionSucceeded}},
+			},
+		},
+	}
+
+	signer := &mocksigner.Signer{}
+	recorder := &mockrecorder.Recorder{}
+	events := record.NewFakeRecorder(1)
+	ctx, _ := rtesting.SetupFakeContext(t)
+	c := fakepipelineclient.Get(ctx)
+	tekton.CreateObject(t, ctx, c, objects.NewPipelineRunObjectV1(pr))
+
+	r := &Reconciler{
+		PipelineRunSigner: signer,
+		Pipelineclientset: c,
+		TaskRunLister:     faketaskruninformer.Get(ctx).Lister(),
+		Tracker:           &rtesting.FakeTracker{},
+		MarkerKey:         key,
+		Recorder:          recorder,
+	}
+	ctx = controller.WithEventRecorder(ctx, events)
+	if err := r.ReconcileKind(ctx, pr); err != nil {
+		t.Fatalf("Reconciler.ReconcileKind() error = %v", err)
+	}
+	if !signer.Signed {
+		t.Error("expected the PipelineRun with a forged marker to be signed")
+	}
+	if got := recorder.Counts[metrics.ForgedMarkerCount]; got != 1 {
+		t.Errorf("expected 1 forged marker recorded, got %d", got)
+	}
+	select {
+	case event := <-events.Events:
+		if !strings.Contains(event, "ForgedSignedMarker") {
+			t.Errorf("unexpected event %q", event)
+		}
+	default:
+		t.Error("expected an event for the forged marker")
+	}
+}
+
 func TestFinalizeKind_SSAMigration(t *testing.T) {
 	now := metav1.Now()
 	mergePatchFieldsV1 := metav1.NewFieldsV1(
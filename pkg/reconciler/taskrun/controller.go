@@ -17,6 +17,7 @@ import (
 	"context"
 
 	"github.com/tektoncd/chains/pkg/chains"
+	"github.com/tektoncd/chains/pkg/chains/annotations"
 	"github.com/tektoncd/chains/pkg/chains/storage"
 	"github.com/tektoncd/chains/pkg/config"
 	"github.com/tektoncd/chains/pkg/reconciler"
@@ -26,6 +27,7 @@ import (
 	taskrunreconciler "github.com/tektoncd/pipeline/pkg/client/injection/reconciler/pipeline/v1/taskrun"
 	"k8s.io/client-go/tools/cache"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	namespaceinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/namespace"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
@@ -39,19 +41,34 @@ func NewNamespacesScopedController(namespaces []string) func(ctx context.Context
 	return func(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
 		logger := logging.FromContext(ctx)
 		taskRunInformer := taskruninformer.Get(ctx)
+		namespaceInformer := namespaceinformer.Get(ctx)
 
 		kubeClient := kubeclient.Get(ctx)
 		pipelineClient := pipelineclient.Get(ctx)
 
+		markerKey, err := annotations.LoadMarkerKey(SecretPath)
+		if err != nil {
+			logger.Fatalf("Failed to load the signed marker key: %v", err)
+		}
+		if markerKey == nil {
+			logger.Warnf("No %s found in %s, the signed markers are only checked against the managed fields", annotations.MarkerKeyFile, SecretPath)
+		}
+
 		tsSigner := &chains.ObjectSigner{
 			SecretPath:        SecretPath,
 			Pipelineclientset: pipelineClient,
 			Recorder:          taskrunmetrics.Get(ctx),
 		}
+		tlogQueue := chains.NewTlogQueue(tsSigner)
+		tsSigner.TlogQueue = tlogQueue
+		go tlogQueue.Run(ctx)
 
 		c := &Reconciler{
 			TaskRunSigner:     tsSigner,
 			Pipelineclientset: pipelineClient,
+			TlogQueue:         tlogQueue,
+			MarkerKey:         markerKey,
+			Recorder:          taskrunmetrics.Get(ctx),
 		}
 
 		watcherStop := make(chan bool)
@@ -90,13 +107,18 @@ func NewNamespacesScopedController(namespaces []string) func(ctx context.Context
 			}
 		})
 
+		filterFunc := reconciler.TaskRunInformerFilterFunc(namespaces, cfgStore, namespaceInformer.Lister())
 		if _, err := taskRunInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
-			FilterFunc: reconciler.TaskRunInformerFilterFunc(namespaces, cfgStore),
+			FilterFunc: filterFunc,
 			Handler:    controller.HandleAll(impl.Enqueue),
 		}); err != nil {
 			logger.Errorf("adding event handler for taskrun controller's taskrun informer encountered error: %v", err)
 		}
 
+		if _, err := namespaceInformer.Informer().AddEventHandler(reconciler.NamespaceLabelsHandler(impl, taskRunInformer.Informer(), filterFunc)); err != nil {
+			logger.Errorf("adding event handler for taskrun controller's namespace informer encountered error: %v", err)
+		}
+
 		return impl
 	}
 }
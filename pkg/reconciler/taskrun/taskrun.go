@@ -22,10 +22,12 @@ import (
 	signing "github.com/tektoncd/chains/pkg/chains"
 	"github.com/tektoncd/chains/pkg/chains/annotations"
 	"github.com/tektoncd/chains/pkg/chains/objects"
+	"github.com/tektoncd/chains/pkg/metrics"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
 	taskrunreconciler "github.com/tektoncd/pipeline/pkg/client/injection/reconciler/pipeline/v1/taskrun"
 	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"knative.dev/pkg/controller"
@@ -44,6 +46,13 @@ const (
 type Reconciler struct {
 	TaskRunSigner     signing.Signer
 	Pipelineclientset versioned.Interface
+	// TlogQueue, when set, receives the signed TaskRuns with pending transparency log uploads.
+	TlogQueue *signing.TlogQueue
+	// MarkerKey, when set, authenticates the signed markers so that the ones not
+	// written by Chains are ignored.
+	MarkerKey []byte
+	// Recorder records the TaskRuns found with a forged signed marker.
+	Recorder metrics.Recorder
 }
 
 // Check that our Reconciler implements taskrunreconciler.Interface and taskrunreconciler.Finalizer
@@ -94,13 +103,27 @@ func (r *Reconciler) FinalizeKind(ctx context.Context, tr *v1.TaskRun) (result p
 		return nil
 	}
 
+	ctx = annotations.WithMarkerKey(ctx, r.MarkerKey)
 	obj := objects.NewTaskRunObjectV1(tr)
 
+	// Reset the signing state when the run was requested to be signed again, it
+	// is signed once the update is observed.
+	if annotations.ResignRequested(tr.Annotations) {
+		logging.FromContext(ctx).Infof("taskrun %s/%s was requested to be signed again: %s", tr.Namespace, tr.Name, tr.Annotations[annotations.ResignAnnotation])
+		return annotations.ResetForResign(ctx, obj, r.Pipelineclientset)
+	}
+
 	// Check to see if it has already been signed.
 	if annotations.Reconciled(ctx, r.Pipelineclientset, obj) {
 		logging.FromContext(ctx).Infof("taskrun %s/%s has been reconciled", tr.Namespace, tr.Name)
+		if r.TlogQueue != nil && annotations.TlogPending(tr.Annotations) {
+			r.TlogQueue.Enqueue(ctx, obj)
+		}
 		return nil
 	}
+	if annotations.ForgedMarker(ctx, obj) {
+		r.reportForgedMarker(ctx, tr)
+	}
 
 	if err := r.TaskRunSigner.Sign(ctx, obj); err != nil {
 		return err
@@ -108,6 +131,18 @@ func (r *Reconciler) FinalizeKind(ctx context.Context, tr *v1.TaskRun) (result p
 	return nil
 }
 
+// reportForgedMarker warns about a signed marker that was not written by Chains.
+// The TaskRun is then signed as if the marker was not there.
+func (r *Reconciler) reportForgedMarker(ctx context.Context, tr *v1.TaskRun) {
+	logging.FromContext(ctx).Warnf("taskrun %s/%s has a %s annotation not written by Chains, ignoring it", tr.Namespace, tr.Name, annotations.ChainsAnnotation)
+	if recorder := controller.GetEventRecorder(ctx); recorder != nil {
+		recorder.Eventf(tr, corev1.EventTypeWarning, "ForgedSignedMarker", "Ignoring the %s annotation not written by Chains", annotations.ChainsAnnotation)
+	}
+	if r.Recorder != nil {
+		r.Recorder.RecordCountMetrics(ctx, metrics.ForgedMarkerCount)
+	}
+}
+
 // isFinalizerOwnedByMergePatch checks if the finalizer was added via merge patch (Update operation).
 // MIGRATION: This is a temporary migration feature to handle the upgrade scenario where
 // in-flight TaskRuns have finalizers set via merge patch by the old controller version.
@@ -15,12 +15,15 @@ package taskrun
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/tektoncd/chains/pkg/chains/annotations"
 	"github.com/tektoncd/chains/pkg/chains/objects"
 	"github.com/tektoncd/chains/pkg/config"
+	"github.com/tektoncd/chains/pkg/internal/mockrecorder"
 	"github.com/tektoncd/chains/pkg/internal/mocksigner"
+	"github.com/tektoncd/chains/pkg/metrics"
 	_ "github.com/tektoncd/chains/pkg/taskrunmetrics/fake"
 	"github.com/tektoncd/chains/pkg/test/tekton"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
@@ -30,10 +33,13 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	_ "knative.dev/pkg/client/injection/kube/client/fake"
+	_ "knative.dev/pkg/client/injection/kube/informers/core/v1/namespace/fake"
 	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
 	pkgreconciler "knative.dev/pkg/reconciler"
 	rtesting "knative.dev/pkg/reconciler/testing"
 	"knative.dev/pkg/system"
@@ -139,6 +145,57 @@ func TestReconciler_handleTaskRun(t *testing.T) {
 			},
 			shouldSign: true,
 		},
+		{
+			name: "complete, already signed, re-sign requested",
+			tr: &v1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "resign-requested",
+					Annotations: map[string]string{
+						annotations.ChainsAnnotation: "true",
+						annotations.ResignAnnotation: "new format",
+					},
+				},
+				Status: v1.TaskRunStatus{
+					Status: duckv1.Status{
+						Conditions: []apis.Condition{{Type: apis.ConditionSucceeded}},
+					}},
+			},
+			shouldSign: false,
+		},
+		{
+			name: "complete, re-sign request handled",
+			tr: &v1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						annotations.ChainsAnnotation:   annotations.ResigningMarker,
+						annotations.ResignAnnotation:   "new format",
+						annotations.ResignedAnnotation: "new format",
+					},
+				},
+				Status: v1.TaskRunStatus{
+					Status: duckv1.Status{
+						Conditions: []apis.Condition{{Type: apis.ConditionSucceeded}},
+					}},
+			},
+			shouldSign: true,
+		},
+		{
+			name: "complete, signed again",
+			tr: &v1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						annotations.ChainsAnnotation:   "true",
+						annotations.ResignAnnotation:   "new format",
+						annotations.ResignedAnnotation: "new format",
+					},
+				},
+				Status: v1.TaskRunStatus{
+					Status: duckv1.Status{
+						Conditions: []apis.Condition{{Type: apis.ConditionSucceeded}},
+					}},
+			},
+			shouldSign: false,
+		},
 		{
 			name: "not complete, not already signed",
 			tr: &v1.TaskRun{
@@ -175,6 +232,55 @@ func TestReconciler_handleTaskRun(t *testing.T) {
 	}
 }
 
+func TestReconciler_forgedMarker(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	tr := &v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "forged",
+			Namespace:   "default",
+			UID:         "forged-uid",
+			Annotations: map[string]string{annotations.ChainsAnnotation: "true"},
+		},
+		Status: v1.TaskRunStatus{
+			Status: duckv1.Status{
+				Conditions: []apis.Condition{{Type: apis.ConditionSucceeded}},
+			}},
+	}
+
+	signer := &mocksigner.Signer{}
+	recorder := &mockrecorder.Recorder{}
+	events := record.NewFakeRecorder(1)
+	ctx, _ := rtesting.SetupFakeContext(t)
+	c := fakepipelineclient.Get(ctx)
+	tekton.CreateObject(t, ctx, c, objects.NewTaskRunObjectV1(tr))
+
+	r := &Reconciler{
+		TaskRunSigner:     signer,
+		Pipelineclientset: c,
+		MarkerKey:         key,
+		Recorder:          recorder,
+	}
+	ctx = config.ToContext(ctx, &config.Config{})
+	ctx = controller.WithEventRecorder(ctx, events)
+	if err := r.ReconcileKind(ctx, tr); err != nil {
+		t.Fatalf("Reconciler.ReconcileKind() error = %v", err)
+	}
+	if !signer.Signed {
+		t.Error("expected the TaskRun with a forged marker to be signed")
+	}
+	if got := recorder.Counts[metrics.ForgedMarkerCount]; got != 1 {
+		t.Errorf("expected 1 forged marker recorded, got %d", got)
+	}
+	select {
+	case event := <-events.Events:
+		if !strings.Contains(event, "ForgedSignedMarker") {
+			t.Errorf("unexpected event %q", event)
+		}
+	default:
+		t.Error("expected an event for the forged marker")
+	}
+}
+
 func TestFinalizeKind_SSAMigration(t *testing.T) {
 	now := metav1.Now()
 	mergePatchFieldsV1 := metav1.NewFieldsV1(
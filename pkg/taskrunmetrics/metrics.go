@@ -29,16 +29,20 @@ import (
 )
 
 const (
-	taskRunSignedName     common.Metric = "watcher_taskrun_sign_created_total"
-	taskRunSignedDesc     string        = "Total number of signed messages for taskruns"
-	taskRunUploadedName   common.Metric = "watcher_taskrun_payload_uploaded_total"
-	taskRunUploadedDesc   string        = "Total number of uploaded payloads for taskruns"
-	taskRunStoredName     common.Metric = "watcher_taskrun_payload_stored_total"
-	taskRunStoredDesc     string        = "Total number of stored payloads for taskruns"
-	taskRunMarkedName     common.Metric = "watcher_taskrun_marked_signed_total"
-	taskRunMarkedDesc     string        = "Total number of objects marked as signed for taskruns"
-	taskRunErrorCountName common.Metric = "watcher_taskrun_signing_failures_total"
-	taskRunErrorCountDesc string        = "Total number of TaskRun signing failures"
+	taskRunSignedName      common.Metric = "watcher_taskrun_sign_created_total"
+	taskRunSignedDesc      string        = "Total number of signed messages for taskruns"
+	taskRunUploadedName    common.Metric = "watcher_taskrun_payload_uploaded_total"
+	taskRunUploadedDesc    string        = "Total number of uploaded payloads for taskruns"
+	taskRunStoredName      common.Metric = "watcher_taskrun_payload_stored_total"
+	taskRunStoredDesc      string        = "Total number of stored payloads for taskruns"
+	taskRunMarkedName      common.Metric = "watcher_taskrun_marked_signed_total"
+	taskRunMarkedDesc      string        = "Total number of objects marked as signed for taskruns"
+	taskRunTlogPendingName common.Metric = "watcher_taskrun_tlog_pending_total"
+	taskRunTlogPendingDesc string        = "Total number of taskruns signed with transparency log uploads pending"
+	taskRunForgedName      common.Metric = "watcher_taskrun_forged_marker_total"
+	taskRunForgedDesc      string        = "Total number of taskruns found with a forged signed marker"
+	taskRunErrorCountName  common.Metric = "watcher_taskrun_signing_failures_total"
+	taskRunErrorCountDesc  string        = "Total number of TaskRun signing failures"
 )
 
 var _ common.Recorder = &Recorder{}
@@ -50,6 +54,8 @@ type Recorder struct {
 	plCount     otelmetric.Int64Counter
 	stCount     otelmetric.Int64Counter
 	mrCount     otelmetric.Int64Counter
+	tpCount     otelmetric.Int64Counter
+	fmCount     otelmetric.Int64Counter
 	errCount    otelmetric.Int64Counter
 }
 
@@ -111,6 +117,24 @@ func NewRecorder(ctx context.Context) (*Recorder, error) {
 		return nil, err
 	}
 
+	newR.tpCount, err = meter.Int64Counter(
+		string(taskRunTlogPendingName),
+		otelmetric.WithDescription(taskRunTlogPendingDesc),
+	)
+	if err != nil {
+		logger.Errorf("Failed to create %s counter: %v", taskRunTlogPendingName, err)
+		return nil, err
+	}
+
+	newR.fmCount, err = meter.Int64Counter(
+		string(taskRunForgedName),
+		otelmetric.WithDescription(taskRunForgedDesc),
+	)
+	if err != nil {
+		logger.Errorf("Failed to create %s counter: %v", taskRunForgedName, err)
+		return nil, err
+	}
+
 	newR.errCount, err = meter.Int64Counter(
 		string(taskRunErrorCountName),
 		otelmetric.WithDescription(taskRunErrorCountDesc),
@@ -144,6 +168,10 @@ func (r *Recorder) RecordCountMetrics(ctx context.Context, metricType common.Met
 		r.stCount.Add(ctx, 1)
 	case common.MarkedAsSignedCount:
 		r.mrCount.Add(ctx, 1)
+	case common.TlogPendingCount:
+		r.tpCount.Add(ctx, 1)
+	case common.ForgedMarkerCount:
+		r.fmCount.Add(ctx, 1)
 	default:
 		logger.Errorf("Ignoring the metrics recording as valid Metric type matching %v was not found", mt)
 	}
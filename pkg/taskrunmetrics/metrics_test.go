@@ -81,6 +81,8 @@ func TestCountMetrics(t *testing.T) {
 	rec.RecordCountMetrics(ctx, metrics.PayloadUploadedCount)
 	rec.RecordCountMetrics(ctx, metrics.SignsStoredCount)
 	rec.RecordCountMetrics(ctx, metrics.MarkedAsSignedCount)
+	rec.RecordCountMetrics(ctx, metrics.TlogPendingCount)
+	rec.RecordCountMetrics(ctx, metrics.ForgedMarkerCount)
 
 	var rm metricdata.ResourceMetrics
 	if err := reader.Collect(ctx, &rm); err != nil {
@@ -91,6 +93,8 @@ func TestCountMetrics(t *testing.T) {
 	checkCounterValue(t, rm, string(taskRunUploadedName))
 	checkCounterValue(t, rm, string(taskRunStoredName))
 	checkCounterValue(t, rm, string(taskRunMarkedName))
+	checkCounterValue(t, rm, string(taskRunTlogPendingName))
+	checkCounterValue(t, rm, string(taskRunForgedName))
 }
 
 func TestRecordErrorMetric(t *testing.T) {
@@ -0,0 +1,306 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package rekor provides an in-process Rekor v2 transparency log for tests.
+package rekor
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/cyberphone/json-canonicalization/go/src/webpki.org/jsoncanonicalizer"
+	"github.com/secure-systems-lab/go-securesystemslib/dsse"
+	protocommon "github.com/sigstore/protobuf-specs/gen/pb-go/common/v1"
+	protorekor "github.com/sigstore/protobuf-specs/gen/pb-go/rekor/v1"
+	pb "github.com/sigstore/rekor-tiles/v2/pkg/generated/protobuf"
+	"github.com/sigstore/rekor-tiles/v2/pkg/note"
+	"github.com/sigstore/rekor-tiles/v2/pkg/types/hashedrekord"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/transparency-dev/formats/log"
+	"github.com/transparency-dev/merkle/rfc6962"
+	sumdbnote "golang.org/x/mod/sumdb/note"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	// Origin is the name of the fake log in its checkpoints.
+	Origin = "rekor.chains.test"
+
+	entriesPath = "/api/v2/log/entries"
+	apiVersion  = "0.0.2"
+)
+
+// Log is a Rekor v2 transparency log served over HTTP. Entries are checked and
+// logged like a real log does, and returned with their inclusion proof in a
+// checkpoint signed by the log key.
+type Log struct {
+	server     *httptest.Server
+	publicKey  crypto.PublicKey
+	noteSigner sumdbnote.Signer
+	logID      []byte
+	registry   *signature.AlgorithmRegistryConfig
+
+	mu     sync.Mutex
+	leaves [][]byte
+}
+
+// NewLog starts a log with a new signing key, stopped at the end of the test.
+func NewLog(t *testing.T) *Log {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := signature.LoadECDSASignerVerifier(key, crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	noteSigner, err := note.NewNoteSigner(context.Background(), Origin, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, logID, err := note.KeyHash(Origin, key.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	registry, err := signature.NewAlgorithmRegistryConfig([]protocommon.PublicKeyDetails{
+		protocommon.PublicKeyDetails_PKIX_ECDSA_P256_SHA_256,
+		protocommon.PublicKeyDetails_PKIX_ECDSA_P384_SHA_384,
+		protocommon.PublicKeyDetails_PKIX_ECDSA_P521_SHA_512,
+		protocommon.PublicKeyDetails_PKIX_RSA_PKCS1V15_2048_SHA256,
+		protocommon.PublicKeyDetails_PKIX_RSA_PKCS1V15_3072_SHA256,
+		protocommon.PublicKeyDetails_PKIX_RSA_PKCS1V15_4096_SHA256,
+		protocommon.PublicKeyDetails_PKIX_ED25519,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l := &Log{
+		publicKey:  key.Public(),
+		noteSigner: noteSigner,
+		logID:      logID,
+		registry:   registry,
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST "+entriesPath, l.handleAdd)
+	l.server = httptest.NewServer(mux)
+	t.Cleanup(l.server.Close)
+	return l
+}
+
+// URL returns the base URL of the log.
+func (l *Log) URL() string {
+	return l.server.URL
+}
+
+// PublicKeyPEM returns the PEM encoded public key of the log.
+func (l *Log) PublicKeyPEM(t *testing.T) string {
+	t.Helper()
+	pemKey, err := cryptoutils.MarshalPublicKeyToPEM(l.publicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pemKey)
+}
+
+// Size returns the number of entries in the log.
+func (l *Log) Size() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.leaves)
+}
+
+func (l *Log) handleAdd(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	req := &pb.CreateEntryRequest{}
+	if err := protojson.Unmarshal(body, req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var entry *pb.Entry
+	switch spec := req.GetSpec().(type) {
+	case *pb.CreateEntryRequest_HashedRekordRequestV002:
+		entry, err = hashedrekord.ToLogEntry(spec.HashedRekordRequestV002, l.registry)
+	case *pb.CreateEntryRequest_DsseRequestV002:
+		entry, err = dsseLogEntry(spec.DsseRequestV002)
+	default:
+		err = fmt.Errorf("unsupported entry type %T", spec)
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	tle, err := l.add(entry)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	out, err := protojson.Marshal(tle)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_, _ = w.Write(out)
+}
+
+// add appends the entry to the log and returns it with its inclusion proof.
+func (l *Log) add(entry *pb.Entry) (*protorekor.TransparencyLogEntry, error) {
+	marshalled, err := protojson.Marshal(entry)
+	if err != nil {
+		return nil, err
+	}
+	canonicalized, err := jsoncanonicalizer.Transform(marshalled)
+	if err != nil {
+		return nil, err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	index := len(l.leaves)
+	l.leaves = append(l.leaves, rfc6962.DefaultHasher.HashLeaf(canonicalized))
+	root := rootHash(l.leaves)
+	checkpoint, err := sumdbnote.Sign(&sumdbnote.Note{
+		Text: string(log.Checkpoint{Origin: Origin, Size: uint64(len(l.leaves)), Hash: root}.Marshal()),
+	}, l.noteSigner)
+	if err != nil {
+		return nil, err
+	}
+
+	return &protorekor.TransparencyLogEntry{
+		LogIndex:    int64(index),
+		LogId:       &protocommon.LogId{KeyId: l.logID},
+		KindVersion: &protorekor.KindVersion{Kind: entry.GetKind(), Version: apiVersion},
+		InclusionProof: &protorekor.InclusionProof{
+			LogIndex:   int64(index),
+			RootHash:   root,
+			TreeSize:   int64(len(l.leaves)),
+			Hashes:     inclusionPath(index, l.leaves),
+			Checkpoint: &protorekor.Checkpoint{Envelope: string(checkpoint)},
+		},
+		CanonicalizedBody: canonicalized,
+	}, nil
+}
+
+// dsseLogEntry checks the envelope signatures and returns the entry recording
+// them along with the payload digest.
+func dsseLogEntry(req *pb.DSSERequestV002) (*pb.Entry, error) {
+	env := req.GetEnvelope()
+	if env == nil || len(env.GetSignatures()) == 0 {
+		return nil, errors.New("missing envelope or signatures")
+	}
+	pae := dsse.PAE(env.GetPayloadType(), env.GetPayload())
+
+	var signatures []*pb.Signature
+	for _, sig := range env.GetSignatures() {
+		verified := false
+		for _, v := range req.GetVerifiers() {
+			pub, err := verifierPublicKey(v)
+			if err != nil {
+				return nil, err
+			}
+			sv, err := signature.LoadDefaultVerifier(pub)
+			if err != nil {
+				return nil, err
+			}
+			if sv.VerifySignature(bytes.NewReader(sig.GetSig()), bytes.NewReader(pae)) == nil {
+				signatures = append(signatures, &pb.Signature{Content: sig.GetSig(), Verifier: v})
+				verified = true
+				break
+			}
+		}
+		if !verified {
+			return nil, errors.New("could not verify envelope signature")
+		}
+	}
+
+	digest := sha256.Sum256(env.GetPayload())
+	return &pb.Entry{
+		Kind:       "dsse",
+		ApiVersion: apiVersion,
+		Spec: &pb.Spec{
+			Spec: &pb.Spec_DsseV002{
+				DsseV002: &pb.DSSELogEntryV002{
+					PayloadHash: &protocommon.HashOutput{Algorithm: protocommon.HashAlgorithm_SHA2_256, Digest: digest[:]},
+					Signatures:  signatures,
+				},
+			},
+		},
+	}, nil
+}
+
+func verifierPublicKey(v *pb.Verifier) (crypto.PublicKey, error) {
+	switch key := v.GetVerifier().(type) {
+	case *pb.Verifier_PublicKey:
+		return x509.ParsePKIXPublicKey(key.PublicKey.GetRawBytes())
+	case *pb.Verifier_X509Certificate:
+		cert, err := x509.ParseCertificate(key.X509Certificate.GetRawBytes())
+		if err != nil {
+			return nil, err
+		}
+		return cert.PublicKey, nil
+	}
+	return nil, fmt.Errorf("unsupported verifier %T", v.GetVerifier())
+}
+
+// rootHash returns the RFC 6962 Merkle tree hash of the leaf hashes.
+func rootHash(leaves [][]byte) []byte {
+	if len(leaves) == 1 {
+		return leaves[0]
+	}
+	k := split(len(leaves))
+	return rfc6962.DefaultHasher.HashChildren(rootHash(leaves[:k]), rootHash(leaves[k:]))
+}
+
+// inclusionPath returns the RFC 6962 audit path of the leaf at index m.
+func inclusionPath(m int, leaves [][]byte) [][]byte {
+	if len(leaves) == 1 {
+		return [][]byte{}
+	}
+	k := split(len(leaves))
+	if m < k {
+		return append(inclusionPath(m, leaves[:k]), rootHash(leaves[k:]))
+	}
+	return append(inclusionPath(m-k, leaves[k:]), rootHash(leaves[:k]))
+}
+
+// split returns the largest power of two smaller than n.
+func split(n int) int {
+	k := 1
+	for k<<1 < n {
+		k <<= 1
+	}
+	return k
+}
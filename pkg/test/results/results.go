@@ -0,0 +1,238 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package results provides an in-process Tekton Results REST API for tests.
+package results
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/tektoncd/chains/pkg/chains/tektonresults"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+var (
+	quoted     = regexp.MustCompile(`"([^"]*)"`)
+	typeFilter = regexp.MustCompile(`data_type (==|in) (\[[^\]]*\]|"[^"]*")`)
+	nameFilter = regexp.MustCompile(`data\.metadata\.name == "([^"]*)"`)
+)
+
+// Server serves the records added to it or written through its API. The records
+// are listed one per page, and filtered by type and name like Tekton Results does.
+type Server struct {
+	server *httptest.Server
+	// Token, when set, is the bearer token the requests must be authenticated with.
+	Token string
+
+	mu sync.Mutex
+	// results holds the names of the results of each namespace.
+	results  map[string][]string
+	records  map[string][]tektonresults.Record
+	requests int
+}
+
+// NewServer starts a server without records, stopped at the end of the test.
+func NewServer(t *testing.T) *Server {
+	t.Helper()
+	s := &Server{
+		results: map[string][]string{},
+		records: map[string][]tektonresults.Record{},
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST "+fmt.Sprintf(tektonresults.ResultsPath, "{namespace}"), s.handleCreateResult)
+	mux.HandleFunc("GET "+fmt.Sprintf(tektonresults.RecordsPath, "{namespace}", "{result}"), s.handleList)
+	mux.HandleFunc("POST "+fmt.Sprintf(tektonresults.RecordsPath, "{namespace}", "{result}"), s.handleCreateRecord)
+	mux.HandleFunc("PUT "+fmt.Sprintf(tektonresults.RecordPath, "{namespace}", "{result}", "{record}"), s.handleUpdateRecord)
+	s.server = httptest.NewServer(mux)
+	t.Cleanup(s.server.Close)
+	return s
+}
+
+// URL returns the base URL of the server.
+func (s *Server) URL() string {
+	return s.server.URL
+}
+
+// Requests returns the number of requests served.
+func (s *Server) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+// Records returns the records of the namespace.
+func (s *Server) Records(namespace string) []tektonresults.Record {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.records[namespace])
+}
+
+// AddTaskRun archives the TaskRun in its namespace.
+func (s *Server) AddTaskRun(t *testing.T, tr *v1.TaskRun) {
+	t.Helper()
+	value, err := json.Marshal(tr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	result := fmt.Sprintf("%s/results/%s", tr.Namespace, tr.UID)
+	s.results[tr.Namespace] = append(s.results[tr.Namespace], result)
+	s.records[tr.Namespace] = append(s.records[tr.Namespace], tektonresults.Record{
+		Name: fmt.Sprintf("%s/records/%s", result, tr.UID),
+		Data: tektonresults.RecordData{Type: tektonresults.TaskRunType, Value: value},
+	})
+}
+
+// serve checks the authentication of the request before handling it.
+func (s *Server) serve(w http.ResponseWriter, r *http.Request, handle func() (int, any)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests++
+
+	if s.Token != "" && r.Header.Get("Authorization") != "Bearer "+s.Token {
+		http.Error(w, "unauthenticated", http.StatusUnauthorized)
+		return
+	}
+	status, resp := handle()
+	if msg, ok := resp.(string); ok {
+		http.Error(w, msg, status)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) handleCreateResult(w http.ResponseWriter, r *http.Request) {
+	s.serve(w, r, func() (int, any) {
+		namespace := r.PathValue("namespace")
+		result := tektonresults.Result{}
+		if err := json.NewDecoder(r.Body).Decode(&result); err != nil {
+			return http.StatusBadRequest, err.Error()
+		}
+		if !strings.HasPrefix(result.Name, namespace+"/results/") {
+			return http.StatusBadRequest, fmt.Sprintf("result %s is not in namespace %s", result.Name, namespace)
+		}
+		if slices.Contains(s.results[namespace], result.Name) {
+			return http.StatusConflict, fmt.Sprintf("result %s already exists", result.Name)
+		}
+		s.results[namespace] = append(s.results[namespace], result.Name)
+		return http.StatusOK, result
+	})
+}
+
+func (s *Server) handleCreateRecord(w http.ResponseWriter, r *http.Request) {
+	s.serve(w, r, func() (int, any) {
+		namespace, result := r.PathValue("namespace"), fmt.Sprintf("%s/results/%s", r.PathValue("namespace"), r.PathValue("result"))
+		if !slices.Contains(s.results[namespace], result) {
+			return http.StatusNotFound, fmt.Sprintf("result %s not found", result)
+		}
+		record := tektonresults.Record{}
+		if err := json.NewDecoder(r.Body).Decode(&record); err != nil {
+			return http.StatusBadRequest, err.Error()
+		}
+		if !strings.HasPrefix(record.Name, result+"/records/") {
+			return http.StatusBadRequest, fmt.Sprintf("record %s is not in result %s", record.Name, result)
+		}
+		if slices.ContainsFunc(s.records[namespace], func(existing tektonresults.Record) bool { return existing.Name == record.Name }) {
+			return http.StatusConflict, fmt.Sprintf("record %s already exists", record.Name)
+		}
+		s.records[namespace] = append(s.records[namespace], record)
+		return http.StatusOK, record
+	})
+}
+
+func (s *Server) handleUpdateRecord(w http.ResponseWriter, r *http.Request) {
+	s.serve(w, r, func() (int, any) {
+		namespace := r.PathValue("namespace")
+		name := fmt.Sprintf("%s/results/%s/records/%s", namespace, r.PathValue("result"), r.PathValue("record"))
+		record := tektonresults.Record{}
+		if err := json.NewDecoder(r.Body).Decode(&record); err != nil {
+			return http.StatusBadRequest, err.Error()
+		}
+		i := slices.IndexFunc(s.records[namespace], func(existing tektonresults.Record) bool { return existing.Name == name })
+		if i < 0 {
+			return http.StatusNotFound, fmt.Sprintf("record %s not found", name)
+		}
+		s.records[namespace][i] = record
+		return http.StatusOK, record
+	})
+}
+
+func (s *Server) handleList(w http.ResponseWriter, r *http.Request) {
+	s.serve(w, r, func() (int, any) {
+		filter := r.URL.Query().Get("filter")
+		typeMatch := typeFilter.FindStringSubmatch(filter)
+		if typeMatch == nil {
+			return http.StatusBadRequest, fmt.Sprintf("unsupported filter %q", filter)
+		}
+		var types []string
+		for _, match := range quoted.FindAllStringSubmatch(typeMatch[2], -1) {
+			types = append(types, match[1])
+		}
+		nameMatch := nameFilter.FindStringSubmatch(filter)
+
+		prefix := fmt.Sprintf("%s/results/", r.PathValue("namespace"))
+		if result := r.PathValue("result"); result != tektonresults.AllResults {
+			prefix += result + "/records/"
+		}
+		var matching []tektonresults.Record
+		for _, record := range s.records[r.PathValue("namespace")] {
+			if !strings.HasPrefix(record.Name, prefix) || !slices.Contains(types, record.Data.Type) {
+				continue
+			}
+			if nameMatch != nil {
+				var obj struct {
+					Metadata struct {
+						Name string `json:"name"`
+					} `json:"metadata"`
+				}
+				if err := json.Unmarshal(record.Data.Value, &obj); err != nil {
+					return http.StatusInternalServerError, err.Error()
+				}
+				if obj.Metadata.Name != nameMatch[1] {
+					continue
+				}
+			}
+			matching = append(matching, record)
+		}
+
+		page := 0
+		if token := r.URL.Query().Get("page_token"); token != "" {
+			var err error
+			if page, err = strconv.Atoi(token); err != nil {
+				return http.StatusBadRequest, "invalid page token"
+			}
+		}
+		resp := tektonresults.ListRecordsResponse{}
+		if page < len(matching) {
+			resp.Records = matching[page : page+1]
+			if page+1 < len(matching) {
+				resp.NextPageToken = strconv.Itoa(page + 1)
+			}
+		}
+		return http.StatusOK, resp
+	})
+}
@@ -20,6 +20,7 @@ import (
 
 	"github.com/tektoncd/chains/pkg/chains/objects"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	pipelineclientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/watch"
@@ -39,6 +40,12 @@ func CreateObject(t *testing.T, ctx context.Context, ps pipelineclientset.Interf
 			t.Fatalf("error creating taskrun: %v", err)
 		}
 		return objects.NewTaskRunObjectV1(tr)
+	case *v1beta1.CustomRun:
+		run, err := ps.TektonV1beta1().CustomRuns(obj.GetNamespace()).Create(ctx, o, metav1.CreateOptions{})
+		if err != nil {
+			t.Fatalf("error creating customrun: %v", err)
+		}
+		return objects.NewCustomRunObjectV1beta1(run)
 	}
 	return nil
 }
@@ -53,6 +60,8 @@ func GetObject(t *testing.T, ctx context.Context, ps pipelineclientset.Interface
 		return GetPipelineRun(t, ctx, ps, obj.GetNamespace(), obj.GetName())
 	case *v1.TaskRun:
 		return GetTaskRun(t, ctx, ps, obj.GetNamespace(), obj.GetName())
+	case *v1beta1.CustomRun:
+		return GetCustomRun(t, ctx, ps, obj.GetNamespace(), obj.GetName())
 	}
 	t.Fatalf("unknown object type %T", obj.GetObject())
 	return nil, fmt.Errorf("unknown object type %T", obj.GetObject())
@@ -74,6 +83,14 @@ func GetTaskRun(t *testing.T, ctx context.Context, ps pipelineclientset.Interfac
 	return objects.NewTaskRunObjectV1(tr), nil
 }
 
+func GetCustomRun(t *testing.T, ctx context.Context, ps pipelineclientset.Interface, namespace, name string) (objects.TektonObject, error) {
+	run, err := ps.TektonV1beta1().CustomRuns(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("error getting customrun: %v", err)
+	}
+	return objects.NewCustomRunObjectV1beta1(run), nil
+}
+
 func WatchObject(t *testing.T, ctx context.Context, ps pipelineclientset.Interface, obj objects.TektonObject) (watch.Interface, error) {
 	switch o := obj.GetObject().(type) {
 	case *v1.PipelineRun:
@@ -86,6 +103,11 @@ func WatchObject(t *testing.T, ctx context.Context, ps pipelineclientset.Interfa
 			Name:      o.GetName(),
 			Namespace: o.GetNamespace(),
 		}))
+	case *v1beta1.CustomRun:
+		return ps.TektonV1beta1().CustomRuns(obj.GetNamespace()).Watch(ctx, metav1.SingleObject(metav1.ObjectMeta{
+			Name:      o.GetName(),
+			Namespace: o.GetNamespace(),
+		}))
 	}
 	return nil, fmt.Errorf("unknown object type %T", obj.GetObject())
 }